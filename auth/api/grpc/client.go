@@ -26,8 +26,13 @@ type grpcClient struct {
 	issue     endpoint.Endpoint
 	identify  endpoint.Endpoint
 	authorize endpoint.Endpoint
+	granted   endpoint.Endpoint
 	assign    endpoint.Endpoint
 	members   endpoint.Endpoint
+	claim     endpoint.Endpoint
+	addPolicy endpoint.Endpoint
+	delPolicy endpoint.Endpoint
+	policies  endpoint.Endpoint
 	timeout   time.Duration
 }
 
@@ -58,6 +63,14 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeAuthorizeResponse,
 			mainflux.AuthorizeRes{},
 		).Endpoint()),
+		granted: kitot.TraceClient(tracer, "granted")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"Granted",
+			encodeGrantedRequest,
+			decodeGrantedResponse,
+			mainflux.GrantedRes{},
+		).Endpoint()),
 		assign: kitot.TraceClient(tracer, "assign")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -74,6 +87,38 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeMembersResponse,
 			mainflux.MembersRes{},
 		).Endpoint()),
+		claim: kitot.TraceClient(tracer, "claim")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"Claim",
+			encodeClaimRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		addPolicy: kitot.TraceClient(tracer, "add_policy")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"AddPolicy",
+			encodePolicyRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		delPolicy: kitot.TraceClient(tracer, "delete_policy")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"DeletePolicy",
+			encodePolicyRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		policies: kitot.TraceClient(tracer, "list_policies")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ListPolicies",
+			encodeListPoliciesRequest,
+			decodeListPoliciesResponse,
+			mainflux.PoliciesRes{},
+		).Endpoint()),
 
 		timeout: timeout,
 	}
@@ -129,7 +174,7 @@ func (client grpcClient) Authorize(ctx context.Context, req *mainflux.AuthorizeR
 	ctx, close := context.WithTimeout(ctx, client.timeout)
 	defer close()
 
-	res, err := client.authorize(ctx, authReq{Act: req.Act, Obj: req.Obj, Sub: req.Sub})
+	res, err := client.authorize(ctx, authReq{token: req.GetToken(), Act: req.GetAct(), Obj: req.GetObj(), Sub: req.GetSub()})
 	if err != nil {
 		return &mainflux.AuthorizeRes{Authorized: false}, err
 	}
@@ -146,12 +191,147 @@ func decodeAuthorizeResponse(_ context.Context, grpcRes interface{}) (interface{
 func encodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(authReq)
 	return &mainflux.AuthorizeReq{
-		Sub: req.Sub,
-		Obj: req.Obj,
-		Act: req.Act,
+		Token: req.token,
+		Sub:   req.Sub,
+		Obj:   req.Obj,
+		Act:   req.Act,
+	}, nil
+}
+
+func (client grpcClient) Granted(ctx context.Context, req *mainflux.GrantedReq, _ ...grpc.CallOption) (*mainflux.GrantedRes, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.granted(ctx, grantedReq{token: req.GetToken(), obj: req.GetObj(), acts: req.GetActs()})
+	if err != nil {
+		return nil, err
+	}
+
+	gr := res.(grantedRes)
+	return &mainflux.GrantedRes{Act: gr.act}, nil
+}
+
+func decodeGrantedResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.GrantedRes)
+	return grantedRes{act: res.GetAct()}, nil
+}
+
+func encodeGrantedRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(grantedReq)
+	return &mainflux.GrantedReq{
+		Token: req.token,
+		Obj:   req.obj,
+		Acts:  req.acts,
+	}, nil
+}
+
+func (client grpcClient) Claim(ctx context.Context, req *mainflux.ClaimReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.claim(ctx, claimReq{key: req.GetKey(), sub: req.GetSub(), obj: req.GetObj()}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func encodeClaimRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(claimReq)
+	return &mainflux.ClaimReq{Key: req.key, Sub: req.sub, Obj: req.obj}, nil
+}
+
+func (client grpcClient) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.addPolicy(ctx, policyReq{token: req.GetToken(), sub: req.GetSub(), obj: req.GetObj(), act: req.GetAct()}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (client grpcClient) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.delPolicy(ctx, policyReq{token: req.GetToken(), sub: req.GetSub(), obj: req.GetObj(), act: req.GetAct()}); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func encodePolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(policyReq)
+	return &mainflux.PolicyReq{
+		Token: req.token,
+		Sub:   req.sub,
+		Obj:   req.obj,
+		Act:   req.act,
+	}, nil
+}
+
+func decodeEmptyResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return emptyRes{}, nil
+}
+
+func (client grpcClient) ListPolicies(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*mainflux.PoliciesRes, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.policies(ctx, listPoliciesReq{
+		token:  req.GetToken(),
+		sub:    req.GetSub(),
+		obj:    req.GetObj(),
+		act:    req.GetAct(),
+		offset: req.GetOffset(),
+		limit:  req.GetLimit(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pr := res.(policiesRes)
+	ret := &mainflux.PoliciesRes{
+		Total:  pr.total,
+		Offset: pr.offset,
+		Limit:  pr.limit,
+	}
+	for _, p := range pr.policies {
+		ret.Policies = append(ret.Policies, &mainflux.Policy{Sub: p.sub, Obj: p.obj, Act: p.act})
+	}
+
+	return ret, nil
+}
+
+func encodeListPoliciesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listPoliciesReq)
+	return &mainflux.PolicyReq{
+		Token:  req.token,
+		Sub:    req.sub,
+		Obj:    req.obj,
+		Act:    req.act,
+		Offset: req.offset,
+		Limit:  req.limit,
 	}, nil
 }
 
+func decodeListPoliciesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.PoliciesRes)
+	pr := policiesRes{
+		total:  res.GetTotal(),
+		offset: res.GetOffset(),
+		limit:  res.GetLimit(),
+	}
+	for _, p := range res.GetPolicies() {
+		pr.policies = append(pr.policies, policy{sub: p.GetSub(), obj: p.GetObj(), act: p.GetAct()})
+	}
+
+	return pr, nil
+}
+
 func (client grpcClient) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
 	ctx, close := context.WithTimeout(ctx, client.timeout)
 	defer close()
@@ -62,12 +62,29 @@ func authorizeEndpoint(svc auth.Service) endpoint.Endpoint {
 			return authorizeRes{}, err
 		}
 
-		_, err := svc.Identify(ctx, req.token)
+		user, err := svc.Identify(ctx, req.token)
 		if err != nil {
 			return authorizeRes{}, err
 		}
 
-		authorized, err := svc.Authorize(ctx, req.token, req.Sub, req.Obj, req.Obj)
+		// Only the object admins are allowed to check the policies
+		// of the other subjects.
+		sub := req.Sub
+		switch sub {
+		case "":
+			sub = user.ID
+		case user.ID:
+		default:
+			admin, err := svc.Authorize(ctx, user.ID, req.Obj, auth.AdminAction)
+			if err != nil {
+				return authorizeRes{}, err
+			}
+			if !admin {
+				return authorizeRes{}, auth.ErrUnauthorizedAccess
+			}
+		}
+
+		authorized, err := svc.Authorize(ctx, sub, req.Obj, req.Act)
 		if err != nil {
 			return authorizeRes{}, err
 		}
@@ -76,6 +93,121 @@ func authorizeEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func grantedEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(grantedReq)
+
+		if err := req.validate(); err != nil {
+			return grantedRes{}, err
+		}
+
+		user, err := svc.Identify(ctx, req.token)
+		if err != nil {
+			return grantedRes{}, err
+		}
+
+		act, err := svc.Granted(ctx, user.ID, req.obj, req.acts)
+		if err != nil {
+			return grantedRes{}, err
+		}
+
+		return grantedRes{act: act}, nil
+	}
+}
+
+func claimEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(claimReq)
+
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		if err := svc.Claim(ctx, req.key, req.sub, req.obj); err != nil {
+			return emptyRes{}, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func addPolicyEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		p := auth.Policy{
+			Subject: req.sub,
+			Object:  req.obj,
+			Action:  req.act,
+		}
+		if err := svc.AddPolicy(ctx, req.token, p); err != nil {
+			return emptyRes{}, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func deletePolicyEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		p := auth.Policy{
+			Subject: req.sub,
+			Object:  req.obj,
+			Action:  req.act,
+		}
+		if err := svc.RemovePolicy(ctx, req.token, p); err != nil {
+			return emptyRes{}, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func listPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listPoliciesReq)
+
+		if err := req.validate(); err != nil {
+			return policiesRes{}, err
+		}
+
+		filter := auth.Policy{
+			Subject: req.sub,
+			Object:  req.obj,
+			Action:  req.act,
+		}
+		pm := auth.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		pp, err := svc.ListPolicies(ctx, req.token, filter, pm)
+		if err != nil {
+			return policiesRes{}, err
+		}
+
+		res := policiesRes{
+			total:  pp.Total,
+			offset: pp.Offset,
+			limit:  pp.Limit,
+		}
+		for _, p := range pp.Policies {
+			res.policies = append(res.policies, policy{sub: p.Subject, obj: p.Object, act: p.Action})
+		}
+
+		return res, nil
+	}
+}
+
 func assignEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(assignReq)
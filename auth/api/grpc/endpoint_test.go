@@ -26,6 +26,7 @@ import (
 const (
 	port        = 8081
 	secret      = "secret"
+	claimKey    = "claimKey"
 	email       = "test@example.com"
 	id          = "testID"
 	thingsType  = "things"
@@ -41,10 +42,11 @@ var svc auth.Service
 func newService() auth.Service {
 	repo := mocks.NewKeyRepository()
 	groupRepo := mocks.NewGroupRepository()
+	policyRepo := mocks.NewPolicyRepository()
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
 
-	return auth.New(repo, groupRepo, idProvider, t)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t, claimKey)
 }
 
 func startGRPCServer(svc auth.Service, port int) {
@@ -260,3 +262,258 @@ func TestMembers(t *testing.T) {
 		assert.True(t, ok, "OK expected to be true")
 	}
 }
+
+func TestAuthorize(t *testing.T) {
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), token, auth.Group{Name: "Authorized", Description: description})
+	assert.Nil(t, err, fmt.Sprintf("Creating group expected to succeed: %s", err))
+
+	chanID, err := uuid.New().ID()
+	assert.Nil(t, err, fmt.Sprintf("Generate channel id expected to succeed: %s", err))
+	userID, err := uuid.New().ID()
+	assert.Nil(t, err, fmt.Sprintf("Generate user id expected to succeed: %s", err))
+
+	err = svc.Assign(context.Background(), token, group.ID, thingsType, chanID)
+	assert.Nil(t, err, fmt.Sprintf("Assign members to group expected to succeed: %s", err))
+
+	err = svc.AddPolicy(context.Background(), token, auth.Policy{Subject: userID, Object: group.ID, Action: auth.ReadAction})
+	assert.Nil(t, err, fmt.Sprintf("Adding policy expected to succeed: %s", err))
+
+	_, otherToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: userID, Subject: "other@example.com"})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc       string
+		req        *mainflux.AuthorizeReq
+		authorized bool
+		code       codes.Code
+	}{
+		{
+			desc:       "authorize user with policy over the channel group",
+			req:        &mainflux.AuthorizeReq{Token: token, Sub: userID, Obj: chanID, Act: auth.ReadAction},
+			authorized: true,
+			code:       codes.OK,
+		},
+		{
+			desc:       "authorize user for action without policy",
+			req:        &mainflux.AuthorizeReq{Token: token, Sub: userID, Obj: chanID, Act: auth.WriteAction},
+			authorized: false,
+			code:       codes.OK,
+		},
+		{
+			desc:       "authorize the caller",
+			req:        &mainflux.AuthorizeReq{Token: otherToken, Obj: chanID, Act: auth.ReadAction},
+			authorized: true,
+			code:       codes.OK,
+		},
+		{
+			desc:       "authorize other subject without admin policy",
+			req:        &mainflux.AuthorizeReq{Token: otherToken, Sub: id, Obj: chanID, Act: auth.ReadAction},
+			authorized: false,
+			code:       codes.Unauthenticated,
+		},
+		{
+			desc:       "authorize without token",
+			req:        &mainflux.AuthorizeReq{Sub: userID, Obj: chanID, Act: auth.ReadAction},
+			authorized: false,
+			code:       codes.Unauthenticated,
+		},
+		{
+			desc:       "authorize with invalid token",
+			req:        &mainflux.AuthorizeReq{Token: "invalid", Sub: userID, Obj: chanID, Act: auth.ReadAction},
+			authorized: false,
+			code:       codes.Unauthenticated,
+		},
+		{
+			desc:       "authorize with missing action",
+			req:        &mainflux.AuthorizeReq{Token: token, Sub: userID, Obj: chanID},
+			authorized: false,
+			code:       codes.InvalidArgument,
+		},
+	}
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	for _, tc := range cases {
+		res, err := client.Authorize(context.Background(), tc.req)
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+		assert.Equal(t, tc.authorized, res.GetAuthorized(), fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.authorized, res.GetAuthorized()))
+	}
+}
+
+func TestGranted(t *testing.T) {
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	chanID, err := uuid.New().ID()
+	assert.Nil(t, err, fmt.Sprintf("Generate channel id expected to succeed: %s", err))
+	userID, err := uuid.New().ID()
+	assert.Nil(t, err, fmt.Sprintf("Generate user id expected to succeed: %s", err))
+
+	err = svc.Claim(context.Background(), claimKey, id, chanID)
+	assert.Nil(t, err, fmt.Sprintf("Claiming channel expected to succeed: %s", err))
+	err = svc.AddPolicy(context.Background(), token, auth.Policy{Subject: userID, Object: chanID, Action: auth.WriteAction})
+	assert.Nil(t, err, fmt.Sprintf("Adding policy expected to succeed: %s", err))
+
+	_, otherToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: userID, Subject: "other@example.com"})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	acts := []string{auth.AdminAction, auth.WriteAction, auth.ReadAction}
+	cases := []struct {
+		desc string
+		req  *mainflux.GrantedReq
+		act  string
+		code codes.Code
+	}{
+		{
+			desc: "granted action of the channel admin",
+			req:  &mainflux.GrantedReq{Token: token, Obj: chanID, Acts: acts},
+			act:  auth.AdminAction,
+			code: codes.OK,
+		},
+		{
+			desc: "granted action of the user with policy",
+			req:  &mainflux.GrantedReq{Token: otherToken, Obj: chanID, Acts: acts},
+			act:  auth.WriteAction,
+			code: codes.OK,
+		},
+		{
+			desc: "granted action without policy",
+			req:  &mainflux.GrantedReq{Token: otherToken, Obj: chanID, Acts: []string{auth.AdminAction}},
+			act:  "",
+			code: codes.OK,
+		},
+		{
+			desc: "granted action without token",
+			req:  &mainflux.GrantedReq{Obj: chanID, Acts: acts},
+			act:  "",
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "granted action with invalid token",
+			req:  &mainflux.GrantedReq{Token: "invalid", Obj: chanID, Acts: acts},
+			act:  "",
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "granted action without actions",
+			req:  &mainflux.GrantedReq{Token: token, Obj: chanID},
+			act:  "",
+			code: codes.InvalidArgument,
+		},
+	}
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	for _, tc := range cases {
+		res, err := client.Granted(context.Background(), tc.req)
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+		assert.Equal(t, tc.act, res.GetAct(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.act, res.GetAct()))
+	}
+}
+
+func TestClaim(t *testing.T) {
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	thingID, err := uuid.New().ID()
+	assert.Nil(t, err, fmt.Sprintf("Generate thing id expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc string
+		req  *mainflux.ClaimReq
+		code codes.Code
+	}{
+		{
+			desc: "claim object with user token",
+			req:  &mainflux.ClaimReq{Key: token, Sub: id, Obj: thingID},
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "claim new object",
+			req:  &mainflux.ClaimReq{Key: claimKey, Sub: id, Obj: thingID},
+			code: codes.OK,
+		},
+		{
+			desc: "claim already claimed object",
+			req:  &mainflux.ClaimReq{Key: claimKey, Sub: id, Obj: thingID},
+			code: codes.AlreadyExists,
+		},
+		{
+			desc: "claim object with invalid claim key",
+			req:  &mainflux.ClaimReq{Key: "invalid", Sub: id, Obj: thingID},
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "claim object without subject",
+			req:  &mainflux.ClaimReq{Key: claimKey, Obj: thingID},
+			code: codes.InvalidArgument,
+		},
+		{
+			desc: "claim object without object",
+			req:  &mainflux.ClaimReq{Key: claimKey, Sub: id},
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.Claim(context.Background(), tc.req)
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+
+	res, err := client.Authorize(context.Background(), &mainflux.AuthorizeReq{Token: token, Obj: thingID, Act: auth.DeleteAction})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, res.GetAuthorized(), "claimed object expected to be authorized")
+}
+
+func TestPolicies(t *testing.T) {
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	thingID, err := uuid.New().ID()
+	assert.Nil(t, err, fmt.Sprintf("Generate thing id expected to succeed: %s", err))
+	userID, err := uuid.New().ID()
+	assert.Nil(t, err, fmt.Sprintf("Generate user id expected to succeed: %s", err))
+
+	err = svc.Claim(context.Background(), claimKey, id, thingID)
+	assert.Nil(t, err, fmt.Sprintf("Claiming object expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	p := &mainflux.PolicyReq{Token: token, Sub: userID, Obj: thingID, Act: auth.ReadAction}
+	_, err = client.AddPolicy(context.Background(), p)
+	assert.Nil(t, err, fmt.Sprintf("Adding policy expected to succeed: %s", err))
+
+	_, err = client.AddPolicy(context.Background(), &mainflux.PolicyReq{Token: token, Sub: userID, Obj: thingID, Act: "invalid"})
+	e, _ := status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, e.Code(), fmt.Sprintf("adding invalid policy: expected %s got %s", codes.InvalidArgument, e.Code()))
+
+	pr, err := client.ListPolicies(context.Background(), &mainflux.PolicyReq{Token: token, Obj: thingID, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("Listing policies expected to succeed: %s", err))
+	assert.Equal(t, 2, len(pr.GetPolicies()), fmt.Sprintf("listing policies: expected %d got %d", 2, len(pr.GetPolicies())))
+
+	_, err = client.DeletePolicy(context.Background(), p)
+	assert.Nil(t, err, fmt.Sprintf("Deleting policy expected to succeed: %s", err))
+
+	pr, err = client.ListPolicies(context.Background(), &mainflux.PolicyReq{Token: token, Obj: thingID, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("Listing policies expected to succeed: %s", err))
+	assert.Equal(t, 1, len(pr.GetPolicies()), fmt.Sprintf("listing policies: expected %d got %d", 1, len(pr.GetPolicies())))
+}
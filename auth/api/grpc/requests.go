@@ -82,10 +82,29 @@ func (req membersReq) validate() error {
 	return nil
 }
 
+type grantedReq struct {
+	token string
+	obj   string
+	acts  []string
+}
+
+func (req grantedReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.obj == "" || len(req.acts) == 0 {
+		return auth.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 // authReq represents authorization request. It contains:
-// 1. subject - an action invoker
-// 2. object - an entity over which action will be executed
-// 3. action - type of action that will be executed (read/write)
+// 1. token - the key of the caller
+// 2. subject - an action invoker; the caller if empty
+// 3. object - an entity over which action will be executed
+// 4. action - type of action that will be executed (read/write)
 type authReq struct {
 	token string
 	Sub   string
@@ -95,18 +114,76 @@ type authReq struct {
 
 func (req authReq) validate() error {
 	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.Obj == "" {
 		return auth.ErrMalformedEntity
 	}
 
-	if req.Sub == "" {
+	if req.Act == "" {
 		return auth.ErrMalformedEntity
 	}
 
-	if req.Obj == "" {
+	return nil
+}
+
+type claimReq struct {
+	key string
+	sub string
+	obj string
+}
+
+func (req claimReq) validate() error {
+	if req.key == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.sub == "" || req.obj == "" {
 		return auth.ErrMalformedEntity
 	}
 
-	if req.Act == "" {
+	return nil
+}
+
+type policyReq struct {
+	token string
+	sub   string
+	obj   string
+	act   string
+}
+
+func (req policyReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.sub == "" || req.obj == "" {
+		return auth.ErrMalformedEntity
+	}
+
+	if !auth.ValidAction(req.act) {
+		return auth.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type listPoliciesReq struct {
+	token  string
+	sub    string
+	obj    string
+	act    string
+	offset uint64
+	limit  uint64
+}
+
+func (req listPoliciesReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.act != "" && !auth.ValidAction(req.act) {
 		return auth.ErrMalformedEntity
 	}
 
@@ -15,6 +15,11 @@ type issueRes struct {
 type authorizeRes struct {
 	authorized bool
 }
+
+type grantedRes struct {
+	act string
+}
+
 type membersRes struct {
 	total     uint64
 	offset    uint64
@@ -22,6 +27,20 @@ type membersRes struct {
 	groupType string
 	members   []string
 }
+
+type policy struct {
+	sub string
+	obj string
+	act string
+}
+
+type policiesRes struct {
+	total    uint64
+	offset   uint64
+	limit    uint64
+	policies []policy
+}
+
 type emptyRes struct {
 	err error
 }
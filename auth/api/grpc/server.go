@@ -23,8 +23,13 @@ type grpcServer struct {
 	issue     kitgrpc.Handler
 	identify  kitgrpc.Handler
 	authorize kitgrpc.Handler
+	granted   kitgrpc.Handler
 	assign    kitgrpc.Handler
 	members   kitgrpc.Handler
+	claim     kitgrpc.Handler
+	addPolicy kitgrpc.Handler
+	delPolicy kitgrpc.Handler
+	policies  kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -45,6 +50,11 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeAuthorizeRequest,
 			encodeAuthorizeResponse,
 		),
+		granted: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "granted")(grantedEndpoint(svc)),
+			decodeGrantedRequest,
+			encodeGrantedResponse,
+		),
 		assign: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "assign")(assignEndpoint(svc)),
 			decodeAssignRequest,
@@ -55,6 +65,26 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeMembersRequest,
 			encodeMembersResponse,
 		),
+		claim: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "claim")(claimEndpoint(svc)),
+			decodeClaimRequest,
+			encodeEmptyResponse,
+		),
+		addPolicy: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "add_policy")(addPolicyEndpoint(svc)),
+			decodePolicyRequest,
+			encodeEmptyResponse,
+		),
+		delPolicy: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "delete_policy")(deletePolicyEndpoint(svc)),
+			decodePolicyRequest,
+			encodeEmptyResponse,
+		),
+		policies: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "list_policies")(listPoliciesEndpoint(svc)),
+			decodeListPoliciesRequest,
+			encodeListPoliciesResponse,
+		),
 	}
 }
 
@@ -82,6 +112,14 @@ func (s *grpcServer) Authorize(ctx context.Context, token *mainflux.AuthorizeReq
 	return res.(*mainflux.AuthorizeRes), nil
 }
 
+func (s *grpcServer) Granted(ctx context.Context, req *mainflux.GrantedReq) (*mainflux.GrantedRes, error) {
+	_, res, err := s.granted.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.GrantedRes), nil
+}
+
 func (s *grpcServer) Assign(ctx context.Context, token *mainflux.Assignment) (*empty.Empty, error) {
 	_, res, err := s.assign.ServeGRPC(ctx, token)
 	if err != nil {
@@ -98,6 +136,38 @@ func (s *grpcServer) Members(ctx context.Context, req *mainflux.MembersReq) (*ma
 	return res.(*mainflux.MembersRes), nil
 }
 
+func (s *grpcServer) Claim(ctx context.Context, req *mainflux.ClaimReq) (*empty.Empty, error) {
+	_, res, err := s.claim.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) AddPolicy(ctx context.Context, req *mainflux.PolicyReq) (*empty.Empty, error) {
+	_, res, err := s.addPolicy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq) (*empty.Empty, error) {
+	_, res, err := s.delPolicy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) ListPolicies(ctx context.Context, req *mainflux.PolicyReq) (*mainflux.PoliciesRes, error) {
+	_, res, err := s.policies.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.PoliciesRes), nil
+}
+
 func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.IssueReq)
 	return issueReq{id: req.GetId(), email: req.GetEmail(), keyType: req.GetType()}, nil
@@ -120,12 +190,22 @@ func encodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}
 
 func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AuthorizeReq)
-	return authReq{Act: req.Act, Obj: req.Obj, Sub: req.Sub}, nil
+	return authReq{token: req.GetToken(), Act: req.GetAct(), Obj: req.GetObj(), Sub: req.GetSub()}, nil
 }
 
 func encodeAuthorizeResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
-	res := grpcRes.(*mainflux.AuthorizeRes)
-	return res, nil
+	res := grpcRes.(authorizeRes)
+	return &mainflux.AuthorizeRes{Authorized: res.authorized}, nil
+}
+
+func decodeGrantedRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.GrantedReq)
+	return grantedReq{token: req.GetToken(), obj: req.GetObj(), acts: req.GetActs()}, nil
+}
+
+func encodeGrantedResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(grantedRes)
+	return &mainflux.GrantedRes{Act: res.act}, nil
 }
 
 func decodeAssignRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -155,6 +235,46 @@ func encodeMembersResponse(_ context.Context, grpcRes interface{}) (interface{},
 	}, nil
 }
 
+func decodeClaimRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ClaimReq)
+	return claimReq{key: req.GetKey(), sub: req.GetSub(), obj: req.GetObj()}, nil
+}
+
+func decodePolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.PolicyReq)
+	return policyReq{
+		token: req.GetToken(),
+		sub:   req.GetSub(),
+		obj:   req.GetObj(),
+		act:   req.GetAct(),
+	}, nil
+}
+
+func decodeListPoliciesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.PolicyReq)
+	return listPoliciesReq{
+		token:  req.GetToken(),
+		sub:    req.GetSub(),
+		obj:    req.GetObj(),
+		act:    req.GetAct(),
+		offset: req.GetOffset(),
+		limit:  req.GetLimit(),
+	}, nil
+}
+
+func encodeListPoliciesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(policiesRes)
+	pr := &mainflux.PoliciesRes{
+		Total:  res.total,
+		Offset: res.offset,
+		Limit:  res.limit,
+	}
+	for _, p := range res.policies {
+		pr.Policies = append(pr.Policies, &mainflux.Policy{Sub: p.sub, Obj: p.obj, Act: p.act})
+	}
+	return pr, nil
+}
+
 func encodeEmptyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(emptyRes)
 	return &empty.Empty{}, encodeError(res.err)
@@ -170,6 +290,10 @@ func encodeError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, auth.ErrKeyExpired):
 		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Contains(err, auth.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Contains(err, auth.ErrPolicyNotFound):
+		return status.Error(codes.NotFound, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
@@ -25,6 +25,7 @@ import (
 
 const (
 	secret      = "secret"
+	claimKey    = "claimKey"
 	contentType = "application/json"
 	id          = "123e4567-e89b-12d3-a456-000000000001"
 	email       = "user@example.com"
@@ -63,9 +64,10 @@ func (tr testRequest) make() (*http.Response, error) {
 func newService() auth.Service {
 	repo := mocks.NewKeyRepository()
 	groupRepo := mocks.NewGroupRepository()
+	policyRepo := mocks.NewPolicyRepository()
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
-	return auth.New(repo, groupRepo, idProvider, t)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t, claimKey)
 }
 
 func newServer(svc auth.Service) *httptest.Server {
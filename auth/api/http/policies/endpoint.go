@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/auth"
+)
+
+func addPolicyEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		p := auth.Policy{
+			Subject: req.Subject,
+			Object:  req.Object,
+			Action:  req.Action,
+		}
+		if err := svc.AddPolicy(ctx, req.token, p); err != nil {
+			return nil, err
+		}
+
+		return addPolicyRes{}, nil
+	}
+}
+
+func listPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listPoliciesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		filter := auth.Policy{
+			Subject: req.subject,
+			Object:  req.object,
+			Action:  req.action,
+		}
+		pm := auth.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListPolicies(ctx, req.token, filter, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := policyPageRes{
+			Total:    page.Total,
+			Offset:   page.Offset,
+			Limit:    page.Limit,
+			Policies: []viewPolicyRes{},
+		}
+		for _, p := range page.Policies {
+			view := viewPolicyRes{
+				Subject:   p.Subject,
+				Object:    p.Object,
+				Action:    p.Action,
+				CreatedAt: p.CreatedAt,
+			}
+			res.Policies = append(res.Policies, view)
+		}
+
+		return res, nil
+	}
+}
+
+func removePolicyEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		p := auth.Policy{
+			Subject: req.Subject,
+			Object:  req.Object,
+			Action:  req.Action,
+		}
+		if err := svc.RemovePolicy(ctx, req.token, p); err != nil {
+			return nil, err
+		}
+
+		return removePolicyRes{}, nil
+	}
+}
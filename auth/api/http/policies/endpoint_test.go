@@ -0,0 +1,320 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/auth"
+	httpapi "github.com/mainflux/mainflux/auth/api/http"
+	"github.com/mainflux/mainflux/auth/jwt"
+	"github.com/mainflux/mainflux/auth/mocks"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secret      = "secret"
+	claimKey    = "claimKey"
+	contentType = "application/json"
+	id          = "123e4567-e89b-12d3-a456-000000000001"
+	email       = "user@example.com"
+	subject     = "123e4567-e89b-12d3-a456-000000000002"
+)
+
+type policyRequest struct {
+	Subject string `json:"subject,omitempty"`
+	Object  string `json:"object,omitempty"`
+	Action  string `json:"action,omitempty"`
+}
+
+type policiesPageRes struct {
+	Total    uint64          `json:"total"`
+	Policies []policyRequest `json:"policies"`
+}
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	req.Header.Set("Referer", "http://localhost")
+	return tr.client.Do(req)
+}
+
+func newService() auth.Service {
+	repo := mocks.NewKeyRepository()
+	groupRepo := mocks.NewGroupRepository()
+	policyRepo := mocks.NewPolicyRepository()
+	idProvider := uuid.NewMock()
+	t := jwt.New(secret)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t, claimKey)
+}
+
+func newServer(svc auth.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, mocktracer.New())
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+func createGroup(t *testing.T, svc auth.Service) (string, string) {
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), token, auth.Group{Name: "group"})
+	require.Nil(t, err, fmt.Sprintf("Creating group expected to succeed: %s", err))
+
+	return token, group.ID
+}
+
+func TestAddPolicy(t *testing.T) {
+	svc := newService()
+	token, groupID := createGroup(t, svc)
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	valid := policyRequest{Subject: subject, Object: groupID, Action: auth.ReadAction}
+
+	cases := []struct {
+		desc   string
+		req    string
+		ct     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "add policy",
+			req:    toJSON(valid),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "add existing policy",
+			req:    toJSON(valid),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusConflict,
+		},
+		{
+			desc:   "add policy with invalid action",
+			req:    toJSON(policyRequest{Subject: subject, Object: groupID, Action: "invalid"}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "add policy without object",
+			req:    toJSON(policyRequest{Subject: subject, Action: auth.ReadAction}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "add policy over unknown object",
+			req:    toJSON(policyRequest{Subject: subject, Object: "unknown", Action: auth.ReadAction}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "add policy with invalid token",
+			req:    toJSON(valid),
+			ct:     contentType,
+			token:  "wrong",
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "add policy with wrong content type",
+			req:    toJSON(valid),
+			ct:     "",
+			token:  token,
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:   "add policy with invalid JSON",
+			req:    "{",
+			ct:     contentType,
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/policies", ts.URL),
+			contentType: tc.ct,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListPolicies(t *testing.T) {
+	svc := newService()
+	token, groupID := createGroup(t, svc)
+
+	n := 3
+	for i := 0; i < n; i++ {
+		p := auth.Policy{Subject: fmt.Sprintf("%s%d", subject, i), Object: groupID, Action: auth.ReadAction}
+		err := svc.AddPolicy(context.Background(), token, p)
+		require.Nil(t, err, fmt.Sprintf("Adding policy expected to succeed: %s", err))
+	}
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		total  uint64
+	}{
+		{
+			desc:   "list policies of the group",
+			query:  fmt.Sprintf("object=%s", groupID),
+			token:  token,
+			status: http.StatusOK,
+			total:  uint64(n),
+		},
+		{
+			desc:   "list own policies",
+			query:  "",
+			token:  token,
+			status: http.StatusOK,
+			total:  0,
+		},
+		{
+			desc:   "list policies with invalid limit",
+			query:  "limit=1000",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list policies with invalid action",
+			query:  "action=invalid",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list policies with invalid token",
+			query:  "",
+			token:  "wrong",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/policies?%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusOK {
+			continue
+		}
+		var page policiesPageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestRemovePolicy(t *testing.T) {
+	svc := newService()
+	token, groupID := createGroup(t, svc)
+
+	p := auth.Policy{Subject: subject, Object: groupID, Action: auth.ReadAction}
+	err := svc.AddPolicy(context.Background(), token, p)
+	require.Nil(t, err, fmt.Sprintf("Adding policy expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	valid := policyRequest{Subject: p.Subject, Object: p.Object, Action: p.Action}
+
+	cases := []struct {
+		desc   string
+		req    string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove policy with invalid token",
+			req:    toJSON(valid),
+			token:  "wrong",
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove policy",
+			req:    toJSON(valid),
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed policy",
+			req:    toJSON(valid),
+			token:  token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodDelete,
+			url:         fmt.Sprintf("%s/policies", ts.URL),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
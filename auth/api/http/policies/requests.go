@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+type policyReq struct {
+	token   string
+	Subject string `json:"subject"`
+	Object  string `json:"object"`
+	Action  string `json:"action"`
+}
+
+func (req policyReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.Subject == "" || req.Object == "" {
+		return auth.ErrMalformedEntity
+	}
+
+	if len(req.Subject) > maxIDSize || len(req.Object) > maxIDSize {
+		return auth.ErrMalformedEntity
+	}
+
+	if !auth.ValidAction(req.Action) {
+		return errors.Wrap(auth.ErrMalformedEntity, auth.ErrInvalidAction)
+	}
+
+	return nil
+}
+
+type listPoliciesReq struct {
+	token   string
+	subject string
+	object  string
+	action  string
+	offset  uint64
+	limit   uint64
+}
+
+func (req listPoliciesReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return auth.ErrMalformedEntity
+	}
+
+	if req.action != "" && !auth.ValidAction(req.action) {
+		return errors.Wrap(auth.ErrMalformedEntity, auth.ErrInvalidAction)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mainflux/mainflux/auth"
 	"github.com/mainflux/mainflux/auth/api/http/groups"
 	"github.com/mainflux/mainflux/auth/api/http/keys"
+	"github.com/mainflux/mainflux/auth/api/http/policies"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -18,6 +19,7 @@ func MakeHandler(svc auth.Service, tracer opentracing.Tracer) http.Handler {
 	mux := bone.New()
 	mux = keys.MakeHandler(svc, mux, tracer)
 	mux = groups.MakeHandler(svc, mux, tracer)
+	mux = policies.MakeHandler(svc, mux, tracer)
 	mux.GetFunc("/version", mainflux.Version("auth"))
 	mux.Handle("/metrics", promhttp.Handler())
 	return mux
@@ -82,9 +82,9 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id auth.
 	return lm.svc.Identify(ctx, key)
 }
 
-func (lm *loggingMiddleware) Authorize(ctx context.Context, token, sub, obj, act string) (auth bool, err error) {
+func (lm *loggingMiddleware) Authorize(ctx context.Context, sub, obj, act string) (auth bool, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method authorize took %s to complete", time.Since(begin))
+		message := fmt.Sprintf("Method authorize for subject %s object %s and action %s took %s to complete", sub, obj, act, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -92,7 +92,20 @@ func (lm *loggingMiddleware) Authorize(ctx context.Context, token, sub, obj, act
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Authorize(ctx, token, sub, obj, act)
+	return lm.svc.Authorize(ctx, sub, obj, act)
+}
+
+func (lm *loggingMiddleware) Granted(ctx context.Context, sub, obj string, acts []string) (act string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method granted for subject %s object %s and actions %v took %s to complete", sub, obj, acts, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Granted(ctx, sub, obj, acts)
 }
 
 func (lm *loggingMiddleware) CreateGroup(ctx context.Context, token string, group auth.Group) (g auth.Group, err error) {
@@ -237,3 +250,55 @@ func (lm *loggingMiddleware) Unassign(ctx context.Context, token string, groupID
 
 	return lm.svc.Unassign(ctx, token, groupID, memberIDs...)
 }
+
+func (lm *loggingMiddleware) AddPolicy(ctx context.Context, token string, p auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_policy for subject %s object %s and action %s took %s to complete", p.Subject, p.Object, p.Action, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddPolicy(ctx, token, p)
+}
+
+func (lm *loggingMiddleware) ListPolicies(ctx context.Context, token string, filter auth.Policy, pm auth.PageMetadata) (pp auth.PolicyPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_policies for subject %s and object %s took %s to complete", filter.Subject, filter.Object, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListPolicies(ctx, token, filter, pm)
+}
+
+func (lm *loggingMiddleware) RemovePolicy(ctx context.Context, token string, p auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_policy for subject %s object %s and action %s took %s to complete", p.Subject, p.Object, p.Action, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemovePolicy(ctx, token, p)
+}
+
+func (lm *loggingMiddleware) Claim(ctx context.Context, key, sub, obj string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method claim for object %s and subject %s took %s to complete", obj, sub, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Claim(ctx, key, sub, obj)
+}
@@ -64,13 +64,22 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (auth.I
 	return ms.svc.Identify(ctx, token)
 }
 
-func (ms *metricsMiddleware) Authorize(ctx context.Context, token, sub, obj, act string) (auth bool, err error) {
+func (ms *metricsMiddleware) Authorize(ctx context.Context, sub, obj, act string) (auth bool, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "authorize").Add(1)
 		ms.latency.With("method", "authorize").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Authorize(ctx, token, sub, obj, act)
+	return ms.svc.Authorize(ctx, sub, obj, act)
+}
+
+func (ms *metricsMiddleware) Granted(ctx context.Context, sub, obj string, acts []string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "granted").Add(1)
+		ms.latency.With("method", "granted").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Granted(ctx, sub, obj, acts)
 }
 
 func (ms *metricsMiddleware) CreateGroup(ctx context.Context, token string, group auth.Group) (gr auth.Group, err error) {
@@ -168,3 +177,39 @@ func (ms *metricsMiddleware) Unassign(ctx context.Context, token, groupID string
 
 	return ms.svc.Unassign(ctx, token, groupID, memberIDs...)
 }
+
+func (ms *metricsMiddleware) AddPolicy(ctx context.Context, token string, p auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_policy").Add(1)
+		ms.latency.With("method", "add_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AddPolicy(ctx, token, p)
+}
+
+func (ms *metricsMiddleware) ListPolicies(ctx context.Context, token string, filter auth.Policy, pm auth.PageMetadata) (pp auth.PolicyPage, err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_policies").Add(1)
+		ms.latency.With("method", "list_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListPolicies(ctx, token, filter, pm)
+}
+
+func (ms *metricsMiddleware) RemovePolicy(ctx context.Context, token string, p auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_policy").Add(1)
+		ms.latency.With("method", "remove_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemovePolicy(ctx, token, p)
+}
+
+func (ms *metricsMiddleware) Claim(ctx context.Context, key, sub, obj string) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "claim").Add(1)
+		ms.latency.With("method", "claim").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Claim(ctx, key, sub, obj)
+}
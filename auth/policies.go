@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	// ReadAction allows reading the object.
+	ReadAction = "read"
+	// WriteAction allows writing to the object.
+	WriteAction = "write"
+	// DeleteAction allows removal of the object.
+	DeleteAction = "delete"
+	// AdminAction implies all the other actions and allows the subject
+	// to manage policies of the object.
+	AdminAction = "admin"
+)
+
+var (
+	// ErrPolicyNotFound indicates that the policy does not exist.
+	ErrPolicyNotFound = errors.New("policy not found")
+
+	// ErrInvalidAction indicates that the policy action is not supported.
+	ErrInvalidAction = errors.New("invalid policy action")
+)
+
+// Policy represents a permission for the subject to perform the action
+// over the object. Subject and object are entity IDs: a user, a thing,
+// a channel or a group. Group subjects pass the permission to all their
+// members, while group objects pass it to all the entities assigned to
+// the group and to its descendants.
+type Policy struct {
+	Subject   string
+	Object    string
+	Action    string
+	CreatedAt time.Time
+}
+
+// PolicyPage contains a page of policies.
+type PolicyPage struct {
+	PageMetadata
+	Policies []Policy
+}
+
+// ValidAction checks whether the action is supported by the policy engine.
+func ValidAction(act string) bool {
+	switch act {
+	case ReadAction, WriteAction, DeleteAction, AdminAction:
+		return true
+	default:
+		return false
+	}
+}
+
+// PolicyService specifies an API for policies management.
+type PolicyService interface {
+	// AddPolicy persists a new policy. The user identified by the token
+	// has to own the object group or to have the admin action granted
+	// over the object.
+	AddPolicy(ctx context.Context, token string, p Policy) error
+
+	// ListPolicies retrieves policies matching the non-empty fields of the
+	// provided filter. If no object is set, only policies of the user
+	// identified by the token are returned.
+	ListPolicies(ctx context.Context, token string, filter Policy, pm PageMetadata) (PolicyPage, error)
+
+	// RemovePolicy removes the policy. The same rules as for AddPolicy apply.
+	RemovePolicy(ctx context.Context, token string, p Policy) error
+
+	// Claim grants the admin action over the object to the subject. It
+	// fails if the object already has policies. The key has to match the
+	// claim key shared with the services owning the objects, which claim
+	// the object for its owner after checking the ownership themselves.
+	Claim(ctx context.Context, key, sub, obj string) error
+}
+
+// PolicyRepository specifies policy persistence API.
+type PolicyRepository interface {
+	// Save persists the policy.
+	Save(ctx context.Context, p Policy) error
+
+	// Claim persists the policy if there are no policies over its object,
+	// and returns ErrConflict otherwise. The check and the insert are
+	// atomic, so only one of the concurrent claims succeeds.
+	Claim(ctx context.Context, p Policy) error
+
+	// RetrieveAll retrieves policies matching the non-empty fields of
+	// the provided filter.
+	RetrieveAll(ctx context.Context, filter Policy, pm PageMetadata) (PolicyPage, error)
+
+	// Evaluate checks whether there is a policy granting any of the
+	// actions over any of the objects to any of the subjects.
+	Evaluate(ctx context.Context, subjects, objects, actions []string) (bool, error)
+
+	// Remove removes the policy.
+	Remove(ctx context.Context, p Policy) error
+}
@@ -0,0 +1,225 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errSavePolicy     = errors.New("failed to save policy in database")
+	errRetrievePolicy = errors.New("failed to retrieve policies from database")
+	errRemovePolicy   = errors.New("failed to remove policy from database")
+)
+
+var _ auth.PolicyRepository = (*policyRepository)(nil)
+
+type policyRepository struct {
+	db Database
+}
+
+// NewPolicyRepo instantiates a PostgreSQL implementation of policy
+// repository.
+func NewPolicyRepo(db Database) auth.PolicyRepository {
+	return &policyRepository{
+		db: db,
+	}
+}
+
+func (pr policyRepository) Save(ctx context.Context, p auth.Policy) error {
+	q := `INSERT INTO policies (subject, object, action, created_at)
+	      VALUES (:subject, :object, :action, :created_at)`
+
+	if _, err := pr.db.NamedExecContext(ctx, q, toDBPolicy(p)); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(auth.ErrMalformedEntity, err)
+			case errDuplicate:
+				return errors.Wrap(auth.ErrConflict, err)
+			}
+		}
+		return errors.Wrap(errSavePolicy, err)
+	}
+
+	return nil
+}
+
+func (pr policyRepository) Claim(ctx context.Context, p auth.Policy) error {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errSavePolicy, err)
+	}
+
+	// The advisory lock serializes the concurrent claims of the same object
+	// until the end of the transaction.
+	qLock := `SELECT pg_advisory_xact_lock(hashtext($1))`
+	qCheck := `SELECT EXISTS (SELECT 1 FROM policies WHERE object = $1)`
+	qIns := `INSERT INTO policies (subject, object, action, created_at)
+	         VALUES (:subject, :object, :action, :created_at)`
+
+	if _, err := tx.ExecContext(ctx, qLock, p.Object); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errSavePolicy, err)
+	}
+
+	var exists bool
+	if err := tx.QueryRowxContext(ctx, qCheck, p.Object).Scan(&exists); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errSavePolicy, err)
+	}
+	if exists {
+		tx.Rollback()
+		return auth.ErrConflict
+	}
+
+	if _, err := tx.NamedExecContext(ctx, qIns, toDBPolicy(p)); err != nil {
+		tx.Rollback()
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(auth.ErrMalformedEntity, err)
+			case errDuplicate:
+				return errors.Wrap(auth.ErrConflict, err)
+			}
+		}
+		return errors.Wrap(errSavePolicy, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errSavePolicy, err)
+	}
+
+	return nil
+}
+
+func (pr policyRepository) RetrieveAll(ctx context.Context, filter auth.Policy, pm auth.PageMetadata) (auth.PolicyPage, error) {
+	var conds []string
+	if filter.Subject != "" {
+		conds = append(conds, "subject = :subject")
+	}
+	if filter.Object != "" {
+		conds = append(conds, "object = :object")
+	}
+	if filter.Action != "" {
+		conds = append(conds, "action = :action")
+	}
+
+	var wq string
+	if len(conds) > 0 {
+		wq = fmt.Sprintf("WHERE %s", strings.Join(conds, " AND "))
+	}
+
+	q := fmt.Sprintf(`SELECT subject, object, action, created_at FROM policies %s
+	                  ORDER BY subject, object, action LIMIT :limit OFFSET :offset`, wq)
+
+	params := dbPolicyPage{
+		dbPolicy: toDBPolicy(filter),
+		Limit:    pm.Limit,
+		Offset:   pm.Offset,
+	}
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return auth.PolicyPage{}, errors.Wrap(errRetrievePolicy, err)
+	}
+	defer rows.Close()
+
+	items := []auth.Policy{}
+	for rows.Next() {
+		dbp := dbPolicy{}
+		if err := rows.StructScan(&dbp); err != nil {
+			return auth.PolicyPage{}, errors.Wrap(errRetrievePolicy, err)
+		}
+		items = append(items, toPolicy(dbp))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM policies %s`, wq)
+	total, err := total(ctx, pr.db, cq, params)
+	if err != nil {
+		return auth.PolicyPage{}, errors.Wrap(errRetrievePolicy, err)
+	}
+
+	page := auth.PolicyPage{
+		Policies: items,
+		PageMetadata: auth.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Size:   uint64(len(items)),
+		},
+	}
+
+	return page, nil
+}
+
+func (pr policyRepository) Evaluate(ctx context.Context, subjects, objects, actions []string) (bool, error) {
+	q := `SELECT EXISTS (SELECT 1 FROM policies WHERE subject = ANY($1) AND object = ANY($2) AND action = ANY($3))`
+
+	var exists bool
+	if err := pr.db.QueryRowxContext(ctx, q, pq.Array(subjects), pq.Array(objects), pq.Array(actions)).Scan(&exists); err != nil {
+		return false, errors.Wrap(errRetrievePolicy, err)
+	}
+
+	return exists, nil
+}
+
+func (pr policyRepository) Remove(ctx context.Context, p auth.Policy) error {
+	q := `DELETE FROM policies WHERE subject = :subject AND object = :object AND action = :action`
+
+	res, err := pr.db.NamedExecContext(ctx, q, toDBPolicy(p))
+	if err != nil {
+		return errors.Wrap(errRemovePolicy, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errRemovePolicy, err)
+	}
+	if cnt != 1 {
+		return auth.ErrPolicyNotFound
+	}
+
+	return nil
+}
+
+type dbPolicy struct {
+	Subject   string    `db:"subject"`
+	Object    string    `db:"object"`
+	Action    string    `db:"action"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type dbPolicyPage struct {
+	dbPolicy
+	Limit  uint64 `db:"limit"`
+	Offset uint64 `db:"offset"`
+}
+
+func toDBPolicy(p auth.Policy) dbPolicy {
+	return dbPolicy{
+		Subject:   p.Subject,
+		Object:    p.Object,
+		Action:    p.Action,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func toPolicy(dbp dbPolicy) auth.Policy {
+	return auth.Policy{
+		Subject:   dbp.Subject,
+		Object:    dbp.Object,
+		Action:    dbp.Action,
+		CreatedAt: dbp.CreatedAt,
+	}
+}
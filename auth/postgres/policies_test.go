@@ -0,0 +1,244 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/auth/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicySave(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	sub, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	obj := generateGroupID(t)
+
+	cases := []struct {
+		desc   string
+		policy auth.Policy
+		err    error
+	}{
+		{
+			desc:   "save new policy",
+			policy: auth.Policy{Subject: sub, Object: obj, Action: auth.ReadAction, CreatedAt: time.Now()},
+			err:    nil,
+		},
+		{
+			desc:   "save existing policy",
+			policy: auth.Policy{Subject: sub, Object: obj, Action: auth.ReadAction, CreatedAt: time.Now()},
+			err:    auth.ErrConflict,
+		},
+		{
+			desc:   "save policy with too long subject",
+			policy: auth.Policy{Subject: strings.Repeat("m", maxNameSize+1), Object: obj, Action: auth.ReadAction},
+			err:    auth.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPolicyClaim(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	sub, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	other, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	obj := generateGroupID(t)
+
+	cases := []struct {
+		desc   string
+		policy auth.Policy
+		err    error
+	}{
+		{
+			desc:   "claim new object",
+			policy: auth.Policy{Subject: sub, Object: obj, Action: auth.AdminAction, CreatedAt: time.Now()},
+			err:    nil,
+		},
+		{
+			desc:   "claim claimed object",
+			policy: auth.Policy{Subject: other, Object: obj, Action: auth.AdminAction, CreatedAt: time.Now()},
+			err:    auth.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Claim(context.Background(), tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPolicyRetrieveAll(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	sub, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		p := auth.Policy{Subject: sub, Object: generateGroupID(t), Action: auth.ReadAction, CreatedAt: time.Now()}
+		err := repo.Save(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	obj := generateGroupID(t)
+	err = repo.Save(context.Background(), auth.Policy{Subject: sub, Object: obj, Action: auth.WriteAction})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		filter auth.Policy
+		pm     auth.PageMetadata
+		size   uint64
+		total  uint64
+	}{
+		{
+			desc:   "retrieve all policies of the subject",
+			filter: auth.Policy{Subject: sub},
+			pm:     auth.PageMetadata{Offset: 0, Limit: n + 1},
+			size:   n + 1,
+			total:  n + 1,
+		},
+		{
+			desc:   "retrieve subset of policies of the subject",
+			filter: auth.Policy{Subject: sub},
+			pm:     auth.PageMetadata{Offset: n / 2, Limit: n},
+			size:   n/2 + 1,
+			total:  n + 1,
+		},
+		{
+			desc:   "retrieve policies by action",
+			filter: auth.Policy{Subject: sub, Action: auth.WriteAction},
+			pm:     auth.PageMetadata{Offset: 0, Limit: n},
+			size:   1,
+			total:  1,
+		},
+		{
+			desc:   "retrieve policies by object",
+			filter: auth.Policy{Object: obj},
+			pm:     auth.PageMetadata{Offset: 0, Limit: n},
+			size:   1,
+			total:  1,
+		},
+		{
+			desc:   "retrieve policies of unknown subject",
+			filter: auth.Policy{Subject: "unknown"},
+			pm:     auth.PageMetadata{Offset: 0, Limit: n},
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.filter, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, uint64(len(page.Policies)), fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, len(page.Policies)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	sub, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	grp := generateGroupID(t)
+	obj := generateGroupID(t)
+
+	err = repo.Save(context.Background(), auth.Policy{Subject: grp, Object: obj, Action: auth.ReadAction})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc       string
+		subjects   []string
+		objects    []string
+		actions    []string
+		authorized bool
+	}{
+		{
+			desc:       "evaluate policy granted to the subject group",
+			subjects:   []string{sub, grp},
+			objects:    []string{obj},
+			actions:    []string{auth.ReadAction, auth.AdminAction},
+			authorized: true,
+		},
+		{
+			desc:       "evaluate policy for not granted action",
+			subjects:   []string{sub, grp},
+			objects:    []string{obj},
+			actions:    []string{auth.WriteAction, auth.AdminAction},
+			authorized: false,
+		},
+		{
+			desc:       "evaluate policy for subject without policies",
+			subjects:   []string{sub},
+			objects:    []string{obj},
+			actions:    []string{auth.ReadAction},
+			authorized: false,
+		},
+	}
+
+	for _, tc := range cases {
+		authorized, err := repo.Evaluate(context.Background(), tc.subjects, tc.objects, tc.actions)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.authorized, authorized, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.authorized, authorized))
+	}
+}
+
+func TestPolicyRemove(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	sub, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	p := auth.Policy{Subject: sub, Object: generateGroupID(t), Action: auth.ReadAction}
+
+	err = repo.Save(context.Background(), p)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		policy auth.Policy
+		err    error
+	}{
+		{
+			desc:   "remove existing policy",
+			policy: p,
+			err:    nil,
+		},
+		{
+			desc:   "remove removed policy",
+			policy: p,
+			err:    auth.ErrPolicyNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(context.Background(), tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func cleanUpPolicies(t *testing.T) {
+	_, err := db.Exec("delete from policies")
+	require.Nil(t, err, fmt.Sprintf("clean policies unexpected error: %s", err))
+}
@@ -5,6 +5,7 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
 	"time"
 
 	"github.com/mainflux/mainflux"
@@ -15,6 +16,10 @@ import (
 const (
 	loginDuration    = 10 * time.Hour
 	recoveryDuration = 5 * time.Minute
+
+	// maxMemberships limits the number of groups taken into account
+	// when evaluating the policies of a single entity.
+	maxMemberships = 1000
 )
 
 var (
@@ -54,6 +59,7 @@ var (
 	errRevoke    = errors.New("failed to remove key")
 	errRetrieve  = errors.New("failed to retrieve key data")
 	errIdentify  = errors.New("failed to validate token")
+	errAuthorize = errors.New("failed to evaluate policies")
 )
 
 // Authn specifies an API that must be fullfiled by the domain service
@@ -81,8 +87,18 @@ type Authn interface {
 // Authz specifies an API for the authorization and will be implemented
 // by evaluation of policies.
 type Authz interface {
-	// Authorize checks access rights
-	Authorize(ctx context.Context, token, sub, obj, act string) (bool, error)
+	// Authorize checks whether the subject is allowed to perform the action
+	// over the object. Policies granted to the groups the subject belongs to,
+	// as well as the policies over the groups the object belongs to, are
+	// inherited through the whole groups hierarchy. Owners of a group are
+	// allowed to perform any action over the group and its members.
+	Authorize(ctx context.Context, sub, obj, act string) (bool, error)
+
+	// Granted returns the first of the actions, ordered from the highest
+	// one, which the subject is allowed to perform over the object, or an
+	// empty string if none is allowed. The same rules as for Authorize
+	// apply, but the groups hierarchy is traversed only once.
+	Granted(ctx context.Context, sub, obj string, acts []string) (string, error)
 }
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -95,6 +111,9 @@ type Service interface {
 
 	// Implements groups API, creating groups, assigning members
 	GroupService
+
+	// Implements policies API, adding, listing and removing policies
+	PolicyService
 }
 
 var _ Service = (*service)(nil)
@@ -102,17 +121,22 @@ var _ Service = (*service)(nil)
 type service struct {
 	keys         KeyRepository
 	groups       GroupRepository
+	policies     PolicyRepository
 	idProvider   mainflux.IDProvider
 	ulidProvider mainflux.IDProvider
 	tokenizer    Tokenizer
+	claimKey     string
 }
 
-// New instantiates the auth service implementation.
-func New(keys KeyRepository, groups GroupRepository, idp mainflux.IDProvider, tokenizer Tokenizer) Service {
+// New instantiates the auth service implementation. The objects are claimed
+// using the claim key, and claiming is disabled if the key is empty.
+func New(keys KeyRepository, groups GroupRepository, policies PolicyRepository, idp mainflux.IDProvider, tokenizer Tokenizer, claimKey string) Service {
 	return &service{
 		tokenizer:    tokenizer,
+		claimKey:     claimKey,
 		keys:         keys,
 		groups:       groups,
+		policies:     policies,
 		idProvider:   idp,
 		ulidProvider: ulid.New(),
 	}
@@ -170,8 +194,166 @@ func (svc service) Identify(ctx context.Context, token string) (Identity, error)
 	}
 }
 
-func (svc service) Authorize(ctx context.Context, token, sub, obj, act string) (bool, error) {
-	return true, nil
+func (svc service) Authorize(ctx context.Context, sub, obj, act string) (bool, error) {
+	granted, err := svc.Granted(ctx, sub, obj, []string{act})
+	if err != nil {
+		return false, err
+	}
+
+	return granted != "", nil
+}
+
+func (svc service) Granted(ctx context.Context, sub, obj string, acts []string) (string, error) {
+	if len(acts) == 0 {
+		return "", nil
+	}
+
+	subjects, _, err := svc.lineage(ctx, sub)
+	if err != nil {
+		return "", errors.Wrap(errAuthorize, err)
+	}
+
+	objects, owners, err := svc.lineage(ctx, obj)
+	if err != nil {
+		return "", errors.Wrap(errAuthorize, err)
+	}
+
+	if owners[sub] {
+		return acts[0], nil
+	}
+
+	for _, act := range acts {
+		authorized, err := svc.policies.Evaluate(ctx, subjects, objects, []string{act, AdminAction})
+		if err != nil {
+			return "", errors.Wrap(errAuthorize, err)
+		}
+		if authorized {
+			return act, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (svc service) AddPolicy(ctx context.Context, token string, p Policy) error {
+	if err := svc.canManage(ctx, token, p.Object); err != nil {
+		return err
+	}
+
+	p.CreatedAt = getTimestmap()
+	return svc.policies.Save(ctx, p)
+}
+
+func (svc service) ListPolicies(ctx context.Context, token string, filter Policy, pm PageMetadata) (PolicyPage, error) {
+	if filter.Object != "" {
+		if err := svc.canManage(ctx, token, filter.Object); err != nil {
+			return PolicyPage{}, err
+		}
+		return svc.policies.RetrieveAll(ctx, filter, pm)
+	}
+
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return PolicyPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	switch filter.Subject {
+	case "":
+		filter.Subject = user.ID
+	case user.ID:
+	default:
+		return PolicyPage{}, ErrUnauthorizedAccess
+	}
+
+	return svc.policies.RetrieveAll(ctx, filter, pm)
+}
+
+func (svc service) RemovePolicy(ctx context.Context, token string, p Policy) error {
+	if err := svc.canManage(ctx, token, p.Object); err != nil {
+		return err
+	}
+
+	return svc.policies.Remove(ctx, p)
+}
+
+func (svc service) Claim(ctx context.Context, key, sub, obj string) error {
+	if svc.claimKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(svc.claimKey)) != 1 {
+		return ErrUnauthorizedAccess
+	}
+	if sub == "" || obj == "" {
+		return ErrMalformedEntity
+	}
+
+	p := Policy{
+		Subject:   sub,
+		Object:    obj,
+		Action:    AdminAction,
+		CreatedAt: getTimestmap(),
+	}
+	return svc.policies.Claim(ctx, p)
+}
+
+// canManage checks whether the user identified by the token is allowed
+// to manage the policies of the given object.
+func (svc service) canManage(ctx context.Context, token, obj string) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	authorized, err := svc.Authorize(ctx, user.ID, obj, AdminAction)
+	if err != nil {
+		return err
+	}
+	if !authorized {
+		return ErrUnauthorizedAccess
+	}
+
+	return nil
+}
+
+// lineage returns the provided entity ID alongside the IDs of all the
+// groups the entity belongs to, including their ancestors. If the entity
+// is a group itself, its ancestors are returned as well. The second return
+// value is a set of the owners of the returned groups.
+func (svc service) lineage(ctx context.Context, id string) ([]string, map[string]bool, error) {
+	ids := []string{id}
+	owners := map[string]bool{}
+	visited := map[string]bool{id: true}
+
+	ancestors := func(groupID string) error {
+		gp, err := svc.groups.RetrieveAllParents(ctx, groupID, PageMetadata{Level: MaxLevel})
+		if err != nil && !errors.Contains(err, ErrGroupNotFound) {
+			return err
+		}
+		for _, g := range gp.Groups {
+			if g.OwnerID != "" {
+				owners[g.OwnerID] = true
+			}
+			if visited[g.ID] {
+				continue
+			}
+			visited[g.ID] = true
+			ids = append(ids, g.ID)
+		}
+		return nil
+	}
+
+	if err := ancestors(id); err != nil {
+		return nil, nil, err
+	}
+
+	mp, err := svc.groups.Memberships(ctx, id, PageMetadata{Limit: maxMemberships})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, g := range mp.Groups {
+		if err := ancestors(g.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return ids, owners, nil
 }
 
 func (svc service) tmpKey(duration time.Duration, key Key) (Key, string, error) {
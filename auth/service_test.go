@@ -22,6 +22,7 @@ var idProvider = uuid.New()
 
 const (
 	secret      = "secret"
+	claimKey    = "claimKey"
 	email       = "test@example.com"
 	id          = "testID"
 	groupName   = "mfx"
@@ -31,9 +32,10 @@ const (
 func newService() auth.Service {
 	repo := mocks.NewKeyRepository()
 	groupRepo := mocks.NewGroupRepository()
+	policyRepo := mocks.NewPolicyRepository()
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
-	return auth.New(repo, groupRepo, idProvider, t)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t, claimKey)
 }
 
 func TestIssue(t *testing.T) {
@@ -981,3 +983,419 @@ func TestUnassign(t *testing.T) {
 	err = svc.Unassign(context.Background(), apiToken, group.ID, mid)
 	assert.True(t, errors.Contains(err, auth.ErrGroupNotFound), fmt.Sprintf("Unauthorized access: expected %v got %v", nil, err))
 }
+
+func TestAuthorize(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	parent, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	child, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName + "Child", ParentID: parent.ID})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	team, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName + "Team"})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	memberID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	userID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = svc.Assign(context.Background(), secret, child.ID, "channels", chanID)
+	require.Nil(t, err, fmt.Sprintf("member assign unexpected error: %s", err))
+	err = svc.Assign(context.Background(), secret, team.ID, "users", memberID)
+	require.Nil(t, err, fmt.Sprintf("member assign unexpected error: %s", err))
+
+	err = svc.AddPolicy(context.Background(), secret, auth.Policy{Subject: team.ID, Object: parent.ID, Action: auth.ReadAction})
+	require.Nil(t, err, fmt.Sprintf("adding policy unexpected error: %s", err))
+	err = svc.AddPolicy(context.Background(), secret, auth.Policy{Subject: userID, Object: chanID, Action: auth.AdminAction})
+	require.Nil(t, err, fmt.Sprintf("adding policy unexpected error: %s", err))
+
+	cases := []struct {
+		desc       string
+		sub        string
+		obj        string
+		act        string
+		authorized bool
+	}{
+		{
+			desc:       "authorize group owner",
+			sub:        id,
+			obj:        chanID,
+			act:        auth.DeleteAction,
+			authorized: true,
+		},
+		{
+			desc:       "authorize team member to read channel of the child group",
+			sub:        memberID,
+			obj:        chanID,
+			act:        auth.ReadAction,
+			authorized: true,
+		},
+		{
+			desc:       "authorize team member to write channel of the child group",
+			sub:        memberID,
+			obj:        chanID,
+			act:        auth.WriteAction,
+			authorized: false,
+		},
+		{
+			desc:       "authorize team member to read the child group",
+			sub:        memberID,
+			obj:        child.ID,
+			act:        auth.ReadAction,
+			authorized: true,
+		},
+		{
+			desc:       "authorize admin to write the channel",
+			sub:        userID,
+			obj:        chanID,
+			act:        auth.WriteAction,
+			authorized: true,
+		},
+		{
+			desc:       "authorize admin to write the parent group",
+			sub:        userID,
+			obj:        parent.ID,
+			act:        auth.WriteAction,
+			authorized: false,
+		},
+		{
+			desc:       "authorize subject without policies",
+			sub:        "unknown",
+			obj:        chanID,
+			act:        auth.ReadAction,
+			authorized: false,
+		},
+	}
+
+	for _, tc := range cases {
+		authorized, err := svc.Authorize(context.Background(), tc.sub, tc.obj, tc.act)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.authorized, authorized, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.authorized, authorized))
+	}
+}
+
+func TestGranted(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	readerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	adminID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = svc.Assign(context.Background(), secret, group.ID, "channels", chanID)
+	require.Nil(t, err, fmt.Sprintf("member assign unexpected error: %s", err))
+	err = svc.AddPolicy(context.Background(), secret, auth.Policy{Subject: readerID, Object: group.ID, Action: auth.ReadAction})
+	require.Nil(t, err, fmt.Sprintf("adding policy unexpected error: %s", err))
+	err = svc.AddPolicy(context.Background(), secret, auth.Policy{Subject: adminID, Object: chanID, Action: auth.AdminAction})
+	require.Nil(t, err, fmt.Sprintf("adding policy unexpected error: %s", err))
+
+	acts := []string{auth.AdminAction, auth.WriteAction, auth.ReadAction}
+	cases := []struct {
+		desc string
+		sub  string
+		acts []string
+		act  string
+	}{
+		{
+			desc: "highest action granted to group owner",
+			sub:  id,
+			acts: acts,
+			act:  auth.AdminAction,
+		},
+		{
+			desc: "highest action granted through the channel group",
+			sub:  readerID,
+			acts: acts,
+			act:  auth.ReadAction,
+		},
+		{
+			desc: "highest action granted to channel admin",
+			sub:  adminID,
+			acts: acts,
+			act:  auth.AdminAction,
+		},
+		{
+			desc: "lower action implied by the admin policy",
+			sub:  adminID,
+			acts: []string{auth.WriteAction, auth.ReadAction},
+			act:  auth.WriteAction,
+		},
+		{
+			desc: "action granted to subject without policies",
+			sub:  "unknown",
+			acts: acts,
+			act:  "",
+		},
+		{
+			desc: "action granted without actions",
+			sub:  adminID,
+			acts: nil,
+			act:  "",
+		},
+	}
+
+	for _, tc := range cases {
+		act, err := svc.Granted(context.Background(), tc.sub, chanID, tc.acts)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.act, act, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.act, act))
+	}
+}
+
+func TestAddPolicy(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		policy auth.Policy
+		err    error
+	}{
+		{
+			desc:   "add policy over owned group",
+			token:  secret,
+			policy: auth.Policy{Subject: "otherID", Object: group.ID, Action: auth.ReadAction},
+			err:    nil,
+		},
+		{
+			desc:   "add existing policy",
+			token:  secret,
+			policy: auth.Policy{Subject: "otherID", Object: group.ID, Action: auth.ReadAction},
+			err:    auth.ErrConflict,
+		},
+		{
+			desc:   "add policy over group without admin action",
+			token:  otherSecret,
+			policy: auth.Policy{Subject: "otherID", Object: group.ID, Action: auth.WriteAction},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "add policy over unknown object",
+			token:  secret,
+			policy: auth.Policy{Subject: "otherID", Object: "unknown", Action: auth.ReadAction},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "add policy with invalid token",
+			token:  "invalid",
+			policy: auth.Policy{Subject: "otherID", Object: group.ID, Action: auth.WriteAction},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AddPolicy(context.Background(), tc.token, tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListPolicies(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	n := uint64(5)
+	for i := uint64(0); i < n; i++ {
+		p := auth.Policy{Subject: fmt.Sprintf("subject%d", i), Object: group.ID, Action: auth.ReadAction}
+		err := svc.AddPolicy(context.Background(), secret, p)
+		require.Nil(t, err, fmt.Sprintf("adding policy unexpected error: %s", err))
+	}
+	err = svc.AddPolicy(context.Background(), secret, auth.Policy{Subject: "otherID", Object: group.ID, Action: auth.WriteAction})
+	require.Nil(t, err, fmt.Sprintf("adding policy unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		filter auth.Policy
+		size   uint64
+		err    error
+	}{
+		{
+			desc:   "list policies of owned group",
+			token:  secret,
+			filter: auth.Policy{Object: group.ID},
+			size:   n + 1,
+			err:    nil,
+		},
+		{
+			desc:   "list policies of owned group by action",
+			token:  secret,
+			filter: auth.Policy{Object: group.ID, Action: auth.WriteAction},
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:   "list own policies",
+			token:  otherSecret,
+			filter: auth.Policy{},
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:   "list policies of another subject",
+			token:  otherSecret,
+			filter: auth.Policy{Subject: "subject1"},
+			size:   0,
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "list policies of group without admin action",
+			token:  otherSecret,
+			filter: auth.Policy{Object: group.ID},
+			size:   0,
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "list policies with invalid token",
+			token:  "invalid",
+			filter: auth.Policy{},
+			size:   0,
+			err:    auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListPolicies(context.Background(), tc.token, tc.filter, auth.PageMetadata{Offset: 0, Limit: 10})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, uint64(len(page.Policies)), fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, len(page.Policies)))
+	}
+}
+
+func TestRemovePolicy(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	p := auth.Policy{Subject: "otherID", Object: group.ID, Action: auth.ReadAction}
+	err = svc.AddPolicy(context.Background(), secret, p)
+	require.Nil(t, err, fmt.Sprintf("adding policy unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		policy auth.Policy
+		err    error
+	}{
+		{
+			desc:   "remove policy with invalid token",
+			token:  "invalid",
+			policy: p,
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "remove existing policy",
+			token:  secret,
+			policy: p,
+			err:    nil,
+		},
+		{
+			desc:   "remove removed policy",
+			token:  secret,
+			policy: p,
+			err:    auth.ErrPolicyNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemovePolicy(context.Background(), tc.token, tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	authorized, err := svc.Authorize(context.Background(), p.Subject, p.Object, p.Action)
+	assert.Nil(t, err, fmt.Sprintf("authorize unexpected error: %s", err))
+	assert.False(t, authorized, "authorize after policy removal: expected false got true")
+}
+
+func TestClaim(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		sub  string
+		obj  string
+		err  error
+	}{
+		{
+			desc: "claim object with invalid claim key",
+			key:  "invalid",
+			sub:  id,
+			obj:  "thingID",
+			err:  auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "claim object with user token",
+			key:  secret,
+			sub:  id,
+			obj:  "thingID",
+			err:  auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "claim new object",
+			key:  claimKey,
+			sub:  id,
+			obj:  "thingID",
+			err:  nil,
+		},
+		{
+			desc: "claim claimed object",
+			key:  claimKey,
+			sub:  "otherID",
+			obj:  "thingID",
+			err:  auth.ErrConflict,
+		},
+		{
+			desc: "claim object without subject",
+			key:  claimKey,
+			sub:  "",
+			obj:  "otherThingID",
+			err:  auth.ErrMalformedEntity,
+		},
+		{
+			desc: "claim empty object",
+			key:  claimKey,
+			sub:  id,
+			obj:  "",
+			err:  auth.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Claim(context.Background(), tc.key, tc.sub, tc.obj)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	authorized, err := svc.Authorize(context.Background(), id, "thingID", auth.DeleteAction)
+	assert.Nil(t, err, fmt.Sprintf("authorize unexpected error: %s", err))
+	assert.True(t, authorized, "authorize claimed object: expected true got false")
+
+	err = svc.AddPolicy(context.Background(), secret, auth.Policy{Subject: "otherID", Object: "thingID", Action: auth.ReadAction})
+	assert.Nil(t, err, fmt.Sprintf("adding policy over claimed object unexpected error: %s", err))
+}
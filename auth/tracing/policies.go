@@ -0,0 +1,74 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/auth"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	savePolicy        = "save_policy"
+	claimPolicy       = "claim_policy"
+	retrieveAllPolicy = "retrieve_all_policies"
+	evaluatePolicy    = "evaluate_policies"
+	removePolicy      = "remove_policy"
+)
+
+var _ auth.PolicyRepository = (*policyRepositoryMiddleware)(nil)
+
+type policyRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   auth.PolicyRepository
+}
+
+// PolicyRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func PolicyRepositoryMiddleware(tracer opentracing.Tracer, pr auth.PolicyRepository) auth.PolicyRepository {
+	return policyRepositoryMiddleware{
+		tracer: tracer,
+		repo:   pr,
+	}
+}
+
+func (prm policyRepositoryMiddleware) Save(ctx context.Context, p auth.Policy) error {
+	span := createSpan(ctx, prm.tracer, savePolicy)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Save(ctx, p)
+}
+
+func (prm policyRepositoryMiddleware) Claim(ctx context.Context, p auth.Policy) error {
+	span := createSpan(ctx, prm.tracer, claimPolicy)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Claim(ctx, p)
+}
+
+func (prm policyRepositoryMiddleware) RetrieveAll(ctx context.Context, filter auth.Policy, pm auth.PageMetadata) (auth.PolicyPage, error) {
+	span := createSpan(ctx, prm.tracer, retrieveAllPolicy)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveAll(ctx, filter, pm)
+}
+
+func (prm policyRepositoryMiddleware) Evaluate(ctx context.Context, subjects, objects, actions []string) (bool, error) {
+	span := createSpan(ctx, prm.tracer, evaluatePolicy)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Evaluate(ctx, subjects, objects, actions)
+}
+
+func (prm policyRepositoryMiddleware) Remove(ctx context.Context, p auth.Policy) error {
+	span := createSpan(ctx, prm.tracer, removePolicy)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Remove(ctx, p)
+}
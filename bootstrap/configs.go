@@ -62,6 +62,10 @@ type ConfigRepository interface {
 	// RetrieveByExternalID returns Config for given external ID.
 	RetrieveByExternalID(externalID string) (Config, error)
 
+	// RetrieveOwner returns the owner of the Config having the provided
+	// identifier.
+	RetrieveOwner(id string) (string, error)
+
 	// Update updates an existing Config. A non-nil error is returned
 	// to indicate operation failure.
 	Update(cfg Config) error
@@ -127,6 +127,18 @@ func (crm *configRepositoryMock) RetrieveAll(token string, filter bootstrap.Filt
 	}
 }
 
+func (crm *configRepositoryMock) RetrieveOwner(id string) (string, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.configs[id]
+	if !ok {
+		return "", bootstrap.ErrNotFound
+	}
+
+	return c.Owner, nil
+}
+
 func (crm *configRepositoryMock) RetrieveByExternalID(externalID string) (bootstrap.Config, error) {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
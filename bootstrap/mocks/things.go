@@ -225,6 +225,10 @@ func (svc *mainfluxThings) Identify(context.Context, string) (string, error) {
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) DerivePSK(context.Context, string) (string, error) {
+	panic("not implemented")
+}
+
 func findIndex(list []string, val string) int {
 	for i, v := range list {
 		if v == val {
@@ -238,3 +242,19 @@ func findIndex(list []string, val string) int {
 func (svc *mainfluxThings) ListMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.Page, error) {
 	panic("not implemented")
 }
+
+func (svc *mainfluxThings) Share(context.Context, string, things.Share) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) Unshare(context.Context, string, things.Share) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListShares(context.Context, string, string, string) ([]things.Share, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewStatus(context.Context, string, string) (things.Status, error) {
+	panic("not implemented")
+}
@@ -40,7 +40,15 @@ func (svc serviceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ..
 	return nil, users.ErrUnauthorizedAccess
 }
 
+// Authorize grants no access, since the mock has no policies.
 func (svc serviceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
+	if _, ok := svc.users[req.GetToken()]; !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	return &mainflux.AuthorizeRes{Authorized: false}, nil
+}
+
+func (svc serviceMock) Granted(ctx context.Context, req *mainflux.GrantedReq, _ ...grpc.CallOption) (*mainflux.GrantedRes, error) {
 	panic("not implemented")
 }
 
@@ -51,3 +59,19 @@ func (svc serviceMock) Members(ctx context.Context, req *mainflux.MembersReq, _
 func (svc serviceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc serviceMock) Claim(ctx context.Context, req *mainflux.ClaimReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) ListPolicies(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*mainflux.PoliciesRes, error) {
+	panic("not implemented")
+}
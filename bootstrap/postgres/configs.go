@@ -195,6 +195,20 @@ func (cr configRepository) RetrieveAll(owner string, filter bootstrap.Filter, of
 	}
 }
 
+func (cr configRepository) RetrieveOwner(id string) (string, error) {
+	q := `SELECT owner FROM configs WHERE mainflux_thing = $1`
+
+	var owner string
+	if err := cr.db.QueryRowx(q, id).Scan(&owner); err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.Wrap(bootstrap.ErrNotFound, err)
+		}
+		return "", errors.Wrap(errRetrieve, err)
+	}
+
+	return owner, nil
+}
+
 func (cr configRepository) RetrieveByExternalID(externalID string) (bootstrap.Config, error) {
 	q := `SELECT mainflux_thing, mainflux_key, external_key, owner, name, client_cert, client_key, ca_cert, content, state
 		  FROM configs
@@ -15,6 +15,13 @@ import (
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
 )
 
+// Actions checked against the auth service policies.
+const (
+	readAction   = "read"
+	writeAction  = "write"
+	deleteAction = "delete"
+)
+
 var (
 	// ErrNotFound indicates a non-existent entity request.
 	ErrNotFound = errors.New("non-existent entity")
@@ -181,7 +188,7 @@ func (bs bootstrapService) Add(token string, cfg Config) (Config, error) {
 }
 
 func (bs bootstrapService) View(token, id string) (Config, error) {
-	owner, err := bs.identify(token)
+	owner, err := bs.authorize(token, id, readAction)
 	if err != nil {
 		return Config{}, err
 	}
@@ -190,7 +197,7 @@ func (bs bootstrapService) View(token, id string) (Config, error) {
 }
 
 func (bs bootstrapService) Update(token string, cfg Config) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.authorize(token, cfg.MFThing, writeAction)
 	if err != nil {
 		return err
 	}
@@ -201,7 +208,7 @@ func (bs bootstrapService) Update(token string, cfg Config) error {
 }
 
 func (bs bootstrapService) UpdateCert(token, thingID, clientCert, clientKey, caCert string) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.authorize(token, thingID, writeAction)
 	if err != nil {
 		return err
 	}
@@ -212,7 +219,7 @@ func (bs bootstrapService) UpdateCert(token, thingID, clientCert, clientKey, caC
 }
 
 func (bs bootstrapService) UpdateConnections(token, id string, connections []string) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.authorize(token, id, writeAction)
 	if err != nil {
 		return err
 	}
@@ -278,7 +285,7 @@ func (bs bootstrapService) List(token string, filter Filter, offset, limit uint6
 }
 
 func (bs bootstrapService) Remove(token, id string) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.authorize(token, id, deleteAction)
 	if err != nil {
 		return err
 	}
@@ -310,7 +317,7 @@ func (bs bootstrapService) Bootstrap(externalKey, externalID string, secure bool
 }
 
 func (bs bootstrapService) ChangeState(token, id string, state State) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.authorize(token, id, writeAction)
 	if err != nil {
 		return err
 	}
@@ -391,6 +398,41 @@ func (bs bootstrapService) identify(token string) (string, error) {
 	return res.GetEmail(), nil
 }
 
+// authorize returns the owner of the Config if the user identified by the
+// token owns it, or is granted the action over the Config thing by the auth
+// service policies. Other users act on their own behalf, leaving the
+// ownership check to the repository.
+func (bs bootstrapService) authorize(token, id, action string) (string, error) {
+	user, err := bs.identify(token)
+	if err != nil {
+		return "", err
+	}
+
+	owner, err := bs.configs.RetrieveOwner(id)
+	if err != nil {
+		if errors.Contains(err, ErrNotFound) {
+			return user, nil
+		}
+		return "", err
+	}
+	if owner == user {
+		return user, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	res, err := bs.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Obj: id, Act: action})
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if !res.GetAuthorized() {
+		return user, nil
+	}
+
+	return owner, nil
+}
+
 // Method thing retrieves Mainflux Thing creating one if an empty ID is passed.
 func (bs bootstrapService) thing(token, id string) (mfsdk.Thing, error) {
 	thingID := id
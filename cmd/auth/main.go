@@ -44,6 +44,7 @@ const (
 	defHTTPPort      = "8180"
 	defGRPCPort      = "8181"
 	defSecret        = "auth"
+	defClaimKey      = ""
 	defServerCert    = ""
 	defServerKey     = ""
 	defJaegerURL     = ""
@@ -61,6 +62,7 @@ const (
 	envHTTPPort      = "MF_AUTH_HTTP_PORT"
 	envGRPCPort      = "MF_AUTH_GRPC_PORT"
 	envSecret        = "MF_AUTH_SECRET"
+	envClaimKey      = "MF_AUTH_CLAIM_KEY"
 	envServerCert    = "MF_AUTH_SERVER_CERT"
 	envServerKey     = "MF_AUTH_SERVER_KEY"
 	envJaegerURL     = "MF_JAEGER_URL"
@@ -72,6 +74,7 @@ type config struct {
 	httpPort   string
 	grpcPort   string
 	secret     string
+	claimKey   string
 	serverCert string
 	serverKey  string
 	jaegerURL  string
@@ -100,7 +103,7 @@ func main() {
 	dbTracer, dbCloser := initJaeger("auth_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, cfg.secret, logger)
+	svc := newService(db, dbTracer, cfg.secret, cfg.claimKey, logger)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, errs)
@@ -135,6 +138,7 @@ func loadConfig() config {
 		httpPort:   mainflux.Env(envHTTPPort, defHTTPPort),
 		grpcPort:   mainflux.Env(envGRPCPort, defGRPCPort),
 		secret:     mainflux.Env(envSecret, defSecret),
+		claimKey:   mainflux.Env(envClaimKey, defClaimKey),
 		serverCert: mainflux.Env(envServerCert, defServerCert),
 		serverKey:  mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:  mainflux.Env(envJaegerURL, defJaegerURL),
@@ -175,17 +179,20 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, secret string, logger logger.Logger) auth.Service {
+func newService(db *sqlx.DB, tracer opentracing.Tracer, secret, claimKey string, logger logger.Logger) auth.Service {
 	database := postgres.NewDatabase(db)
 	keysRepo := tracing.New(postgres.New(database), tracer)
 
 	groupsRepo := postgres.NewGroupRepo(database)
 	groupsRepo = tracing.GroupRepositoryMiddleware(tracer, groupsRepo)
 
+	policiesRepo := postgres.NewPolicyRepo(database)
+	policiesRepo = tracing.PolicyRepositoryMiddleware(tracer, policiesRepo)
+
 	idProvider := uuid.New()
 	t := jwt.New(secret)
 
-	svc := auth.New(keysRepo, groupsRepo, idProvider, t)
+	svc := auth.New(keysRepo, groupsRepo, policiesRepo, idProvider, t, claimKey)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
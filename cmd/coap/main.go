@@ -16,14 +16,24 @@ import (
 	"time"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis"
 	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/auth/api/grpc"
 	"github.com/mainflux/mainflux/coap"
 	"github.com/mainflux/mainflux/coap/api"
 	logger "github.com/mainflux/mainflux/logger"
+	mqttredis "github.com/mainflux/mainflux/mqtt/redis"
+	"github.com/mainflux/mainflux/pkg/codec"
+	codecredis "github.com/mainflux/mainflux/pkg/codec/redis"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	broker "github.com/nats-io/nats.go"
 	opentracing "github.com/opentracing/opentracing-go"
-	gocoap "github.com/plgd-dev/go-coap/v2"
+	"github.com/plgd-dev/go-coap/v2/dtls"
+	"github.com/plgd-dev/go-coap/v2/mux"
+	coapnet "github.com/plgd-dev/go-coap/v2/net"
+	"github.com/plgd-dev/go-coap/v2/net/blockwise"
+	"github.com/plgd-dev/go-coap/v2/tcp"
+	"github.com/plgd-dev/go-coap/v2/udp"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
@@ -39,6 +49,23 @@ const (
 	defJaegerURL         = ""
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
+	defRetainedURL       = "localhost:6379"
+	defRetainedPass      = ""
+	defRetainedDB        = "0"
+	defCodecURL          = ""
+	defCodecPass         = ""
+	defCodecDB           = "0"
+	defDTLSMode          = ""
+	defDTLSPort          = "5684"
+	defServerCert        = ""
+	defServerKey         = ""
+	defClientCACerts     = ""
+	defTCPPort           = "5685"
+	defMaxMessageSize    = "1000000"
+	defBlockSize         = "1024"
+	defBlockwiseTimeout  = "30s"
+	defAuthURL           = "localhost:8181"
+	defAuthTimeout       = "1s"
 
 	envPort              = "MF_COAP_ADAPTER_PORT"
 	envNatsURL           = "MF_NATS_URL"
@@ -48,6 +75,23 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envRetainedURL       = "MF_RETAINED_URL"
+	envRetainedPass      = "MF_RETAINED_PASS"
+	envRetainedDB        = "MF_RETAINED_DB"
+	envCodecURL          = "MF_CODEC_URL"
+	envCodecPass         = "MF_CODEC_PASS"
+	envCodecDB           = "MF_CODEC_DB"
+	envDTLSMode          = "MF_COAP_ADAPTER_DTLS_MODE"
+	envDTLSPort          = "MF_COAP_ADAPTER_DTLS_PORT"
+	envServerCert        = "MF_COAP_ADAPTER_SERVER_CERT"
+	envServerKey         = "MF_COAP_ADAPTER_SERVER_KEY"
+	envClientCACerts     = "MF_COAP_ADAPTER_CLIENT_CA_CERTS"
+	envTCPPort           = "MF_COAP_ADAPTER_TCP_PORT"
+	envMaxMessageSize    = "MF_COAP_ADAPTER_MAX_MESSAGE_SIZE"
+	envBlockSize         = "MF_COAP_ADAPTER_BLOCK_SIZE"
+	envBlockwiseTimeout  = "MF_COAP_ADAPTER_BLOCKWISE_TIMEOUT"
+	envAuthURL           = "MF_AUTH_GRPC_URL"
+	envAuthTimeout       = "MF_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
@@ -59,6 +103,23 @@ type config struct {
 	jaegerURL         string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
+	retainedURL       string
+	retainedPass      string
+	retainedDB        string
+	codecURL          string
+	codecPass         string
+	codecDB           string
+	dtlsMode          string
+	dtlsPort          string
+	serverCert        string
+	serverKey         string
+	clientCACerts     string
+	tcpPort           string
+	maxMessageSize    int
+	blockSZX          blockwise.SZX
+	blockwiseTimeout  time.Duration
+	authURL           string
+	authTimeout       time.Duration
 }
 
 func main() {
@@ -69,13 +130,20 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	conn := connectToThings(cfg, logger)
-	defer conn.Close()
+	thingsConn := connect(cfg.thingsAuthURL, "things", cfg, logger)
+	defer thingsConn.Close()
+
+	authConn := connect(cfg.authURL, "auth", cfg, logger)
+	defer authConn.Close()
 
 	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
 	defer thingsCloser.Close()
 
-	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	tc := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsAuthTimeout)
+	ac := authapi.NewClient(authTracer, authConn, cfg.authTimeout)
 
 	nc, err := broker.Connect(cfg.natsURL)
 	if err != nil {
@@ -83,7 +151,18 @@ func main() {
 	}
 	defer nc.Close()
 
-	svc := coap.New(tc, nc)
+	rc := connectToRedis(cfg.retainedURL, cfg.retainedPass, cfg.retainedDB, logger)
+	defer rc.Close()
+
+	// The payloads are not decoded unless the codec database is configured.
+	var codecs codec.Repository
+	if cfg.codecURL != "" {
+		cc := connectToRedis(cfg.codecURL, cfg.codecPass, cfg.codecDB, logger)
+		defer cc.Close()
+		codecs = codecredis.NewRepository(cc)
+	}
+
+	svc := coap.New(tc, ac, nc, mqttredis.NewRetainedRepository(rc), codecs)
 
 	svc = api.LoggingMiddleware(svc, logger)
 
@@ -103,10 +182,19 @@ func main() {
 		}, []string{"method"}),
 	)
 
-	errs := make(chan error, 2)
+	errs := make(chan error, 5)
+
+	handler := api.MakeCoAPHandler(svc, logger)
+	wl := api.NewWSListener()
+	defer wl.Close()
 
-	go startHTTPServer(cfg.port, logger, errs)
-	go startCOAPServer(cfg, svc, nil, logger, errs)
+	go startHTTPServer(cfg.port, svc, wl, logger, errs)
+	go startCOAPServer(cfg, handler, logger, errs)
+	go startTCPServer(cfg, handler, logger, errs)
+	go startWSServer(cfg, handler, wl, errs)
+	if cfg.dtlsMode != "" {
+		go startDTLSServer(cfg, svc, tc, logger, errs)
+	}
 
 	go func() {
 		c := make(chan os.Signal)
@@ -129,6 +217,30 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
 	}
 
+	maxMessageSize, err := strconv.Atoi(mainflux.Env(envMaxMessageSize, defMaxMessageSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxMessageSize, err.Error())
+	}
+
+	blockSize, err := strconv.ParseInt(mainflux.Env(envBlockSize, defBlockSize), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBlockSize, err.Error())
+	}
+	blockSZX, err := toSZX(blockSize)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBlockSize, err.Error())
+	}
+
+	blockwiseTimeout, err := time.ParseDuration(mainflux.Env(envBlockwiseTimeout, defBlockwiseTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBlockwiseTimeout, err.Error())
+	}
+
+	usersAuthTimeout, err := time.ParseDuration(mainflux.Env(envAuthTimeout, defAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthTimeout, err.Error())
+	}
+
 	return config{
 		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
 		port:              mainflux.Env(envPort, defPort),
@@ -138,10 +250,37 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
+		retainedURL:       mainflux.Env(envRetainedURL, defRetainedURL),
+		retainedPass:      mainflux.Env(envRetainedPass, defRetainedPass),
+		retainedDB:        mainflux.Env(envRetainedDB, defRetainedDB),
+		codecURL:          mainflux.Env(envCodecURL, defCodecURL),
+		codecPass:         mainflux.Env(envCodecPass, defCodecPass),
+		codecDB:           mainflux.Env(envCodecDB, defCodecDB),
+		dtlsMode:          mainflux.Env(envDTLSMode, defDTLSMode),
+		dtlsPort:          mainflux.Env(envDTLSPort, defDTLSPort),
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		clientCACerts:     mainflux.Env(envClientCACerts, defClientCACerts),
+		tcpPort:           mainflux.Env(envTCPPort, defTCPPort),
+		maxMessageSize:    maxMessageSize,
+		blockSZX:          blockSZX,
+		blockwiseTimeout:  blockwiseTimeout,
+		authURL:           mainflux.Env(envAuthURL, defAuthURL),
+		authTimeout:       usersAuthTimeout,
+	}
+}
+
+// toSZX returns the block-wise transfer SZX of the block size.
+func toSZX(size int64) (blockwise.SZX, error) {
+	for szx := blockwise.SZX16; szx <= blockwise.SZX1024; szx++ {
+		if szx.Size() == size {
+			return szx, nil
+		}
 	}
+	return 0, fmt.Errorf("block size must be a power of two between 16 and 1024")
 }
 
-func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+func connect(url, name string, cfg config, logger logger.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	if cfg.clientTLS {
 		if cfg.caCerts != "" {
@@ -157,9 +296,9 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 		opts = append(opts, grpc.WithInsecure())
 	}
 
-	conn, err := grpc.Dial(cfg.thingsAuthURL, opts...)
+	conn, err := grpc.Dial(url, opts...)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", name, err))
 		os.Exit(1)
 	}
 	return conn
@@ -189,14 +328,89 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func startHTTPServer(port string, logger logger.Logger, errs chan error) {
+func startHTTPServer(port string, svc coap.Service, wl *api.WSListener, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", port)
 	logger.Info(fmt.Sprintf("CoAP service started, exposed port %s", port))
-	errs <- http.ListenAndServe(p, api.MakeHTTPHandler())
+	errs <- http.ListenAndServe(p, api.MakeHTTPHandler(svc, wl))
 }
 
-func startCOAPServer(cfg config, svc coap.Service, auth mainflux.ThingsServiceClient, l logger.Logger, errs chan error) {
+func startCOAPServer(cfg config, handler mux.Handler, l logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", cfg.port)
+	ln, err := coapnet.NewListenUDP("udp", p)
+	if err != nil {
+		errs <- err
+		return
+	}
+	defer ln.Close()
+
 	l.Info(fmt.Sprintf("CoAP adapter service started, exposed port %s", cfg.port))
-	errs <- gocoap.ListenAndServe("udp", p, api.MakeCoAPHandler(svc, l))
+	errs <- udp.NewServer(
+		udp.WithMux(handler),
+		udp.WithMaxMessageSize(cfg.maxMessageSize),
+		udp.WithBlockwise(true, cfg.blockSZX, cfg.blockwiseTimeout),
+	).Serve(ln)
+}
+
+func startTCPServer(cfg config, handler mux.Handler, l logger.Logger, errs chan error) {
+	p := fmt.Sprintf(":%s", cfg.tcpPort)
+	ln, err := coapnet.NewTCPListener("tcp", p)
+	if err != nil {
+		errs <- err
+		return
+	}
+	defer ln.Close()
+
+	l.Info(fmt.Sprintf("CoAP over TCP adapter service started, exposed port %s", cfg.tcpPort))
+	errs <- newTCPServer(cfg, handler).Serve(ln)
+}
+
+// startWSServer serves CoAP over WebSocket connections upgraded by the
+// HTTP server.
+func startWSServer(cfg config, handler mux.Handler, wl *api.WSListener, errs chan error) {
+	errs <- newTCPServer(cfg, handler).Serve(wl)
+}
+
+func newTCPServer(cfg config, handler mux.Handler) *tcp.Server {
+	return tcp.NewServer(
+		tcp.WithMux(handler),
+		tcp.WithMaxMessageSize(cfg.maxMessageSize),
+		tcp.WithBlockwise(true, cfg.blockSZX, cfg.blockwiseTimeout),
+	)
+}
+
+func startDTLSServer(cfg config, svc coap.Service, things mainflux.ThingsServiceClient, l logger.Logger, errs chan error) {
+	p := fmt.Sprintf(":%s", cfg.dtlsPort)
+	dtlsCfg := api.DTLSConfig{
+		Mode:         cfg.dtlsMode,
+		CertFile:     cfg.serverCert,
+		KeyFile:      cfg.serverKey,
+		ClientCAFile: cfg.clientCACerts,
+	}
+	dl, err := api.NewDTLSListener(p, dtlsCfg, things)
+	if err != nil {
+		errs <- err
+		return
+	}
+	defer dl.Close()
+
+	l.Info(fmt.Sprintf("CoAP adapter service started using DTLS %s mode, exposed port %s", cfg.dtlsMode, cfg.dtlsPort))
+	errs <- dtls.NewServer(
+		dtls.WithMux(api.MakeDTLSHandler(svc, l)),
+		dtls.WithMaxMessageSize(cfg.maxMessageSize),
+		dtls.WithBlockwise(true, cfg.blockSZX, cfg.blockwiseTimeout),
+	).Serve(dl)
+}
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
 }
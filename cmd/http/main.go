@@ -18,10 +18,14 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis"
 	"github.com/mainflux/mainflux"
 	adapter "github.com/mainflux/mainflux/http"
 	"github.com/mainflux/mainflux/http/api"
 	"github.com/mainflux/mainflux/logger"
+	mqttredis "github.com/mainflux/mainflux/mqtt/redis"
+	"github.com/mainflux/mainflux/pkg/codec"
+	codecredis "github.com/mainflux/mainflux/pkg/codec/redis"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	"github.com/opentracing/opentracing-go"
@@ -39,6 +43,12 @@ const (
 	defJaegerURL         = ""
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
+	defRetainedURL       = "localhost:6379"
+	defRetainedPass      = ""
+	defRetainedDB        = "0"
+	defCodecURL          = ""
+	defCodecPass         = ""
+	defCodecDB           = "0"
 
 	envLogLevel          = "MF_HTTP_ADAPTER_LOG_LEVEL"
 	envClientTLS         = "MF_HTTP_ADAPTER_CLIENT_TLS"
@@ -48,6 +58,12 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envRetainedURL       = "MF_RETAINED_URL"
+	envRetainedPass      = "MF_RETAINED_PASS"
+	envRetainedDB        = "MF_RETAINED_DB"
+	envCodecURL          = "MF_CODEC_URL"
+	envCodecPass         = "MF_CODEC_PASS"
+	envCodecDB           = "MF_CODEC_DB"
 )
 
 type config struct {
@@ -59,6 +75,12 @@ type config struct {
 	jaegerURL         string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
+	retainedURL       string
+	retainedPass      string
+	retainedDB        string
+	codecURL          string
+	codecPass         string
+	codecDB           string
 }
 
 func main() {
@@ -78,15 +100,35 @@ func main() {
 	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
 	defer thingsCloser.Close()
 
-	pub, err := nats.NewPublisher(cfg.natsURL)
+	pub, err := nats.NewPublisherFromEnv(cfg.natsURL)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
 		os.Exit(1)
 	}
 	defer pub.Close()
 
+	// Every instance forwards all the messages to its own subscribers, so
+	// neither the queue group nor the durable consumer is used.
+	sub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer sub.Close()
+
+	rc := connectToRedis(cfg.retainedURL, cfg.retainedPass, cfg.retainedDB, logger)
+	defer rc.Close()
+
+	// The payloads are not decoded unless the codec database is configured.
+	var codecs codec.Repository
+	if cfg.codecURL != "" {
+		cc := connectToRedis(cfg.codecURL, cfg.codecPass, cfg.codecDB, logger)
+		defer cc.Close()
+		codecs = codecredis.NewRepository(cc)
+	}
+
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
-	svc := adapter.New(pub, tc)
+	svc := adapter.New(pub, sub, tc, mqttredis.NewRetainedRepository(rc), codecs)
 
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -143,6 +185,12 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
+		retainedURL:       mainflux.Env(envRetainedURL, defRetainedURL),
+		retainedPass:      mainflux.Env(envRetainedPass, defRetainedPass),
+		retainedDB:        mainflux.Env(envRetainedDB, defRetainedDB),
+		codecURL:          mainflux.Env(envCodecURL, defCodecURL),
+		codecPass:         mainflux.Env(envCodecPass, defCodecPass),
+		codecDB:           mainflux.Env(envCodecDB, defCodecDB),
 	}
 }
 
@@ -193,3 +241,17 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	}
 	return conn
 }
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
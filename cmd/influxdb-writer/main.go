@@ -4,68 +4,125 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	influxdata "github.com/influxdata/influxdb/client/v2"
 	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/auth/api/grpc"
 	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	dlqapi "github.com/mainflux/mainflux/consumers/dlq/api"
+	"github.com/mainflux/mainflux/consumers/dlq/memory"
+	dlqnats "github.com/mainflux/mainflux/consumers/dlq/nats"
+	"github.com/mainflux/mainflux/consumers/retention"
+	retentionapi "github.com/mainflux/mainflux/consumers/retention/api"
+	"github.com/mainflux/mainflux/consumers/retention/file"
 	"github.com/mainflux/mainflux/consumers/writers/api"
 	"github.com/mainflux/mainflux/consumers/writers/influxdb"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	"github.com/mainflux/mainflux/pkg/transformers"
 	"github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	svcName = "influxdb-writer"
 
-	defNatsURL     = "nats://localhost:4222"
-	defLogLevel    = "error"
-	defPort        = "8180"
-	defDB          = "mainflux"
-	defDBHost      = "localhost"
-	defDBPort      = "8086"
-	defDBUser      = "mainflux"
-	defDBPass      = "mainflux"
-	defConfigPath  = "/config.toml"
-	defContentType = "application/senml+json"
-	defTransformer = "senml"
-
-	envNatsURL     = "MF_NATS_URL"
-	envLogLevel    = "MF_INFLUX_WRITER_LOG_LEVEL"
-	envPort        = "MF_INFLUX_WRITER_PORT"
-	envDB          = "MF_INFLUXDB_DB"
-	envDBHost      = "MF_INFLUX_WRITER_DB_HOST"
-	envDBPort      = "MF_INFLUXDB_PORT"
-	envDBUser      = "MF_INFLUXDB_ADMIN_USER"
-	envDBPass      = "MF_INFLUXDB_ADMIN_PASSWORD"
-	envConfigPath  = "MF_INFLUX_WRITER_CONFIG_PATH"
-	envContentType = "MF_INFLUX_WRITER_CONTENT_TYPE"
-	envTransformer = "MF_INFLUX_WRITER_TRANSFORMER"
+	defNatsURL           = "nats://localhost:4222"
+	defLogLevel          = "error"
+	defPort              = "8180"
+	defDB                = "mainflux"
+	defDBHost            = "localhost"
+	defDBPort            = "8086"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defConfigPath        = "/config.toml"
+	defContentType       = "application/senml+json"
+	defTransformer       = "senml"
+	defDLQCapacity       = "1000"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defThingsAuthURL     = "localhost:8181"
+	defThingsAuthTimeout = "1s"
+	defAuthURL           = "localhost:8181"
+	defAuthTimeout       = "1s"
+	defRetentionPath     = "/retention.toml"
+	defRetentionInterval = "1h"
+	defBatchSize         = "1"
+	defBatchInterval     = "1s"
+	defBatchBuffer       = "1000"
+
+	envNatsURL           = "MF_NATS_URL"
+	envLogLevel          = "MF_INFLUX_WRITER_LOG_LEVEL"
+	envPort              = "MF_INFLUX_WRITER_PORT"
+	envDB                = "MF_INFLUXDB_DB"
+	envDBHost            = "MF_INFLUX_WRITER_DB_HOST"
+	envDBPort            = "MF_INFLUXDB_PORT"
+	envDBUser            = "MF_INFLUXDB_ADMIN_USER"
+	envDBPass            = "MF_INFLUXDB_ADMIN_PASSWORD"
+	envConfigPath        = "MF_INFLUX_WRITER_CONFIG_PATH"
+	envContentType       = "MF_INFLUX_WRITER_CONTENT_TYPE"
+	envTransformer       = "MF_INFLUX_WRITER_TRANSFORMER"
+	envDLQCapacity       = "MF_INFLUX_WRITER_DLQ_CAPACITY"
+	envClientTLS         = "MF_INFLUX_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_INFLUX_WRITER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthURL           = "MF_AUTH_GRPC_URL"
+	envAuthTimeout       = "MF_AUTH_GRPC_TIMEOUT"
+	envRetentionPath     = "MF_INFLUX_WRITER_RETENTION_PATH"
+	envRetentionInterval = "MF_INFLUX_WRITER_RETENTION_INTERVAL"
+	envBatchSize         = "MF_INFLUX_WRITER_BATCH_SIZE"
+	envBatchInterval     = "MF_INFLUX_WRITER_BATCH_INTERVAL"
+	envBatchBuffer       = "MF_INFLUX_WRITER_BATCH_BUFFER"
 )
 
 type config struct {
-	natsURL     string
-	logLevel    string
-	port        string
-	dbName      string
-	dbHost      string
-	dbPort      string
-	dbUser      string
-	dbPass      string
-	configPath  string
-	contentType string
-	transformer string
+	natsURL           string
+	logLevel          string
+	port              string
+	dbName            string
+	dbHost            string
+	dbPort            string
+	dbUser            string
+	dbPass            string
+	configPath        string
+	contentType       string
+	transformer       string
+	dlqCapacity       int
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	thingsAuthURL     string
+	thingsAuthTimeout time.Duration
+	authURL           string
+	authTimeout       time.Duration
+	retentionPath     string
+	retentionInterval time.Duration
+	batch             consumers.BatchConfig
 }
 
 func main() {
@@ -76,7 +133,7 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	pubSub, err := nats.NewPubSubFromEnv(cfg.natsURL, "", svcName, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
 		os.Exit(1)
@@ -97,7 +154,43 @@ func main() {
 	repo = api.MetricsMiddleware(repo, counter, latency)
 	t := makeTransformer(cfg, logger)
 
-	if err := consumers.Start(pubSub, repo, t, cfg.configPath, logger); err != nil {
+	thingsConn := connect(cfg.thingsAuthURL, "things", cfg, logger)
+	defer thingsConn.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	tc := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsAuthTimeout)
+
+	authConn := connect(cfg.authURL, "auth", cfg, logger)
+	defer authConn.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	ac := authapi.NewClient(authTracer, authConn, cfg.authTimeout)
+
+	dlqPub, err := dlqnats.NewPublisher(cfg.natsURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer dlqPub.Close()
+	dlqSvc := newDLQService(cfg.dlqCapacity, ac, tc, dlqPub, consumers.Handler(t, repo), logger)
+
+	retentionSvc := newRetentionService(cfg.retentionPath, ac, tc, influxdb.NewRetentionStore(client, cfg.dbName), logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	go retention.Run(ctx, retentionSvc, cfg.retentionInterval, logger)
+
+	// Batching is enabled only if more than a single message is batched.
+	var consumer consumers.Consumer = repo
+	var batch consumers.BatchConsumer
+	if cfg.batch.Size > 1 {
+		batch = consumers.NewBatchConsumer(repo, cfg.batch, dlqSvc, logger)
+		consumer = batch
+	}
+
+	if err := consumers.Start(pubSub, consumer, t, dlqSvc, cfg.configPath, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to start InfluxDB writer: %s", err))
 		os.Exit(1)
 	}
@@ -109,25 +202,88 @@ func main() {
 		errs <- fmt.Errorf("%s", <-c)
 	}()
 
-	go startHTTPService(cfg.port, logger, errs)
+	go startHTTPService(cfg.port, dlqSvc, retentionSvc, logger, errs)
 
 	err = <-errs
+	cancel()
+	// Stop receiving messages before flushing the buffered ones.
+	pubSub.Close()
+	if batch != nil {
+		batch.Close()
+	}
 	logger.Error(fmt.Sprintf("InfluxDB writer service terminated: %s", err))
 }
 
 func loadConfigs() (config, influxdata.HTTPConfig) {
+	dlqCapacity, err := strconv.Atoi(mainflux.Env(envDLQCapacity, defDLQCapacity))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envDLQCapacity, err)
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsAuthTimeout, err := time.ParseDuration(mainflux.Env(envThingsAuthTimeout, defThingsAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
+	}
+
+	authTimeout, err := time.ParseDuration(mainflux.Env(envAuthTimeout, defAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthTimeout, err.Error())
+	}
+
+	retentionInterval, err := time.ParseDuration(mainflux.Env(envRetentionInterval, defRetentionInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRetentionInterval, err)
+	}
+
+	batchSize, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchSize, err)
+	}
+
+	batchInterval, err := time.ParseDuration(mainflux.Env(envBatchInterval, defBatchInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchInterval, err)
+	}
+
+	batchBuffer, err := strconv.Atoi(mainflux.Env(envBatchBuffer, defBatchBuffer))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchBuffer, err)
+	}
+
+	batch := consumers.BatchConfig{
+		Size:     batchSize,
+		Interval: batchInterval,
+		Buffer:   batchBuffer,
+	}
+
 	cfg := config{
-		natsURL:     mainflux.Env(envNatsURL, defNatsURL),
-		logLevel:    mainflux.Env(envLogLevel, defLogLevel),
-		port:        mainflux.Env(envPort, defPort),
-		dbName:      mainflux.Env(envDB, defDB),
-		dbHost:      mainflux.Env(envDBHost, defDBHost),
-		dbPort:      mainflux.Env(envDBPort, defDBPort),
-		dbUser:      mainflux.Env(envDBUser, defDBUser),
-		dbPass:      mainflux.Env(envDBPass, defDBPass),
-		configPath:  mainflux.Env(envConfigPath, defConfigPath),
-		contentType: mainflux.Env(envContentType, defContentType),
-		transformer: mainflux.Env(envTransformer, defTransformer),
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		port:              mainflux.Env(envPort, defPort),
+		dbName:            mainflux.Env(envDB, defDB),
+		dbHost:            mainflux.Env(envDBHost, defDBHost),
+		dbPort:            mainflux.Env(envDBPort, defDBPort),
+		dbUser:            mainflux.Env(envDBUser, defDBUser),
+		dbPass:            mainflux.Env(envDBPass, defDBPass),
+		configPath:        mainflux.Env(envConfigPath, defConfigPath),
+		contentType:       mainflux.Env(envContentType, defContentType),
+		transformer:       mainflux.Env(envTransformer, defTransformer),
+		dlqCapacity:       dlqCapacity,
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
+		thingsAuthTimeout: thingsAuthTimeout,
+		authURL:           mainflux.Env(envAuthURL, defAuthURL),
+		authTimeout:       authTimeout,
+		retentionPath:     mainflux.Env(envRetentionPath, defRetentionPath),
+		retentionInterval: retentionInterval,
+		batch:             batch,
 	}
 
 	clientCfg := influxdata.HTTPConfig{
@@ -157,23 +313,133 @@ func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
 	return counter, latency
 }
 
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connect(url, svc string, cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(url, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", svc, err))
+		os.Exit(1)
+	}
+	return conn
+}
+
+func newDLQService(capacity int, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, pub dlq.Publisher, handler messaging.MessageHandler, logger logger.Logger) dlq.Service {
+	msgs := memory.NewMessageRepository(capacity)
+	svc := dlq.New(ac, tc, msgs, pub, handler, uuid.New())
+	svc = dlqapi.LoggingMiddleware(svc, logger)
+	svc = dlqapi.MetricsMiddleware(
+		svc,
+		msgs,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "dead_letter_queue",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "influxdb",
+			Subsystem: "dead_letter_queue",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: "influxdb",
+			Subsystem: "dead_letter_queue",
+			Name:      "depth",
+			Help:      "Number of stored dead letters.",
+		}, []string{}),
+	)
+
+	return svc
+}
+
+func newRetentionService(path string, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, store retention.Store, logger logger.Logger) retention.Service {
+	svc := retention.New(ac, tc, file.NewRuleRepository(path), store)
+	svc = retentionapi.LoggingMiddleware(svc, logger)
+	svc = retentionapi.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "retention",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "influxdb",
+			Subsystem: "retention",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
 func makeTransformer(cfg config, logger logger.Logger) transformers.Transformer {
+	senmlT, jsonT := senml.New(cfg.contentType), json.New()
+
+	// The configured transformer is used for the messages that don't
+	// specify the content type.
+	var def transformers.Transformer
 	switch strings.ToUpper(cfg.transformer) {
 	case "SENML":
 		logger.Info("Using SenML transformer")
-		return senml.New(cfg.contentType)
+		def = senmlT
 	case "JSON":
 		logger.Info("Using JSON transformer")
-		return json.New()
+		def = jsonT
 	default:
 		logger.Error(fmt.Sprintf("Can't create transformer: unknown transformer type %s", cfg.transformer))
 		os.Exit(1)
-		return nil
 	}
+
+	return transformers.NewMux(def, map[string]transformers.Transformer{
+		senml.JSON:       senmlT,
+		senml.CBOR:       senmlT,
+		json.ContentType: jsonT,
+	})
 }
 
-func startHTTPService(port string, logger logger.Logger, errs chan error) {
+func startHTTPService(port string, dlqSvc dlq.Service, retentionSvc retention.Service, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", port)
 	logger.Info(fmt.Sprintf("InfluxDB writer service started, exposed port %s", p))
-	errs <- http.ListenAndServe(p, api.MakeHandler(svcName))
+	errs <- http.ListenAndServe(p, api.MakeHandler(dlqSvc, retentionSvc, svcName))
 }
@@ -5,7 +5,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,17 +14,21 @@ import (
 	"syscall"
 	"time"
 
+	paho "github.com/eclipse/paho.mqtt.golang"
 	r "github.com/go-redis/redis"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/lora"
 	"github.com/mainflux/mainflux/lora/api"
+	loramqtt "github.com/mainflux/mainflux/lora/mqtt"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/messaging/mqtt"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/mainflux/mainflux/lora/redis"
+	codecredis "github.com/mainflux/mainflux/pkg/codec/redis"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 )
 
@@ -42,6 +45,9 @@ const (
 	defRouteMapURL    = "localhost:6379"
 	defRouteMapPass   = ""
 	defRouteMapDB     = "0"
+	defSenMLMetadata  = "false"
+	defBaseURL        = "http://localhost"
+	defThingsPrefix   = ""
 
 	envHTTPPort       = "MF_LORA_ADAPTER_HTTP_PORT"
 	envLoraMsgURL     = "MF_LORA_ADAPTER_MESSAGES_URL"
@@ -55,11 +61,16 @@ const (
 	envRouteMapURL    = "MF_LORA_ADAPTER_ROUTE_MAP_URL"
 	envRouteMapPass   = "MF_LORA_ADAPTER_ROUTE_MAP_PASS"
 	envRouteMapDB     = "MF_LORA_ADAPTER_ROUTE_MAP_DB"
-
-	loraServerTopic = "application/+/device/+/rx"
+	envSenMLMetadata  = "MF_LORA_ADAPTER_SENML_METADATA"
+	envBaseURL        = "MF_SDK_BASE_URL"
+	envThingsPrefix   = "MF_SDK_THINGS_PREFIX"
 
 	thingsRMPrefix   = "thing"
 	channelsRMPrefix = "channel"
+
+	// All the instances share the queue group, so that each downlink is
+	// sent to the LoRa Server once.
+	queue = "lora"
 )
 
 type config struct {
@@ -75,6 +86,9 @@ type config struct {
 	routeMapURL    string
 	routeMapPass   string
 	routeMapDB     string
+	senmlMetadata  bool
+	baseURL        string
+	thingsPrefix   string
 }
 
 func main() {
@@ -91,17 +105,34 @@ func main() {
 	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer esConn.Close()
 
-	pub, err := nats.NewPublisher(cfg.natsURL)
+	pub, err := nats.NewPublisherFromEnv(cfg.natsURL)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
 		os.Exit(1)
 	}
 	defer pub.Close()
 
+	loraPub, err := mqtt.NewPublisher(cfg.loraMsgURL, cfg.subTimeout)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create MQTT publisher: %s", err))
+		os.Exit(1)
+	}
+
+	if err := redis.MigrateLoRaIDs(rmConn, thingsRMPrefix); err != nil {
+		logger.Error(fmt.Sprintf("Failed to migrate device EUIs: %s", err))
+		os.Exit(1)
+	}
 	thingRM := newRouteMapRepositoy(rmConn, thingsRMPrefix, logger)
 	chanRM := newRouteMapRepositoy(rmConn, channelsRMPrefix, logger)
 
-	svc := lora.New(pub, thingRM, chanRM)
+	formats := redis.NewFormatRepository(rmConn)
+	codecs := codecredis.NewRepository(rmConn)
+	sdk := mfsdk.NewSDK(mfsdk.Config{
+		BaseURL:      cfg.baseURL,
+		ThingsPrefix: cfg.thingsPrefix,
+	})
+
+	svc := lora.New(pub, loraPub, thingRM, chanRM, formats, lora.Decoders(), codecs, sdk, cfg.senmlMetadata)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -119,19 +150,25 @@ func main() {
 		}, []string{"method"}),
 	)
 
-	msub, err := mqtt.NewSubscriber(cfg.loraMsgURL, cfg.subTimeout, logger)
+	mqttConn := connectToMQTTBroker(cfg.loraMsgURL, cfg.subTimeout, logger)
+	defer mqttConn.Disconnect(0)
+
+	go subscribeToLoRaBroker(svc, mqttConn, cfg.subTimeout, logger)
+
+	nps, err := nats.NewPubSubFromEnv(cfg.natsURL, queue, queue, logger)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to create MQTT subscriber: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
 		os.Exit(1)
 	}
+	defer nps.Close()
 
-	go subscribeToLoRaBroker(svc, msub, logger)
+	go subscribeToNATS(svc, nps, logger)
 
 	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
 
 	errs := make(chan error, 2)
 
-	go startHTTPServer(cfg, logger, errs)
+	go startHTTPServer(svc, cfg, logger, errs)
 
 	go func() {
 		c := make(chan os.Signal)
@@ -148,6 +185,10 @@ func loadConfig() config {
 	if err != nil {
 		log.Fatalf("Invalid %s value: %s", envSubTimeout, err.Error())
 	}
+	senmlMetadata, err := strconv.ParseBool(mainflux.Env(envSenMLMetadata, defSenMLMetadata))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envSenMLMetadata, err.Error())
+	}
 	return config{
 		httpPort:       mainflux.Env(envHTTPPort, defHTTPPort),
 		loraMsgURL:     mainflux.Env(envLoraMsgURL, defLoraMsgURL),
@@ -161,6 +202,9 @@ func loadConfig() config {
 		routeMapURL:    mainflux.Env(envRouteMapURL, defRouteMapURL),
 		routeMapPass:   mainflux.Env(envRouteMapPass, defRouteMapPass),
 		routeMapDB:     mainflux.Env(envRouteMapDB, defRouteMapDB),
+		senmlMetadata:  senmlMetadata,
+		baseURL:        mainflux.Env(envBaseURL, defBaseURL),
+		thingsPrefix:   mainflux.Env(envThingsPrefix, defThingsPrefix),
 	}
 }
 
@@ -178,23 +222,51 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *
 	})
 }
 
-func subscribeToLoRaBroker(svc lora.Service, msub messaging.Subscriber, logger logger.Logger) {
-	err := msub.Subscribe(loraServerTopic, func(msg messaging.Message) error {
-		var m lora.Message
-		if err := json.Unmarshal(msg.Payload, &m); err != nil {
-			logger.Warn(fmt.Sprintf("Failed to Unmarshal message: %s", err.Error()))
-			return err
-		}
-		if err := svc.Publish(context.Background(), "", m); err != nil {
-			return err
+func connectToMQTTBroker(url string, timeout time.Duration, logger logger.Logger) paho.Client {
+	opts := paho.NewClientOptions().
+		AddBroker(url).
+		SetOnConnectHandler(func(c paho.Client) {
+			logger.Info("Connected to LoRa MQTT broker")
+		}).
+		SetConnectionLostHandler(func(c paho.Client, err error) {
+			logger.Error(fmt.Sprintf("MQTT connection lost: %s", err))
+			os.Exit(1)
+		})
+
+	client := paho.NewClient(opts)
+	token := client.Connect()
+	if token.Error() == nil && !token.WaitTimeout(timeout) {
+		logger.Error("Failed to connect to LoRa MQTT broker: timeout reached")
+		os.Exit(1)
+	}
+	if token.Error() != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to LoRa MQTT broker: %s", token.Error()))
+		os.Exit(1)
+	}
+
+	return client
+}
+
+func subscribeToLoRaBroker(svc lora.Service, client paho.Client, timeout time.Duration, logger logger.Logger) {
+	broker := loramqtt.NewBroker(svc, client, timeout, logger)
+	for _, topic := range loramqtt.Topics {
+		if err := broker.Subscribe(topic); err != nil {
+			logger.Error(fmt.Sprintf("Failed to subscribe to LoRa MQTT broker: %s", err))
+			os.Exit(1)
 		}
-		return nil
+	}
+	logger.Info("Subscribed to LoRa MQTT broker")
+}
+
+func subscribeToNATS(svc lora.Service, nps messaging.Subscriber, logger logger.Logger) {
+	err := nps.Subscribe(nats.SubjectAllChannels, func(msg messaging.Message) error {
+		return svc.Downlink(context.Background(), msg)
 	})
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to subscribe to LoRa MQTT broker: %s", err))
+		logger.Error(fmt.Sprintf("Failed to subscribe to NATS: %s", err))
 		os.Exit(1)
 	}
-	logger.Info("Subscribed to LoRa MQTT broker")
+	logger.Info("Subscribed to NATS")
 }
 
 func subscribeToThingsES(svc lora.Service, client *r.Client, consumer string, logger logger.Logger) {
@@ -210,8 +282,8 @@ func newRouteMapRepositoy(client *r.Client, prefix string, logger logger.Logger)
 	return redis.NewRouteMapRepository(client, prefix)
 }
 
-func startHTTPServer(cfg config, logger logger.Logger, errs chan error) {
+func startHTTPServer(svc lora.Service, cfg config, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", cfg.httpPort)
 	logger.Info(fmt.Sprintf("LoRa-adapter service started, exposed port %s", cfg.httpPort))
-	errs <- http.ListenAndServe(p, api.MakeHandler())
+	errs <- http.ListenAndServe(p, api.MakeHandler(svc))
 }
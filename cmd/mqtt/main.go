@@ -13,10 +13,12 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/go-redis/redis"
 	"github.com/mainflux/mainflux"
 	mflog "github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/mqtt"
+	"github.com/mainflux/mainflux/mqtt/proxy"
 	mqttredis "github.com/mainflux/mainflux/mqtt/redis"
 	"github.com/mainflux/mainflux/pkg/auth"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -24,10 +26,9 @@ import (
 	mqttpub "github.com/mainflux/mainflux/pkg/messaging/mqtt"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
-	mp "github.com/mainflux/mproxy/pkg/mqtt"
-	"github.com/mainflux/mproxy/pkg/session"
-	ws "github.com/mainflux/mproxy/pkg/websocket"
 	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -43,11 +44,13 @@ const (
 	defMQTTTargetPort        = "1883"
 	defMQTTForwarderTimeout  = "30s" // 30 seconds
 	defMQTTTargetHealthCheck = ""
+	defMQTTErrorsFeedback    = "false"
 	envMQTTPort              = "MF_MQTT_ADAPTER_MQTT_PORT"
 	envMQTTTargetHost        = "MF_MQTT_ADAPTER_MQTT_TARGET_HOST"
 	envMQTTTargetPort        = "MF_MQTT_ADAPTER_MQTT_TARGET_PORT"
 	envMQTTTargetHealthCheck = "MF_MQTT_ADAPTER_MQTT_TARGET_HEALTH_CHECK"
 	envMQTTForwarderTimeout  = "MF_MQTT_ADAPTER_FORWARDER_TIMEOUT"
+	envMQTTErrorsFeedback    = "MF_MQTT_ADAPTER_ERRORS_FEEDBACK"
 	// HTTP
 	defHTTPPort       = "8080"
 	defHTTPTargetHost = "localhost"
@@ -90,6 +93,13 @@ const (
 	defAuthcacheURL  = "localhost:6379"
 	defAuthCachePass = ""
 	defAuthCacheDB   = "0"
+	// Retained messages
+	envRetainedURL  = "MF_RETAINED_URL"
+	envRetainedPass = "MF_RETAINED_PASS"
+	envRetainedDB   = "MF_RETAINED_DB"
+	defRetainedURL  = "localhost:6379"
+	defRetainedPass = ""
+	defRetainedDB   = "0"
 )
 
 type config struct {
@@ -98,6 +108,7 @@ type config struct {
 	mqttTargetPort        string
 	mqttForwarderTimeout  time.Duration
 	mqttTargetHealthCheck string
+	mqttErrorsFeedback    bool
 	httpPort              string
 	httpTargetHost        string
 	httpTargetPort        string
@@ -117,6 +128,9 @@ type config struct {
 	authURL               string
 	authPass              string
 	authDB                string
+	retainedURL           string
+	retainedPass          string
+	retainedDB            string
 }
 
 func main() {
@@ -145,7 +159,7 @@ func main() {
 	ec := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer ec.Close()
 
-	nps, err := nats.NewPubSub(cfg.natsURL, "mqtt", logger)
+	nps, err := nats.NewPubSubFromEnv(cfg.natsURL, "mqtt", "mqtt", logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
 		os.Exit(1)
@@ -164,7 +178,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	np, err := nats.NewPublisher(cfg.natsURL)
+	np, err := nats.NewPublisherFromEnv(cfg.natsURL)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
 		os.Exit(1)
@@ -173,6 +187,24 @@ func main() {
 
 	es := mqttredis.NewEventStore(ec, cfg.instance)
 
+	rc := connectToRedis(cfg.retainedURL, cfg.retainedPass, cfg.retainedDB, logger)
+	defer rc.Close()
+	retained := mqttredis.NewRetainedRepository(rc)
+
+	// The last message of each channel and subtopic is kept for all the
+	// adapters, so a single queue group is used by all the instances.
+	rps, err := nats.NewPubSubFromEnv(cfg.natsURL, "mqtt-retained", "mqtt-retained", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer rps.Close()
+
+	if err := rps.Subscribe(nats.SubjectAllChannels, retained.Save); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe for retained messages: %s", err))
+		os.Exit(1)
+	}
+
 	ac := connectToRedis(cfg.authURL, cfg.authPass, cfg.authDB, logger)
 	defer ac.Close()
 
@@ -182,8 +214,23 @@ func main() {
 
 	authClient := auth.New(ac, tc)
 
+	// Publish errors are sent to the things over the MQTT broker.
+	var fbPub messaging.Publisher
+	if cfg.mqttErrorsFeedback {
+		fbPub = mpub
+	}
+	fb := mqtt.MetricsMiddleware(
+		mqtt.NewFeedback(fbPub),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "mqtt_adapter",
+			Subsystem: "feedback",
+			Name:      "publish_errors",
+			Help:      "Number of lost messages per thing.",
+		}, []string{"thing_id", "error"}),
+	)
+
 	// Event handler for MQTT hooks
-	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient)
+	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient, fb, retained)
 
 	errs := make(chan error, 2)
 
@@ -219,12 +266,18 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envMQTTForwarderTimeout, err.Error())
 	}
 
+	errorsFeedback, err := strconv.ParseBool(mainflux.Env(envMQTTErrorsFeedback, defMQTTErrorsFeedback))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envMQTTErrorsFeedback)
+	}
+
 	return config{
 		mqttPort:              mainflux.Env(envMQTTPort, defMQTTPort),
 		mqttTargetHost:        mainflux.Env(envMQTTTargetHost, defMQTTTargetHost),
 		mqttTargetPort:        mainflux.Env(envMQTTTargetPort, defMQTTTargetPort),
 		mqttForwarderTimeout:  mqttTimeout,
 		mqttTargetHealthCheck: mainflux.Env(envMQTTTargetHealthCheck, defMQTTTargetHealthCheck),
+		mqttErrorsFeedback:    errorsFeedback,
 		httpPort:              mainflux.Env(envHTTPPort, defHTTPPort),
 		httpTargetHost:        mainflux.Env(envHTTPTargetHost, defHTTPTargetHost),
 		httpTargetPort:        mainflux.Env(envHTTPTargetPort, defHTTPTargetPort),
@@ -244,6 +297,9 @@ func loadConfig() config {
 		authURL:               mainflux.Env(envAuthCacheURL, defAuthcacheURL),
 		authPass:              mainflux.Env(envAuthCachePass, defAuthCachePass),
 		authDB:                mainflux.Env(envAuthCacheDB, defAuthCacheDB),
+		retainedURL:           mainflux.Env(envRetainedURL, defRetainedURL),
+		retainedPass:          mainflux.Env(envRetainedPass, defRetainedPass),
+		retainedDB:            mainflux.Env(envRetainedDB, defRetainedDB),
 	}
 }
 
@@ -309,19 +365,20 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger mflog.Logger) *r
 	})
 }
 
-func proxyMQTT(cfg config, logger mflog.Logger, handler session.Handler, errs chan error) {
+func proxyMQTT(cfg config, logger mflog.Logger, handler proxy.Handler, errs chan error) {
 	address := fmt.Sprintf(":%s", cfg.mqttPort)
 	target := fmt.Sprintf("%s:%s", cfg.mqttTargetHost, cfg.mqttTargetPort)
-	mp := mp.New(address, target, handler, logger)
+	mp := proxy.New(address, target, handler, logger)
 
 	errs <- mp.Listen()
 }
-func proxyWS(cfg config, logger mflog.Logger, handler session.Handler, errs chan error) {
+func proxyWS(cfg config, logger mflog.Logger, handler proxy.Handler, errs chan error) {
 	target := fmt.Sprintf("%s:%s", cfg.httpTargetHost, cfg.httpTargetPort)
-	wp := ws.New(target, cfg.httpTargetPath, "ws", handler, logger)
+	wp := proxy.NewWebSocket(target, cfg.httpTargetPath, "ws", handler, logger)
 	http.Handle("/mqtt", wp.Handler())
+	http.Handle("/metrics", promhttp.Handler())
 
-	errs <- wp.Listen(cfg.httpPort)
+	errs <- http.ListenAndServe(fmt.Sprintf(":%s", cfg.httpPort), nil)
 }
 
 func healthcheck(cfg config) func() error {
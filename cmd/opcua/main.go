@@ -5,7 +5,9 @@ package main
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
@@ -18,9 +20,9 @@ import (
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/opcua"
 	"github.com/mainflux/mainflux/opcua/api"
-	"github.com/mainflux/mainflux/opcua/db"
 	"github.com/mainflux/mainflux/opcua/gopcua"
 	"github.com/mainflux/mainflux/opcua/redis"
+	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
@@ -60,9 +62,17 @@ const (
 	envRouteMapPass   = "MF_OPCUA_ADAPTER_ROUTE_MAP_PASS"
 	envRouteMapDB     = "MF_OPCUA_ADAPTER_ROUTE_MAP_DB"
 
+	// storedNodesPath is the CSV file of the subscriptions stored by the
+	// previous versions of the adapter.
+	storedNodesPath = "/store/nodes.csv"
+
 	thingsRMPrefix     = "thing"
 	channelsRMPrefix   = "channel"
 	connectionRMPrefix = "connection"
+
+	// All the instances share the queue group, so that each command is
+	// executed on the OPC-UA Server once.
+	queue = "opcua"
 )
 
 type config struct {
@@ -93,11 +103,12 @@ func main() {
 	thingRM := newRouteMapRepositoy(rmConn, thingsRMPrefix, logger)
 	chanRM := newRouteMapRepositoy(rmConn, channelsRMPrefix, logger)
 	connRM := newRouteMapRepositoy(rmConn, connectionRMPrefix, logger)
+	nodeRepo := redis.NewNodeRepository(rmConn)
 
 	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer esConn.Close()
 
-	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	pubSub, err := nats.NewPubSubFromEnv(cfg.natsURL, queue, queue, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
 		os.Exit(1)
@@ -107,8 +118,9 @@ func main() {
 	ctx := context.Background()
 	sub := gopcua.NewSubscriber(ctx, pubSub, thingRM, chanRM, connRM, logger)
 	browser := gopcua.NewBrowser(ctx, logger)
+	writer := gopcua.NewWriter(ctx, logger)
 
-	svc := opcua.New(sub, browser, thingRM, chanRM, connRM, cfg.opcuaConfig, logger)
+	svc := opcua.New(sub, browser, writer, pubSub, thingRM, chanRM, connRM, nodeRepo, cfg.opcuaConfig, logger)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -126,8 +138,10 @@ func main() {
 		}, []string{"method"}),
 	)
 
-	go subscribeToStoredSubs(sub, cfg.opcuaConfig, logger)
+	importStoredNodes(storedNodesPath, thingRM, chanRM, connRM, nodeRepo, cfg.opcuaConfig, logger)
+	go subscribeToStoredSubs(sub, nodeRepo, logger)
 	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
+	go subscribeToNATS(svc, pubSub, logger)
 
 	errs := make(chan error, 2)
 
@@ -180,30 +194,107 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *
 	})
 }
 
-func subscribeToStoredSubs(sub opcua.Subscriber, cfg opcua.Config, logger logger.Logger) {
+func subscribeToStoredSubs(sub opcua.Subscriber, nodes opcua.NodeRepository, logger logger.Logger) {
 	// Get all stored subscriptions
-	nodes, err := db.ReadAll()
+	ns, err := nodes.RetrieveAll()
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Read stored subscriptions failed: %s", err))
+		return
 	}
 
-	for _, n := range nodes {
-		cfg.ServerURI = n.ServerURI
-		cfg.NodeID = n.NodeID
-		go func() {
-			if err := sub.Subscribe(cfg); err != nil {
+	for _, n := range ns {
+		go func(n opcua.Node) {
+			if err := sub.Subscribe(n); err != nil {
 				logger.Warn(fmt.Sprintf("Subscription failed: %s", err))
 			}
-		}()
+		}(n)
 	}
 }
 
-func subscribeToOpcuaServer(gc opcua.Subscriber, cfg opcua.Config, logger logger.Logger) {
-	if err := gc.Subscribe(cfg); err != nil {
+// importStoredNodes imports the subscriptions stored in the CSV file by the
+// previous versions of the adapter, as the server URI and the node ID rows.
+// The nodes of the existing connections are saved with the default settings,
+// and the file is renamed, so that it's imported only once.
+func importStoredNodes(path string, thingRM, chanRM, connRM opcua.RouteMapRepository, nodes opcua.NodeRepository, cfg opcua.Config, logger logger.Logger) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to open stored subscriptions: %s", err))
+		return
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	imported := 0
+	for {
+		l, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to read stored subscriptions: %s", err))
+			return
+		}
+		if len(l) < 2 {
+			continue
+		}
+
+		chanID, err := chanRM.Get(l[0])
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Skipped stored subscription of server %s: %s", l[0], err))
+			continue
+		}
+		thingID, err := thingRM.Get(l[1])
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Skipped stored subscription of node %s: %s", l[1], err))
+			continue
+		}
+		c := fmt.Sprintf("%s:%s", chanID, thingID)
+		if _, err := connRM.Get(c); err != nil {
+			logger.Warn(fmt.Sprintf("Skipped stored subscription of channel %s and thing %s: %s", chanID, thingID, err))
+			continue
+		}
+
+		n := opcua.Node{
+			ChanID:  chanID,
+			ThingID: thingID,
+			Config:  cfg,
+		}
+		n.Config.ServerURI = l[0]
+		n.Config.NodeID = l[1]
+		if err := nodes.Save(n); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to import stored subscriptions: %s", err))
+			return
+		}
+		imported++
+	}
+
+	if err := os.Rename(path, path+".imported"); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to rename stored subscriptions: %s", err))
+	}
+	logger.Info(fmt.Sprintf("Imported %d stored subscriptions", imported))
+}
+
+func subscribeToOpcuaServer(gc opcua.Subscriber, n opcua.Node, logger logger.Logger) {
+	if err := gc.Subscribe(n); err != nil {
 		logger.Warn(fmt.Sprintf("OPC-UA Subscription failed: %s", err))
 	}
 }
 
+func subscribeToNATS(svc opcua.Service, nps messaging.Subscriber, logger logger.Logger) {
+	err := nps.Subscribe(nats.SubjectAllChannels, func(msg messaging.Message) error {
+		return svc.Command(context.Background(), msg)
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to NATS: %s", err))
+		os.Exit(1)
+	}
+	logger.Info("Subscribed to NATS")
+}
+
 func subscribeToThingsES(svc opcua.Service, client *r.Client, prefix string, logger logger.Logger) {
 	eventStore := redis.NewEventStore(svc, client, prefix, logger)
 	if err := eventStore.Subscribe("mainflux.things"); err != nil {
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -27,6 +28,7 @@ import (
 	"github.com/mainflux/mainflux"
 	authapi "github.com/mainflux/mainflux/auth/api/grpc"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things"
 	"github.com/mainflux/mainflux/things/api"
@@ -70,6 +72,11 @@ const (
 	defJaegerURL       = ""
 	defAuthURL         = "localhost:8181"
 	defAuthTimeout     = "1s"
+	defAuthClaimKey    = ""
+	defNatsURL         = "nats://localhost:4222"
+	defEventConsumer   = "things"
+	defPresenceChannel = ""
+	defPresenceTimeout = "5m"
 
 	envLogLevel        = "MF_THINGS_LOG_LEVEL"
 	envDBHost          = "MF_THINGS_DB_HOST"
@@ -99,6 +106,11 @@ const (
 	envJaegerURL       = "MF_JAEGER_URL"
 	envAuthURL         = "MF_AUTH_GRPC_URL"
 	envAuthTimeout     = "MF_AUTH_GRPC_TIMEOUT"
+	envAuthClaimKey    = "MF_AUTH_CLAIM_KEY"
+	envNatsURL         = "MF_NATS_URL"
+	envEventConsumer   = "MF_THINGS_EVENT_CONSUMER"
+	envPresenceChannel = "MF_THINGS_PRESENCE_CHANNEL"
+	envPresenceTimeout = "MF_THINGS_PRESENCE_TIMEOUT"
 )
 
 type config struct {
@@ -122,6 +134,11 @@ type config struct {
 	jaegerURL       string
 	authURL         string
 	authTimeout     time.Duration
+	authClaimKey    string
+	natsURL         string
+	eventConsumer   string
+	presenceChannel string
+	presenceTimeout time.Duration
 }
 
 func main() {
@@ -156,9 +173,27 @@ func main() {
 	cacheTracer, cacheCloser := initJaeger("things_cache", cfg.jaegerURL, logger)
 	defer cacheCloser.Close()
 
-	svc := newService(auth, dbTracer, cacheTracer, db, cacheClient, esClient, logger)
+	statusRepo := postgres.NewStatusRepository(postgres.NewDatabase(db))
+	statusRepo = tracing.StatusRepositoryMiddleware(dbTracer, statusRepo)
+
+	svc := newService(auth, cfg.authClaimKey, dbTracer, cacheTracer, db, statusRepo, cacheClient, esClient, logger)
 	errs := make(chan error, 2)
 
+	ps, err := nats.NewPubSubFromEnv(cfg.natsURL, "things-presence", "things-presence", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer ps.Close()
+
+	presence := things.NewPresence(statusRepo, ps, cfg.presenceChannel, cfg.presenceTimeout)
+	if err := ps.Subscribe(nats.SubjectAllChannels, things.ActivityHandler(presence)); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to things activity: %s", err))
+		os.Exit(1)
+	}
+	go subscribeToPresence(presence, esClient, cfg.eventConsumer, logger)
+	go expirePresence(presence, cfg.presenceTimeout, logger)
+
 	go startHTTPServer(thhttpapi.MakeHandler(thingsTracer, svc), cfg.httpPort, cfg, logger, errs)
 	go startHTTPServer(authhttpapi.MakeHandler(thingsTracer, svc), cfg.authHTTPPort, cfg, logger, errs)
 	go startGRPCServer(svc, thingsTracer, cfg, logger, errs)
@@ -184,6 +219,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envAuthTimeout, err.Error())
 	}
 
+	presenceTimeout, err := time.ParseDuration(mainflux.Env(envPresenceTimeout, defPresenceTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envPresenceTimeout, err.Error())
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -217,6 +257,11 @@ func loadConfig() config {
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		authURL:         mainflux.Env(envAuthURL, defAuthURL),
 		authTimeout:     authTimeout,
+		authClaimKey:    mainflux.Env(envAuthClaimKey, defAuthClaimKey),
+		natsURL:         mainflux.Env(envNatsURL, defNatsURL),
+		eventConsumer:   mainflux.Env(envEventConsumer, defEventConsumer),
+		presenceChannel: mainflux.Env(envPresenceChannel, defPresenceChannel),
+		presenceTimeout: presenceTimeout,
 	}
 }
 
@@ -301,7 +346,7 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func newService(auth mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cacheTracer opentracing.Tracer, db *sqlx.DB, cacheClient *redis.Client, esClient *redis.Client, logger logger.Logger) things.Service {
+func newService(auth mainflux.AuthServiceClient, claimKey string, dbTracer opentracing.Tracer, cacheTracer opentracing.Tracer, db *sqlx.DB, statusRepo things.StatusRepository, cacheClient *redis.Client, esClient *redis.Client, logger logger.Logger) things.Service {
 	database := postgres.NewDatabase(db)
 
 	thingsRepo := postgres.NewThingRepository(database)
@@ -317,7 +362,7 @@ func newService(auth mainflux.AuthServiceClient, dbTracer opentracing.Tracer, ca
 	thingCache = tracing.ThingCacheMiddleware(cacheTracer, thingCache)
 	idProvider := uuid.New()
 
-	svc := things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, idProvider)
+	svc := things.New(auth, thingsRepo, channelsRepo, statusRepo, chanCache, thingCache, idProvider, claimKey)
 	svc = rediscache.NewEventStoreMiddleware(svc, esClient)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -338,6 +383,25 @@ func newService(auth mainflux.AuthServiceClient, dbTracer opentracing.Tracer, ca
 	return svc
 }
 
+func subscribeToPresence(presence things.Presence, client *redis.Client, consumer string, logger logger.Logger) {
+	ps := rediscache.NewPresenceSubscriber(presence, client, consumer, logger)
+	if err := ps.Subscribe(context.Background()); err != nil {
+		logger.Warn(fmt.Sprintf("Things service failed to subscribe to connection events: %s", err))
+	}
+}
+
+func expirePresence(presence things.Presence, timeout time.Duration, logger logger.Logger) {
+	// Things are checked often enough to go offline shortly after the timeout.
+	ticker := time.NewTicker(timeout / 10)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := presence.Expire(context.Background()); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to expire things presence: %s", err))
+		}
+	}
+}
+
 func startHTTPServer(handler http.Handler, port string, cfg config, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", port)
 	if cfg.serverCert != "" || cfg.serverKey != "" {
@@ -9,9 +9,12 @@ package coap
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/mainflux/mainflux/pkg/codec"
 	"github.com/mainflux/mainflux/pkg/errors"
 	broker "github.com/nats-io/nats.go"
 
@@ -19,25 +22,49 @@ import (
 	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
-const chansPrefix = "channels"
+const (
+	chansPrefix = "channels"
+
+	// Actions checked against the auth service policies.
+	readAction  = "read"
+	writeAction = "write"
+)
 
 // Exported errors
 var (
 	ErrUnauthorized = errors.New("unauthorized access")
 	ErrUnsubscribe  = errors.New("unable to unsubscribe")
+	ErrNotFound     = errors.New("observer not found")
 )
 
+// Thing identifies the thing which sent the message. Things authenticated
+// by the DTLS pre-shared key handshake are identified by their ID, since
+// their key is never known to the adapter. Otherwise, the key is used.
+type Thing struct {
+	ID  string
+	Key string
+}
+
 // Service specifies CoAP service API.
 type Service interface {
 	// Publish Messssage
-	Publish(ctx context.Context, key string, msg messaging.Message) error
+	Publish(ctx context.Context, thing Thing, msg messaging.Message) error
 
 	// Subscribes to channel with specified id, subtopic and adds subscription to
 	// service map of subscriptions under given ID.
-	Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error
+	Subscribe(ctx context.Context, thing Thing, chanID, subtopic string, c Client) error
 
 	// Unsubscribe method is used to stop observing resource.
-	Unsubscribe(ctx context.Context, key, chanID, subptopic, token string) error
+	Unsubscribe(ctx context.Context, thing Thing, chanID, subptopic, token string) error
+
+	// Observers returns the observers of the channel. The token must belong
+	// to the channel owner.
+	Observers(ctx context.Context, token, chanID string) ([]ObserverInfo, error)
+
+	// RemoveObserver cancels the observations of the channel made with the
+	// given observe request token. The token must belong to the channel
+	// owner.
+	RemoveObserver(ctx context.Context, token, chanID, id string) error
 }
 
 var _ Service = (*adapterService)(nil)
@@ -45,16 +72,23 @@ var _ Service = (*adapterService)(nil)
 // Observers is a map of maps,
 type adapterService struct {
 	auth      mainflux.ThingsServiceClient
+	users     mainflux.AuthServiceClient
 	conn      *broker.Conn
+	retained  messaging.RetainedRepository
+	codecs    codec.Repository
 	observers map[string]observers
 	obsLock   sync.Mutex
 }
 
-// New instantiates the CoAP adapter implementation.
-func New(auth mainflux.ThingsServiceClient, nc *broker.Conn) Service {
+// New instantiates the CoAP adapter implementation. The binary payloads are
+// decoded to SenML using the codecs of the things and the channels.
+func New(auth mainflux.ThingsServiceClient, users mainflux.AuthServiceClient, nc *broker.Conn, retained messaging.RetainedRepository, codecs codec.Repository) Service {
 	as := &adapterService{
 		auth:      auth,
+		users:     users,
 		conn:      nc,
+		retained:  retained,
+		codecs:    codecs,
 		observers: make(map[string]observers),
 		obsLock:   sync.Mutex{},
 	}
@@ -62,71 +96,171 @@ func New(auth mainflux.ThingsServiceClient, nc *broker.Conn) Service {
 	return as
 }
 
-func (svc *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
-	ar := &mainflux.AccessByKeyReq{
-		Token:  key,
-		ChanID: msg.Channel,
-	}
-	thid, err := svc.auth.CanAccessByKey(ctx, ar)
+func (svc *adapterService) Publish(ctx context.Context, thing Thing, msg messaging.Message) error {
+	thid, err := svc.access(ctx, thing, msg.Channel)
 	if err != nil {
-		return errors.Wrap(ErrUnauthorized, err)
+		return err
 	}
-	msg.Publisher = thid.GetValue()
+	msg.Publisher = thid
 
-	data, err := proto.Marshal(&msg)
+	msg, err = codec.DecodeMessage(svc.codecs, msg)
 	if err != nil {
 		return err
 	}
 
-	subject := fmt.Sprintf("%s.%s", chansPrefix, msg.Channel)
-	if msg.Subtopic != "" {
-		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
 	}
 
-	return svc.conn.Publish(subject, data)
+	return svc.conn.Publish(subject(msg.Channel, msg.Subtopic), data)
 }
 
-func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error {
-	ar := &mainflux.AccessByKeyReq{
-		Token:  key,
-		ChanID: chanID,
-	}
-	if _, err := svc.auth.CanAccessByKey(ctx, ar); err != nil {
-		return errors.Wrap(ErrUnauthorized, err)
+func (svc *adapterService) Subscribe(ctx context.Context, thing Thing, chanID, subtopic string, c Client) error {
+	if _, err := svc.access(ctx, thing, chanID); err != nil {
+		return err
 	}
 
-	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
-	if subtopic != "" {
-		subject = fmt.Sprintf("%s.%s", subject, subtopic)
-	}
+	endpoint := subject(chanID, subtopic)
 
 	go func() {
 		<-c.Done()
-		svc.remove(subject, c.Token())
+		svc.remove(endpoint, c.Token())
 	}()
 
-	obs, err := NewObserver(subject, c, svc.conn)
+	obs, err := NewObserver(chanID, subtopic, c, svc.conn)
 	if err != nil {
 		c.Cancel()
 		return err
 	}
-	return svc.put(subject, c.Token(), obs)
+	if err := svc.put(endpoint, c.Token(), obs); err != nil {
+		return err
+	}
+
+	// Retained messages are sent on the best effort basis, since the
+	// observation is already established.
+	msgs, err := svc.retained.Retrieve(chanID, subtopic)
+	if err != nil {
+		return nil
+	}
+	for _, msg := range msgs {
+		// The client takes care to log the error.
+		if err := c.SendMessage(msg); err != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (svc *adapterService) Unsubscribe(ctx context.Context, thing Thing, chanID, subtopic, token string) error {
+	if _, err := svc.access(ctx, thing, chanID); err != nil {
+		return err
+	}
+
+	return svc.remove(subject(chanID, subtopic), token)
+}
+
+func (svc *adapterService) Observers(ctx context.Context, token, chanID string) ([]ObserverInfo, error) {
+	if err := svc.authorize(ctx, token, chanID, readAction); err != nil {
+		return nil, err
+	}
+
+	svc.obsLock.Lock()
+	defer svc.obsLock.Unlock()
+
+	infos := []ObserverInfo{}
+	for endpoint, obs := range svc.observers {
+		if !channelEndpoint(endpoint, chanID) {
+			continue
+		}
+		for _, o := range obs {
+			infos = append(infos, o.Info())
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Created.Before(infos[j].Created)
+	})
+
+	return infos, nil
+}
+
+func (svc *adapterService) RemoveObserver(ctx context.Context, token, chanID, id string) error {
+	if err := svc.authorize(ctx, token, chanID, writeAction); err != nil {
+		return err
+	}
+
+	svc.obsLock.Lock()
+	var endpoints []string
+	for endpoint, obs := range svc.observers {
+		if _, ok := obs[id]; ok && channelEndpoint(endpoint, chanID) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	svc.obsLock.Unlock()
+
+	if len(endpoints) == 0 {
+		return ErrNotFound
+	}
+	for _, endpoint := range endpoints {
+		if err := svc.remove(endpoint, id); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
+// access checks if the thing is connected to the channel and returns its ID.
+func (svc *adapterService) access(ctx context.Context, thing Thing, chanID string) (string, error) {
+	if thing.Key == "" {
+		ar := &mainflux.AccessByIDReq{
+			ThingID: thing.ID,
+			ChanID:  chanID,
+		}
+		if _, err := svc.auth.CanAccessByID(ctx, ar); err != nil {
+			return "", errors.Wrap(ErrUnauthorized, err)
+		}
+		return thing.ID, nil
+	}
+
 	ar := &mainflux.AccessByKeyReq{
-		Token:  key,
+		Token:  thing.Key,
 		ChanID: chanID,
 	}
-	if _, err := svc.auth.CanAccessByKey(ctx, ar); err != nil {
+	thid, err := svc.auth.CanAccessByKey(ctx, ar)
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorized, err)
+	}
+	return thid.GetValue(), nil
+}
+
+// authorize checks if the user identified by the token owns the channel,
+// or is granted the action over it by the auth service policies.
+func (svc *adapterService) authorize(ctx context.Context, token, chanID, action string) error {
+	ui, err := svc.users.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
 		return errors.Wrap(ErrUnauthorized, err)
 	}
-	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
-	if subtopic != "" {
-		subject = fmt.Sprintf("%s.%s", subject, subtopic)
+	cr := &mainflux.ChannelOwnerReq{
+		Owner:  ui.GetEmail(),
+		ChanID: chanID,
+	}
+	if _, err := svc.auth.IsChannelOwner(ctx, cr); err == nil {
+		return nil
 	}
 
-	return svc.remove(subject, token)
+	ar := &mainflux.AuthorizeReq{
+		Token: token,
+		Obj:   chanID,
+		Act:   action,
+	}
+	res, err := svc.users.Authorize(ctx, ar)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorized, err)
+	}
+	if !res.GetAuthorized() {
+		return ErrUnauthorized
+	}
+	return nil
 }
 
 func (svc *adapterService) put(endpoint, token string, o Observer) error {
@@ -170,3 +304,17 @@ func (svc *adapterService) remove(endpoint, token string) error {
 	}
 	return nil
 }
+
+func subject(chanID, subtopic string) string {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
+	if subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, subtopic)
+	}
+	return subject
+}
+
+// channelEndpoint checks if the endpoint belongs to the channel.
+func channelEndpoint(endpoint, chanID string) bool {
+	prefix := subject(chanID, "")
+	return endpoint == prefix || strings.HasPrefix(endpoint, prefix+".")
+}
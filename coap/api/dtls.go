@@ -0,0 +1,261 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"sync"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/coap"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	piondtls "github.com/pion/dtls/v2"
+	"github.com/plgd-dev/go-coap/v2/mux"
+	coapnet "github.com/plgd-dev/go-coap/v2/net"
+)
+
+// DTLS modes.
+const (
+	// DTLSModePSK authenticates things using pre-shared keys. The PSK
+	// identity is the thing ID and the key is the HMAC-SHA256 of the thing
+	// ID keyed by the thing key, derived by the things service, so neither
+	// the thing key is sent nor the adapter knows it.
+	DTLSModePSK = "psk"
+
+	// DTLSModeCert authenticates things using client certificates issued
+	// by the certs service, whose common name is the thing key.
+	DTLSModeCert = "cert"
+)
+
+var (
+	errInvalidDTLSMode = errors.New("invalid DTLS mode")
+	errLoadCerts       = errors.New("failed to load certificates")
+	errClientCert      = errors.New("missing client certificate")
+)
+
+// DTLSConfig contains DTLS listener configuration.
+type DTLSConfig struct {
+	// Mode is either DTLSModePSK or DTLSModeCert.
+	Mode string
+
+	// CertFile and KeyFile are the paths to the server certificate and key
+	// used in the certificate mode.
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile is the path to the CA certificates which issued the
+	// client certificates, used in the certificate mode.
+	ClientCAFile string
+}
+
+// DTLSListener accepts DTLS connections of the things authenticated during
+// the handshake. Handshakes of different peers are made concurrently.
+type DTLSListener struct {
+	listener *peerListener
+	config   *piondtls.Config
+	client   mainflux.ThingsServiceClient
+	conns    chan net.Conn
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewDTLSListener returns DTLS listener on the given UDP address.
+func NewDTLSListener(addr string, cfg DTLSConfig, things mainflux.ThingsServiceClient) (*DTLSListener, error) {
+	dl := &DTLSListener{
+		client: things,
+		conns:  make(chan net.Conn),
+		done:   make(chan struct{}),
+	}
+
+	dtlsCfg, err := dtlsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	dl.config = dtlsCfg
+
+	l, err := listenPeers(addr)
+	if err != nil {
+		return nil, err
+	}
+	dl.listener = l
+
+	go dl.accept()
+
+	return dl, nil
+}
+
+// AcceptWithContext waits for the next authenticated connection.
+func (dl *DTLSListener) AcceptWithContext(ctx context.Context) (net.Conn, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-dl.done:
+		return nil, coapnet.ErrListenerIsClosed
+	case conn := <-dl.conns:
+		return conn, nil
+	}
+}
+
+// Close closes the listener. Already accepted connections are not closed.
+func (dl *DTLSListener) Close() error {
+	var err error
+	dl.once.Do(func() {
+		close(dl.done)
+		err = dl.listener.Close()
+	})
+	return err
+}
+
+func (dl *DTLSListener) accept() {
+	for {
+		conn, err := dl.listener.Accept()
+		if err != nil {
+			return
+		}
+		go dl.handshake(conn)
+	}
+}
+
+// handshake authenticates the peer and passes its connection to the
+// server. Connections failing the handshake are dropped.
+func (dl *DTLSListener) handshake(conn net.Conn) {
+	// The PSK callback is bound to the connection, so that the thing is
+	// known once the handshake is made.
+	var id string
+	cfg := *dl.config
+	if cfg.PSK != nil {
+		cfg.PSK = func(identity []byte) ([]byte, error) {
+			psk, err := dl.psk(identity)
+			if err != nil {
+				return nil, err
+			}
+			id = string(identity)
+			return psk, nil
+		}
+	}
+
+	c, err := piondtls.Server(conn, &cfg)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	ac, err := authenticate(c, id)
+	if err != nil {
+		return
+	}
+
+	select {
+	case dl.conns <- ac:
+	case <-dl.done:
+		ac.Close()
+	}
+}
+
+// authenticate binds the thing which made the handshake to the connection.
+// If the thing ID is empty, the thing key is read from the client
+// certificate.
+func authenticate(conn *piondtls.Conn, id string) (net.Conn, error) {
+	thing := coap.Thing{ID: id}
+	if id == "" {
+		if len(conn.ConnectionState().PeerCertificates) == 0 {
+			conn.Close()
+			return nil, errClientCert
+		}
+		cert, err := x509.ParseCertificate(conn.ConnectionState().PeerCertificates[0])
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		thing.Key = cert.Subject.CommonName
+	}
+
+	return &thingConn{Conn: conn, addr: thingAddr{Addr: conn.RemoteAddr(), thing: thing}}, nil
+}
+
+// psk returns the pre-shared key of the thing whose ID is the PSK identity.
+func (dl *DTLSListener) psk(identity []byte) ([]byte, error) {
+	res, err := dl.client.DerivePSK(context.Background(), &mainflux.ThingID{Value: string(identity)})
+	if err != nil {
+		return nil, errors.Wrap(coap.ErrUnauthorized, err)
+	}
+
+	return hex.DecodeString(res.GetValue())
+}
+
+func dtlsConfig(cfg DTLSConfig) (*piondtls.Config, error) {
+	switch cfg.Mode {
+	case DTLSModePSK:
+		return &piondtls.Config{
+			// Replaced per connection by the handshake.
+			PSK:                  func([]byte) ([]byte, error) { return nil, coap.ErrUnauthorized },
+			PSKIdentityHint:      []byte("mainflux"),
+			CipherSuites:         []piondtls.CipherSuiteID{piondtls.TLS_PSK_WITH_AES_128_CCM_8, piondtls.TLS_PSK_WITH_AES_128_GCM_SHA256},
+			ExtendedMasterSecret: piondtls.RequireExtendedMasterSecret,
+		}, nil
+	case DTLSModeCert:
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(errLoadCerts, err)
+		}
+		ca, err := ioutil.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, errors.Wrap(errLoadCerts, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errLoadCerts
+		}
+		return &piondtls.Config{
+			Certificates:         []tls.Certificate{cert},
+			ClientAuth:           piondtls.RequireAndVerifyClientCert,
+			ClientCAs:            pool,
+			ExtendedMasterSecret: piondtls.RequireExtendedMasterSecret,
+		}, nil
+	default:
+		return nil, errInvalidDTLSMode
+	}
+}
+
+// thingConn is the connection of the authenticated thing. The thing is
+// kept in the remote address of the connection, since it is the only part
+// of the connection passed to the CoAP handler. Since it lives and dies
+// with the connection, the thing of a new session from the same address
+// is never mixed with the old one.
+type thingConn struct {
+	net.Conn
+	addr thingAddr
+}
+
+func (c *thingConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+type thingAddr struct {
+	net.Addr
+	thing coap.Thing
+}
+
+// MakeDTLSHandler creates handler for CoAP messages received over DTLS.
+// Things are authenticated during the handshake, so the auth query option
+// is not used.
+func MakeDTLSHandler(svc coap.Service, l log.Logger) mux.HandlerFunc {
+	logger = l
+	service = svc
+
+	return func(w mux.ResponseWriter, m *mux.Message) {
+		handle(w, m, func(w mux.ResponseWriter, _ *mux.Message) (coap.Thing, error) {
+			addr, ok := w.Client().RemoteAddr().(thingAddr)
+			if !ok {
+				return coap.Thing{}, coap.ErrUnauthorized
+			}
+			return addr.thing, nil
+		})
+	}
+}
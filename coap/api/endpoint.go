@@ -0,0 +1,51 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/coap"
+)
+
+func listObserversEndpoint(svc coap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listObserversReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		infos, err := svc.Observers(ctx, req.token, req.chanID)
+		if err != nil {
+			return nil, err
+		}
+
+		res := observersRes{Observers: []observerRes{}}
+		for _, info := range infos {
+			res.Observers = append(res.Observers, observerRes{
+				ID:         info.ID,
+				ChanID:     info.ChanID,
+				Subtopic:   info.Subtopic,
+				RemoteAddr: info.RemoteAddr,
+				Created:    info.Created,
+			})
+		}
+		return res, nil
+	}
+}
+
+func removeObserverEndpoint(svc coap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(removeObserverReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveObserver(ctx, req.token, req.chanID, req.id); err != nil {
+			return nil, err
+		}
+		return removeRes{}, nil
+	}
+}
@@ -27,7 +27,7 @@ func LoggingMiddleware(svc coap.Service, logger log.Logger) coap.Service {
 	return &loggingMiddleware{logger, svc}
 }
 
-func (lm *loggingMiddleware) Publish(ctx context.Context, key string, msg messaging.Message) (err error) {
+func (lm *loggingMiddleware) Publish(ctx context.Context, thing coap.Thing, msg messaging.Message) (err error) {
 	defer func(begin time.Time) {
 		destChannel := msg.Channel
 		if msg.Subtopic != "" {
@@ -41,10 +41,10 @@ func (lm *loggingMiddleware) Publish(ctx context.Context, key string, msg messag
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Publish(ctx, key, msg)
+	return lm.svc.Publish(ctx, thing, msg)
 }
 
-func (lm *loggingMiddleware) Subscribe(ctx context.Context, key, chanID, subtopic string, c coap.Client) (err error) {
+func (lm *loggingMiddleware) Subscribe(ctx context.Context, thing coap.Thing, chanID, subtopic string, c coap.Client) (err error) {
 	defer func(begin time.Time) {
 		destChannel := chanID
 		if subtopic != "" {
@@ -58,14 +58,40 @@ func (lm *loggingMiddleware) Subscribe(ctx context.Context, key, chanID, subtopi
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Subscribe(ctx, key, chanID, subtopic, c)
+	return lm.svc.Subscribe(ctx, thing, chanID, subtopic, c)
 }
 
-func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
+func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, thing coap.Thing, chanID, subtopic, token string) error {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method unsubscribe for the client %s from the channel %s and subtopic %s took %s to complete without errors.", token, chanID, subtopic, time.Since(begin))
 		lm.logger.Info(fmt.Sprintf(message))
 	}(time.Now())
 
-	return lm.svc.Unsubscribe(ctx, key, chanID, subtopic, token)
+	return lm.svc.Unsubscribe(ctx, thing, chanID, subtopic, token)
+}
+
+func (lm *loggingMiddleware) Observers(ctx context.Context, token, chanID string) (infos []coap.ObserverInfo, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method observers for channel %s took %s to complete", chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Observers(ctx, token, chanID)
+}
+
+func (lm *loggingMiddleware) RemoveObserver(ctx context.Context, token, chanID, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_observer %s of channel %s took %s to complete", id, chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveObserver(ctx, token, chanID, id)
 }
@@ -31,29 +31,47 @@ func MetricsMiddleware(svc coap.Service, counter metrics.Counter, latency metric
 	}
 }
 
-func (mm *metricsMiddleware) Publish(ctx context.Context, key string, msg messaging.Message) error {
+func (mm *metricsMiddleware) Publish(ctx context.Context, thing coap.Thing, msg messaging.Message) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "publish").Add(1)
 		mm.latency.With("method", "publish").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Publish(ctx, key, msg)
+	return mm.svc.Publish(ctx, thing, msg)
 }
 
-func (mm *metricsMiddleware) Subscribe(ctx context.Context, key, chanID, subtopic string, c coap.Client) error {
+func (mm *metricsMiddleware) Subscribe(ctx context.Context, thing coap.Thing, chanID, subtopic string, c coap.Client) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "subscribe").Add(1)
 		mm.latency.With("method", "subscribe").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Subscribe(ctx, key, chanID, subtopic, c)
+	return mm.svc.Subscribe(ctx, thing, chanID, subtopic, c)
 }
 
-func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
+func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, thing coap.Thing, chanID, subtopic, token string) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "unsubscribe").Add(1)
 		mm.latency.With("method", "unsubscribe").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Unsubscribe(ctx, key, chanID, subtopic, token)
+	return mm.svc.Unsubscribe(ctx, thing, chanID, subtopic, token)
+}
+
+func (mm *metricsMiddleware) Observers(ctx context.Context, token, chanID string) ([]coap.ObserverInfo, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "observers").Add(1)
+		mm.latency.With("method", "observers").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Observers(ctx, token, chanID)
+}
+
+func (mm *metricsMiddleware) RemoveObserver(ctx context.Context, token, chanID, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_observer").Add(1)
+		mm.latency.With("method", "remove_observer").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveObserver(ctx, token, chanID, id)
 }
@@ -0,0 +1,37 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import "github.com/mainflux/mainflux/coap"
+
+type listObserversReq struct {
+	token  string
+	chanID string
+}
+
+func (req listObserversReq) validate() error {
+	if req.token == "" {
+		return coap.ErrUnauthorized
+	}
+	if req.chanID == "" {
+		return errMalformedData
+	}
+	return nil
+}
+
+type removeObserverReq struct {
+	token  string
+	chanID string
+	id     string
+}
+
+func (req removeObserverReq) validate() error {
+	if req.token == "" {
+		return coap.ErrUnauthorized
+	}
+	if req.chanID == "" || req.id == "" {
+		return errMalformedData
+	}
+	return nil
+}
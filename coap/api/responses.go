@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*observersRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+)
+
+type observerRes struct {
+	ID         string    `json:"id"`
+	ChanID     string    `json:"channel_id"`
+	Subtopic   string    `json:"subtopic,omitempty"`
+	RemoteAddr string    `json:"remote_addr"`
+	Created    time.Time `json:"created"`
+}
+
+type observersRes struct {
+	Observers []observerRes `json:"observers"`
+}
+
+func (res observersRes) Code() int {
+	return http.StatusOK
+}
+
+func (res observersRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res observersRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
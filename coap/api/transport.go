@@ -5,6 +5,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -13,8 +14,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mainflux/mainflux/pkg/codec"
 	"github.com/mainflux/mainflux/pkg/errors"
 
+	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/coap"
@@ -27,22 +30,60 @@ import (
 )
 
 const (
-	protocol  = "coap"
-	authQuery = "auth"
+	protocol    = "coap"
+	authQuery   = "auth"
+	contentType = "application/json"
 )
 
 var channelPartRegExp = regexp.MustCompile(`^channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 
-var errMalformedSubtopic = errors.New("malformed subtopic")
+var (
+	errMalformedData     = errors.New("malformed request data")
+	errMalformedSubtopic = errors.New("malformed subtopic")
+)
+
+// SenML content formats registered in RFC 8428, not defined by go-coap.
+const (
+	senmlJSON message.MediaType = 110
+	senmlCBOR message.MediaType = 112
+)
+
+// contentTypes maps CoAP Content-Format option values to media types.
+var contentTypes = map[message.MediaType]string{
+	message.TextPlain: "text/plain",
+	message.AppJSON:   "application/json",
+	message.AppCBOR:   "application/cbor",
+	message.AppOctets: "application/octet-stream",
+	senmlJSON:         "application/senml+json",
+	senmlCBOR:         "application/senml+cbor",
+}
 
 var (
 	logger  log.Logger
 	service coap.Service
 )
 
-//MakeHTTPHandler creates handler for version endpoint.
-func MakeHTTPHandler() http.Handler {
+//MakeHTTPHandler creates handler for version, observers and CoAP over
+// WebSocket endpoints.
+func MakeHTTPHandler(svc coap.Service, wl *WSListener) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
 	b := bone.New()
+	b.Get("/channels/:id/observers", kithttp.NewServer(
+		listObserversEndpoint(svc),
+		decodeListObservers,
+		encodeResponse,
+		opts...,
+	))
+	b.Delete("/channels/:id/observers/:obsId", kithttp.NewServer(
+		removeObserverEndpoint(svc),
+		decodeRemoveObserver,
+		encodeResponse,
+		opts...,
+	))
+	b.Get(wsPath, wl)
 	b.GetFunc("/version", mainflux.Version(protocol))
 	b.Handle("/metrics", promhttp.Handler())
 
@@ -54,7 +95,9 @@ func MakeCoAPHandler(svc coap.Service, l log.Logger) mux.HandlerFunc {
 	logger = l
 	service = svc
 
-	return handler
+	return func(w mux.ResponseWriter, m *mux.Message) {
+		handle(w, m, parseThing)
+	}
 }
 
 func sendResp(w mux.ResponseWriter, resp *message.Message) {
@@ -63,7 +106,10 @@ func sendResp(w mux.ResponseWriter, resp *message.Message) {
 	}
 }
 
-func handler(w mux.ResponseWriter, m *mux.Message) {
+// thingFunc returns the thing which sent the message.
+type thingFunc func(w mux.ResponseWriter, m *mux.Message) (coap.Thing, error)
+
+func handle(w mux.ResponseWriter, m *mux.Message, getThing thingFunc) {
 	resp := message.Message{
 		Code:    codes.Content,
 		Token:   m.Token,
@@ -82,7 +128,7 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 		resp.Code = codes.BadRequest
 		return
 	}
-	key, err := parseKey(m)
+	thing, err := getThing(w, m)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Error parsing auth: %s", err))
 		resp.Code = codes.Unauthorized
@@ -99,12 +145,12 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 		}
 		if obs == 0 {
 			c := coap.NewClient(w.Client(), m.Token, logger)
-			err = service.Subscribe(context.Background(), key, msg.Channel, msg.Subtopic, c)
+			err = service.Subscribe(context.Background(), thing, msg.Channel, msg.Subtopic, c)
 			break
 		}
-		service.Unsubscribe(context.Background(), key, msg.Channel, msg.Subtopic, m.Token.String())
+		service.Unsubscribe(context.Background(), thing, msg.Channel, msg.Subtopic, m.Token.String())
 	case codes.POST:
-		err = service.Publish(context.Background(), key, msg)
+		err = service.Publish(context.Background(), thing, msg)
 	default:
 		resp.Code = codes.NotFound
 		return
@@ -114,6 +160,9 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 		case errors.Contains(err, coap.ErrUnauthorized):
 			resp.Code = codes.Unauthorized
 			return
+		case errors.Contains(err, codec.ErrShortPayload):
+			resp.Code = codes.BadRequest
+			return
 		case errors.Contains(err, coap.ErrUnsubscribe):
 			resp.Code = codes.InternalServerError
 		}
@@ -141,6 +190,9 @@ func decodeMessage(msg *mux.Message) (messaging.Message, error) {
 		Payload:  []byte{},
 		Created:  time.Now().UnixNano(),
 	}
+	if cf, err := msg.Options.ContentFormat(); err == nil {
+		ret.ContentType = contentTypes[cf]
+	}
 
 	if msg.Body != nil {
 		buff, err := ioutil.ReadAll(msg.Body)
@@ -160,16 +212,16 @@ func parseID(path string) string {
 	return ""
 }
 
-func parseKey(msg *mux.Message) (string, error) {
+func parseThing(_ mux.ResponseWriter, msg *mux.Message) (coap.Thing, error) {
 	auth, err := msg.Options.GetString(message.URIQuery)
 	if err != nil {
-		return "", err
+		return coap.Thing{}, err
 	}
 	vars := strings.Split(auth, "=")
-	if len(vars) != 2 || vars[0] != authQuery {
-		return "", coap.ErrUnauthorized
+	if len(vars) != 2 || vars[0] != authQuery || vars[1] == "" {
+		return coap.Thing{}, coap.ErrUnauthorized
 	}
-	return vars[1], nil
+	return coap.Thing{Key: vars[1]}, nil
 }
 
 func parseSubtopic(subtopic string) (string, error) {
@@ -200,3 +252,48 @@ func parseSubtopic(subtopic string) (string, error) {
 	subtopic = strings.Join(filteredElems, ".")
 	return subtopic, nil
 }
+
+func decodeListObservers(_ context.Context, r *http.Request) (interface{}, error) {
+	req := listObserversReq{
+		token:  r.Header.Get("Authorization"),
+		chanID: bone.GetValue(r, "id"),
+	}
+	return req, nil
+}
+
+func decodeRemoveObserver(_ context.Context, r *http.Request) (interface{}, error) {
+	req := removeObserverReq{
+		token:  r.Header.Get("Authorization"),
+		chanID: bone.GetValue(r, "id"),
+		id:     bone.GetValue(r, "obsId"),
+	}
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	ar, _ := response.(mainflux.Response)
+	for k, v := range ar.Headers() {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(ar.Code())
+
+	if ar.Empty() {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case err == errMalformedData:
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, coap.ErrUnauthorized):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, coap.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
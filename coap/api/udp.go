@@ -0,0 +1,261 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	maxDatagramSize = 65535
+
+	// Datagrams received while the peer queue is full are dropped, the
+	// same as if they were lost.
+	peerQueueSize = 64
+)
+
+var errListenerClosed = errors.New("listener is closed")
+
+// peerListener demultiplexes the UDP datagrams by the remote address into
+// the connections of the peers, so that the DTLS handshakes of the peers
+// are made concurrently.
+type peerListener struct {
+	conn  *net.UDPConn
+	peers chan *peerConn
+	done  chan struct{}
+	once  sync.Once
+
+	mu     sync.Mutex
+	closed bool
+	conns  map[string]*peerConn
+}
+
+func listenPeers(addr string) (*peerListener, error) {
+	a, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", a)
+	if err != nil {
+		return nil, err
+	}
+
+	pl := &peerListener{
+		conn:  conn,
+		peers: make(chan *peerConn),
+		done:  make(chan struct{}),
+		conns: make(map[string]*peerConn),
+	}
+	go pl.read()
+
+	return pl, nil
+}
+
+// Accept waits for the first datagram of the new peer.
+func (pl *peerListener) Accept() (*peerConn, error) {
+	select {
+	case pc := <-pl.peers:
+		return pc, nil
+	case <-pl.done:
+		return nil, errListenerClosed
+	}
+}
+
+// Close stops accepting new peers. The socket is closed once the accepted
+// peer connections are closed.
+func (pl *peerListener) Close() error {
+	var err error
+	pl.once.Do(func() {
+		close(pl.done)
+
+		pl.mu.Lock()
+		pl.closed = true
+		empty := len(pl.conns) == 0
+		pl.mu.Unlock()
+
+		if empty {
+			err = pl.conn.Close()
+		}
+	})
+	return err
+}
+
+func (pl *peerListener) read() {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := pl.conn.ReadFromUDP(buf)
+		if err != nil {
+			pl.Close()
+			return
+		}
+		pkt := make([]byte, n)
+		copy(pkt, buf[:n])
+
+		pc, isNew := pl.peer(addr)
+		if pc == nil {
+			continue
+		}
+		pc.deliver(pkt)
+		if !isNew {
+			continue
+		}
+
+		select {
+		case pl.peers <- pc:
+		case <-pl.done:
+			pc.Close()
+		}
+	}
+}
+
+// peer returns the connection of the peer, creating the new one unless the
+// listener is closed.
+func (pl *peerListener) peer(addr *net.UDPAddr) (*peerConn, bool) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if pc, ok := pl.conns[addr.String()]; ok {
+		return pc, false
+	}
+	if pl.closed {
+		return nil, false
+	}
+
+	pc := &peerConn{
+		listener: pl,
+		addr:     addr,
+		pkts:     make(chan []byte, peerQueueSize),
+		done:     make(chan struct{}),
+		changed:  make(chan struct{}),
+	}
+	pl.conns[addr.String()] = pc
+
+	return pc, true
+}
+
+func (pl *peerListener) remove(addr string) {
+	pl.mu.Lock()
+	delete(pl.conns, addr)
+	closing := pl.closed && len(pl.conns) == 0
+	pl.mu.Unlock()
+
+	if closing {
+		pl.conn.Close()
+	}
+}
+
+// peerConn is the connection of a single peer, reading the datagrams the
+// listener received from the peer address.
+type peerConn struct {
+	listener *peerListener
+	addr     *net.UDPAddr
+	pkts     chan []byte
+	done     chan struct{}
+	once     sync.Once
+
+	// changed is closed when the read deadline changes, to wake up the
+	// pending Read.
+	mu       sync.Mutex
+	deadline time.Time
+	changed  chan struct{}
+}
+
+var _ net.Conn = (*peerConn)(nil)
+
+func (pc *peerConn) deliver(pkt []byte) {
+	select {
+	case pc.pkts <- pkt:
+	default:
+	}
+}
+
+func (pc *peerConn) Read(b []byte) (int, error) {
+	for {
+		pc.mu.Lock()
+		deadline, changed := pc.deadline, pc.changed
+		pc.mu.Unlock()
+
+		if deadline.IsZero() {
+			select {
+			case pkt := <-pc.pkts:
+				return copy(b, pkt), nil
+			case <-pc.done:
+				return 0, io.EOF
+			case <-changed:
+				continue
+			}
+		}
+
+		d := time.Until(deadline)
+		if d <= 0 {
+			return 0, timeoutError{}
+		}
+		timer := time.NewTimer(d)
+		select {
+		case pkt := <-pc.pkts:
+			timer.Stop()
+			return copy(b, pkt), nil
+		case <-pc.done:
+			timer.Stop()
+			return 0, io.EOF
+		case <-timer.C:
+			return 0, timeoutError{}
+		case <-changed:
+			timer.Stop()
+		}
+	}
+}
+
+func (pc *peerConn) Write(b []byte) (int, error) {
+	select {
+	case <-pc.done:
+		return 0, io.ErrClosedPipe
+	default:
+	}
+
+	return pc.listener.conn.WriteToUDP(b, pc.addr)
+}
+
+// Close removes the peer from the listener, so the next datagram from its
+// address is accepted as the new peer.
+func (pc *peerConn) Close() error {
+	pc.once.Do(func() {
+		close(pc.done)
+		pc.listener.remove(pc.addr.String())
+	})
+	return nil
+}
+
+func (pc *peerConn) LocalAddr() net.Addr {
+	return pc.listener.conn.LocalAddr()
+}
+
+func (pc *peerConn) RemoteAddr() net.Addr {
+	return pc.addr
+}
+
+func (pc *peerConn) SetDeadline(t time.Time) error {
+	return pc.SetReadDeadline(t)
+}
+
+func (pc *peerConn) SetReadDeadline(t time.Time) error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	pc.deadline = t
+	close(pc.changed)
+	pc.changed = make(chan struct{})
+
+	return nil
+}
+
+// SetWriteDeadline is a no-op, since writing the datagram doesn't block.
+func (pc *peerConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
@@ -0,0 +1,323 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/pkg/errors"
+	coapnet "github.com/plgd-dev/go-coap/v2/net"
+)
+
+const (
+	// wsPath is the CoAP over WebSocket resource defined in RFC 8323.
+	wsPath      = "/.well-known/coap"
+	wsProtocol  = "coap"
+	wsWriteWait = 10 * time.Second
+)
+
+var errMalformedFrame = errors.New("malformed CoAP frame")
+
+var wsUpgrader = websocket.Upgrader{
+	Subprotocols: []string{wsProtocol},
+	// Browsers send the Origin header, while the access is controlled by
+	// the thing key.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// WSListener accepts CoAP over WebSocket connections. The connections are
+// upgraded by the listener HTTP handler and served by the CoAP over TCP
+// server, since RFC 8323 WebSocket frames differ from the TCP ones only in
+// the length field.
+type WSListener struct {
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+}
+
+// NewWSListener returns new CoAP over WebSocket listener.
+func NewWSListener() *WSListener {
+	return &WSListener{
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+// ServeHTTP upgrades the request to the WebSocket connection.
+func (wl *WSListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to upgrade connection to websocket: %s", err))
+		return
+	}
+
+	c := newWSConn(conn)
+	select {
+	case wl.conns <- c:
+	case <-wl.done:
+		c.Close()
+	}
+}
+
+// AcceptWithContext waits for the next upgraded connection.
+func (wl *WSListener) AcceptWithContext(ctx context.Context) (net.Conn, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-wl.done:
+		return nil, coapnet.ErrListenerIsClosed
+	case c := <-wl.conns:
+		return c, nil
+	}
+}
+
+// Close closes the listener. Already accepted connections are not closed.
+func (wl *WSListener) Close() error {
+	wl.once.Do(func() {
+		close(wl.done)
+	})
+	return nil
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// wsConn converts the WebSocket frames to the CoAP over TCP stream and
+// vice versa. The deadlines are handled by the connection itself, since
+// the WebSocket connection can't be used after the deadline expires.
+type wsConn struct {
+	conn   *websocket.Conn
+	frames chan []byte
+	done   chan struct{}
+	once   sync.Once
+
+	// rbuf is the part of the frame which is not read yet.
+	rbuf []byte
+	rmu  sync.Mutex
+
+	deadline time.Time
+	dmu      sync.Mutex
+
+	// wbuf is the part of the stream which is not a whole frame yet.
+	wbuf []byte
+	wmu  sync.Mutex
+}
+
+func newWSConn(conn *websocket.Conn) *wsConn {
+	c := &wsConn{
+		conn:   conn,
+		frames: make(chan []byte),
+		done:   make(chan struct{}),
+	}
+	go c.readLoop()
+	return c
+}
+
+func (c *wsConn) readLoop() {
+	defer close(c.frames)
+	for {
+		typ, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if typ != websocket.BinaryMessage {
+			continue
+		}
+		frame, err := toTCPFrame(data)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to read CoAP over WebSocket message: %s", err))
+			continue
+		}
+
+		select {
+		case c.frames <- frame:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *wsConn) Read(b []byte) (int, error) {
+	c.rmu.Lock()
+	defer c.rmu.Unlock()
+
+	if len(c.rbuf) == 0 {
+		var timeout <-chan time.Time
+		c.dmu.Lock()
+		deadline := c.deadline
+		c.dmu.Unlock()
+		if !deadline.IsZero() {
+			t := time.NewTimer(time.Until(deadline))
+			defer t.Stop()
+			timeout = t.C
+		}
+
+		select {
+		case frame, ok := <-c.frames:
+			if !ok {
+				return 0, io.EOF
+			}
+			c.rbuf = frame
+		case <-timeout:
+			return 0, timeoutError{}
+		}
+	}
+
+	n := copy(b, c.rbuf)
+	c.rbuf = c.rbuf[n:]
+	return n, nil
+}
+
+func (c *wsConn) Write(b []byte) (int, error) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	c.wbuf = append(c.wbuf, b...)
+	for {
+		n, ok := tcpFrameLen(c.wbuf)
+		if !ok {
+			break
+		}
+		c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		if err := c.conn.WriteMessage(websocket.BinaryMessage, toWSFrame(c.wbuf[:n])); err != nil {
+			return 0, err
+		}
+		c.wbuf = c.wbuf[n:]
+	}
+	return len(b), nil
+}
+
+func (c *wsConn) Close() error {
+	c.once.Do(func() {
+		close(c.done)
+	})
+	return c.conn.Close()
+}
+
+func (c *wsConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *wsConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *wsConn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+func (c *wsConn) SetReadDeadline(t time.Time) error {
+	c.dmu.Lock()
+	defer c.dmu.Unlock()
+
+	c.deadline = t
+	return nil
+}
+
+// SetWriteDeadline is no-op, since each frame is written with its own
+// deadline.
+func (c *wsConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+// The CoAP over TCP frame starts with the 4-bit length of the options and
+// the payload, followed by 0, 1, 2 or 4 bytes of the extended length. The
+// WebSocket frame has the length set to 0 and no extended length.
+const (
+	len8  = 13
+	len16 = 14
+	len32 = 15
+
+	len8Offset  = 13
+	len16Offset = 269
+	len32Offset = 65805
+)
+
+// toTCPFrame converts CoAP over WebSocket frame to CoAP over TCP frame.
+func toTCPFrame(frame []byte) ([]byte, error) {
+	if len(frame) < 2 || frame[0]>>4 != 0 {
+		return nil, errMalformedFrame
+	}
+	tkl := frame[0] & 0x0f
+	l := len(frame) - 2 - int(tkl)
+	if l < 0 {
+		return nil, errMalformedFrame
+	}
+
+	var hdr []byte
+	switch {
+	case l < len8Offset:
+		hdr = []byte{byte(l)<<4 | tkl}
+	case l < len16Offset:
+		hdr = []byte{len8<<4 | tkl, byte(l - len8Offset)}
+	case l < len32Offset:
+		hdr = make([]byte, 3)
+		hdr[0] = len16<<4 | tkl
+		binary.BigEndian.PutUint16(hdr[1:], uint16(l-len16Offset))
+	default:
+		hdr = make([]byte, 5)
+		hdr[0] = len32<<4 | tkl
+		binary.BigEndian.PutUint32(hdr[1:], uint32(l-len32Offset))
+	}
+
+	return append(hdr, frame[1:]...), nil
+}
+
+// tcpFrameLen returns the length of the CoAP over TCP frame at the start
+// of the stream, and whether the stream contains the whole frame.
+func tcpFrameLen(stream []byte) (int, bool) {
+	if len(stream) == 0 {
+		return 0, false
+	}
+	ext := extLen(stream[0])
+	if len(stream) < 1+ext {
+		return 0, false
+	}
+
+	l := int(stream[0] >> 4)
+	switch ext {
+	case 1:
+		l = int(stream[1]) + len8Offset
+	case 2:
+		l = int(binary.BigEndian.Uint16(stream[1:])) + len16Offset
+	case 4:
+		l = int(binary.BigEndian.Uint32(stream[1:])) + len32Offset
+	}
+
+	n := 1 + ext + 1 + int(stream[0]&0x0f) + l
+	return n, len(stream) >= n
+}
+
+// toWSFrame converts the whole CoAP over TCP frame to CoAP over WebSocket
+// frame.
+func toWSFrame(frame []byte) []byte {
+	ext := extLen(frame[0])
+	ret := make([]byte, 0, len(frame)-ext)
+	ret = append(ret, frame[0]&0x0f)
+	return append(ret, frame[1+ext:]...)
+}
+
+func extLen(b byte) int {
+	switch b >> 4 {
+	case len8:
+		return 1
+	case len16:
+		return 2
+	case len32:
+		return 4
+	default:
+		return 0
+	}
+}
@@ -19,6 +19,8 @@ import (
 type Client interface {
 	// In CoAP terminology, Token similar to the Session ID.
 	Token() string
+	// RemoteAddr returns the address of the client.
+	RemoteAddr() string
 	SendMessage(m messaging.Message) error
 	Cancel() error
 	Done() <-chan struct{}
@@ -56,6 +58,10 @@ func (c *client) Token() string {
 	return c.token.String()
 }
 
+func (c *client) RemoteAddr() string {
+	return c.client.RemoteAddr().String()
+}
+
 func (c *client) SendMessage(msg messaging.Message) error {
 	m := message.Message{
 		Code:    codes.Content,
@@ -4,6 +4,8 @@
 package coap
 
 import (
+	"time"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	broker "github.com/nats-io/nats.go"
@@ -12,11 +14,24 @@ import (
 // Observer represents an internal observer used to handle CoAP observe messages.
 type Observer interface {
 	Cancel() error
+
+	// Info returns the observer details.
+	Info() ObserverInfo
+}
+
+// ObserverInfo contains the details of the observer.
+type ObserverInfo struct {
+	// ID is the token of the observe request.
+	ID         string
+	ChanID     string
+	Subtopic   string
+	RemoteAddr string
+	Created    time.Time
 }
 
 // NewObserver returns a new Observer instance.
-func NewObserver(subject string, c Client, conn *broker.Conn) (Observer, error) {
-	sub, err := conn.Subscribe(subject, func(m *broker.Msg) {
+func NewObserver(chanID, subtopic string, c Client, conn *broker.Conn) (Observer, error) {
+	sub, err := conn.Subscribe(subject(chanID, subtopic), func(m *broker.Msg) {
 		var msg messaging.Message
 		if err := proto.Unmarshal(m.Data, &msg); err != nil {
 			return
@@ -28,15 +43,21 @@ func NewObserver(subject string, c Client, conn *broker.Conn) (Observer, error)
 		return nil, err
 	}
 	ret := &observer{
-		client: c,
-		sub:    sub,
+		client:   c,
+		sub:      sub,
+		chanID:   chanID,
+		subtopic: subtopic,
+		created:  time.Now(),
 	}
 	return ret, nil
 }
 
 type observer struct {
-	client Client
-	sub    *broker.Subscription
+	client   Client
+	sub      *broker.Subscription
+	chanID   string
+	subtopic string
+	created  time.Time
 }
 
 func (o *observer) Cancel() error {
@@ -45,3 +66,13 @@ func (o *observer) Cancel() error {
 	}
 	return o.client.Cancel()
 }
+
+func (o *observer) Info() ObserverInfo {
+	return ObserverInfo{
+		ID:         o.client.Token(),
+		ChanID:     o.chanID,
+		Subtopic:   o.subtopic,
+		RemoteAddr: o.client.RemoteAddr(),
+		Created:    o.created,
+	}
+}
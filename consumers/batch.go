@@ -0,0 +1,254 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// ErrClosed indicates that the messages are passed to the closed consumer.
+var ErrClosed = errors.New("consumer closed")
+
+var _ Consumer = (*batchConsumer)(nil)
+
+// BatchConfig contains batching consumer configuration.
+type BatchConfig struct {
+	// Size is the number of messages that triggers the flush.
+	Size int
+	// Interval is the maximum time the message is kept in the batch
+	// before the flush.
+	Interval time.Duration
+	// Buffer is the number of consumed messages waiting to be added to the
+	// batch. Once the buffer is full, Consume blocks until the batch is
+	// flushed, applying the backpressure to the subscriber.
+	Buffer int
+}
+
+// BatchConsumer wraps Consumer exposing Close() method that flushes the
+// buffered messages.
+type BatchConsumer interface {
+	Consumer
+
+	// ConsumeMessage consumes the transformed message, keeping the original
+	// one to be passed to the dead letter handler if consuming fails.
+	ConsumeMessage(msg messaging.Message, transformed interface{}) error
+
+	// Close stops accepting messages and flushes the ones already accepted.
+	Close()
+}
+
+// batchItem is the consumed message along with the original message it is
+// transformed from, if known.
+type batchItem struct {
+	msgs interface{}
+	orig *messaging.Message
+}
+
+type batchConsumer struct {
+	consumer Consumer
+	cfg      BatchConfig
+	dlh      DeadLetterHandler
+	logger   logger.Logger
+	mu       sync.RWMutex
+	closed   bool
+	items    chan batchItem
+	done     chan struct{}
+	finished chan struct{}
+	batch    []batchItem
+	count    int
+}
+
+// NewBatchConsumer returns consumer that buffers received messages and
+// passes them to the wrapped consumer in bulk, once the batch size is
+// reached or the batch interval is elapsed. SenML messages are merged in
+// a single slice and JSON messages are merged by format, other messages
+// are passed to the wrapped consumer as they are. Since messages are
+// consumed asynchronously, they are acknowledged as soon as they are
+// buffered. If the wrapped consumer fails, every message of the failed
+// batch is retried on its own, and the messages that fail again are passed
+// to the dead letter handler, if one is provided, or logged otherwise.
+func NewBatchConsumer(consumer Consumer, cfg BatchConfig, dlh DeadLetterHandler, logger logger.Logger) BatchConsumer {
+	bc := &batchConsumer{
+		consumer: consumer,
+		cfg:      cfg,
+		dlh:      dlh,
+		logger:   logger,
+		items:    make(chan batchItem, cfg.Buffer),
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go bc.run()
+
+	return bc
+}
+
+func (bc *batchConsumer) Consume(msgs interface{}) error {
+	return bc.enqueue(batchItem{msgs: msgs})
+}
+
+func (bc *batchConsumer) ConsumeMessage(msg messaging.Message, transformed interface{}) error {
+	return bc.enqueue(batchItem{msgs: transformed, orig: &msg})
+}
+
+func (bc *batchConsumer) enqueue(item batchItem) error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	if bc.closed {
+		return ErrClosed
+	}
+	bc.items <- item
+
+	return nil
+}
+
+func (bc *batchConsumer) Close() {
+	bc.mu.Lock()
+	if bc.closed {
+		bc.mu.Unlock()
+		return
+	}
+	bc.closed = true
+	bc.mu.Unlock()
+
+	close(bc.done)
+	<-bc.finished
+}
+
+func (bc *batchConsumer) run() {
+	var timer *time.Timer
+	var timeout <-chan time.Time
+
+	flush := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		bc.flush()
+	}
+
+	for {
+		select {
+		case item := <-bc.items:
+			if len(bc.batch) == 0 {
+				timer = time.NewTimer(bc.cfg.Interval)
+				timeout = timer.C
+			}
+			bc.add(item)
+			if bc.count >= bc.cfg.Size {
+				flush()
+			}
+		case <-timeout:
+			timer, timeout = nil, nil
+			bc.flush()
+		case <-bc.done:
+			for {
+				select {
+				case item := <-bc.items:
+					bc.add(item)
+				default:
+					flush()
+					close(bc.finished)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (bc *batchConsumer) add(item batchItem) {
+	bc.batch = append(bc.batch, item)
+	switch m := item.msgs.(type) {
+	case []senml.Message:
+		bc.count += len(m)
+	case json.Messages:
+		bc.count += len(m.Data)
+	default:
+		bc.count++
+	}
+}
+
+func (bc *batchConsumer) flush() {
+	if len(bc.batch) == 0 {
+		return
+	}
+
+	var senmlItems []batchItem
+	var senmlMsgs []senml.Message
+	var formats []string
+	jsonItems := map[string][]batchItem{}
+	jsonMsgs := map[string]json.Messages{}
+
+	for _, item := range bc.batch {
+		switch m := item.msgs.(type) {
+		case []senml.Message:
+			senmlItems = append(senmlItems, item)
+			senmlMsgs = append(senmlMsgs, m...)
+		case json.Messages:
+			if _, ok := jsonMsgs[m.Format]; !ok {
+				formats = append(formats, m.Format)
+				jsonMsgs[m.Format] = json.Messages{Format: m.Format}
+			}
+			jm := jsonMsgs[m.Format]
+			jm.Data = append(jm.Data, m.Data...)
+			jsonMsgs[m.Format] = jm
+			jsonItems[m.Format] = append(jsonItems[m.Format], item)
+		default:
+			bc.consume(item.msgs, []batchItem{item})
+		}
+	}
+
+	if len(senmlItems) > 0 {
+		bc.consume(senmlMsgs, senmlItems)
+	}
+	for _, f := range formats {
+		bc.consume(jsonMsgs[f], jsonItems[f])
+	}
+
+	bc.batch = nil
+	bc.count = 0
+}
+
+// consume passes merged messages to the wrapped consumer. If it fails,
+// the items the batch is made of are consumed one by one, so that a single
+// invalid message doesn't cause the whole batch to be lost.
+func (bc *batchConsumer) consume(msgs interface{}, items []batchItem) {
+	err := bc.consumer.Consume(msgs)
+	if err == nil {
+		return
+	}
+	if len(items) == 1 {
+		bc.deadLetter(items[0], err)
+		return
+	}
+
+	bc.logger.Warn(fmt.Sprintf("Failed to consume batch of %d items, retrying one by one: %s", len(items), err))
+	for _, item := range items {
+		if err := bc.consumer.Consume(item.msgs); err != nil {
+			bc.deadLetter(item, err)
+		}
+	}
+}
+
+// deadLetter passes the item that failed to be consumed to the dead letter
+// handler. Since the message is already acknowledged, it's logged if the
+// dead letter handler is not available.
+func (bc *batchConsumer) deadLetter(item batchItem, err error) {
+	if bc.dlh == nil || item.orig == nil {
+		bc.logger.Warn(fmt.Sprintf("Failed to consume message: %s", err))
+		return
+	}
+	if dlErr := bc.dlh.Handle(context.Background(), *item.orig, err); dlErr != nil {
+		bc.logger.Warn(fmt.Sprintf("Failed to consume message: %s, failed to handle dead letter: %s", err, dlErr))
+	}
+}
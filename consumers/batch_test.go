@@ -0,0 +1,205 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const invalid = "invalid"
+
+var errConsume = errors.New("failed to consume")
+
+type consumer struct {
+	mu    sync.Mutex
+	calls []interface{}
+}
+
+// Consume fails for batches containing the message published to the
+// invalid channel.
+func (c *consumer) Consume(msgs interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if m, ok := msgs.([]senml.Message); ok {
+		for _, msg := range m {
+			if msg.Channel == invalid {
+				return errConsume
+			}
+		}
+	}
+	c.calls = append(c.calls, msgs)
+
+	return nil
+}
+
+func (c *consumer) consumed() []interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]interface{}{}, c.calls...)
+}
+
+func newBatchConsumer(t *testing.T, cfg consumers.BatchConfig) (consumers.BatchConsumer, *consumer) {
+	bc, c, _ := newDeadLetteringBatchConsumer(t, cfg)
+	return bc, c
+}
+
+func newDeadLetteringBatchConsumer(t *testing.T, cfg consumers.BatchConfig) (consumers.BatchConsumer, *consumer, *deadLetters) {
+	logger, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	c := &consumer{}
+	dl := &deadLetters{}
+	return consumers.NewBatchConsumer(c, cfg, dl, logger), c, dl
+}
+
+func senmlMsgs(channel string, n int) []senml.Message {
+	msgs := []senml.Message{}
+	for i := 0; i < n; i++ {
+		msgs = append(msgs, senml.Message{Channel: channel, Name: fmt.Sprintf("name-%d", i)})
+	}
+	return msgs
+}
+
+func TestBatchBySize(t *testing.T) {
+	bc, c := newBatchConsumer(t, consumers.BatchConfig{Size: 4, Interval: time.Hour, Buffer: 10})
+	defer bc.Close()
+
+	for i := 0; i < 2; i++ {
+		err := bc.Consume(senmlMsgs("channel", 2))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	assert.Eventually(t, func() bool { return len(c.consumed()) == 1 }, time.Second, 10*time.Millisecond, "expected batch to be flushed once size is reached")
+	assert.Equal(t, senmlMsgs("channel", 2)[0], c.consumed()[0].([]senml.Message)[0], "expected messages to be merged in order")
+	assert.Len(t, c.consumed()[0], 4, "expected all messages to be merged")
+}
+
+func TestBatchByInterval(t *testing.T) {
+	bc, c := newBatchConsumer(t, consumers.BatchConfig{Size: 100, Interval: 50 * time.Millisecond, Buffer: 10})
+	defer bc.Close()
+
+	err := bc.Consume(senmlMsgs("channel", 1))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, c.consumed(), 0, "expected message to be buffered")
+
+	assert.Eventually(t, func() bool { return len(c.consumed()) == 1 }, time.Second, 10*time.Millisecond, "expected batch to be flushed once interval elapses")
+}
+
+func TestBatchMerge(t *testing.T) {
+	bc, c := newBatchConsumer(t, consumers.BatchConfig{Size: 100, Interval: time.Hour, Buffer: 10})
+
+	items := []interface{}{
+		senmlMsgs("channel", 2),
+		json.Messages{Format: "a", Data: []json.Message{{Channel: "channel"}}},
+		json.Messages{Format: "b", Data: []json.Message{{Channel: "channel"}}},
+		senmlMsgs("channel", 1),
+		json.Messages{Format: "a", Data: []json.Message{{Channel: "channel"}}},
+		"unknown",
+	}
+	for _, item := range items {
+		err := bc.Consume(item)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	bc.Close()
+
+	expected := []interface{}{
+		"unknown",
+		append(senmlMsgs("channel", 2), senmlMsgs("channel", 1)...),
+		json.Messages{Format: "a", Data: []json.Message{{Channel: "channel"}, {Channel: "channel"}}},
+		json.Messages{Format: "b", Data: []json.Message{{Channel: "channel"}}},
+	}
+	assert.Equal(t, expected, c.consumed(), fmt.Sprintf("expected %v got %v", expected, c.consumed()))
+}
+
+func TestBatchRetry(t *testing.T) {
+	bc, c := newBatchConsumer(t, consumers.BatchConfig{Size: 100, Interval: time.Hour, Buffer: 10})
+
+	valid := senmlMsgs("channel", 2)
+	for _, msgs := range [][]senml.Message{valid, senmlMsgs(invalid, 1), valid} {
+		err := bc.Consume(msgs)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	bc.Close()
+
+	expected := []interface{}{valid, valid}
+	assert.Equal(t, expected, c.consumed(), fmt.Sprintf("expected valid messages to be consumed one by one, got %v", c.consumed()))
+}
+
+func TestBatchDeadLetters(t *testing.T) {
+	bc, c, dl := newDeadLetteringBatchConsumer(t, consumers.BatchConfig{Size: 100, Interval: time.Hour, Buffer: 10})
+
+	valid := senmlMsgs("channel", 2)
+	msgs := []messaging.Message{{Channel: "channel"}, {Channel: invalid}}
+	for i, items := range [][]senml.Message{valid, senmlMsgs(invalid, 1)} {
+		err := bc.ConsumeMessage(msgs[i], items)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	bc.Close()
+
+	assert.Equal(t, []interface{}{valid}, c.consumed(), fmt.Sprintf("expected valid messages to be consumed, got %v", c.consumed()))
+	expected := []messaging.Message{{Channel: invalid}}
+	assert.Equal(t, expected, dl.msgs, fmt.Sprintf("expected dead letters %v got %v", expected, dl.msgs))
+}
+
+func TestBatchClose(t *testing.T) {
+	bc, c := newBatchConsumer(t, consumers.BatchConfig{Size: 100, Interval: time.Hour, Buffer: 10})
+
+	err := bc.Consume(senmlMsgs("channel", 1))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	bc.Close()
+	assert.Len(t, c.consumed(), 1, "expected buffered messages to be flushed on close")
+
+	err = bc.Consume(senmlMsgs("channel", 1))
+	assert.True(t, errors.Contains(err, consumers.ErrClosed), fmt.Sprintf("consume after close: expected %s got %s", consumers.ErrClosed, err))
+
+	// Closing twice is a no-op.
+	bc.Close()
+}
+
+func TestBatchBackpressure(t *testing.T) {
+	bc, c := newBatchConsumer(t, consumers.BatchConfig{Size: 1, Interval: time.Hour, Buffer: 0})
+	c.mu.Lock()
+
+	// The first message is taken by the flushing goroutine which is blocked
+	// by the consumer, so the second one can't be accepted.
+	err := bc.Consume(senmlMsgs("channel", 1))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	accepted := make(chan struct{})
+	go func() {
+		bc.Consume(senmlMsgs("channel", 1))
+		close(accepted)
+	}()
+
+	select {
+	case <-accepted:
+		t.Fatal("expected consume to block while the batch is being flushed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	c.mu.Unlock()
+	select {
+	case <-accepted:
+	case <-time.After(time.Second):
+		t.Fatal("expected consume to be unblocked after the flush")
+	}
+	bc.Close()
+	assert.Len(t, c.consumed(), 2, "expected all messages to be consumed")
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/consumers/dlq"
+)
+
+func listMessagesEndpoint(svc dlq.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := dlq.PageMetadata{
+			Offset:  req.offset,
+			Limit:   req.limit,
+			Channel: req.channel,
+		}
+		page, err := svc.List(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listMsgsRes{
+			Total:    page.Total,
+			Offset:   page.Offset,
+			Limit:    page.Limit,
+			Messages: []viewMsgRes{},
+		}
+		for _, msg := range page.Messages {
+			res.Messages = append(res.Messages, toViewMsgRes(msg))
+		}
+
+		return res, nil
+	}
+}
+
+func viewMessageEndpoint(svc dlq.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(msgReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		msg, err := svc.View(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toViewMsgRes(msg), nil
+	}
+}
+
+func replayMessageEndpoint(svc dlq.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(msgReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Replay(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return replayMsgRes{}, nil
+	}
+}
+
+func removeMessageEndpoint(svc dlq.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(msgReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Remove(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeMsgRes{}, nil
+	}
+}
+
+func toViewMsgRes(msg dlq.Message) viewMsgRes {
+	return viewMsgRes{
+		ID:        msg.ID,
+		Reason:    msg.Reason,
+		Created:   msg.Created,
+		Channel:   msg.Message.Channel,
+		Subtopic:  msg.Message.Subtopic,
+		Publisher: msg.Message.Publisher,
+		Protocol:  msg.Message.Protocol,
+		Payload:   msg.Message.Payload,
+	}
+}
@@ -0,0 +1,423 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/consumers/dlq/api"
+	"github.com/mainflux/mainflux/consumers/dlq/memory"
+	"github.com/mainflux/mainflux/consumers/dlq/mocks"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	capacity   = 100
+	chanID     = "chan"
+	wrongID    = "wrong"
+	token      = "token"
+	otherToken = "other-token"
+	email      = "user@example.com"
+	otherEmail = "other@example.com"
+)
+
+var (
+	errConsume  = errors.New("failed to consume message")
+	notFoundRes = toJSON(errorRes{dlq.ErrNotFound.Error()})
+	invalidRes  = toJSON(errorRes{errors.ErrInvalidQueryParams.Error()})
+	unauthRes   = toJSON(errorRes{dlq.ErrUnauthorizedAccess.Error()})
+)
+
+type errorRes struct {
+	Err string `json:"error"`
+}
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+type consumer struct {
+	fail bool
+}
+
+func (c *consumer) handle(messaging.Message) error {
+	if c.fail {
+		return errConsume
+	}
+	return nil
+}
+
+func newService(c *consumer) dlq.Service {
+	auth := mocks.NewAuthService(map[string]string{token: email, otherToken: otherEmail})
+	things := mocks.NewThingsService(map[string]string{
+		chanID:                         email,
+		fmt.Sprintf("%s-even", chanID): email,
+	})
+	return dlq.New(auth, things, memory.NewMessageRepository(capacity), mocks.NewPublisher(), c.handle, uuid.NewMock())
+}
+
+func newServer(svc dlq.Service) *httptest.Server {
+	mux := api.MakeHandler(svc, bone.New())
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+type viewMsgRes struct {
+	ID       string `json:"id"`
+	Reason   string `json:"reason"`
+	Channel  string `json:"channel"`
+	Subtopic string `json:"subtopic,omitempty"`
+	Payload  []byte `json:"payload,omitempty"`
+}
+
+type listMsgsRes struct {
+	Total    uint64       `json:"total"`
+	Offset   uint64       `json:"offset"`
+	Limit    uint64       `json:"limit"`
+	Messages []viewMsgRes `json:"messages"`
+}
+
+func handle(t *testing.T, svc dlq.Service, n int) []viewMsgRes {
+	res := []viewMsgRes{}
+	for i := 0; i < n; i++ {
+		msg := messaging.Message{
+			Channel:  chanID,
+			Subtopic: "subtopic",
+			Payload:  []byte(fmt.Sprintf("payload-%d", i)),
+		}
+		if i%2 == 0 {
+			msg.Channel = fmt.Sprintf("%s-even", chanID)
+		}
+		err := svc.Handle(context.Background(), msg, errConsume)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+		res = append(res, viewMsgRes{
+			ID:       fmt.Sprintf("%s%012d", uuid.Prefix, i+1),
+			Reason:   errConsume.Error(),
+			Channel:  msg.Channel,
+			Subtopic: msg.Subtopic,
+			Payload:  msg.Payload,
+		})
+	}
+	return res
+}
+
+func TestList(t *testing.T) {
+	svc := newService(&consumer{})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	n := 20
+	msgs := handle(t, svc, n)
+	odd := []viewMsgRes{}
+	for i, msg := range msgs {
+		if i%2 == 1 {
+			odd = append(odd, msg)
+		}
+	}
+
+	url := fmt.Sprintf("%s/dlq", ts.URL)
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		res    listMsgsRes
+	}{
+		{
+			desc:   "list dead letters",
+			url:    url,
+			token:  token,
+			status: http.StatusOK,
+			res:    listMsgsRes{Total: uint64(n), Offset: 0, Limit: 10, Messages: msgs[:10]},
+		},
+		{
+			desc:   "list dead letters with offset and limit",
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d", url, 15, 10),
+			token:  token,
+			status: http.StatusOK,
+			res:    listMsgsRes{Total: uint64(n), Offset: 15, Limit: 10, Messages: msgs[15:]},
+		},
+		{
+			desc:   "list dead letters by channel",
+			url:    fmt.Sprintf("%s?channel=%s&limit=%d", url, chanID, n),
+			token:  token,
+			status: http.StatusOK,
+			res:    listMsgsRes{Total: uint64(len(odd)), Offset: 0, Limit: uint64(n), Messages: odd},
+		},
+		{
+			desc:   "list other user's dead letters",
+			url:    url,
+			token:  otherToken,
+			status: http.StatusOK,
+			res:    listMsgsRes{Total: 0, Offset: 0, Limit: 10, Messages: []viewMsgRes{}},
+		},
+		{
+			desc:   "list dead letters with invalid token",
+			url:    url,
+			token:  wrongID,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list dead letters without token",
+			url:    url,
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list dead letters with zero limit",
+			url:    fmt.Sprintf("%s?limit=%d", url, 0),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with limit greater than max",
+			url:    fmt.Sprintf("%s?limit=%d", url, 110),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with invalid offset",
+			url:    fmt.Sprintf("%s?offset=%s", url, "invalid"),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body listMsgsRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestView(t *testing.T) {
+	svc := newService(&consumer{})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	msg := handle(t, svc, 1)[0]
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+		res    string
+	}{
+		{
+			desc:   "view existing dead letter",
+			id:     msg.ID,
+			token:  token,
+			status: http.StatusOK,
+			res:    toJSON(msg),
+		},
+		{
+			desc:   "view non-existing dead letter",
+			id:     wrongID,
+			token:  token,
+			status: http.StatusNotFound,
+			res:    notFoundRes,
+		},
+		{
+			desc:   "view other user's dead letter",
+			id:     msg.ID,
+			token:  otherToken,
+			status: http.StatusNotFound,
+			res:    notFoundRes,
+		},
+		{
+			desc:   "view dead letter with invalid token",
+			id:     msg.ID,
+			token:  wrongID,
+			status: http.StatusUnauthorized,
+			res:    unauthRes,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/dlq/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var body viewMsgRes
+		if tc.status == http.StatusOK {
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, msg, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, msg, body))
+			continue
+		}
+		data, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, strings.Trim(string(data), "\n"), fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, data))
+	}
+}
+
+func TestReplay(t *testing.T) {
+	c := &consumer{}
+	svc := newService(c)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	msg := handle(t, svc, 1)[0]
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		fail   bool
+		status int
+	}{
+		{
+			desc:   "replay dead letter with invalid token",
+			id:     msg.ID,
+			token:  wrongID,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "replay other user's dead letter",
+			id:     msg.ID,
+			token:  otherToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "replay dead letter failing again",
+			id:     msg.ID,
+			token:  token,
+			fail:   true,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			desc:   "replay dead letter",
+			id:     msg.ID,
+			token:  token,
+			fail:   false,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "replay replayed dead letter",
+			id:     msg.ID,
+			token:  token,
+			fail:   false,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		c.fail = tc.fail
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/dlq/%s/replay", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRemove(t *testing.T) {
+	svc := newService(&consumer{})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	msg := handle(t, svc, 1)[0]
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove dead letter without token",
+			id:     msg.ID,
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove other user's dead letter",
+			id:     msg.ID,
+			token:  otherToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "remove existing dead letter",
+			id:     msg.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed dead letter",
+			id:     msg.ID,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/dlq/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/dlq"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ dlq.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    dlq.Service
+}
+
+// LoggingMiddleware adds logging facilities to the dead letter service.
+func LoggingMiddleware(svc dlq.Service, logger log.Logger) dlq.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) Handle(ctx context.Context, msg messaging.Message, reason error) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method handle for channel %s took %s to complete", msg.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Handle(ctx, msg, reason)
+}
+
+func (lm *loggingMiddleware) List(ctx context.Context, token string, pm dlq.PageMetadata) (page dlq.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list for channel %s took %s to complete", pm.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.List(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) View(ctx context.Context, token, id string) (msg dlq.Message, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.View(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) Replay(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method replay for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Replay(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) Remove(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Remove(ctx, token, id)
+}
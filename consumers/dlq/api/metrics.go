@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ dlq.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	messages dlq.MessageRepository
+	counter  metrics.Counter
+	latency  metrics.Histogram
+	depth    metrics.Gauge
+	svc      dlq.Service
+}
+
+// MetricsMiddleware instruments dead letter service by tracking request
+// count and latency, and the number of dead letters stored in the given
+// repository.
+func MetricsMiddleware(svc dlq.Service, messages dlq.MessageRepository, counter metrics.Counter, latency metrics.Histogram, depth metrics.Gauge) dlq.Service {
+	return &metricsMiddleware{
+		messages: messages,
+		counter:  counter,
+		latency:  latency,
+		depth:    depth,
+		svc:      svc,
+	}
+}
+
+func (mm *metricsMiddleware) Handle(ctx context.Context, msg messaging.Message, reason error) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "handle").Add(1)
+		mm.latency.With("method", "handle").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	defer mm.updateDepth(ctx)
+
+	return mm.svc.Handle(ctx, msg, reason)
+}
+
+func (mm *metricsMiddleware) List(ctx context.Context, token string, pm dlq.PageMetadata) (dlq.Page, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list").Add(1)
+		mm.latency.With("method", "list").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.List(ctx, token, pm)
+}
+
+func (mm *metricsMiddleware) View(ctx context.Context, token, id string) (dlq.Message, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view").Add(1)
+		mm.latency.With("method", "view").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.View(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) Replay(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "replay").Add(1)
+		mm.latency.With("method", "replay").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	defer mm.updateDepth(ctx)
+
+	return mm.svc.Replay(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) Remove(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove").Add(1)
+		mm.latency.With("method", "remove").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	defer mm.updateDepth(ctx)
+
+	return mm.svc.Remove(ctx, token, id)
+}
+
+// updateDepth sets the depth gauge to the number of stored dead letters.
+func (mm *metricsMiddleware) updateDepth(ctx context.Context) {
+	page, err := mm.messages.RetrieveAll(ctx, dlq.PageMetadata{})
+	if err != nil {
+		return
+	}
+	mm.depth.Set(float64(page.Total))
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const maxLimitSize = 100
+
+var errInvalidID = errors.New("invalid or empty dead letter id")
+
+type listReq struct {
+	token   string
+	offset  uint64
+	limit   uint64
+	channel string
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return dlq.ErrUnauthorizedAccess
+	}
+
+	if req.limit < 1 || req.limit > maxLimitSize {
+		return errors.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+type msgReq struct {
+	token string
+	id    string
+}
+
+func (req msgReq) validate() error {
+	if req.token == "" {
+		return dlq.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return errInvalidID
+	}
+
+	return nil
+}
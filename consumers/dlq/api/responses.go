@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*viewMsgRes)(nil)
+	_ mainflux.Response = (*listMsgsRes)(nil)
+	_ mainflux.Response = (*replayMsgRes)(nil)
+	_ mainflux.Response = (*removeMsgRes)(nil)
+)
+
+type viewMsgRes struct {
+	ID        string    `json:"id"`
+	Reason    string    `json:"reason"`
+	Created   time.Time `json:"created"`
+	Channel   string    `json:"channel"`
+	Subtopic  string    `json:"subtopic,omitempty"`
+	Publisher string    `json:"publisher,omitempty"`
+	Protocol  string    `json:"protocol,omitempty"`
+	Payload   []byte    `json:"payload,omitempty"`
+}
+
+func (res viewMsgRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewMsgRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewMsgRes) Empty() bool {
+	return false
+}
+
+type listMsgsRes struct {
+	Total    uint64       `json:"total"`
+	Offset   uint64       `json:"offset"`
+	Limit    uint64       `json:"limit"`
+	Messages []viewMsgRes `json:"messages"`
+}
+
+func (res listMsgsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listMsgsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listMsgsRes) Empty() bool {
+	return false
+}
+
+type replayMsgRes struct{}
+
+func (res replayMsgRes) Code() int {
+	return http.StatusOK
+}
+
+func (res replayMsgRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res replayMsgRes) Empty() bool {
+	return true
+}
+
+type removeMsgRes struct{}
+
+func (res removeMsgRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeMsgRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeMsgRes) Empty() bool {
+	return true
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/internal/httputil"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	contentType = "application/json"
+
+	offsetKey  = "offset"
+	limitKey   = "limit"
+	channelKey = "channel"
+
+	defOffset = 0
+	defLimit  = 10
+)
+
+// MakeHandler registers dead letter API endpoints on the given router.
+func MakeHandler(svc dlq.Service, mux *bone.Mux) *bone.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
+	mux.Get("/dlq", kithttp.NewServer(
+		listMessagesEndpoint(svc),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/dlq/:id", kithttp.NewServer(
+		viewMessageEndpoint(svc),
+		decodeMessage,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/dlq/:id/replay", kithttp.NewServer(
+		replayMessageEndpoint(svc),
+		decodeMessage,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/dlq/:id", kithttp.NewServer(
+		removeMessageEndpoint(svc),
+		decodeMessage,
+		encodeResponse,
+		opts...,
+	))
+
+	return mux
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := httputil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := httputil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := listReq{
+		token:   r.Header.Get("Authorization"),
+		offset:  o,
+		limit:   l,
+		channel: c,
+	}
+	return req, nil
+}
+
+func decodeMessage(_ context.Context, r *http.Request) (interface{}, error) {
+	req := msgReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", contentType)
+
+	switch {
+	case errors.Contains(err, errors.ErrInvalidQueryParams),
+		errors.Contains(err, errInvalidID):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, dlq.ErrUnauthorizedAccess):
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, dlq.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, dlq.ErrReplay):
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		if err := json.NewEncoder(w).Encode(errorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dlq contains the dead-letter queue service used by consumers to
+// keep track of the messages that failed to be transformed or consumed.
+package dlq
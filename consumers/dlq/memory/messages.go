@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory contains in-memory dead letter repository implementation.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/dlq"
+)
+
+var _ dlq.MessageRepository = (*messageRepository)(nil)
+
+type messageRepository struct {
+	mu       sync.Mutex
+	capacity int
+	ids      []string
+	messages map[string]dlq.Message
+}
+
+// NewMessageRepository instantiates in-memory dead letter repository that
+// keeps at most capacity messages. Once the capacity is reached, the oldest
+// message is evicted to make room for the new one.
+func NewMessageRepository(capacity int) dlq.MessageRepository {
+	return &messageRepository{
+		capacity: capacity,
+		messages: make(map[string]dlq.Message),
+	}
+}
+
+func (mr *messageRepository) Save(_ context.Context, msg dlq.Message) error {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if _, ok := mr.messages[msg.ID]; ok {
+		mr.messages[msg.ID] = msg
+		return nil
+	}
+
+	if mr.capacity > 0 && len(mr.ids) >= mr.capacity {
+		delete(mr.messages, mr.ids[0])
+		mr.ids = mr.ids[1:]
+	}
+	mr.ids = append(mr.ids, msg.ID)
+	mr.messages[msg.ID] = msg
+
+	return nil
+}
+
+func (mr *messageRepository) RetrieveByID(_ context.Context, id string) (dlq.Message, error) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	msg, ok := mr.messages[id]
+	if !ok {
+		return dlq.Message{}, dlq.ErrNotFound
+	}
+
+	return msg, nil
+}
+
+func (mr *messageRepository) RetrieveAll(_ context.Context, pm dlq.PageMetadata) (dlq.Page, error) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	msgs := []dlq.Message{}
+	for _, id := range mr.ids {
+		msg := mr.messages[id]
+		if pm.Channel != "" && msg.Message.Channel != pm.Channel {
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+
+	page := dlq.Page{
+		PageMetadata: pm,
+		Total:        uint64(len(msgs)),
+		Messages:     []dlq.Message{},
+	}
+	if pm.Offset >= page.Total {
+		return page, nil
+	}
+
+	end := pm.Offset + pm.Limit
+	if end > page.Total {
+		end = page.Total
+	}
+	page.Messages = msgs[pm.Offset:end]
+
+	return page, nil
+}
+
+func (mr *messageRepository) Remove(_ context.Context, id string) error {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if _, ok := mr.messages[id]; !ok {
+		return dlq.ErrNotFound
+	}
+
+	delete(mr.messages, id)
+	for i, v := range mr.ids {
+		if v == id {
+			mr.ids = append(mr.ids[:i], mr.ids[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
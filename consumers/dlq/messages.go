@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dlq
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// Message represents a message that failed to be consumed.
+type Message struct {
+	ID      string
+	Reason  string
+	Created time.Time
+	Message messaging.Message
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset  uint64
+	Limit   uint64
+	Channel string
+}
+
+// Page represents page metadata with content.
+type Page struct {
+	PageMetadata
+	Total    uint64
+	Messages []Message
+}
+
+// MessageRepository specifies a dead letter persistence API.
+type MessageRepository interface {
+	// Save persists the dead letter. Saving the message with the existing ID
+	// overwrites it.
+	Save(ctx context.Context, msg Message) error
+
+	// RetrieveByID retrieves the dead letter having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Message, error)
+
+	// RetrieveAll retrieves the subset of dead letters ordered by the time
+	// of creation.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (Page, error)
+
+	// Remove removes the dead letter having the provided identifier.
+	Remove(ctx context.Context, id string) error
+}
+
+// Publisher specifies an API for announcing dead letters to the other
+// services.
+type Publisher interface {
+	// Publish publishes the dead letter.
+	Publish(msg Message) error
+}
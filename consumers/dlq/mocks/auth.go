@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
+
+type authServiceMock struct {
+	users map[string]string
+}
+
+// NewAuthService returns mock implementation of auth service. Users map
+// tokens to the user emails.
+func NewAuthService(users map[string]string) mainflux.AuthServiceClient {
+	return authServiceMock{users}
+}
+
+func (svc authServiceMock) Issue(ctx context.Context, req *mainflux.IssueReq, _ ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Identify(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	email, ok := svc.users[token.GetValue()]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid credentials provided")
+	}
+	return &mainflux.UserIdentity{Id: email, Email: email}, nil
+}
+
+func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (*mainflux.AuthorizeRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Granted(ctx context.Context, req *mainflux.GrantedReq, _ ...grpc.CallOption) (*mainflux.GrantedRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (*mainflux.MembersRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Claim(ctx context.Context, req *mainflux.ClaimReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) ListPolicies(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*mainflux.PoliciesRes, error) {
+	panic("not implemented")
+}
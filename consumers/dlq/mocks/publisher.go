@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/dlq"
+)
+
+var _ dlq.Publisher = (*Publisher)(nil)
+
+// Publisher is a dead letter publisher mock that keeps published messages.
+type Publisher struct {
+	mu       sync.Mutex
+	messages []dlq.Message
+}
+
+// NewPublisher returns dead letter publisher mock.
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+// Publish stores the published dead letter.
+func (pub *Publisher) Publish(msg dlq.Message) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.messages = append(pub.messages, msg)
+	return nil
+}
+
+// Messages returns the published dead letters.
+func (pub *Publisher) Messages() []dlq.Message {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	return append([]dlq.Message{}, pub.messages...)
+}
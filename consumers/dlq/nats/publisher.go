@@ -0,0 +1,76 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nats contains NATS dead letter publisher implementation.
+package nats
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+// SubjectPrefix is the prefix of the subjects dead letters are published to.
+// Dead letter of the message published to the channel is published to the
+// dlq.channels.<channel_id>[.<subtopic>] subject.
+const SubjectPrefix = "dlq.channels"
+
+var _ dlq.Publisher = (*publisher)(nil)
+
+// Publisher wraps dead letter Publisher exposing Close() method for NATS
+// connection.
+type Publisher interface {
+	dlq.Publisher
+	Close()
+}
+
+type publisher struct {
+	conn *broker.Conn
+}
+
+type deadLetter struct {
+	ID      string            `json:"id"`
+	Reason  string            `json:"reason"`
+	Created time.Time         `json:"created"`
+	Message messaging.Message `json:"message"`
+}
+
+// NewPublisher returns NATS dead letter publisher. Dead letters are published
+// as JSON encoded documents carrying the failure reason and the original
+// message.
+func NewPublisher(url string) (Publisher, error) {
+	conn, err := broker.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &publisher{conn: conn}, nil
+}
+
+func (pub *publisher) Publish(msg dlq.Message) error {
+	dl := deadLetter{
+		ID:      msg.ID,
+		Reason:  msg.Reason,
+		Created: msg.Created,
+		Message: msg.Message,
+	}
+	data, err := json.Marshal(dl)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("%s.%s", SubjectPrefix, msg.Message.Channel)
+	if msg.Message.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Message.Subtopic)
+	}
+
+	return pub.conn.Publish(subject, data)
+}
+
+func (pub *publisher) Close() {
+	pub.conn.Close()
+}
@@ -0,0 +1,225 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dlq
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotFound indicates a non-existent dead letter request.
+	ErrNotFound = errors.New("non-existent entity")
+
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when accessing the dead letters.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrCreateID indicates error in creating id for the dead letter.
+	ErrCreateID = errors.New("failed to create id")
+
+	// ErrPublish indicates error in publishing the dead letter.
+	ErrPublish = errors.New("failed to publish dead letter")
+
+	// ErrReplay indicates that the replayed message failed to be consumed
+	// again.
+	ErrReplay = errors.New("failed to replay dead letter")
+)
+
+// Service specifies an API for handling dead letters.
+type Service interface {
+	// Handle stores the message that failed to be consumed along with the
+	// failure reason and publishes it to the dead letter subject.
+	Handle(ctx context.Context, msg messaging.Message, reason error) error
+
+	// List retrieves dead letters of the channels owned by the user
+	// identified by the provided token for the given page metadata.
+	List(ctx context.Context, token string, pm PageMetadata) (Page, error)
+
+	// View retrieves the dead letter having the provided identifier, as long
+	// as its channel is owned by the user identified by the provided token.
+	View(ctx context.Context, token, id string) (Message, error)
+
+	// Replay passes the dead letter to the consumer once again. Successfully
+	// replayed dead letter is removed, otherwise its reason is updated.
+	Replay(ctx context.Context, token, id string) error
+
+	// Remove removes the dead letter having the provided identifier.
+	Remove(ctx context.Context, token, id string) error
+}
+
+var _ Service = (*dlqService)(nil)
+
+type dlqService struct {
+	auth      mainflux.AuthServiceClient
+	things    mainflux.ThingsServiceClient
+	messages  MessageRepository
+	publisher Publisher
+	handler   messaging.MessageHandler
+	idp       mainflux.IDProvider
+}
+
+// New instantiates the dead letter service implementation. Replayed
+// messages are passed to the given handler.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, messages MessageRepository, publisher Publisher, handler messaging.MessageHandler, idp mainflux.IDProvider) Service {
+	return &dlqService{
+		auth:      auth,
+		things:    things,
+		messages:  messages,
+		publisher: publisher,
+		handler:   handler,
+		idp:       idp,
+	}
+}
+
+func (ds *dlqService) Handle(ctx context.Context, msg messaging.Message, reason error) error {
+	id, err := ds.idp.ID()
+	if err != nil {
+		return errors.Wrap(ErrCreateID, err)
+	}
+
+	dl := Message{
+		ID:      id,
+		Reason:  reason.Error(),
+		Created: time.Now().UTC(),
+		Message: msg,
+	}
+	if err := ds.messages.Save(ctx, dl); err != nil {
+		return err
+	}
+
+	if err := ds.publisher.Publish(dl); err != nil {
+		return errors.Wrap(ErrPublish, err)
+	}
+
+	return nil
+}
+
+func (ds *dlqService) List(ctx context.Context, token string, pm PageMetadata) (Page, error) {
+	owner, err := ds.identify(ctx, token)
+	if err != nil {
+		return Page{}, err
+	}
+
+	// Dead letters are few and bounded by the store capacity, so they are
+	// filtered by the channel owner before paging.
+	all, err := ds.messages.RetrieveAll(ctx, PageMetadata{Channel: pm.Channel})
+	if err != nil {
+		return Page{}, err
+	}
+	all, err = ds.messages.RetrieveAll(ctx, PageMetadata{Channel: pm.Channel, Limit: all.Total})
+	if err != nil {
+		return Page{}, err
+	}
+
+	owned := map[string]bool{}
+	msgs := []Message{}
+	for _, msg := range all.Messages {
+		ch := msg.Message.Channel
+		if _, ok := owned[ch]; !ok {
+			if owned[ch], err = ds.isOwner(ctx, owner, ch); err != nil {
+				return Page{}, err
+			}
+		}
+		if owned[ch] {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	page := Page{
+		PageMetadata: pm,
+		Total:        uint64(len(msgs)),
+		Messages:     []Message{},
+	}
+	if pm.Offset >= page.Total {
+		return page, nil
+	}
+	end := pm.Offset + pm.Limit
+	if end > page.Total {
+		end = page.Total
+	}
+	page.Messages = msgs[pm.Offset:end]
+
+	return page, nil
+}
+
+func (ds *dlqService) View(ctx context.Context, token, id string) (Message, error) {
+	return ds.retrieve(ctx, token, id)
+}
+
+func (ds *dlqService) Replay(ctx context.Context, token, id string) error {
+	dl, err := ds.retrieve(ctx, token, id)
+	if err != nil {
+		return err
+	}
+
+	if err := ds.handler(dl.Message); err != nil {
+		dl.Reason = err.Error()
+		if err := ds.messages.Save(ctx, dl); err != nil {
+			return err
+		}
+		return errors.Wrap(ErrReplay, err)
+	}
+
+	return ds.messages.Remove(ctx, id)
+}
+
+func (ds *dlqService) Remove(ctx context.Context, token, id string) error {
+	if _, err := ds.retrieve(ctx, token, id); err != nil {
+		return err
+	}
+
+	return ds.messages.Remove(ctx, id)
+}
+
+// retrieve retrieves the dead letter if its channel is owned by the user.
+// Dead letters of the other users' channels are reported as non-existent.
+func (ds *dlqService) retrieve(ctx context.Context, token, id string) (Message, error) {
+	owner, err := ds.identify(ctx, token)
+	if err != nil {
+		return Message{}, err
+	}
+
+	dl, err := ds.messages.RetrieveByID(ctx, id)
+	if err != nil {
+		return Message{}, err
+	}
+
+	ok, err := ds.isOwner(ctx, owner, dl.Message.Channel)
+	if err != nil {
+		return Message{}, err
+	}
+	if !ok {
+		return Message{}, ErrNotFound
+	}
+
+	return dl, nil
+}
+
+func (ds *dlqService) identify(ctx context.Context, token string) (string, error) {
+	res, err := ds.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return res.GetEmail(), nil
+}
+
+func (ds *dlqService) isOwner(ctx context.Context, owner, chanID string) (bool, error) {
+	_, err := ds.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: owner, ChanID: chanID})
+	switch status.Code(err) {
+	case codes.OK:
+		return true, nil
+	case codes.NotFound, codes.PermissionDenied:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package dlq_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers/dlq"
+	"github.com/mainflux/mainflux/consumers/dlq/memory"
+	"github.com/mainflux/mainflux/consumers/dlq/mocks"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	capacity   = 10
+	chanID     = "chan"
+	wrongID    = "wrong"
+	token      = "token"
+	otherToken = "other-token"
+	email      = "user@example.com"
+	otherEmail = "other@example.com"
+	otherChan  = "other-chan"
+)
+
+var errConsume = errors.New("failed to consume message")
+
+// consumer mocks the consumer used for the replay.
+type consumer struct {
+	mu   sync.Mutex
+	fail bool
+	msgs []messaging.Message
+}
+
+func (c *consumer) handle(msg messaging.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fail {
+		return errConsume
+	}
+	c.msgs = append(c.msgs, msg)
+	return nil
+}
+
+func (c *consumer) setFail(fail bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fail = fail
+}
+
+func newService(c *consumer) (dlq.Service, *mocks.Publisher) {
+	pub := mocks.NewPublisher()
+	auth := mocks.NewAuthService(map[string]string{token: email, otherToken: otherEmail})
+	things := mocks.NewThingsService(map[string]string{
+		chanID:                         email,
+		fmt.Sprintf("%s-even", chanID): email,
+		otherChan:                      otherEmail,
+	})
+	return dlq.New(auth, things, memory.NewMessageRepository(capacity), pub, c.handle, uuid.NewMock()), pub
+}
+
+func TestHandle(t *testing.T) {
+	svc, pub := newService(&consumer{})
+
+	msg := messaging.Message{Channel: chanID, Subtopic: "subtopic", Payload: []byte("payload")}
+	err := svc.Handle(context.Background(), msg, errConsume)
+	require.Nil(t, err, fmt.Sprintf("handling dead letter expected to succeed: %s", err))
+
+	page, err := svc.List(context.Background(), token, dlq.PageMetadata{Limit: capacity})
+	require.Nil(t, err, fmt.Sprintf("listing dead letters expected to succeed: %s", err))
+	require.Len(t, page.Messages, 1, "expected single dead letter stored")
+	dl := page.Messages[0]
+	assert.Equal(t, msg, dl.Message, fmt.Sprintf("expected %v got %v", msg, dl.Message))
+	assert.Equal(t, errConsume.Error(), dl.Reason, fmt.Sprintf("expected reason %s got %s", errConsume, dl.Reason))
+
+	published := pub.Messages()
+	require.Len(t, published, 1, "expected single dead letter published")
+	assert.Equal(t, dl, published[0], fmt.Sprintf("expected %v got %v", dl, published[0]))
+}
+
+func TestList(t *testing.T) {
+	svc, _ := newService(&consumer{})
+
+	n := capacity + 2
+	for i := 0; i < n; i++ {
+		ch := chanID
+		if i%2 == 0 {
+			ch = fmt.Sprintf("%s-even", chanID)
+		}
+		err := svc.Handle(context.Background(), messaging.Message{Channel: ch}, errConsume)
+		require.Nil(t, err, fmt.Sprintf("handling dead letter expected to succeed: %s", err))
+	}
+
+	err := svc.Handle(context.Background(), messaging.Message{Channel: otherChan}, errConsume)
+	require.Nil(t, err, fmt.Sprintf("handling dead letter expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    dlq.PageMetadata
+		total uint64
+		size  int
+		err   error
+	}{
+		{
+			desc:  "list all dead letters",
+			token: token,
+			pm:    dlq.PageMetadata{Offset: 0, Limit: capacity},
+			total: capacity - 1,
+			size:  capacity - 1,
+		},
+		{
+			desc:  "list half of dead letters",
+			token: token,
+			pm:    dlq.PageMetadata{Offset: capacity / 2, Limit: capacity},
+			total: capacity - 1,
+			size:  capacity/2 - 1,
+		},
+		{
+			desc:  "list dead letters with offset out of range",
+			token: token,
+			pm:    dlq.PageMetadata{Offset: capacity, Limit: capacity},
+			total: capacity - 1,
+			size:  0,
+		},
+		{
+			desc:  "list dead letters by channel",
+			token: token,
+			pm:    dlq.PageMetadata{Offset: 0, Limit: capacity, Channel: chanID},
+			total: capacity / 2,
+			size:  capacity / 2,
+		},
+		{
+			desc:  "list dead letters by non-existing channel",
+			token: token,
+			pm:    dlq.PageMetadata{Offset: 0, Limit: capacity, Channel: wrongID},
+			total: 0,
+			size:  0,
+		},
+		{
+			desc:  "list dead letters by other user's channel",
+			token: token,
+			pm:    dlq.PageMetadata{Offset: 0, Limit: capacity, Channel: otherChan},
+			total: 0,
+			size:  0,
+		},
+		{
+			desc:  "list other user's dead letters",
+			token: otherToken,
+			pm:    dlq.PageMetadata{Offset: 0, Limit: capacity},
+			total: 1,
+			size:  1,
+		},
+		{
+			desc:  "list dead letters with invalid token",
+			token: wrongID,
+			pm:    dlq.PageMetadata{Offset: 0, Limit: capacity},
+			err:   dlq.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.List(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+		assert.Len(t, page.Messages, tc.size, fmt.Sprintf("%s: expected size %d got %d", tc.desc, tc.size, len(page.Messages)))
+	}
+
+	// The oldest dead letters are evicted once the capacity is reached.
+	_, err = svc.View(context.Background(), token, fmt.Sprintf("%s%012d", uuid.Prefix, 1))
+	assert.True(t, errors.Contains(err, dlq.ErrNotFound), fmt.Sprintf("view evicted dead letter: expected %s got %s", dlq.ErrNotFound, err))
+}
+
+func TestView(t *testing.T) {
+	svc, _ := newService(&consumer{})
+
+	msg := messaging.Message{Channel: chanID}
+	err := svc.Handle(context.Background(), msg, errConsume)
+	require.Nil(t, err, fmt.Sprintf("handling dead letter expected to succeed: %s", err))
+	id := fmt.Sprintf("%s%012d", uuid.Prefix, 1)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "view existing dead letter",
+			token: token,
+			id:    id,
+			err:   nil,
+		},
+		{
+			desc:  "view non-existing dead letter",
+			token: token,
+			id:    wrongID,
+			err:   dlq.ErrNotFound,
+		},
+		{
+			desc:  "view other user's dead letter",
+			token: otherToken,
+			id:    id,
+			err:   dlq.ErrNotFound,
+		},
+		{
+			desc:  "view dead letter with invalid token",
+			token: wrongID,
+			id:    id,
+			err:   dlq.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		dl, err := svc.View(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, msg, dl.Message, fmt.Sprintf("%s: expected %v got %v", tc.desc, msg, dl.Message))
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	c := &consumer{}
+	svc, _ := newService(c)
+
+	msg := messaging.Message{Channel: chanID}
+	err := svc.Handle(context.Background(), msg, errConsume)
+	require.Nil(t, err, fmt.Sprintf("handling dead letter expected to succeed: %s", err))
+	id := fmt.Sprintf("%s%012d", uuid.Prefix, 1)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		fail  bool
+		err   error
+	}{
+		{
+			desc:  "replay dead letter with invalid token",
+			token: wrongID,
+			id:    id,
+			fail:  false,
+			err:   dlq.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "replay other user's dead letter",
+			token: otherToken,
+			id:    id,
+			fail:  false,
+			err:   dlq.ErrNotFound,
+		},
+		{
+			desc:  "replay dead letter failing again",
+			token: token,
+			id:    id,
+			fail:  true,
+			err:   dlq.ErrReplay,
+		},
+		{
+			desc:  "replay dead letter",
+			token: token,
+			id:    id,
+			fail:  false,
+			err:   nil,
+		},
+		{
+			desc:  "replay already replayed dead letter",
+			token: token,
+			id:    id,
+			fail:  false,
+			err:   dlq.ErrNotFound,
+		},
+		{
+			desc:  "replay non-existing dead letter",
+			token: token,
+			id:    wrongID,
+			fail:  false,
+			err:   dlq.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		c.setFail(tc.fail)
+		err := svc.Replay(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+
+	assert.Equal(t, []messaging.Message{msg}, c.msgs, fmt.Sprintf("expected replayed message %v got %v", msg, c.msgs))
+}
+
+func TestRemove(t *testing.T) {
+	svc, _ := newService(&consumer{})
+
+	err := svc.Handle(context.Background(), messaging.Message{Channel: chanID}, errConsume)
+	require.Nil(t, err, fmt.Sprintf("handling dead letter expected to succeed: %s", err))
+	id := fmt.Sprintf("%s%012d", uuid.Prefix, 1)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove dead letter with invalid token",
+			token: wrongID,
+			id:    id,
+			err:   dlq.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "remove other user's dead letter",
+			token: otherToken,
+			id:    id,
+			err:   dlq.ErrNotFound,
+		},
+		{
+			desc:  "remove existing dead letter",
+			token: token,
+			id:    id,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed dead letter",
+			token: token,
+			id:    id,
+			err:   dlq.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Remove(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
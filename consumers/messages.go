@@ -4,6 +4,7 @@
 package consumers
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 
@@ -20,24 +21,37 @@ var (
 	errParseConfFile = errors.New("unable to parse configuration file")
 )
 
+// DeadLetterHandler specifies an API for handling messages that failed to be
+// transformed or consumed.
+type DeadLetterHandler interface {
+	// Handle handles the message that failed to be consumed for the given
+	// reason.
+	Handle(ctx context.Context, msg messaging.Message, reason error) error
+}
+
 // Start method starts consuming messages received from NATS.
 // This method transforms messages to SenML format before
-// using MessageRepository to store them.
-func Start(sub messaging.Subscriber, consumer Consumer, transformer transformers.Transformer, subjectsCfgPath string, logger logger.Logger) error {
+// using MessageRepository to store them. Messages that fail to be
+// transformed or consumed on the last delivery attempt are passed
+// to the dead letter handler, if one is provided.
+func Start(sub messaging.Subscriber, consumer Consumer, transformer transformers.Transformer, dlh DeadLetterHandler, subjectsCfgPath string, logger logger.Logger) error {
 	subjects, err := loadSubjectsConfig(subjectsCfgPath)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Failed to load subjects: %s", err))
 	}
 
 	for _, subject := range subjects {
-		if err := sub.Subscribe(subject, handler(transformer, consumer)); err != nil {
+		if err := sub.Subscribe(subject, deadLetterHandler(Handler(transformer, consumer), dlh, logger)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func handler(t transformers.Transformer, c Consumer) messaging.MessageHandler {
+// Handler returns the message handler that transforms the message using the
+// given transformer, if any, and passes it to the consumer. Batch consumer
+// also receives the original message, so that it can be dead-lettered.
+func Handler(t transformers.Transformer, c Consumer) messaging.MessageHandler {
 	return func(msg messaging.Message) error {
 		m := interface{}(msg)
 		var err error
@@ -47,10 +61,40 @@ func handler(t transformers.Transformer, c Consumer) messaging.MessageHandler {
 				return err
 			}
 		}
+		if bc, ok := c.(BatchConsumer); ok {
+			return bc.ConsumeMessage(msg, m)
+		}
 		return c.Consume(m)
 	}
 }
 
+// deadLetterHandler passes the message to the dead letter handler once it
+// fails to be consumed on the last delivery. Until then, the error is
+// returned, so the message is redelivered.
+func deadLetterHandler(h messaging.MessageHandler, dlh DeadLetterHandler, logger logger.Logger) messaging.MessageHandler {
+	if dlh == nil {
+		return h
+	}
+
+	return func(msg messaging.Message) error {
+		last := messaging.LastDelivery(msg)
+		msg = messaging.StripDelivery(msg)
+
+		err := h(msg)
+		if err == nil || !last {
+			return err
+		}
+
+		if dlErr := dlh.Handle(context.Background(), msg, err); dlErr != nil {
+			logger.Warn(fmt.Sprintf("Failed to handle dead letter: %s", dlErr))
+			return err
+		}
+
+		// Message is taken care of by the dead letter handler.
+		return nil
+	}
+}
+
 type filterConfig struct {
 	Filter []string `toml:"filter"`
 }
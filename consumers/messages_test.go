@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subscriber keeps the handler of the last subscription.
+type subscriber struct {
+	handler messaging.MessageHandler
+}
+
+func (s *subscriber) Subscribe(_ string, h messaging.MessageHandler) error {
+	s.handler = h
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(string) error {
+	return nil
+}
+
+// deadLetters keeps the messages passed to the dead letter handler.
+type deadLetters struct {
+	msgs []messaging.Message
+}
+
+func (dl *deadLetters) Handle(_ context.Context, msg messaging.Message, _ error) error {
+	dl.msgs = append(dl.msgs, msg)
+	return nil
+}
+
+// failing consumer fails to consume the messages published to the invalid
+// channel.
+type failing struct{}
+
+func (failing) Consume(m interface{}) error {
+	if m.(messaging.Message).Channel == invalid {
+		return errConsume
+	}
+	return nil
+}
+
+func TestDeadLetters(t *testing.T) {
+	logger, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sub := &subscriber{}
+	dl := &deadLetters{}
+	err = consumers.Start(sub, failing{}, nil, dl, "", logger)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	delivery := func(n string) map[string]string {
+		return map[string]string{messaging.DeliveryHeader: n, messaging.MaxDeliverHeader: "3"}
+	}
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		err  error
+		dead bool
+	}{
+		{
+			desc: "consume message",
+			msg:  messaging.Message{Channel: "channel", Headers: delivery("1")},
+			err:  nil,
+			dead: false,
+		},
+		{
+			desc: "fail to consume message to be redelivered",
+			msg:  messaging.Message{Channel: invalid, Headers: delivery("2")},
+			err:  errConsume,
+			dead: false,
+		},
+		{
+			desc: "fail to consume message on the last delivery",
+			msg:  messaging.Message{Channel: invalid, Headers: delivery("3")},
+			err:  nil,
+			dead: true,
+		},
+		{
+			desc: "fail to consume message delivered once",
+			msg:  messaging.Message{Channel: invalid},
+			err:  nil,
+			dead: true,
+		},
+	}
+
+	for _, tc := range cases {
+		dl.msgs = nil
+		err := sub.handler(tc.msg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if !tc.dead {
+			assert.Empty(t, dl.msgs, fmt.Sprintf("%s: expected no dead letters", tc.desc))
+			continue
+		}
+		expected := []messaging.Message{{Channel: invalid}}
+		assert.Equal(t, expected, dl.msgs, fmt.Sprintf("%s: expected dead letters %v got %v", tc.desc, expected, dl.msgs))
+	}
+}
@@ -44,6 +44,10 @@ func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.Authoriz
 	panic("not implemented")
 }
 
+func (svc authServiceMock) Granted(ctx context.Context, req *mainflux.GrantedReq, _ ...grpc.CallOption) (*mainflux.GrantedRes, error) {
+	panic("not implemented")
+}
+
 func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
 	panic("not implemented")
 }
@@ -51,3 +55,19 @@ func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq
 func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc authServiceMock) Claim(ctx context.Context, req *mainflux.ClaimReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) ListPolicies(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*mainflux.PoliciesRes, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"math"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+var hour = time.Hour.Seconds()
+
+// Aggregate represents the numeric values of SenML messages having the same
+// publisher and name, aggregated over an hour.
+type Aggregate struct {
+	Channel   string
+	Publisher string
+	Name      string
+	// Time is the hour start time in seconds.
+	Time  float64
+	Min   float64
+	Max   float64
+	Sum   float64
+	Count uint64
+}
+
+type aggregateKey struct {
+	publisher string
+	name      string
+	time      float64
+}
+
+func (a Aggregate) key() aggregateKey {
+	return aggregateKey{
+		publisher: a.Publisher,
+		name:      a.Name,
+		time:      a.Time,
+	}
+}
+
+// Merge returns the aggregate of the values of both aggregates.
+func (a Aggregate) Merge(other Aggregate) Aggregate {
+	a.Min = math.Min(a.Min, other.Min)
+	a.Max = math.Max(a.Max, other.Max)
+	a.Sum += other.Sum
+	a.Count += other.Count
+	return a
+}
+
+// Hourly aggregates numeric values of the messages by publisher, name and
+// hour. Messages without numeric value are skipped.
+func Hourly(msgs []senml.Message) []Aggregate {
+	var ret []Aggregate
+	idx := map[aggregateKey]int{}
+	for _, msg := range msgs {
+		if msg.Value == nil {
+			continue
+		}
+		v := *msg.Value
+		agg := Aggregate{
+			Channel:   msg.Channel,
+			Publisher: msg.Publisher,
+			Name:      msg.Name,
+			Time:      math.Floor(msg.Time/hour) * hour,
+			Min:       v,
+			Max:       v,
+			Sum:       v,
+			Count:     1,
+		}
+		if i, ok := idx[agg.key()]; ok {
+			ret[i] = ret[i].Merge(agg)
+			continue
+		}
+		idx[agg.key()] = len(ret)
+		ret = append(ret, agg)
+	}
+
+	return ret
+}
+
+// MergeAll merges the new aggregates with the existing ones of the same
+// publisher, name and hour. Only the aggregates affected by the new ones
+// are returned.
+func MergeAll(existing, aggs []Aggregate) []Aggregate {
+	idx := map[aggregateKey]Aggregate{}
+	for _, agg := range existing {
+		idx[agg.key()] = agg
+	}
+
+	var ret []Aggregate
+	for _, agg := range aggs {
+		if e, ok := idx[agg.key()]; ok {
+			agg = agg.Merge(e)
+		}
+		ret = append(ret, agg)
+	}
+
+	return ret
+}
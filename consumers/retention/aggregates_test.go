@@ -0,0 +1,41 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHourly(t *testing.T) {
+	v1, v2, v3 := 1.0, 5.0, 3.0
+	vs := "high"
+	msgs := []senml.Message{
+		{Channel: chanID, Publisher: "pub", Name: "temp", Time: 7200, Value: &v1},
+		{Channel: chanID, Publisher: "pub", Name: "temp", Time: 10799.5, Value: &v2},
+		{Channel: chanID, Publisher: "pub", Name: "temp", Time: 10800, Value: &v3},
+		{Channel: chanID, Publisher: "pub", Name: "hum", Time: 7300, Value: &v3},
+		{Channel: chanID, Publisher: "pub", Name: "temp", Time: 7400, StringValue: &vs},
+	}
+
+	expected := []retention.Aggregate{
+		{Channel: chanID, Publisher: "pub", Name: "temp", Time: 7200, Min: 1, Max: 5, Sum: 6, Count: 2},
+		{Channel: chanID, Publisher: "pub", Name: "temp", Time: 10800, Min: 3, Max: 3, Sum: 3, Count: 1},
+		{Channel: chanID, Publisher: "pub", Name: "hum", Time: 7200, Min: 3, Max: 3, Sum: 3, Count: 1},
+	}
+	aggs := retention.Hourly(msgs)
+	assert.Equal(t, expected, aggs, fmt.Sprintf("expected %v got %v", expected, aggs))
+
+	existing := []retention.Aggregate{
+		{Channel: chanID, Publisher: "pub", Name: "temp", Time: 7200, Min: 0, Max: 2, Sum: 2, Count: 2},
+		{Channel: chanID, Publisher: "pub", Name: "temp", Time: 3600, Min: 0, Max: 0, Sum: 0, Count: 1},
+	}
+	expected[0] = retention.Aggregate{Channel: chanID, Publisher: "pub", Name: "temp", Time: 7200, Min: 0, Max: 5, Sum: 8, Count: 4}
+	merged := retention.MergeAll(existing, aggs)
+	assert.Equal(t, expected, merged, fmt.Sprintf("expected %v got %v", expected, merged))
+}
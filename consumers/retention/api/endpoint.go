@@ -0,0 +1,88 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/consumers/retention"
+)
+
+func listRulesEndpoint(svc retention.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rules, err := svc.ListRules(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listRulesRes{
+			Rules: []viewRuleRes{},
+		}
+		for _, rule := range rules {
+			res.Rules = append(res.Rules, toViewRuleRes(rule))
+		}
+
+		return res, nil
+	}
+}
+
+func viewRuleEndpoint(svc retention.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rule, err := svc.ViewRule(ctx, req.token, req.channel)
+		if err != nil {
+			return nil, err
+		}
+
+		return toViewRuleRes(rule), nil
+	}
+}
+
+func saveRuleEndpoint(svc retention.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(saveRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		// Durations are already validated.
+		rule := retention.Rule{Channel: req.channel}
+		rule.Raw, _ = time.ParseDuration(req.Raw)
+		if req.Aggregates != "" {
+			rule.Aggregates, _ = time.ParseDuration(req.Aggregates)
+		}
+
+		if err := svc.SaveRule(ctx, req.token, rule); err != nil {
+			return nil, err
+		}
+
+		return saveRuleRes{}, nil
+	}
+}
+
+func removeRuleEndpoint(svc retention.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveRule(ctx, req.token, req.channel); err != nil {
+			return nil, err
+		}
+
+		return removeRuleRes{}, nil
+	}
+}
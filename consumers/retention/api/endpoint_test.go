@@ -0,0 +1,415 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/consumers/retention/api"
+	"github.com/mainflux/mainflux/consumers/retention/file"
+	"github.com/mainflux/mainflux/consumers/retention/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType = "application/json"
+	chanID      = "chan"
+	otherID     = "other-chan"
+	wrongID     = "wrong"
+	token       = "token"
+	otherToken  = "other-token"
+	wrongToken  = "wrong-token"
+	email       = "user@example.com"
+	otherEmail  = "other@example.com"
+)
+
+var (
+	notFoundRes     = toJSON(errorRes{retention.ErrNotFound.Error()})
+	unauthorizedRes = toJSON(errorRes{retention.ErrUnauthorizedAccess.Error()})
+	forbiddenRes    = toJSON(errorRes{retention.ErrForbidden.Error()})
+)
+
+type errorRes struct {
+	Err string `json:"error"`
+}
+
+type ruleRes struct {
+	Channel    string `json:"channel"`
+	Raw        string `json:"raw"`
+	Aggregates string `json:"aggregates,omitempty"`
+}
+
+type listRulesRes struct {
+	Rules []ruleRes `json:"rules"`
+}
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	dir, err := ioutil.TempDir("", "retention")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	rules := file.NewRuleRepository(filepath.Join(dir, "retention.toml"))
+	auth := mocks.NewAuthService(map[string]string{token: email, otherToken: otherEmail}, map[string]string{})
+	things := mocks.NewThingsService(map[string]string{chanID: email, wrongID: email, otherID: otherEmail, "chan-a": email, "chan-b": email})
+	svc := retention.New(auth, things, rules, mocks.NewStore(nil))
+	mux := api.MakeHandler(svc, bone.New())
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+func save(t *testing.T, ts *httptest.Server, rule ruleRes) {
+	req := testRequest{
+		client:      ts.Client(),
+		method:      http.MethodPut,
+		url:         fmt.Sprintf("%s/retention/%s", ts.URL, rule.Channel),
+		contentType: contentType,
+		token:       token,
+		body:        strings.NewReader(toJSON(rule)),
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	require.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+}
+
+func TestSave(t *testing.T) {
+	ts := newServer(t)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		channel     string
+		contentType string
+		token       string
+		body        string
+		status      int
+		res         ruleRes
+	}{
+		{
+			desc:        "save rule",
+			channel:     chanID,
+			contentType: contentType,
+			token:       token,
+			body:        `{"raw":"720h","aggregates":"8760h"}`,
+			status:      http.StatusOK,
+			res:         ruleRes{Channel: chanID, Raw: "720h0m0s", Aggregates: "8760h0m0s"},
+		},
+		{
+			desc:        "replace rule keeping aggregates forever",
+			channel:     chanID,
+			contentType: contentType,
+			token:       token,
+			body:        `{"raw":"24h"}`,
+			status:      http.StatusOK,
+			res:         ruleRes{Channel: chanID, Raw: "24h0m0s"},
+		},
+		{
+			desc:        "save rule with invalid raw retention",
+			channel:     chanID,
+			contentType: contentType,
+			token:       token,
+			body:        `{"raw":"30d"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save rule with invalid aggregates retention",
+			channel:     chanID,
+			contentType: contentType,
+			token:       token,
+			body:        `{"raw":"24h","aggregates":"year"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save rule with malformed body",
+			channel:     chanID,
+			contentType: contentType,
+			token:       token,
+			body:        `{"raw":`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save rule removing aggregates before messages",
+			channel:     chanID,
+			contentType: contentType,
+			token:       token,
+			body:        `{"raw":"720h","aggregates":"24h"}`,
+			status:      http.StatusUnprocessableEntity,
+		},
+		{
+			desc:        "save rule with invalid content type",
+			channel:     chanID,
+			contentType: "text/plain",
+			token:       token,
+			body:        `{"raw":"24h"}`,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "save rule without token",
+			channel:     chanID,
+			contentType: contentType,
+			token:       "",
+			body:        `{"raw":"24h"}`,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "save rule with invalid token",
+			channel:     chanID,
+			contentType: contentType,
+			token:       wrongToken,
+			body:        `{"raw":"24h"}`,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "save rule of other user channel",
+			channel:     otherID,
+			contentType: contentType,
+			token:       token,
+			body:        `{"raw":"24h"}`,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/retention/%s", ts.URL, tc.channel),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		req = testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/retention/%s", ts.URL, tc.channel),
+			token:  tc.token,
+		}
+		res, err = req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var body ruleRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestView(t *testing.T) {
+	ts := newServer(t)
+	defer ts.Close()
+
+	rule := ruleRes{Channel: chanID, Raw: "720h0m0s", Aggregates: "8760h0m0s"}
+	save(t, ts, rule)
+
+	cases := []struct {
+		desc    string
+		channel string
+		token   string
+		status  int
+		res     string
+	}{
+		{
+			desc:    "view existing rule",
+			channel: chanID,
+			token:   token,
+			status:  http.StatusOK,
+			res:     toJSON(rule),
+		},
+		{
+			desc:    "view non-existent rule",
+			channel: wrongID,
+			token:   token,
+			status:  http.StatusNotFound,
+			res:     notFoundRes,
+		},
+		{
+			desc:    "view rule without token",
+			channel: chanID,
+			token:   "",
+			status:  http.StatusUnauthorized,
+			res:     unauthorizedRes,
+		},
+		{
+			desc:    "view rule with invalid token",
+			channel: chanID,
+			token:   wrongToken,
+			status:  http.StatusUnauthorized,
+			res:     unauthorizedRes,
+		},
+		{
+			desc:    "view rule of other user channel",
+			channel: chanID,
+			token:   otherToken,
+			status:  http.StatusForbidden,
+			res:     forbiddenRes,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/retention/%s", ts.URL, tc.channel),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		data, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, strings.Trim(string(data), "\n"), fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, data))
+	}
+}
+
+func TestList(t *testing.T) {
+	ts := newServer(t)
+	defer ts.Close()
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/retention", ts.URL),
+		token:  token,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	var body listRulesRes
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, listRulesRes{Rules: []ruleRes{}}, body, fmt.Sprintf("expected no rules got %v", body))
+
+	rules := []ruleRes{
+		{Channel: "chan-b", Raw: "24h0m0s"},
+		{Channel: "chan-a", Raw: "720h0m0s", Aggregates: "8760h0m0s"},
+	}
+	for _, rule := range rules {
+		save(t, ts, rule)
+	}
+
+	res, err = req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := listRulesRes{Rules: []ruleRes{rules[1], rules[0]}}
+	assert.Equal(t, expected, body, fmt.Sprintf("expected body %v got %v", expected, body))
+
+	// Users see only the rules of their own channels.
+	req.token = otherToken
+	res, err = req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+	body = listRulesRes{}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, listRulesRes{Rules: []ruleRes{}}, body, fmt.Sprintf("expected no rules got %v", body))
+
+	for _, tkn := range []string{"", wrongToken} {
+		req.token = tkn
+		res, err = req.make()
+		require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusUnauthorized, res.StatusCode))
+	}
+}
+
+func TestRemove(t *testing.T) {
+	ts := newServer(t)
+	defer ts.Close()
+
+	save(t, ts, ruleRes{Channel: chanID, Raw: "24h"})
+
+	cases := []struct {
+		desc    string
+		channel string
+		token   string
+		status  int
+	}{
+		{
+			desc:    "remove rule without token",
+			channel: chanID,
+			token:   "",
+			status:  http.StatusUnauthorized,
+		},
+		{
+			desc:    "remove rule with invalid token",
+			channel: chanID,
+			token:   wrongToken,
+			status:  http.StatusUnauthorized,
+		},
+		{
+			desc:    "remove rule of other user channel",
+			channel: chanID,
+			token:   otherToken,
+			status:  http.StatusForbidden,
+		},
+		{
+			desc:    "remove existing rule",
+			channel: chanID,
+			token:   token,
+			status:  http.StatusNoContent,
+		},
+		{
+			desc:    "remove removed rule",
+			channel: chanID,
+			token:   token,
+			status:  http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/retention/%s", ts.URL, tc.channel),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	log "github.com/mainflux/mainflux/logger"
+)
+
+var _ retention.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    retention.Service
+}
+
+// LoggingMiddleware adds logging facilities to the retention service.
+func LoggingMiddleware(svc retention.Service, logger log.Logger) retention.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) SaveRule(ctx context.Context, token string, rule retention.Rule) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method save_rule for channel %s took %s to complete", rule.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SaveRule(ctx, token, rule)
+}
+
+func (lm *loggingMiddleware) ViewRule(ctx context.Context, token, channel string) (rule retention.Rule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_rule for channel %s took %s to complete", channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewRule(ctx, token, channel)
+}
+
+func (lm *loggingMiddleware) ListRules(ctx context.Context, token string) (rules []retention.Rule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_rules took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListRules(ctx, token)
+}
+
+func (lm *loggingMiddleware) RemoveRule(ctx context.Context, token, channel string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_rule for channel %s took %s to complete", channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveRule(ctx, token, channel)
+}
+
+func (lm *loggingMiddleware) Enforce(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method enforce took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Enforce(ctx)
+}
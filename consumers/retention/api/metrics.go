@@ -0,0 +1,77 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/consumers/retention"
+)
+
+var _ retention.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     retention.Service
+}
+
+// MetricsMiddleware instruments retention service by tracking request count
+// and latency.
+func MetricsMiddleware(svc retention.Service, counter metrics.Counter, latency metrics.Histogram) retention.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) SaveRule(ctx context.Context, token string, rule retention.Rule) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "save_rule").Add(1)
+		mm.latency.With("method", "save_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SaveRule(ctx, token, rule)
+}
+
+func (mm *metricsMiddleware) ViewRule(ctx context.Context, token, channel string) (retention.Rule, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_rule").Add(1)
+		mm.latency.With("method", "view_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewRule(ctx, token, channel)
+}
+
+func (mm *metricsMiddleware) ListRules(ctx context.Context, token string) ([]retention.Rule, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_rules").Add(1)
+		mm.latency.With("method", "list_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListRules(ctx, token)
+}
+
+func (mm *metricsMiddleware) RemoveRule(ctx context.Context, token, channel string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_rule").Add(1)
+		mm.latency.With("method", "remove_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveRule(ctx, token, channel)
+}
+
+func (mm *metricsMiddleware) Enforce(ctx context.Context) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "enforce").Add(1)
+		mm.latency.With("method", "enforce").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Enforce(ctx)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var errInvalidChannel = errors.New("invalid or empty channel id")
+
+type listReq struct {
+	token string
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return retention.ErrUnauthorizedAccess
+	}
+
+	return nil
+}
+
+type ruleReq struct {
+	token   string
+	channel string
+}
+
+func (req ruleReq) validate() error {
+	if req.token == "" {
+		return retention.ErrUnauthorizedAccess
+	}
+
+	if req.channel == "" {
+		return errInvalidChannel
+	}
+
+	return nil
+}
+
+type saveRuleReq struct {
+	token      string
+	channel    string
+	Raw        string `json:"raw"`
+	Aggregates string `json:"aggregates,omitempty"`
+}
+
+func (req saveRuleReq) validate() error {
+	if req.token == "" {
+		return retention.ErrUnauthorizedAccess
+	}
+
+	if req.channel == "" {
+		return errInvalidChannel
+	}
+
+	if _, err := time.ParseDuration(req.Raw); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	if req.Aggregates == "" {
+		return nil
+	}
+	if _, err := time.ParseDuration(req.Aggregates); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/retention"
+)
+
+var (
+	_ mainflux.Response = (*viewRuleRes)(nil)
+	_ mainflux.Response = (*listRulesRes)(nil)
+	_ mainflux.Response = (*saveRuleRes)(nil)
+	_ mainflux.Response = (*removeRuleRes)(nil)
+)
+
+type viewRuleRes struct {
+	Channel    string `json:"channel"`
+	Raw        string `json:"raw"`
+	Aggregates string `json:"aggregates,omitempty"`
+}
+
+func toViewRuleRes(rule retention.Rule) viewRuleRes {
+	res := viewRuleRes{
+		Channel: rule.Channel,
+		Raw:     rule.Raw.String(),
+	}
+	if rule.Aggregates > 0 {
+		res.Aggregates = rule.Aggregates.String()
+	}
+
+	return res
+}
+
+func (res viewRuleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewRuleRes) Empty() bool {
+	return false
+}
+
+type listRulesRes struct {
+	Rules []viewRuleRes `json:"rules"`
+}
+
+func (res listRulesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listRulesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listRulesRes) Empty() bool {
+	return false
+}
+
+type saveRuleRes struct{}
+
+func (res saveRuleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res saveRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res saveRuleRes) Empty() bool {
+	return true
+}
+
+type removeRuleRes struct{}
+
+func (res removeRuleRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRuleRes) Empty() bool {
+	return true
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const contentType = "application/json"
+
+// MakeHandler registers retention rules API endpoints on the given router.
+func MakeHandler(svc retention.Service, mux *bone.Mux) *bone.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
+	mux.Get("/retention", kithttp.NewServer(
+		listRulesEndpoint(svc),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/retention/:channel", kithttp.NewServer(
+		viewRuleEndpoint(svc),
+		decodeRule,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/retention/:channel", kithttp.NewServer(
+		saveRuleEndpoint(svc),
+		decodeSave,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/retention/:channel", kithttp.NewServer(
+		removeRuleEndpoint(svc),
+		decodeRule,
+		encodeResponse,
+		opts...,
+	))
+
+	return mux
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	req := listReq{
+		token: r.Header.Get("Authorization"),
+	}
+	return req, nil
+}
+
+func decodeRule(_ context.Context, r *http.Request) (interface{}, error) {
+	req := ruleReq{
+		token:   r.Header.Get("Authorization"),
+		channel: bone.GetValue(r, "channel"),
+	}
+	return req, nil
+}
+
+func decodeSave(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := saveRuleReq{
+		token:   r.Header.Get("Authorization"),
+		channel: bone.GetValue(r, "channel"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", contentType)
+
+	switch {
+	case errors.Contains(err, errors.ErrMalformedEntity),
+		errors.Contains(err, errInvalidChannel):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case errors.Contains(err, retention.ErrUnauthorizedAccess):
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, retention.ErrForbidden):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, retention.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, retention.ErrInvalidRule):
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		if err := json.NewEncoder(w).Encode(errorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package retention contains the service used by writers to enforce
+// per-channel retention rules, downsampling old messages to hourly
+// aggregates and removing the expired ones.
+package retention
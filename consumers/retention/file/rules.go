@@ -0,0 +1,180 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package file contains retention rules repository implementation backed by
+// the TOML file.
+package file
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errReadFile  = errors.New("failed to read retention rules file")
+	errParseFile = errors.New("failed to parse retention rules file")
+	errWriteFile = errors.New("failed to write retention rules file")
+)
+
+var _ retention.RuleRepository = (*ruleRepository)(nil)
+
+type ruleConfig struct {
+	Channel    string `toml:"channel"`
+	Raw        string `toml:"raw"`
+	Aggregates string `toml:"aggregates,omitempty"`
+}
+
+type rulesConfig struct {
+	Rules []ruleConfig `toml:"rules"`
+}
+
+type ruleRepository struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewRuleRepository instantiates retention rules repository that keeps the
+// rules in the TOML file at the given path. Non-existent file is treated as
+// an empty set of rules and is created once the first rule is saved.
+func NewRuleRepository(path string) retention.RuleRepository {
+	return &ruleRepository{
+		path: path,
+	}
+}
+
+func (rr *ruleRepository) Save(_ context.Context, rule retention.Rule) error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rules, err := rr.load()
+	if err != nil {
+		return err
+	}
+	rules[rule.Channel] = rule
+
+	return rr.store(rules)
+}
+
+func (rr *ruleRepository) Retrieve(_ context.Context, channel string) (retention.Rule, error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rules, err := rr.load()
+	if err != nil {
+		return retention.Rule{}, err
+	}
+
+	rule, ok := rules[channel]
+	if !ok {
+		return retention.Rule{}, retention.ErrNotFound
+	}
+
+	return rule, nil
+}
+
+func (rr *ruleRepository) RetrieveAll(_ context.Context) ([]retention.Rule, error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rules, err := rr.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return sorted(rules), nil
+}
+
+func (rr *ruleRepository) Remove(_ context.Context, channel string) error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rules, err := rr.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := rules[channel]; !ok {
+		return retention.ErrNotFound
+	}
+	delete(rules, channel)
+
+	return rr.store(rules)
+}
+
+func (rr *ruleRepository) load() (map[string]retention.Rule, error) {
+	rules := map[string]retention.Rule{}
+
+	data, err := ioutil.ReadFile(rr.path)
+	if os.IsNotExist(err) {
+		return rules, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(errReadFile, err)
+	}
+
+	var cfg rulesConfig
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrap(errParseFile, err)
+	}
+
+	for _, rc := range cfg.Rules {
+		rule := retention.Rule{Channel: rc.Channel}
+		if rule.Raw, err = time.ParseDuration(rc.Raw); err != nil {
+			return nil, errors.Wrap(errParseFile, err)
+		}
+		if rc.Aggregates != "" {
+			if rule.Aggregates, err = time.ParseDuration(rc.Aggregates); err != nil {
+				return nil, errors.Wrap(errParseFile, err)
+			}
+		}
+		rules[rule.Channel] = rule
+	}
+
+	return rules, nil
+}
+
+func (rr *ruleRepository) store(rules map[string]retention.Rule) error {
+	var cfg rulesConfig
+	for _, rule := range sorted(rules) {
+		rc := ruleConfig{
+			Channel: rule.Channel,
+			Raw:     rule.Raw.String(),
+		}
+		if rule.Aggregates > 0 {
+			rc.Aggregates = rule.Aggregates.String()
+		}
+		cfg.Rules = append(cfg.Rules, rc)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return errors.Wrap(errWriteFile, err)
+	}
+
+	if err := ioutil.WriteFile(rr.path, buf.Bytes(), 0644); err != nil {
+		return errors.Wrap(errWriteFile, err)
+	}
+
+	return nil
+}
+
+func sorted(rules map[string]retention.Rule) []retention.Rule {
+	ret := []retention.Rule{}
+	for _, rule := range rules {
+		ret = append(ret, rule)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Channel < ret[j].Channel
+	})
+
+	return ret
+}
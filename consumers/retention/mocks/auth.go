@@ -0,0 +1,77 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
+
+type authServiceMock struct {
+	users  map[string]string
+	admins map[string]string
+}
+
+// NewAuthService returns mock implementation of auth service. Users map
+// tokens to the user emails, and admins map channel IDs to the emails of
+// the users having the admin access to them.
+func NewAuthService(users, admins map[string]string) mainflux.AuthServiceClient {
+	return authServiceMock{users, admins}
+}
+
+func (svc authServiceMock) Issue(ctx context.Context, req *mainflux.IssueReq, _ ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Identify(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	email, ok := svc.users[token.GetValue()]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid credentials provided")
+	}
+	return &mainflux.UserIdentity{Id: email, Email: email}, nil
+}
+
+func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (*mainflux.AuthorizeRes, error) {
+	email, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid credentials provided")
+	}
+	admin := req.GetAct() == "admin" && svc.admins[req.GetObj()] == email
+	return &mainflux.AuthorizeRes{Authorized: admin}, nil
+}
+
+func (svc authServiceMock) Granted(ctx context.Context, req *mainflux.GrantedReq, _ ...grpc.CallOption) (*mainflux.GrantedRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (*mainflux.MembersRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Claim(ctx context.Context, req *mainflux.ClaimReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) ListPolicies(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*mainflux.PoliciesRes, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+)
+
+var _ retention.Store = (*Store)(nil)
+
+// Call represents the call of the store method.
+type Call struct {
+	Method  string
+	Channel string
+	Before  time.Time
+}
+
+// Store is a message store mock that records the calls. Calls for the
+// channels set to fail return the error.
+type Store struct {
+	mu    sync.Mutex
+	err   error
+	fail  map[string]bool
+	calls []Call
+}
+
+// NewStore returns message store mock failing with the given error for the
+// given channels.
+func NewStore(err error, fail ...string) *Store {
+	s := &Store{
+		err:  err,
+		fail: map[string]bool{},
+	}
+	for _, ch := range fail {
+		s.fail[ch] = true
+	}
+
+	return s
+}
+
+// Downsample records the call.
+func (s *Store) Downsample(_ context.Context, channel string, before time.Time) error {
+	return s.call("downsample", channel, before)
+}
+
+// RemoveAggregates records the call.
+func (s *Store) RemoveAggregates(_ context.Context, channel string, before time.Time) error {
+	return s.call("remove_aggregates", channel, before)
+}
+
+// Calls returns the recorded calls.
+func (s *Store) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Call{}, s.calls...)
+}
+
+func (s *Store) call(method, channel string, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, Call{
+		Method:  method,
+		Channel: channel,
+		Before:  before,
+	})
+	if s.fail[channel] {
+		return s.err
+	}
+
+	return nil
+}
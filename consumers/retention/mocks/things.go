@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.ThingsServiceClient = (*thingsServiceMock)(nil)
+
+type thingsServiceMock struct {
+	owners map[string]string
+}
+
+// NewThingsService returns mock implementation of things service. Owners
+// map channel IDs to the emails of their owners.
+func NewThingsService(owners map[string]string) mainflux.ThingsServiceClient {
+	return thingsServiceMock{owners}
+}
+
+func (svc thingsServiceMock) CanAccessByKey(context.Context, *mainflux.AccessByKeyReq, ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) CanAccessByID(context.Context, *mainflux.AccessByIDReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) IsChannelOwner(ctx context.Context, req *mainflux.ChannelOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	if owner, ok := svc.owners[req.GetChanID()]; !ok || owner != req.GetOwner() {
+		return nil, status.Error(codes.NotFound, "entity does not exist")
+	}
+	return &empty.Empty{}, nil
+}
+
+func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) DerivePSK(context.Context, *mainflux.ThingID, ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
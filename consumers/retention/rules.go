@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"time"
+)
+
+// Rule represents the retention rule of the channel.
+type Rule struct {
+	Channel string
+	// Raw is the age after which messages are downsampled to hourly
+	// aggregates and removed.
+	Raw time.Duration
+	// Aggregates is the age after which hourly aggregates are removed.
+	// Zero value keeps the aggregates forever.
+	Aggregates time.Duration
+}
+
+// RuleRepository specifies a retention rules persistence API.
+type RuleRepository interface {
+	// Save persists the rule. Saving the rule of the channel that already
+	// has one replaces it.
+	Save(ctx context.Context, rule Rule) error
+
+	// Retrieve retrieves the rule of the channel.
+	Retrieve(ctx context.Context, channel string) (Rule, error)
+
+	// RetrieveAll retrieves all the rules ordered by channel.
+	RetrieveAll(ctx context.Context) ([]Rule, error)
+
+	// Remove removes the rule of the channel.
+	Remove(ctx context.Context, channel string) error
+}
+
+// Store specifies an API of the message store the rules are enforced on.
+type Store interface {
+	// Downsample merges numeric values of the channel SenML messages older
+	// than the given time into the hourly aggregates and removes these
+	// messages, as well as the channel JSON messages older than that time.
+	Downsample(ctx context.Context, channel string, before time.Time) error
+
+	// RemoveAggregates removes the channel hourly aggregates older than the
+	// given time.
+	RemoveAggregates(ctx context.Context, channel string, before time.Time) error
+}
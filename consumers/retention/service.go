@@ -0,0 +1,234 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const adminAction = "admin"
+
+var (
+	// ErrNotFound indicates a non-existent retention rule.
+	ErrNotFound = errors.New("non-existent entity")
+
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when managing the retention rules.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrForbidden indicates that the user neither owns nor administers the
+	// channel of the retention rule.
+	ErrForbidden = errors.New("channel is neither owned nor administered by the user")
+
+	// ErrInvalidRule indicates the retention rule that can't be enforced.
+	ErrInvalidRule = errors.New("invalid retention rule")
+
+	// ErrEnforce indicates failure to enforce the retention rules.
+	ErrEnforce = errors.New("failed to enforce retention rules")
+)
+
+// Service specifies an API for managing and enforcing retention rules.
+type Service interface {
+	// SaveRule saves the retention rule of the channel, replacing the
+	// existing one. Rules are managed only by the users owning or
+	// administering the channel identified by the provided token.
+	SaveRule(ctx context.Context, token string, rule Rule) error
+
+	// ViewRule retrieves the retention rule of the channel.
+	ViewRule(ctx context.Context, token, channel string) (Rule, error)
+
+	// ListRules retrieves the retention rules of the channels owned or
+	// administered by the user identified by the provided token.
+	ListRules(ctx context.Context, token string) ([]Rule, error)
+
+	// RemoveRule removes the retention rule of the channel, so that its
+	// messages are kept forever.
+	RemoveRule(ctx context.Context, token, channel string) error
+
+	// Enforce downsamples and removes the messages of every channel
+	// having the retention rule. Failure to enforce the rule of a single
+	// channel doesn't prevent enforcing the others.
+	Enforce(ctx context.Context) error
+}
+
+var _ Service = (*retentionService)(nil)
+
+type retentionService struct {
+	auth   mainflux.AuthServiceClient
+	things mainflux.ThingsServiceClient
+	rules  RuleRepository
+	store  Store
+}
+
+// New instantiates the retention service implementation enforcing the rules
+// on the given store.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, rules RuleRepository, store Store) Service {
+	return &retentionService{
+		auth:   auth,
+		things: things,
+		rules:  rules,
+		store:  store,
+	}
+}
+
+func (rs *retentionService) SaveRule(ctx context.Context, token string, rule Rule) error {
+	// Aggregates are made of the removed messages, so they can't be
+	// removed before them.
+	if rule.Channel == "" || rule.Raw <= 0 || rule.Aggregates < 0 ||
+		(rule.Aggregates > 0 && rule.Aggregates < rule.Raw) {
+		return ErrInvalidRule
+	}
+
+	if err := rs.authorize(ctx, token, rule.Channel); err != nil {
+		return err
+	}
+
+	return rs.rules.Save(ctx, rule)
+}
+
+func (rs *retentionService) ViewRule(ctx context.Context, token, channel string) (Rule, error) {
+	if err := rs.authorize(ctx, token, channel); err != nil {
+		return Rule{}, err
+	}
+
+	return rs.rules.Retrieve(ctx, channel)
+}
+
+func (rs *retentionService) ListRules(ctx context.Context, token string) ([]Rule, error) {
+	user, err := rs.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := rs.rules.RetrieveAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []Rule{}
+	for _, rule := range all {
+		ok, err := rs.canManage(ctx, token, user, rule.Channel)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+func (rs *retentionService) RemoveRule(ctx context.Context, token, channel string) error {
+	if err := rs.authorize(ctx, token, channel); err != nil {
+		return err
+	}
+
+	return rs.rules.Remove(ctx, channel)
+}
+
+func (rs *retentionService) Enforce(ctx context.Context) error {
+	rules, err := rs.rules.RetrieveAll(ctx)
+	if err != nil {
+		return errors.Wrap(ErrEnforce, err)
+	}
+
+	// Messages are downsampled up to the full hour, so that the hourly
+	// aggregates are never made of the part of the hour.
+	now := time.Now()
+	var failed error
+	for _, rule := range rules {
+		before := now.Add(-rule.Raw).Truncate(time.Hour)
+		if err := rs.store.Downsample(ctx, rule.Channel, before); err != nil {
+			failed = err
+			continue
+		}
+		if rule.Aggregates == 0 {
+			continue
+		}
+		before = now.Add(-rule.Aggregates).Truncate(time.Hour)
+		if err := rs.store.RemoveAggregates(ctx, rule.Channel, before); err != nil {
+			failed = err
+		}
+	}
+
+	if failed != nil {
+		return errors.Wrap(ErrEnforce, failed)
+	}
+	return nil
+}
+
+// authorize checks that the user identified by the token owns or
+// administers the channel.
+func (rs *retentionService) authorize(ctx context.Context, token, channel string) error {
+	user, err := rs.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	ok, err := rs.canManage(ctx, token, user, channel)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func (rs *retentionService) identify(ctx context.Context, token string) (string, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return res.GetEmail(), nil
+}
+
+// canManage reports whether the user owns the channel or was granted the
+// admin access to it.
+func (rs *retentionService) canManage(ctx context.Context, token, user, channel string) (bool, error) {
+	_, err := rs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user, ChanID: channel})
+	switch status.Code(err) {
+	case codes.OK:
+		return true, nil
+	case codes.NotFound, codes.PermissionDenied:
+	default:
+		return false, err
+	}
+
+	res, err := rs.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Obj: channel, Act: adminAction})
+	if err != nil {
+		return false, err
+	}
+
+	return res.GetAuthorized(), nil
+}
+
+// Run enforces the retention rules periodically, until the context is
+// canceled. Enforcement failures are logged and retried in the next run.
+func Run(ctx context.Context, svc Service, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := svc.Enforce(ctx); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to enforce retention rules: %s", err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/consumers/retention/file"
+	"github.com/mainflux/mainflux/consumers/retention/mocks"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID     = "chan"
+	adminID    = "admin-chan"
+	wrongID    = "wrong"
+	token      = "token"
+	adminToken = "admin-token"
+	wrongToken = "wrong-token"
+	email      = "user@example.com"
+	adminEmail = "admin@example.com"
+	day        = 24 * time.Hour
+)
+
+var (
+	errStore = errors.New("failed to access store")
+
+	users  = map[string]string{token: email, adminToken: adminEmail}
+	owners = map[string]string{chanID: email, wrongID: email, "fail": email, "forever": email, adminID: email}
+	admins = map[string]string{adminID: adminEmail}
+)
+
+func newService(t *testing.T, store retention.Store) retention.Service {
+	dir, err := ioutil.TempDir("", "retention")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	rules := file.NewRuleRepository(filepath.Join(dir, "retention.toml"))
+	auth := mocks.NewAuthService(users, admins)
+	things := mocks.NewThingsService(owners)
+	return retention.New(auth, things, rules, store)
+}
+
+func TestSaveRule(t *testing.T) {
+	svc := newService(t, mocks.NewStore(nil))
+
+	cases := []struct {
+		desc  string
+		token string
+		rule  retention.Rule
+		err   error
+	}{
+		{
+			desc:  "save rule",
+			token: token,
+			rule:  retention.Rule{Channel: chanID, Raw: 30 * day, Aggregates: 365 * day},
+			err:   nil,
+		},
+		{
+			desc:  "save rule keeping aggregates forever",
+			token: token,
+			rule:  retention.Rule{Channel: chanID, Raw: 30 * day},
+			err:   nil,
+		},
+		{
+			desc:  "save rule of administered channel",
+			token: adminToken,
+			rule:  retention.Rule{Channel: adminID, Raw: 30 * day},
+			err:   nil,
+		},
+		{
+			desc:  "save rule with invalid token",
+			token: wrongToken,
+			rule:  retention.Rule{Channel: chanID, Raw: 30 * day},
+			err:   retention.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "save rule of other user channel",
+			token: adminToken,
+			rule:  retention.Rule{Channel: chanID, Raw: 30 * day},
+			err:   retention.ErrForbidden,
+		},
+		{
+			desc: "save rule without channel",
+			rule: retention.Rule{Raw: 30 * day},
+			err:  retention.ErrInvalidRule,
+		},
+		{
+			desc: "save rule without raw retention",
+			rule: retention.Rule{Channel: chanID, Aggregates: 365 * day},
+			err:  retention.ErrInvalidRule,
+		},
+		{
+			desc: "save rule with negative aggregates retention",
+			rule: retention.Rule{Channel: chanID, Raw: 30 * day, Aggregates: -day},
+			err:  retention.ErrInvalidRule,
+		},
+		{
+			desc: "save rule removing aggregates before messages",
+			rule: retention.Rule{Channel: chanID, Raw: 30 * day, Aggregates: day},
+			err:  retention.ErrInvalidRule,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.SaveRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		rule, err := svc.ViewRule(context.Background(), tc.token, tc.rule.Channel)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.rule, rule, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rule, rule))
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	svc := newService(t, mocks.NewStore(nil))
+
+	rule := retention.Rule{Channel: chanID, Raw: day}
+	err := svc.SaveRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		channel string
+		err     error
+	}{
+		{
+			desc:    "remove rule with invalid token",
+			token:   wrongToken,
+			channel: chanID,
+			err:     retention.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "remove rule of other user channel",
+			token:   adminToken,
+			channel: chanID,
+			err:     retention.ErrForbidden,
+		},
+		{
+			desc:    "remove existing rule",
+			token:   token,
+			channel: chanID,
+			err:     nil,
+		},
+		{
+			desc:    "remove removed rule",
+			token:   token,
+			channel: chanID,
+			err:     retention.ErrNotFound,
+		},
+		{
+			desc:    "remove non-existent rule",
+			token:   token,
+			channel: wrongID,
+			err:     retention.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveRule(context.Background(), tc.token, tc.channel)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	rules, err := svc.ListRules(context.Background(), token)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, rules, "expected no rules left")
+}
+
+func TestListRules(t *testing.T) {
+	svc := newService(t, mocks.NewStore(nil))
+
+	rules := []retention.Rule{
+		{Channel: adminID, Raw: day},
+		{Channel: chanID, Raw: 30 * day, Aggregates: 365 * day},
+	}
+	for _, rule := range rules {
+		err := svc.SaveRule(context.Background(), token, rule)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		rules []retention.Rule
+		err   error
+	}{
+		{
+			desc:  "list rules of owned channels",
+			token: token,
+			rules: rules,
+			err:   nil,
+		},
+		{
+			desc:  "list rules of administered channels",
+			token: adminToken,
+			rules: rules[:1],
+			err:   nil,
+		},
+		{
+			desc:  "list rules with invalid token",
+			token: wrongToken,
+			rules: nil,
+			err:   retention.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		rules, err := svc.ListRules(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.rules, rules, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rules, rules))
+	}
+}
+
+func TestEnforce(t *testing.T) {
+	failID := "fail"
+	store := mocks.NewStore(errStore, failID)
+	svc := newService(t, store)
+
+	rules := []retention.Rule{
+		{Channel: chanID, Raw: 30 * day, Aggregates: 365 * day},
+		{Channel: failID, Raw: day, Aggregates: 30 * day},
+		{Channel: "forever", Raw: 7 * day},
+	}
+	for _, rule := range rules {
+		err := svc.SaveRule(context.Background(), token, rule)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	start := time.Now()
+	err := svc.Enforce(context.Background())
+	assert.True(t, errors.Contains(err, retention.ErrEnforce), fmt.Sprintf("expected %s got %s\n", retention.ErrEnforce, err))
+	assert.True(t, errors.Contains(err, errStore), fmt.Sprintf("expected %s got %s\n", errStore, err))
+
+	// Rules are enforced ordered by channel and the failing channel
+	// doesn't prevent enforcing the others.
+	expected := []struct {
+		method  string
+		channel string
+		age     time.Duration
+	}{
+		{"downsample", chanID, 30 * day},
+		{"remove_aggregates", chanID, 365 * day},
+		{"downsample", failID, day},
+		{"downsample", "forever", 7 * day},
+	}
+	calls := store.Calls()
+	require.Len(t, calls, len(expected), fmt.Sprintf("expected %d calls got %d", len(expected), len(calls)))
+	for i, e := range expected {
+		call := calls[i]
+		assert.Equal(t, e.method, call.Method, fmt.Sprintf("expected method %s got %s", e.method, call.Method))
+		assert.Equal(t, e.channel, call.Channel, fmt.Sprintf("expected channel %s got %s", e.channel, call.Channel))
+		assert.Equal(t, call.Before, call.Before.Truncate(time.Hour), fmt.Sprintf("expected full hour got %s", call.Before))
+		assert.WithinDuration(t, start.Add(-e.age), call.Before, time.Hour, fmt.Sprintf("%s: unexpected cutoff %s", e.method, call.Before))
+	}
+}
@@ -10,12 +10,22 @@ import (
 
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/dlq"
+	dlqapi "github.com/mainflux/mainflux/consumers/dlq/api"
+	"github.com/mainflux/mainflux/consumers/retention"
+	retentionapi "github.com/mainflux/mainflux/consumers/retention/api"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// MakeHandler returns a HTTP API handler with version and metrics.
-func MakeHandler(svcName string) http.Handler {
+// MakeHandler returns a HTTP API handler with version, metrics, dead letter
+// and retention rules endpoints. Retention rules endpoints are registered
+// only if the retention service is provided.
+func MakeHandler(dlqSvc dlq.Service, retentionSvc retention.Service, svcName string) http.Handler {
 	r := bone.New()
+	r = dlqapi.MakeHandler(dlqSvc, r)
+	if retentionSvc != nil {
+		r = retentionapi.MakeHandler(retentionSvc, r)
+	}
 	r.GetFunc("/version", mainflux.Version(svcName))
 	r.Handle("/metrics", promhttp.Handler())
 
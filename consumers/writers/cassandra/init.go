@@ -24,6 +24,18 @@ const (
         PRIMARY KEY (channel, time, id)
     ) WITH CLUSTERING ORDER BY (time DESC)`
 
+	hourlyTable = `CREATE TABLE IF NOT EXISTS messages_hourly (
+        channel text,
+        publisher text,
+        name text,
+        time double,
+        min_value double,
+        max_value double,
+        sum_value double,
+        count_value bigint,
+        PRIMARY KEY (channel, time, publisher, name)
+    ) WITH CLUSTERING ORDER BY (time DESC, publisher ASC, name ASC)`
+
 	jsonTable = `CREATE TABLE IF NOT EXISTS %s (
         id uuid,
         channel text,
@@ -65,5 +77,9 @@ func Connect(cfg DBConfig) (*gocql.Session, error) {
 		return nil, err
 	}
 
+	if err := session.Query(hourlyTable).Exec(); err != nil {
+		return nil, err
+	}
+
 	return session, nil
 }
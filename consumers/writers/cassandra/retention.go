@@ -0,0 +1,145 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+var (
+	errDownsample       = errors.New("failed to downsample messages in cassandra database")
+	errRemoveAggregates = errors.New("failed to remove aggregates from cassandra database")
+)
+
+var _ retention.Store = (*retentionStore)(nil)
+
+type retentionStore struct {
+	session  *gocql.Session
+	keyspace string
+}
+
+// NewRetentionStore returns new Cassandra store the retention rules are
+// enforced on. The keyspace is searched for the JSON messages tables.
+func NewRetentionStore(session *gocql.Session, keyspace string) retention.Store {
+	return &retentionStore{
+		session:  session,
+		keyspace: keyspace,
+	}
+}
+
+// Downsample aggregates the messages and merges the aggregates with the
+// existing ones, since Cassandra doesn't support GROUP BY on non-primary
+// key columns.
+func (rs *retentionStore) Downsample(ctx context.Context, channel string, before time.Time) error {
+	end := float64(before.Unix())
+
+	cql := `SELECT publisher, name, time, value FROM messages WHERE channel = ? AND time < ?`
+	iter := rs.session.Query(cql, channel, end).WithContext(ctx).Iter()
+	var msgs []senml.Message
+	msg := senml.Message{Channel: channel}
+	for iter.Scan(&msg.Publisher, &msg.Name, &msg.Time, &msg.Value) {
+		msgs = append(msgs, msg)
+		msg = senml.Message{Channel: channel}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+
+	aggs := retention.Hourly(msgs)
+	if len(aggs) > 0 {
+		existing, err := rs.aggregates(ctx, channel, start(aggs), end)
+		if err != nil {
+			return errors.Wrap(errDownsample, err)
+		}
+
+		cql = `INSERT INTO messages_hourly (channel, publisher, name, time,
+               min_value, max_value, sum_value, count_value)
+               VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		for _, agg := range retention.MergeAll(existing, aggs) {
+			err := rs.session.Query(cql, agg.Channel, agg.Publisher, agg.Name, agg.Time,
+				agg.Min, agg.Max, agg.Sum, int64(agg.Count)).WithContext(ctx).Exec()
+			if err != nil {
+				return errors.Wrap(errDownsample, err)
+			}
+		}
+	}
+
+	cql = `DELETE FROM messages WHERE channel = ? AND time < ?`
+	if err := rs.session.Query(cql, channel, end).WithContext(ctx).Exec(); err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+
+	return rs.removeJSON(ctx, channel, before)
+}
+
+// removeJSON removes the JSON messages, stored in the tables named by their
+// format, since they have no numeric values to aggregate.
+func (rs *retentionStore) removeJSON(ctx context.Context, channel string, before time.Time) error {
+	cql := `SELECT table_name FROM system_schema.columns WHERE keyspace_name = ? AND column_name = 'payload' ALLOW FILTERING`
+	iter := rs.session.Query(cql, rs.keyspace).WithContext(ctx).Iter()
+	var tables []string
+	var table string
+	for iter.Scan(&table) {
+		tables = append(tables, table)
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+
+	for _, table := range tables {
+		cql := fmt.Sprintf(`DELETE FROM %s WHERE channel = ? AND created < ?`, table)
+		if err := rs.session.Query(cql, channel, before.UnixNano()).WithContext(ctx).Exec(); err != nil {
+			return errors.Wrap(errDownsample, err)
+		}
+	}
+
+	return nil
+}
+
+func (rs *retentionStore) RemoveAggregates(ctx context.Context, channel string, before time.Time) error {
+	cql := `DELETE FROM messages_hourly WHERE channel = ? AND time < ?`
+	if err := rs.session.Query(cql, channel, float64(before.Unix())).WithContext(ctx).Exec(); err != nil {
+		return errors.Wrap(errRemoveAggregates, err)
+	}
+
+	return nil
+}
+
+func (rs *retentionStore) aggregates(ctx context.Context, channel string, from, to float64) ([]retention.Aggregate, error) {
+	cql := `SELECT publisher, name, time, min_value, max_value, sum_value, count_value
+            FROM messages_hourly WHERE channel = ? AND time >= ? AND time < ?`
+	iter := rs.session.Query(cql, channel, from, to).WithContext(ctx).Iter()
+
+	var aggs []retention.Aggregate
+	agg := retention.Aggregate{Channel: channel}
+	var count int64
+	for iter.Scan(&agg.Publisher, &agg.Name, &agg.Time, &agg.Min, &agg.Max, &agg.Sum, &count) {
+		agg.Count = uint64(count)
+		aggs = append(aggs, agg)
+		agg = retention.Aggregate{Channel: channel}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	return aggs, nil
+}
+
+func start(aggs []retention.Aggregate) float64 {
+	ret := aggs[0].Time
+	for _, agg := range aggs[1:] {
+		if agg.Time < ret {
+			ret = agg.Time
+		}
+	}
+
+	return ret
+}
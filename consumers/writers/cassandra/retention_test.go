@@ -0,0 +1,90 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/consumers/writers/cassandra"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownsample(t *testing.T) {
+	session, err := cassandra.Connect(cassandra.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+
+	repo := cassandra.New(session)
+	store := cassandra.NewRetentionStore(session, keyspace)
+
+	chanID := "retention"
+	before := time.Now().Truncate(time.Hour)
+	start := before.Add(-4 * time.Hour)
+	var msgs, old []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		val := float64(i)
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: "1",
+			Name:      fmt.Sprintf("name-%d", i%2),
+			Time:      float64(start.Add(time.Duration(i) * 10 * time.Minute).Unix()),
+			Value:     &val,
+		}
+		if i%5 == 0 {
+			msg.Value = nil
+			msg.StringValue = &stringV
+		}
+		msgs = append(msgs, msg)
+		if msg.Time < float64(before.Unix()) {
+			old = append(old, msg)
+		}
+	}
+
+	// Downsample in two steps splitting the hour, to merge the new
+	// aggregates with the existing ones.
+	split := len(old)/2 + 3
+	err = repo.Consume(msgs[:split])
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	err = store.Downsample(context.Background(), chanID, before)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	err = repo.Consume(msgs[split:])
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	err = store.Downsample(context.Background(), chanID, before)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	var count int
+	err = session.Query("SELECT COUNT(*) FROM messages WHERE channel = ?", chanID).Scan(&count)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, len(msgs)-len(old), count, fmt.Sprintf("expected %d messages got %d", len(msgs)-len(old), count))
+
+	cql := `SELECT publisher, name, time, min_value, max_value, sum_value, count_value
+            FROM messages_hourly WHERE channel = ?`
+	iter := session.Query(cql, chanID).Iter()
+	var aggs []retention.Aggregate
+	agg := retention.Aggregate{Channel: chanID}
+	var aggCount int64
+	for iter.Scan(&agg.Publisher, &agg.Name, &agg.Time, &agg.Min, &agg.Max, &agg.Sum, &aggCount) {
+		agg.Count = uint64(aggCount)
+		aggs = append(aggs, agg)
+		agg = retention.Aggregate{Channel: chanID}
+	}
+	err = iter.Close()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	expected := retention.Hourly(old)
+	assert.ElementsMatch(t, expected, aggs, fmt.Sprintf("expected %v got %v", expected, aggs))
+
+	err = store.RemoveAggregates(context.Background(), chanID, before.Add(-2*time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	err = session.Query("SELECT COUNT(*) FROM messages_hourly WHERE channel = ?", chanID).Scan(&count)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, 4, count, fmt.Sprintf("expected %d aggregates got %d", 4, count))
+}
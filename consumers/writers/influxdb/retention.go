@@ -0,0 +1,212 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	influxdata "github.com/influxdata/influxdb/client/v2"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const hourlyPoints = "messages_hourly"
+
+var (
+	errDownsample       = errors.New("failed to downsample messages in influxdb database")
+	errRemoveAggregates = errors.New("failed to remove aggregates from influxdb database")
+)
+
+var _ retention.Store = (*retentionStore)(nil)
+
+type retentionStore struct {
+	client   influxdata.Client
+	database string
+}
+
+// NewRetentionStore returns new InfluxDB store the retention rules are
+// enforced on.
+func NewRetentionStore(client influxdata.Client, database string) retention.Store {
+	return &retentionStore{
+		client:   client,
+		database: database,
+	}
+}
+
+// Downsample merges the hourly aggregates of the messages with the existing
+// ones, since InfluxDB can't update points in place. The messages are
+// removed once the merged aggregates are written.
+func (rs *retentionStore) Downsample(_ context.Context, channel string, before time.Time) error {
+	old := fmt.Sprintf(`channel='%s' AND time < %d`, channel, before.UnixNano())
+
+	// GROUP BY time requires the lower time bound, so the time of the first
+	// numeric value is used.
+	rows, err := rs.query(fmt.Sprintf(`SELECT FIRST(value) FROM %s WHERE %s`, senmlPoints, old))
+	if err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+	if len(rows) == 0 {
+		return rs.removeMessages(old)
+	}
+	from := time.Unix(int64(rows[0].time), 0).Truncate(time.Hour)
+	condition := fmt.Sprintf(`%s AND time >= %d`, old, from.UnixNano())
+
+	cmd := fmt.Sprintf(`SELECT MIN(value), MAX(value), SUM(value), COUNT(value) FROM %s WHERE %s GROUP BY time(1h), "publisher", "name" fill(none)`, senmlPoints, condition)
+	rows, err = rs.query(cmd)
+	if err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+	aggs := toAggregates(channel, rows)
+
+	cmd = fmt.Sprintf(`SELECT "min", "max", "sum", "count" FROM %s WHERE %s GROUP BY "publisher", "name"`, hourlyPoints, condition)
+	rows, err = rs.query(cmd)
+	if err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+	aggs = retention.MergeAll(toAggregates(channel, rows), aggs)
+
+	pts, err := influxdata.NewBatchPoints(influxdata.BatchPointsConfig{Database: rs.database})
+	if err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+	for _, agg := range aggs {
+		tgs := tags{
+			"channel":   agg.Channel,
+			"publisher": agg.Publisher,
+			"name":      agg.Name,
+		}
+		flds := fields{
+			"min":   agg.Min,
+			"max":   agg.Max,
+			"sum":   agg.Sum,
+			"count": int64(agg.Count),
+		}
+		pt, err := influxdata.NewPoint(hourlyPoints, tgs, flds, time.Unix(int64(agg.Time), 0))
+		if err != nil {
+			return errors.Wrap(errDownsample, err)
+		}
+		pts.AddPoint(pt)
+	}
+	if err := rs.client.Write(pts); err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+
+	return rs.removeMessages(old)
+}
+
+func (rs *retentionStore) RemoveAggregates(_ context.Context, channel string, before time.Time) error {
+	condition := fmt.Sprintf(`channel='%s' AND time < %d`, channel, before.UnixNano())
+	return rs.remove(hourlyPoints, condition, errRemoveAggregates)
+}
+
+// removeMessages removes both SenML and JSON messages. The JSON messages are
+// stored in the measurements named by their format, so every measurement
+// but the aggregates one is pruned.
+func (rs *retentionStore) removeMessages(condition string) error {
+	resp, err := rs.client.Query(influxdata.Query{
+		Command:  "SHOW MEASUREMENTS",
+		Database: rs.database,
+	})
+	if err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+	if resp.Error() != nil {
+		return errors.Wrap(errDownsample, resp.Error())
+	}
+
+	for _, res := range resp.Results {
+		for _, series := range res.Series {
+			for _, v := range series.Values {
+				name, ok := v[0].(string)
+				if !ok || name == hourlyPoints {
+					continue
+				}
+				if err := rs.remove(fmt.Sprintf("%q", name), condition, errDownsample); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (rs *retentionStore) remove(measurement, condition string, errRemove error) error {
+	if _, err := rs.query(fmt.Sprintf(`DELETE FROM %s WHERE %s`, measurement, condition)); err != nil {
+		return errors.Wrap(errRemove, err)
+	}
+
+	return nil
+}
+
+type row struct {
+	tags   map[string]string
+	time   float64
+	values []interface{}
+}
+
+// query returns the rows of all the series with the time in seconds and
+// the values without the time.
+func (rs *retentionStore) query(cmd string) ([]row, error) {
+	q := influxdata.Query{
+		Command:   cmd,
+		Database:  rs.database,
+		Precision: "s",
+	}
+	resp, err := rs.client.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+
+	var rows []row
+	for _, res := range resp.Results {
+		for _, series := range res.Series {
+			for _, v := range series.Values {
+				rows = append(rows, row{
+					tags:   series.Tags,
+					time:   number(v[0]),
+					values: v[1:],
+				})
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// toAggregates returns the aggregates of the rows having min, max, sum and
+// count values.
+func toAggregates(channel string, rows []row) []retention.Aggregate {
+	var aggs []retention.Aggregate
+	for _, r := range rows {
+		aggs = append(aggs, retention.Aggregate{
+			Channel:   channel,
+			Publisher: r.tags["publisher"],
+			Name:      r.tags["name"],
+			Time:      r.time,
+			Min:       number(r.values[0]),
+			Max:       number(r.values[1]),
+			Sum:       number(r.values[2]),
+			Count:     uint64(number(r.values[3])),
+		})
+	}
+
+	return aggs
+}
+
+func number(value interface{}) float64 {
+	num, ok := value.(json.Number)
+	if !ok {
+		return 0
+	}
+	f, _ := num.Float64()
+
+	return f
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	writer "github.com/mainflux/mainflux/consumers/writers/influxdb"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func toFloat(value interface{}) float64 {
+	f, _ := value.(json.Number).Float64()
+	return f
+}
+
+func TestDownsample(t *testing.T) {
+	repo := writer.New(client, testDB)
+	store := writer.NewRetentionStore(client, testDB)
+
+	chanID := "retention"
+	before := time.Now().Truncate(time.Hour)
+	start := before.Add(-4 * time.Hour)
+	var msgs, old []senml.Message
+	for i := 0; i < 42; i++ {
+		val := float64(i)
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: "2580",
+			Name:      fmt.Sprintf("name-%d", i%2),
+			Time:      float64(start.Add(time.Duration(i) * 10 * time.Minute).Unix()),
+			Value:     &val,
+		}
+		if i%5 == 0 {
+			msg.Value = nil
+			msg.StringValue = &stringV
+		}
+		msgs = append(msgs, msg)
+		if msg.Time < float64(before.Unix()) {
+			old = append(old, msg)
+		}
+	}
+
+	// Downsample in two steps splitting the hour, to merge the new
+	// aggregates with the existing ones.
+	split := len(old)/2 + 3
+	err := repo.Consume(msgs[:split])
+	require.Nil(t, err, fmt.Sprintf("Save operation expected to succeed: %s.\n", err))
+	err = store.Downsample(context.Background(), chanID, before)
+	require.Nil(t, err, fmt.Sprintf("Downsample operation expected to succeed: %s.\n", err))
+	err = repo.Consume(msgs[split:])
+	require.Nil(t, err, fmt.Sprintf("Save operation expected to succeed: %s.\n", err))
+	err = store.Downsample(context.Background(), chanID, before)
+	assert.Nil(t, err, fmt.Sprintf("Downsample operation expected to succeed: %s.\n", err))
+
+	rows, err := queryDB(fmt.Sprintf("SELECT * FROM test..messages WHERE channel='%s'", chanID))
+	require.Nil(t, err, fmt.Sprintf("Querying InfluxDB to retrieve data expected to succeed: %s.\n", err))
+	assert.Equal(t, len(msgs)-len(old), len(rows), fmt.Sprintf("Expected to have %d messages left, found %d instead.\n", len(msgs)-len(old), len(rows)))
+
+	cmd := fmt.Sprintf(`SELECT "publisher", "name", "min", "max", "sum", "count" FROM test..messages_hourly WHERE channel='%s'`, chanID)
+	rows, err = queryDB(cmd)
+	require.Nil(t, err, fmt.Sprintf("Querying InfluxDB to retrieve data expected to succeed: %s.\n", err))
+	var aggs []retention.Aggregate
+	for _, row := range rows {
+		tm, err := time.Parse(time.RFC3339Nano, row[0].(string))
+		require.Nil(t, err, fmt.Sprintf("Parsing time expected to succeed: %s.\n", err))
+		aggs = append(aggs, retention.Aggregate{
+			Channel:   chanID,
+			Publisher: row[1].(string),
+			Name:      row[2].(string),
+			Time:      float64(tm.Unix()),
+			Min:       toFloat(row[3]),
+			Max:       toFloat(row[4]),
+			Sum:       toFloat(row[5]),
+			Count:     uint64(toFloat(row[6])),
+		})
+	}
+	expected := retention.Hourly(old)
+	assert.ElementsMatch(t, expected, aggs, fmt.Sprintf("Expected aggregates %v, found %v instead.\n", expected, aggs))
+
+	err = store.RemoveAggregates(context.Background(), chanID, before.Add(-2*time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("Remove aggregates operation expected to succeed: %s.\n", err))
+	rows, err = queryDB(cmd)
+	require.Nil(t, err, fmt.Sprintf("Querying InfluxDB to retrieve data expected to succeed: %s.\n", err))
+	assert.Equal(t, 4, len(rows), fmt.Sprintf("Expected to have %d aggregates left, found %d instead.\n", 4, len(rows)))
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const hourlyCollection = "messages_hourly"
+
+var (
+	errDownsample       = errors.New("failed to downsample messages in mongodb database")
+	errRemoveAggregates = errors.New("failed to remove aggregates from mongodb database")
+)
+
+var _ retention.Store = (*retentionStore)(nil)
+
+type retentionStore struct {
+	db *mongo.Database
+}
+
+// NewRetentionStore returns new MongoDB store the retention rules are
+// enforced on.
+func NewRetentionStore(db *mongo.Database) retention.Store {
+	return &retentionStore{db: db}
+}
+
+func (rs *retentionStore) Downsample(ctx context.Context, channel string, before time.Time) error {
+	hour := time.Hour.Seconds()
+	old := bson.M{
+		"channel": channel,
+		"time":    bson.M{"$lt": float64(before.Unix())},
+	}
+	filter := bson.D{{Key: "$and", Value: bson.A{
+		old,
+		bson.M{"value": bson.M{"$ne": nil}},
+	}}}
+	group := bson.D{
+		{Key: "_id", Value: bson.M{
+			"name":      "$name",
+			"publisher": "$publisher",
+			"time":      bson.M{"$subtract": bson.A{"$time", bson.M{"$mod": bson.A{"$time", hour}}}},
+		}},
+		{Key: "min", Value: bson.M{"$min": "$value"}},
+		{Key: "max", Value: bson.M{"$max": "$value"}},
+		{Key: "sum", Value: bson.M{"$sum": "$value"}},
+		{Key: "count", Value: bson.M{"$sum": 1}},
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: group}},
+	}
+
+	msgs := rs.db.Collection(senmlCollection)
+	cursor, err := msgs.Aggregate(ctx, pipeline)
+	if err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+	defer cursor.Close(ctx)
+
+	var models []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var agg struct {
+			ID struct {
+				Name      string  `bson:"name"`
+				Publisher string  `bson:"publisher"`
+				Time      float64 `bson:"time"`
+			} `bson:"_id"`
+			Min   float64 `bson:"min"`
+			Max   float64 `bson:"max"`
+			Sum   float64 `bson:"sum"`
+			Count int64   `bson:"count"`
+		}
+		if err := cursor.Decode(&agg); err != nil {
+			return errors.Wrap(errDownsample, err)
+		}
+
+		// Upsert merges the aggregate with the existing one.
+		model := mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"channel":   channel,
+				"publisher": agg.ID.Publisher,
+				"name":      agg.ID.Name,
+				"time":      agg.ID.Time,
+			}).
+			SetUpdate(bson.M{
+				"$min": bson.M{"min": agg.Min},
+				"$max": bson.M{"max": agg.Max},
+				"$inc": bson.M{"sum": agg.Sum, "count": agg.Count},
+			}).
+			SetUpsert(true)
+		models = append(models, model)
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+
+	if len(models) > 0 {
+		if _, err := rs.db.Collection(hourlyCollection).BulkWrite(ctx, models); err != nil {
+			return errors.Wrap(errDownsample, err)
+		}
+	}
+
+	if _, err := msgs.DeleteMany(ctx, old); err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+
+	return rs.removeJSON(ctx, channel, before)
+}
+
+// removeJSON removes the JSON messages, stored in the collections named by
+// their format, since they have no numeric values to aggregate.
+func (rs *retentionStore) removeJSON(ctx context.Context, channel string, before time.Time) error {
+	names, err := rs.db.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+
+	old := bson.M{
+		"channel": channel,
+		"created": bson.M{"$lt": before.UnixNano()},
+	}
+	for _, name := range names {
+		if name == senmlCollection || name == hourlyCollection {
+			continue
+		}
+		if _, err := rs.db.Collection(name).DeleteMany(ctx, old); err != nil {
+			return errors.Wrap(errDownsample, err)
+		}
+	}
+
+	return nil
+}
+
+func (rs *retentionStore) RemoveAggregates(ctx context.Context, channel string, before time.Time) error {
+	filter := bson.M{
+		"channel": channel,
+		"time":    bson.M{"$lt": float64(before.Unix())},
+	}
+	if _, err := rs.db.Collection(hourlyCollection).DeleteMany(ctx, filter); err != nil {
+		return errors.Wrap(errRemoveAggregates, err)
+	}
+
+	return nil
+}
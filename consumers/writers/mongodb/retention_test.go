@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/consumers/writers/mongodb"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestDownsample(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	repo := mongodb.New(db)
+	store := mongodb.NewRetentionStore(db)
+
+	chanID := "retention"
+	before := time.Now().Truncate(time.Hour)
+	start := before.Add(-4 * time.Hour)
+	var msgs, old []senml.Message
+	for i := 0; i < 42; i++ {
+		val := float64(i)
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: "2580",
+			Name:      fmt.Sprintf("name-%d", i%2),
+			Time:      float64(start.Add(time.Duration(i) * 10 * time.Minute).Unix()),
+			Value:     &val,
+		}
+		if i%5 == 0 {
+			msg.Value = nil
+			msg.StringValue = &stringV
+		}
+		msgs = append(msgs, msg)
+		if msg.Time < float64(before.Unix()) {
+			old = append(old, msg)
+		}
+	}
+
+	// Downsample in two steps splitting the hour, to merge the new
+	// aggregates with the existing ones.
+	split := len(old)/2 + 3
+	err = repo.Consume(msgs[:split])
+	require.Nil(t, err, fmt.Sprintf("Save operation expected to succeed: %s.\n", err))
+	err = store.Downsample(context.Background(), chanID, before)
+	require.Nil(t, err, fmt.Sprintf("Downsample operation expected to succeed: %s.\n", err))
+	err = repo.Consume(msgs[split:])
+	require.Nil(t, err, fmt.Sprintf("Save operation expected to succeed: %s.\n", err))
+	err = store.Downsample(context.Background(), chanID, before)
+	assert.Nil(t, err, fmt.Sprintf("Downsample operation expected to succeed: %s.\n", err))
+
+	filter := bson.M{"channel": chanID}
+	count, err := db.Collection(collection).CountDocuments(context.Background(), filter)
+	require.Nil(t, err, fmt.Sprintf("Querying database expected to succeed: %s.\n", err))
+	assert.Equal(t, int64(len(msgs)-len(old)), count, fmt.Sprintf("Expected to have %d messages left, found %d instead.\n", len(msgs)-len(old), count))
+
+	cursor, err := db.Collection("messages_hourly").Find(context.Background(), filter)
+	require.Nil(t, err, fmt.Sprintf("Querying database expected to succeed: %s.\n", err))
+	var aggs []retention.Aggregate
+	err = cursor.All(context.Background(), &aggs)
+	require.Nil(t, err, fmt.Sprintf("Decoding aggregates expected to succeed: %s.\n", err))
+	expected := retention.Hourly(old)
+	assert.ElementsMatch(t, expected, aggs, fmt.Sprintf("Expected aggregates %v, found %v instead.\n", expected, aggs))
+
+	err = store.RemoveAggregates(context.Background(), chanID, before.Add(-2*time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("Remove aggregates operation expected to succeed: %s.\n", err))
+	count, err = db.Collection("messages_hourly").CountDocuments(context.Background(), filter)
+	require.Nil(t, err, fmt.Sprintf("Querying database expected to succeed: %s.\n", err))
+	assert.Equal(t, int64(4), count, fmt.Sprintf("Expected to have %d aggregates left, found %d instead.\n", 4, count))
+}
@@ -70,6 +70,25 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS messages_hourly (
+                        channel       UUID,
+                        publisher     UUID,
+                        name          TEXT,
+                        time          FLOAT,
+                        min_value     FLOAT,
+                        max_value     FLOAT,
+                        sum_value     FLOAT,
+                        count_value   BIGINT,
+                        PRIMARY KEY (channel, publisher, name, time)
+                    )`,
+				},
+				Down: []string{
+					"DROP TABLE messages_hourly",
+				},
+			},
 		},
 	}
 
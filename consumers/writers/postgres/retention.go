@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errDownsample       = errors.New("failed to downsample messages in postgres database")
+	errRemoveAggregates = errors.New("failed to remove aggregates from postgres database")
+)
+
+var _ retention.Store = (*retentionStore)(nil)
+
+type retentionStore struct {
+	db *sqlx.DB
+}
+
+// NewRetentionStore returns new PostgreSQL store the retention rules are
+// enforced on.
+func NewRetentionStore(db *sqlx.DB) retention.Store {
+	return &retentionStore{db: db}
+}
+
+// Downsample aggregates and removes the SenML messages and removes the JSON
+// messages, which have no numeric values to aggregate, in one transaction.
+func (rs retentionStore) Downsample(ctx context.Context, channel string, before time.Time) (err error) {
+	aggregate := `INSERT INTO messages_hourly (channel, publisher, name, time,
+          min_value, max_value, sum_value, count_value)
+          SELECT channel, publisher, name, FLOOR(time / 3600) * 3600 AS bucket,
+          MIN(value), MAX(value), SUM(value), COUNT(value)
+          FROM messages WHERE channel = $1 AND time < $2 AND value IS NOT NULL
+          GROUP BY channel, publisher, name, bucket
+          ON CONFLICT (channel, publisher, name, time) DO UPDATE SET
+          min_value = LEAST(messages_hourly.min_value, EXCLUDED.min_value),
+          max_value = GREATEST(messages_hourly.max_value, EXCLUDED.max_value),
+          sum_value = messages_hourly.sum_value + EXCLUDED.sum_value,
+          count_value = messages_hourly.count_value + EXCLUDED.count_value;`
+	remove := `DELETE FROM messages WHERE channel = $1 AND time < $2;`
+	// JSON messages are stored in the tables named by their format.
+	jsonTables := `SELECT table_name FROM information_schema.columns
+          WHERE table_schema = current_schema() AND column_name = 'payload';`
+
+	tx, err := rs.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errDownsample, err)
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, aggregate, channel, before.Unix()); err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+	if _, err = tx.ExecContext(ctx, remove, channel, before.Unix()); err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+
+	var tables []string
+	if err = tx.SelectContext(ctx, &tables, jsonTables); err != nil {
+		return errors.Wrap(errDownsample, err)
+	}
+	for _, table := range tables {
+		q := fmt.Sprintf(`DELETE FROM %s WHERE channel = $1 AND created < $2;`, pq.QuoteIdentifier(table))
+		if _, err = tx.ExecContext(ctx, q, channel, before.UnixNano()); err != nil {
+			return errors.Wrap(errDownsample, err)
+		}
+	}
+
+	return nil
+}
+
+func (rs retentionStore) RemoveAggregates(ctx context.Context, channel string, before time.Time) error {
+	q := `DELETE FROM messages_hourly WHERE channel = $1 AND time < $2;`
+	if _, err := rs.db.ExecContext(ctx, q, channel, before.Unix()); err != nil {
+		return errors.Wrap(errRemoveAggregates, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/consumers/writers/postgres"
+	mfjson "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownsample(t *testing.T) {
+	messageRepo := postgres.New(db)
+	store := postgres.NewRetentionStore(db)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	before := time.Now().Truncate(time.Hour)
+	start := before.Add(-4 * time.Hour)
+	var msgs, old []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		val := float64(i)
+		msg := senml.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Name:      fmt.Sprintf("name-%d", i%2),
+			Time:      float64(start.Add(time.Duration(i) * 10 * time.Minute).Unix()),
+			Value:     &val,
+		}
+		if i%5 == 0 {
+			msg.Value = nil
+			msg.StringValue = &stringV
+		}
+		msgs = append(msgs, msg)
+		if msg.Time < float64(before.Unix()) {
+			old = append(old, msg)
+		}
+	}
+
+	jsonMsgs := mfjson.Messages{Format: "retention"}
+	for i := 0; i < msgsNum; i++ {
+		jsonMsgs.Data = append(jsonMsgs.Data, mfjson.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Created:   start.Add(time.Duration(i) * 10 * time.Minute).UnixNano(),
+			Payload:   map[string]interface{}{"value": float64(i)},
+		})
+	}
+	err = messageRepo.Consume(jsonMsgs)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	// Downsample in two steps splitting the hour, to merge the new
+	// aggregates with the existing ones.
+	split := len(old)/2 + 3
+	err = messageRepo.Consume(msgs[:split])
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	err = store.Downsample(context.Background(), chid.String(), before)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	err = messageRepo.Consume(msgs[split:])
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	err = store.Downsample(context.Background(), chid.String(), before)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	var count int
+	err = db.Get(&count, "SELECT COUNT(*) FROM messages WHERE channel = $1", chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, len(msgs)-len(old), count, fmt.Sprintf("expected %d messages got %d", len(msgs)-len(old), count))
+	err = db.Get(&count, "SELECT COUNT(*) FROM retention WHERE channel = $1", chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, len(msgs)-len(old), count, fmt.Sprintf("expected %d JSON messages got %d", len(msgs)-len(old), count))
+
+	var aggs []retention.Aggregate
+	q := `SELECT channel, publisher, name, time, min_value AS min, max_value AS max,
+          sum_value AS sum, count_value AS count FROM messages_hourly WHERE channel = $1`
+	err = db.Select(&aggs, q, chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	expected := retention.Hourly(old)
+	assert.ElementsMatch(t, expected, aggs, fmt.Sprintf("expected %v got %v", expected, aggs))
+
+	err = store.RemoveAggregates(context.Background(), chid.String(), before.Add(-2*time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	err = db.Get(&count, "SELECT COUNT(*) FROM messages_hourly WHERE channel = $1", chid.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, 4, count, fmt.Sprintf("expected %d aggregates got %d", 4, count))
+}
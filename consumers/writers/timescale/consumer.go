@@ -0,0 +1,258 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq" // required for DB access
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/errors"
+	mfjson "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+const (
+	errInvalid        = "invalid_text_representation"
+	errUndefinedTable = "undefined_table"
+
+	// Table for SenML messages
+	defTable = "messages"
+	// JSON messages hypertables are partitioned in chunks of one day.
+	jsonChunkInterval = int64(24 * time.Hour)
+)
+
+var (
+	errInvalidMessage = errors.New("invalid message representation")
+	errSaveMessage    = errors.New("failed to save message to timescale database")
+	errTransRollback  = errors.New("failed to rollback transaction")
+	errNoTable        = errors.New("relation does not exist")
+)
+
+var _ consumers.Consumer = (*timescaleRepo)(nil)
+
+type timescaleRepo struct {
+	db        *sqlx.DB
+	retention time.Duration
+}
+
+// New returns new TimescaleDB writer. Tables of JSON messages are created
+// as hypertables with the given retention, zero retention keeping the
+// messages forever.
+func New(db *sqlx.DB, retention time.Duration) consumers.Consumer {
+	return &timescaleRepo{
+		db:        db,
+		retention: retention,
+	}
+}
+
+func (tr timescaleRepo) Consume(message interface{}) (err error) {
+	switch m := message.(type) {
+	case mfjson.Messages:
+		return tr.saveJSON(m)
+	default:
+		return tr.saveSenml(m)
+	}
+}
+
+func (tr timescaleRepo) saveSenml(messages interface{}) (err error) {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errSaveMessage
+	}
+	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
+          name, unit, value, string_value, bool_value, data_value, sum,
+          time, update_time)
+          VALUES (:id, :channel, :subtopic, :publisher, :protocol, :name, :unit,
+          :value, :string_value, :bool_value, :data_value, :sum,
+          to_timestamp(:time), :update_time);`
+
+	tx, err := tr.db.BeginTxx(context.Background(), nil)
+	if err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errSaveMessage, err)
+		}
+	}()
+
+	for _, msg := range msgs {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		m := senmlMessage{Message: msg, ID: id.String()}
+		if _, err := tx.NamedExec(q, m); err != nil {
+			pqErr, ok := err.(*pq.Error)
+			if ok {
+				switch pqErr.Code.Name() {
+				case errInvalid:
+					return errors.Wrap(errSaveMessage, errInvalidMessage)
+				}
+			}
+
+			return errors.Wrap(errSaveMessage, err)
+		}
+	}
+	return err
+}
+
+func (tr timescaleRepo) saveJSON(msgs mfjson.Messages) error {
+	if err := tr.insertJSON(msgs); err != nil {
+		if err == errNoTable {
+			if err := tr.createTable(msgs.Format); err != nil {
+				return err
+			}
+			return tr.insertJSON(msgs)
+		}
+		return err
+	}
+	return nil
+}
+
+func (tr timescaleRepo) insertJSON(msgs mfjson.Messages) error {
+	tx, err := tr.db.BeginTxx(context.Background(), nil)
+	if err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errSaveMessage, err)
+		}
+	}()
+
+	q := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload)
+          VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload);`
+	q = fmt.Sprintf(q, msgs.Format)
+
+	for _, m := range msgs.Data {
+		var dbmsg jsonMessage
+		dbmsg, err = toJSONMessage(m)
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		if _, err = tx.NamedExec(q, dbmsg); err != nil {
+			pqErr, ok := err.(*pq.Error)
+			if ok {
+				switch pqErr.Code.Name() {
+				case errInvalid:
+					return errors.Wrap(errSaveMessage, errInvalidMessage)
+				case errUndefinedTable:
+					return errNoTable
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (tr timescaleRepo) createTable(name string) (err error) {
+	tx, err := tr.db.BeginTxx(context.Background(), nil)
+	if err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errSaveMessage, err)
+		}
+	}()
+
+	q := `CREATE TABLE IF NOT EXISTS %s (
+                        id            UUID,
+                        created       BIGINT NOT NULL,
+                        channel       VARCHAR(254),
+                        subtopic      VARCHAR(254),
+                        publisher     VARCHAR(254),
+                        protocol      TEXT,
+                        payload       JSONB,
+                        PRIMARY KEY (id, created)
+                    )`
+	if _, err = tx.Exec(fmt.Sprintf(q, name)); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`SELECT create_hypertable($1, 'created', chunk_time_interval => CAST($2 AS BIGINT), if_not_exists => TRUE)`, name, jsonChunkInterval); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`SELECT set_integer_now_func($1, 'unix_nano_now', replace_if_exists => TRUE)`, name); err != nil {
+		return err
+	}
+	if tr.retention > 0 {
+		if _, err = tx.Exec(`SELECT add_retention_policy($1, CAST($2 AS BIGINT), if_not_exists => TRUE)`, name, tr.retention.Nanoseconds()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type senmlMessage struct {
+	senml.Message
+	ID string `db:"id"`
+}
+
+type jsonMessage struct {
+	ID        string `db:"id"`
+	Channel   string `db:"channel"`
+	Created   int64  `db:"created"`
+	Subtopic  string `db:"subtopic"`
+	Publisher string `db:"publisher"`
+	Protocol  string `db:"protocol"`
+	Payload   []byte `db:"payload"`
+}
+
+func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return jsonMessage{}, err
+	}
+
+	data := []byte("{}")
+	if msg.Payload != nil {
+		b, err := json.Marshal(msg.Payload)
+		if err != nil {
+			return jsonMessage{}, errors.Wrap(errSaveMessage, err)
+		}
+		data = b
+	}
+
+	m := jsonMessage{
+		ID:        id.String(),
+		Channel:   msg.Channel,
+		Created:   msg.Created,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Payload:   data,
+	}
+
+	return m, nil
+}
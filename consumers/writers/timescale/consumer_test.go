@@ -0,0 +1,100 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/writers/timescale"
+	mfjson "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	msgsNum     = 42
+	valueFields = 5
+	subtopic    = "topic"
+)
+
+var (
+	v       float64 = 5
+	stringV         = "value"
+	boolV           = true
+	dataV           = "base64"
+	sum     float64 = 42
+)
+
+func TestMessageSave(t *testing.T) {
+	messageRepo := timescale.New(db, time.Hour)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msg := senml.Message{}
+	msg.Channel = chid.String()
+
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	msg.Publisher = pubid.String()
+
+	now := time.Now().Unix()
+	var msgs []senml.Message
+
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Subtopic = subtopic
+			msg.Value = &v
+		case 1:
+			msg.BoolValue = &boolV
+		case 2:
+			msg.StringValue = &stringV
+		case 3:
+			msg.DataValue = &dataV
+		case 4:
+			msg.Sum = &sum
+		}
+
+		msg.Time = float64(now + int64(i))
+		msgs = append(msgs, msg)
+	}
+
+	err = messageRepo.Consume(msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+}
+
+func TestJSONSave(t *testing.T) {
+	messageRepo := timescale.New(db, time.Hour)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().UnixNano()
+	msgs := mfjson.Messages{Format: "some_json"}
+	for i := 0; i < msgsNum; i++ {
+		msgs.Data = append(msgs.Data, mfjson.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Subtopic:  subtopic,
+			Created:   now + int64(i),
+			Payload:   map[string]interface{}{"field": float64(i)},
+		})
+	}
+
+	// The first write creates the hypertable.
+	for i := 0; i < 2; i++ {
+		err = messageRepo.Consume(msgs)
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	}
+}
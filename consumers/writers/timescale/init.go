@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a TimescaleDB instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+	// Retention is the age after which the messages are dropped. Zero
+	// value keeps the messages forever.
+	Retention time.Duration
+}
+
+// Connect creates a connection to the TimescaleDB instance, applies any
+// unapplied database migrations and the configured retention policy.
+// A non-nil error is returned to indicate failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	if err := setRetention(db, defTable, cfg.Retention); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "messages_1",
+				Up: []string{
+					`CREATE EXTENSION IF NOT EXISTS timescaledb`,
+					`CREATE TABLE IF NOT EXISTS messages (
+                        id            UUID,
+                        channel       UUID,
+                        subtopic      VARCHAR(254),
+                        publisher     UUID,
+                        protocol      TEXT,
+                        name          TEXT,
+                        unit          TEXT,
+                        value         FLOAT,
+                        string_value  TEXT,
+                        bool_value    BOOL,
+                        data_value    BYTEA,
+                        sum           FLOAT,
+                        time          TIMESTAMPTZ NOT NULL,
+                        update_time   FLOAT,
+                        PRIMARY KEY (id, time)
+                    )`,
+					`SELECT create_hypertable('messages', 'time', if_not_exists => TRUE)`,
+					`CREATE INDEX IF NOT EXISTS messages_channel_time_idx ON messages (channel, time DESC)`,
+					// JSON messages are partitioned by the creation time in
+					// nanoseconds, which requires the function returning the
+					// current time in the same unit for the retention policies.
+					`CREATE OR REPLACE FUNCTION unix_nano_now() RETURNS BIGINT
+                    LANGUAGE SQL STABLE AS $$ SELECT CAST(EXTRACT(EPOCH FROM NOW()) * 1000000000 AS BIGINT) $$`,
+				},
+				Down: []string{
+					"DROP TABLE messages",
+					"DROP FUNCTION unix_nano_now",
+				},
+			},
+			{
+				// Continuous aggregates can't be created in the transaction.
+				Id: "messages_2",
+				Up: []string{
+					`CREATE MATERIALIZED VIEW IF NOT EXISTS messages_hourly
+                    WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+                    SELECT channel, publisher, name, time_bucket(INTERVAL '1 hour', time) AS bucket,
+                        MIN(value) AS min_value, MAX(value) AS max_value,
+                        SUM(value) AS sum_value, COUNT(value) AS count_value
+                    FROM messages WHERE value IS NOT NULL
+                    GROUP BY channel, publisher, name, bucket
+                    WITH NO DATA`,
+					`SELECT add_continuous_aggregate_policy('messages_hourly',
+                        start_offset => INTERVAL '3 hours',
+                        end_offset => INTERVAL '1 hour',
+                        schedule_interval => INTERVAL '1 hour',
+                        if_not_exists => TRUE)`,
+				},
+				Down: []string{
+					"DROP MATERIALIZED VIEW messages_hourly",
+				},
+				DisableTransactionUp:   true,
+				DisableTransactionDown: true,
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
+
+// setRetention replaces the retention policy of the SenML messages
+// hypertable. Zero retention removes the policy.
+func setRetention(db *sqlx.DB, table string, retention time.Duration) error {
+	if _, err := db.Exec(`SELECT remove_retention_policy($1, if_exists => TRUE)`, table); err != nil {
+		return err
+	}
+	if retention <= 0 {
+		return nil
+	}
+
+	_, err := db.Exec(`SELECT add_retention_policy($1, make_interval(secs => $2))`, table, retention.Seconds())
+	return err
+}
@@ -7,29 +7,92 @@ package http
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/codec"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
+const (
+	chansPrefix = "channels"
+
+	// The messages are dropped if the subscriber doesn't receive them
+	// fast enough.
+	subscriptionBuffer = 64
+)
+
+var (
+	// ErrNotFound indicates that no message was published to the channel
+	// and subtopic.
+	ErrNotFound = errors.New("message not found")
+
+	// ErrSubscribe indicates that the subscription to the channel failed.
+	ErrSubscribe = errors.New("failed to subscribe to channel")
+)
+
+// Subscription receives the messages published to the channel and
+// subtopic.
+type Subscription struct {
+	ChanID   string
+	Subtopic string
+	messages chan messaging.Message
+}
+
+// Messages returns the channel of the received messages.
+func (s *Subscription) Messages() <-chan messaging.Message {
+	return s.messages
+}
+
 // Service specifies coap service API.
 type Service interface {
 	// Publish Messssage
 	Publish(ctx context.Context, token string, msg messaging.Message) error
+
+	// PublishBatch authorizes the thing once and publishes the messages to
+	// the channel. Returned errors correspond to the messages, while the
+	// error is returned if the thing is not allowed to publish.
+	PublishBatch(ctx context.Context, token, chanID string, msgs []messaging.Message) ([]error, error)
+
+	// Latest returns the last message published to the channel and subtopic.
+	Latest(ctx context.Context, token, chanID, subtopic string) (messaging.Message, error)
+
+	// Subscribe authorizes the thing and returns the subscription to the
+	// channel and subtopic, which may contain wildcards.
+	Subscribe(ctx context.Context, token, chanID, subtopic string) (*Subscription, error)
+
+	// Unsubscribe stops receiving the messages of the subscription.
+	Unsubscribe(ctx context.Context, sub *Subscription) error
 }
 
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
-	publisher messaging.Publisher
-	things    mainflux.ThingsServiceClient
+	publisher  messaging.Publisher
+	subscriber messaging.Subscriber
+	things     mainflux.ThingsServiceClient
+	retained   messaging.RetainedRepository
+	codecs     codec.Repository
+
+	mu   sync.Mutex
+	subs map[string]map[*Subscription]struct{}
 }
 
-// New instantiates the HTTP adapter implementation.
-func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient) Service {
+// New instantiates the HTTP adapter implementation. Since the subscriber
+// handles a single subscription per topic, the adapter subscribes once per
+// topic and forwards the messages to all the subscriptions. The binary
+// payloads are decoded to SenML using the codecs of the things and the
+// channels.
+func New(publisher messaging.Publisher, subscriber messaging.Subscriber, things mainflux.ThingsServiceClient, retained messaging.RetainedRepository, codecs codec.Repository) Service {
 	return &adapterService{
-		publisher: publisher,
-		things:    things,
+		publisher:  publisher,
+		subscriber: subscriber,
+		things:     things,
+		retained:   retained,
+		codecs:     codecs,
+		subs:       make(map[string]map[*Subscription]struct{}),
 	}
 }
 
@@ -44,5 +107,126 @@ func (as *adapterService) Publish(ctx context.Context, token string, msg messagi
 	}
 	msg.Publisher = thid.GetValue()
 
+	msg, err = codec.DecodeMessage(as.codecs, msg)
+	if err != nil {
+		return err
+	}
+
 	return as.publisher.Publish(msg.Channel, msg)
 }
+
+func (as *adapterService) PublishBatch(ctx context.Context, token, chanID string, msgs []messaging.Message) ([]error, error) {
+	ar := &mainflux.AccessByKeyReq{
+		Token:  token,
+		ChanID: chanID,
+	}
+	thid, err := as.things.CanAccessByKey(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		msg.Channel = chanID
+		msg.Publisher = thid.GetValue()
+		if msg, errs[i] = codec.DecodeMessage(as.codecs, msg); errs[i] != nil {
+			continue
+		}
+		errs[i] = as.publisher.Publish(chanID, msg)
+	}
+
+	return errs, nil
+}
+
+func (as *adapterService) Latest(ctx context.Context, token, chanID, subtopic string) (messaging.Message, error) {
+	ar := &mainflux.AccessByKeyReq{
+		Token:  token,
+		ChanID: chanID,
+	}
+	if _, err := as.things.CanAccessByKey(ctx, ar); err != nil {
+		return messaging.Message{}, err
+	}
+
+	msgs, err := as.retained.Retrieve(chanID, subtopic)
+	if err != nil {
+		return messaging.Message{}, err
+	}
+	if len(msgs) == 0 {
+		return messaging.Message{}, ErrNotFound
+	}
+
+	return msgs[0], nil
+}
+
+func (as *adapterService) Subscribe(ctx context.Context, token, chanID, subtopic string) (*Subscription, error) {
+	ar := &mainflux.AccessByKeyReq{
+		Token:  token,
+		ChanID: chanID,
+	}
+	if _, err := as.things.CanAccessByKey(ctx, ar); err != nil {
+		return nil, err
+	}
+
+	sub := &Subscription{
+		ChanID:   chanID,
+		Subtopic: subtopic,
+		messages: make(chan messaging.Message, subscriptionBuffer),
+	}
+	topic := subject(chanID, subtopic)
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if subs, ok := as.subs[topic]; ok {
+		subs[sub] = struct{}{}
+		return sub, nil
+	}
+	if err := as.subscriber.Subscribe(topic, as.handle(topic)); err != nil {
+		return nil, errors.Wrap(ErrSubscribe, err)
+	}
+	as.subs[topic] = map[*Subscription]struct{}{sub: {}}
+
+	return sub, nil
+}
+
+func (as *adapterService) Unsubscribe(ctx context.Context, sub *Subscription) error {
+	topic := subject(sub.ChanID, sub.Subtopic)
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	subs, ok := as.subs[topic]
+	if !ok {
+		return nil
+	}
+	delete(subs, sub)
+	if len(subs) > 0 {
+		return nil
+	}
+	delete(as.subs, topic)
+
+	return as.subscriber.Unsubscribe(topic)
+}
+
+func (as *adapterService) handle(topic string) messaging.MessageHandler {
+	return func(msg messaging.Message) error {
+		as.mu.Lock()
+		defer as.mu.Unlock()
+
+		for sub := range as.subs[topic] {
+			select {
+			case sub.messages <- msg:
+			default:
+			}
+		}
+		return nil
+	}
+}
+
+func subject(chanID, subtopic string) string {
+	s := fmt.Sprintf("%s.%s", chansPrefix, chanID)
+	if subtopic != "" {
+		s = fmt.Sprintf("%s.%s", s, subtopic)
+	}
+	return s
+}
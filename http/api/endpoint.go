@@ -5,9 +5,11 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/mainflux/mainflux/http"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 func sendMessageEndpoint(svc http.Service) endpoint.Endpoint {
@@ -17,3 +19,98 @@ func sendMessageEndpoint(svc http.Service) endpoint.Endpoint {
 		return nil, err
 	}
 }
+
+func publishBatchEndpoint(svc http.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(batchReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res := batchRes{Results: make([]batchItemRes, len(req.entries))}
+		msgs := []messaging.Message{}
+		// Indices of the entries of the published messages.
+		idxs := []int{}
+		received := time.Now().UnixNano()
+		for i, e := range req.entries {
+			res.Results[i].Index = i
+			msg, err := e.message(received)
+			if err != nil {
+				res.Results[i].Error = err.Error()
+				continue
+			}
+			msgs = append(msgs, msg)
+			idxs = append(idxs, i)
+		}
+
+		errs, err := svc.PublishBatch(ctx, req.token, req.chanID, msgs)
+		if err != nil {
+			return nil, err
+		}
+		for j, err := range errs {
+			i := idxs[j]
+			if err != nil {
+				res.Results[i].Error = err.Error()
+				continue
+			}
+			res.Results[i].Accepted = true
+		}
+
+		return res, nil
+	}
+}
+
+func latestEndpoint(svc http.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(latestReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		msg, err := svc.Latest(ctx, req.token, req.chanID, req.subtopic)
+		if err != nil {
+			return nil, err
+		}
+
+		return toMessageRes(msg), nil
+	}
+}
+
+// pollEndpoint waits for the messages until the timeout expires, and
+// returns the ones received by the time the first one arrived.
+func pollEndpoint(svc http.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(subscribeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sub, err := svc.Subscribe(ctx, req.token, req.chanID, req.subtopic)
+		if err != nil {
+			return nil, err
+		}
+		defer svc.Unsubscribe(ctx, sub)
+
+		timer := time.NewTimer(req.timeout)
+		defer timer.Stop()
+
+		res := messagesRes{}
+		select {
+		case msg := <-sub.Messages():
+			res = append(res, toMessageRes(msg))
+		case <-timer.C:
+			return res, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		for {
+			select {
+			case msg := <-sub.Messages():
+				res = append(res, toMessageRes(msg))
+			default:
+				return res, nil
+			}
+		}
+	}
+}
@@ -4,12 +4,16 @@
 package api_test
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/opentracing/opentracing-go/mocktracer"
 
@@ -17,12 +21,17 @@ import (
 	adapter "github.com/mainflux/mainflux/http"
 	"github.com/mainflux/mainflux/http/api"
 	"github.com/mainflux/mainflux/http/mocks"
+	"github.com/mainflux/mainflux/pkg/codec"
+	codecmocks "github.com/mainflux/mainflux/pkg/codec/mocks"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	msgmocks "github.com/mainflux/mainflux/pkg/messaging/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func newService(cc mainflux.ThingsServiceClient) adapter.Service {
-	pub := mocks.NewPublisher()
-	return adapter.New(pub, cc)
+func newService(cc mainflux.ThingsServiceClient, retained messaging.RetainedRepository, codecs codec.Repository) adapter.Service {
+	ps := mocks.NewPubSub()
+	return adapter.New(ps, ps, cc, retained, codecs)
 }
 
 func newHTTPServer(svc adapter.Service) *httptest.Server {
@@ -60,7 +69,9 @@ func TestPublish(t *testing.T) {
 	invalidToken := "invalid_token"
 	msg := `[{"n":"current","t":-1,"v":1.6}]`
 	thingsClient := mocks.NewThingsClient(map[string]string{token: chanID})
-	svc := newService(thingsClient)
+	codecs := codecmocks.NewRepository()
+	codecs.Save(codec.Channel, chanID, codec.Codec{Fields: []codec.Field{{Name: "current", Type: codec.Uint16, Scale: 0.1, Unit: "A"}}})
+	svc := newService(thingsClient, msgmocks.NewRetainedRepository(), codecs)
 	ts := newHTTPServer(svc)
 	defer ts.Close()
 
@@ -99,6 +110,20 @@ func TestPublish(t *testing.T) {
 			auth:        token,
 			status:      http.StatusAccepted,
 		},
+		"publish binary message decoded with codec": {
+			chanID:      chanID,
+			msg:         "\x00\x10",
+			contentType: codec.BinaryContentType,
+			auth:        token,
+			status:      http.StatusAccepted,
+		},
+		"publish binary message shorter than codec fields": {
+			chanID:      chanID,
+			msg:         "\x00",
+			contentType: codec.BinaryContentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
 		"publish message to invalid channel": {
 			chanID:      "",
 			msg:         msg,
@@ -129,3 +154,373 @@ func TestPublish(t *testing.T) {
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
 	}
 }
+
+type messageRes struct {
+	Channel     string `json:"channel"`
+	Subtopic    string `json:"subtopic"`
+	ContentType string `json:"content_type"`
+	Payload     []byte `json:"payload"`
+}
+
+func TestLatest(t *testing.T) {
+	chanID := "1"
+	token := "auth_token"
+	thingsClient := mocks.NewThingsClient(map[string]string{token: chanID})
+	retained := msgmocks.NewRetainedRepository()
+	svc := newService(thingsClient, retained, codecmocks.NewRepository())
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	msg := messaging.Message{
+		Channel:     chanID,
+		Subtopic:    "a.b",
+		ContentType: "application/senml+json",
+		Payload:     []byte(`[{"n":"current","t":-1,"v":1.6}]`),
+	}
+	err := retained.Save(msg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error saving message: %s", err))
+
+	cases := map[string]struct {
+		subtopic string
+		auth     string
+		status   int
+		res      messageRes
+	}{
+		"get latest message": {
+			subtopic: "a/b",
+			auth:     token,
+			status:   http.StatusOK,
+			res: messageRes{
+				Channel:     msg.Channel,
+				Subtopic:    msg.Subtopic,
+				ContentType: msg.ContentType,
+				Payload:     msg.Payload,
+			},
+		},
+		"get latest message without authorization token": {
+			subtopic: "a/b",
+			auth:     "",
+			status:   http.StatusForbidden,
+		},
+		"get latest message with invalid authorization token": {
+			subtopic: "a/b",
+			auth:     "invalid_token",
+			status:   http.StatusForbidden,
+		},
+		"get latest message from subtopic without messages": {
+			subtopic: "a/c",
+			auth:     token,
+			status:   http.StatusNotFound,
+		},
+		"get latest message with wildcard subtopic": {
+			subtopic: "a/*",
+			auth:     token,
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for desc, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?subtopic=%s", ts.URL, chanID, tc.subtopic),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body messageRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected %v got %v", desc, tc.res, body))
+	}
+}
+
+func TestPoll(t *testing.T) {
+	chanID := "1"
+	token := "auth_token"
+	thingsClient := mocks.NewThingsClient(map[string]string{token: chanID})
+	svc := newService(thingsClient, msgmocks.NewRetainedRepository(), codecmocks.NewRepository())
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	msg := messaging.Message{
+		Channel:     chanID,
+		Subtopic:    "a.b",
+		ContentType: "application/senml+json",
+		Payload:     []byte(`[{"n":"current","t":-1,"v":1.6}]`),
+	}
+	expected := []messageRes{
+		{
+			Channel:     msg.Channel,
+			Subtopic:    msg.Subtopic,
+			ContentType: msg.ContentType,
+			Payload:     msg.Payload,
+		},
+	}
+
+	cases := map[string]struct {
+		subtopic string
+		timeout  string
+		auth     string
+		status   int
+		res      []messageRes
+	}{
+		"poll messages": {
+			subtopic: "/a/b",
+			timeout:  "1",
+			auth:     token,
+			status:   http.StatusOK,
+			res:      expected,
+		},
+		"poll messages using single level wildcard": {
+			subtopic: "/a/+",
+			timeout:  "1",
+			auth:     token,
+			status:   http.StatusOK,
+			res:      expected,
+		},
+		"poll messages using multi level wildcard": {
+			subtopic: "/%23",
+			timeout:  "1",
+			auth:     token,
+			status:   http.StatusOK,
+			res:      expected,
+		},
+		"poll messages of other subtopic": {
+			subtopic: "/a/c",
+			timeout:  "1",
+			auth:     token,
+			status:   http.StatusNoContent,
+		},
+		"poll messages without authorization token": {
+			subtopic: "/a/b",
+			timeout:  "1",
+			auth:     "",
+			status:   http.StatusForbidden,
+		},
+		"poll messages with invalid authorization token": {
+			subtopic: "/a/b",
+			timeout:  "1",
+			auth:     "invalid_token",
+			status:   http.StatusForbidden,
+		},
+		"poll messages with invalid timeout": {
+			subtopic: "/a/b",
+			timeout:  "invalid",
+			auth:     token,
+			status:   http.StatusBadRequest,
+		},
+		"poll messages with too long timeout": {
+			subtopic: "/a/b",
+			timeout:  "3600",
+			auth:     token,
+			status:   http.StatusBadRequest,
+		},
+		"poll messages with malformed subtopic": {
+			subtopic: "/a>",
+			timeout:  "1",
+			auth:     token,
+			status:   http.StatusBadRequest,
+		},
+	}
+
+	for desc, tc := range cases {
+		// The message is published after the poll request subscribed.
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			time.Sleep(200 * time.Millisecond)
+			svc.Publish(context.Background(), token, msg)
+		}()
+
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/messages%s?timeout=%s", ts.URL, chanID, tc.subtopic, tc.timeout),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		<-done
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body []messageRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected %v got %v", desc, tc.res, body))
+	}
+}
+
+func TestStream(t *testing.T) {
+	chanID := "1"
+	token := "auth_token"
+	thingsClient := mocks.NewThingsClient(map[string]string{token: chanID})
+	svc := newService(thingsClient, msgmocks.NewRetainedRepository(), codecmocks.NewRepository())
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	msg := messaging.Message{
+		Channel:     chanID,
+		Subtopic:    "a.b",
+		ContentType: "application/senml+json",
+		Payload:     []byte(`[{"n":"current","t":-1,"v":1.6}]`),
+	}
+
+	cases := map[string]struct {
+		auth   string
+		status int
+	}{
+		"stream messages": {
+			auth:   token,
+			status: http.StatusOK,
+		},
+		"stream messages with invalid authorization token": {
+			auth:   "invalid_token",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for desc, tc := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/channels/%s/messages/a/b", ts.URL, chanID), nil)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		req.Header.Set("Authorization", tc.auth)
+		req.Header.Set("Accept", "text/event-stream")
+
+		res, err := ts.Client().Do(req)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			cancel()
+			continue
+		}
+
+		r := bufio.NewReader(res.Body)
+		for i := 0; i < 2; i++ {
+			err := svc.Publish(context.Background(), token, msg)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+
+			event, err := r.ReadString('\n')
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			_, err = r.ReadString('\n')
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+
+			var body messageRes
+			err = json.Unmarshal([]byte(strings.TrimPrefix(event, "data: ")), &body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+			assert.Equal(t, string(msg.Payload), string(body.Payload), fmt.Sprintf("%s: expected payload %s got %s", desc, msg.Payload, body.Payload))
+		}
+		cancel()
+	}
+}
+
+type batchRes struct {
+	Results []struct {
+		Index    int    `json:"index"`
+		Accepted bool   `json:"accepted"`
+		Error    string `json:"error"`
+	} `json:"results"`
+}
+
+func TestPublishBatch(t *testing.T) {
+	chanID := "1"
+	token := "auth_token"
+	thingsClient := mocks.NewThingsClient(map[string]string{token: chanID})
+	svc := newService(thingsClient, msgmocks.NewRetainedRepository(), codecmocks.NewRepository())
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	sub, err := svc.Subscribe(context.Background(), token, chanID, ">")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer svc.Unsubscribe(context.Background(), sub)
+
+	batch := `[
+		{"subtopic": "a/b", "content_type": "application/senml+json", "payload": [{"n":"current","v":1.6}], "created": 1600000000000000000},
+		{"subtopic": "a>", "payload": [{"n":"current","v":1.7}]},
+		{"subtopic": "a/c", "payload": {"temperature": 21.5}},
+		{"subtopic": "a/d"}
+	]`
+	tooLarge := fmt.Sprintf("[%s]", strings.TrimSuffix(strings.Repeat(`{"subtopic":"a","payload":1},`, 1001), ","))
+
+	cases := map[string]struct {
+		body     string
+		auth     string
+		status   int
+		accepted []bool
+	}{
+		"publish batch": {
+			body:     batch,
+			auth:     token,
+			status:   http.StatusOK,
+			accepted: []bool{true, false, true, false},
+		},
+		"publish batch without authorization token": {
+			body:   batch,
+			auth:   "",
+			status: http.StatusForbidden,
+		},
+		"publish batch with invalid authorization token": {
+			body:   batch,
+			auth:   "invalid_token",
+			status: http.StatusForbidden,
+		},
+		"publish malformed batch": {
+			body:   `{"subtopic": "a/b"}`,
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		"publish empty batch": {
+			body:   `[]`,
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		"publish too large batch": {
+			body:   tooLarge,
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for desc, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/batch", ts.URL, chanID),
+			contentType: "application/json",
+			token:       tc.auth,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body batchRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		require.Len(t, body.Results, len(tc.accepted), fmt.Sprintf("%s: expected %d results got %d", desc, len(tc.accepted), len(body.Results)))
+		for i, r := range body.Results {
+			assert.Equal(t, i, r.Index, fmt.Sprintf("%s: expected index %d got %d", desc, i, r.Index))
+			assert.Equal(t, tc.accepted[i], r.Accepted, fmt.Sprintf("%s: expected entry %d accepted %t got %t", desc, i, tc.accepted[i], r.Accepted))
+			assert.Equal(t, tc.accepted[i], r.Error == "", fmt.Sprintf("%s: unexpected error of entry %d: %s", desc, i, r.Error))
+		}
+	}
+
+	msg := <-sub.Messages()
+	assert.Equal(t, "a.b", msg.Subtopic, fmt.Sprintf("expected subtopic a.b got %s", msg.Subtopic))
+	assert.Equal(t, chanID, msg.Publisher, fmt.Sprintf("expected publisher %s got %s", chanID, msg.Publisher))
+	assert.Equal(t, int64(1600000000000000000), msg.Created, fmt.Sprintf("expected device timestamp to be preserved, got %d", msg.Created))
+	msg = <-sub.Messages()
+	assert.Equal(t, `{"temperature": 21.5}`, string(msg.Payload), fmt.Sprintf("expected payload to be published as is, got %s", msg.Payload))
+	assert.NotZero(t, msg.Created, "expected creation time to be set")
+}
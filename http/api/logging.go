@@ -43,3 +43,77 @@ func (lm *loggingMiddleware) Publish(ctx context.Context, token string, msg mess
 
 	return lm.svc.Publish(ctx, token, msg)
 }
+
+func (lm *loggingMiddleware) Latest(ctx context.Context, token, chanID, subtopic string) (msg messaging.Message, err error) {
+	defer func(begin time.Time) {
+		destChannel := chanID
+		if subtopic != "" {
+			destChannel = fmt.Sprintf("%s.%s", destChannel, subtopic)
+		}
+		message := fmt.Sprintf("Method latest for channel %s took %s to complete", destChannel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Latest(ctx, token, chanID, subtopic)
+}
+
+func (lm *loggingMiddleware) Subscribe(ctx context.Context, token, chanID, subtopic string) (sub *http.Subscription, err error) {
+	defer func(begin time.Time) {
+		destChannel := chanID
+		if subtopic != "" {
+			destChannel = fmt.Sprintf("%s.%s", destChannel, subtopic)
+		}
+		message := fmt.Sprintf("Method subscribe to channel %s took %s to complete", destChannel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Subscribe(ctx, token, chanID, subtopic)
+}
+
+func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, sub *http.Subscription) (err error) {
+	defer func(begin time.Time) {
+		destChannel := sub.ChanID
+		if sub.Subtopic != "" {
+			destChannel = fmt.Sprintf("%s.%s", destChannel, sub.Subtopic)
+		}
+		message := fmt.Sprintf("Method unsubscribe from channel %s took %s to complete", destChannel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Unsubscribe(ctx, sub)
+}
+
+func (lm *loggingMiddleware) PublishBatch(ctx context.Context, token, chanID string, msgs []messaging.Message) (errs []error, err error) {
+	defer func(begin time.Time) {
+		failed := 0
+		for _, e := range errs {
+			if e != nil {
+				failed++
+			}
+		}
+		message := fmt.Sprintf("Method publish_batch of %d messages to channel %s took %s to complete", len(msgs), chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		if failed > 0 {
+			lm.logger.Warn(fmt.Sprintf("%s with %d failed messages.", message, failed))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublishBatch(ctx, token, chanID, msgs)
+}
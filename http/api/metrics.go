@@ -39,3 +39,39 @@ func (mm *metricsMiddleware) Publish(ctx context.Context, token string, msg mess
 
 	return mm.svc.Publish(ctx, token, msg)
 }
+
+func (mm *metricsMiddleware) Latest(ctx context.Context, token, chanID, subtopic string) (messaging.Message, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "latest").Add(1)
+		mm.latency.With("method", "latest").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Latest(ctx, token, chanID, subtopic)
+}
+
+func (mm *metricsMiddleware) Subscribe(ctx context.Context, token, chanID, subtopic string) (*http.Subscription, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "subscribe").Add(1)
+		mm.latency.With("method", "subscribe").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Subscribe(ctx, token, chanID, subtopic)
+}
+
+func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, sub *http.Subscription) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "unsubscribe").Add(1)
+		mm.latency.With("method", "unsubscribe").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Unsubscribe(ctx, sub)
+}
+
+func (mm *metricsMiddleware) PublishBatch(ctx context.Context, token, chanID string, msgs []messaging.Message) ([]error, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_batch").Add(1)
+		mm.latency.With("method", "publish_batch").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PublishBatch(ctx, token, chanID, msgs)
+}
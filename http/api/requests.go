@@ -4,10 +4,102 @@
 package api
 
 import (
+	"encoding/json"
+	"strings"
+	"time"
+
 	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
+const maxBatchSize = 1000
+
 type publishReq struct {
 	msg   messaging.Message
 	token string
 }
+
+type batchEntry struct {
+	Subtopic    string          `json:"subtopic"`
+	ContentType string          `json:"content_type"`
+	Payload     json.RawMessage `json:"payload"`
+	Created     int64           `json:"created"`
+}
+
+// message returns the message of the entry. If the creation time is not
+// supplied by the device, the time the batch was received is used.
+func (e batchEntry) message(received int64) (messaging.Message, error) {
+	subtopic, err := parseSubtopic(e.Subtopic)
+	if err != nil {
+		return messaging.Message{}, err
+	}
+	if strings.ContainsAny(subtopic, "*>") {
+		return messaging.Message{}, errMalformedSubtopic
+	}
+	if len(e.Payload) == 0 {
+		return messaging.Message{}, errEmptyPayload
+	}
+
+	created := e.Created
+	if created == 0 {
+		created = received
+	}
+
+	msg := messaging.Message{
+		Protocol:    protocol,
+		Subtopic:    subtopic,
+		ContentType: e.ContentType,
+		Payload:     e.Payload,
+		Created:     created,
+	}
+	return msg, nil
+}
+
+type batchReq struct {
+	token   string
+	chanID  string
+	entries []batchEntry
+}
+
+func (req batchReq) validate() error {
+	if req.chanID == "" {
+		return errMalformedData
+	}
+	if len(req.entries) == 0 || len(req.entries) > maxBatchSize {
+		return errInvalidBatch
+	}
+	return nil
+}
+
+type latestReq struct {
+	token    string
+	chanID   string
+	subtopic string
+}
+
+func (req latestReq) validate() error {
+	if req.chanID == "" {
+		return errMalformedData
+	}
+	// The latest message is returned for a single subtopic.
+	if strings.ContainsAny(req.subtopic, "*>") {
+		return errMalformedSubtopic
+	}
+	return nil
+}
+
+type subscribeReq struct {
+	token    string
+	chanID   string
+	subtopic string
+	timeout  time.Duration
+}
+
+func (req subscribeReq) validate() error {
+	if req.chanID == "" {
+		return errMalformedData
+	}
+	if req.timeout <= 0 || req.timeout > maxPollTimeout {
+		return errInvalidTimeout
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var (
+	_ mainflux.Response = (*messageRes)(nil)
+	_ mainflux.Response = (*messagesRes)(nil)
+	_ mainflux.Response = (*batchRes)(nil)
+)
+
+type messageRes struct {
+	Channel     string `json:"channel"`
+	Subtopic    string `json:"subtopic,omitempty"`
+	Publisher   string `json:"publisher"`
+	Protocol    string `json:"protocol"`
+	ContentType string `json:"content_type,omitempty"`
+	Payload     []byte `json:"payload"`
+	Created     int64  `json:"created"`
+}
+
+func toMessageRes(msg messaging.Message) messageRes {
+	return messageRes{
+		Channel:     msg.Channel,
+		Subtopic:    msg.Subtopic,
+		Publisher:   msg.Publisher,
+		Protocol:    msg.Protocol,
+		ContentType: msg.ContentType,
+		Payload:     msg.Payload,
+		Created:     msg.Created,
+	}
+}
+
+func (res messageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res messageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res messageRes) Empty() bool {
+	return false
+}
+
+type messagesRes []messageRes
+
+func (res messagesRes) Code() int {
+	if len(res) == 0 {
+		return http.StatusNoContent
+	}
+	return http.StatusOK
+}
+
+func (res messagesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res messagesRes) Empty() bool {
+	return len(res) == 0
+}
+
+type batchItemRes struct {
+	Index    int    `json:"index"`
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+}
+
+type batchRes struct {
+	Results []batchItemRes `json:"results"`
+}
+
+func (res batchRes) Code() int {
+	return http.StatusOK
+}
+
+func (res batchRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res batchRes) Empty() bool {
+	return false
+}
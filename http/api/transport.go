@@ -5,12 +5,16 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +23,7 @@ import (
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
 	adapter "github.com/mainflux/mainflux/http"
+	"github.com/mainflux/mainflux/pkg/codec"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -27,13 +32,29 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-const protocol = "http"
+const (
+	protocol       = "http"
+	contentType    = "application/json"
+	sseContentType = "text/event-stream"
+	timeoutKey     = "timeout"
+
+	defPollTimeout = 30 * time.Second
+	maxPollTimeout = 5 * time.Minute
+)
 
 var (
 	errMalformedData     = errors.New("malformed request data")
 	errMalformedSubtopic = errors.New("malformed subtopic")
+	errInvalidTimeout    = errors.New("invalid timeout")
+	errStreaming         = errors.New("streaming not supported")
+	errInvalidBatch      = errors.New("batch must contain between 1 and 1000 entries")
+	errEmptyPayload      = errors.New("empty payload")
 )
 
+// The comment is sent periodically to keep the idle event stream open
+// through the proxies.
+var sseKeepAlive = 15 * time.Second
+
 var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 
 // MakeHandler returns a HTTP handler for API endpoints.
@@ -57,6 +78,33 @@ func MakeHandler(svc adapter.Service, tracer opentracing.Tracer) http.Handler {
 		opts...,
 	))
 
+	r.Post("/channels/:id/batch", kithttp.NewServer(
+		kitot.TraceServer(tracer, "publish_batch")(publishBatchEndpoint(svc)),
+		decodeBatch,
+		encodeResponse,
+		opts...,
+	))
+
+	// Registered before the subscription routes, so it shadows the subtopic
+	// named `latest`, which is then received only through the wildcards.
+	r.Get("/channels/:id/messages/latest", kithttp.NewServer(
+		kitot.TraceServer(tracer, "latest")(latestEndpoint(svc)),
+		decodeLatest,
+		encodeResponse,
+		opts...,
+	))
+
+	// Long-polling subscribes per request and doesn't buffer the messages
+	// sent between the requests.
+	poll := kithttp.NewServer(
+		kitot.TraceServer(tracer, "poll")(pollEndpoint(svc)),
+		decodeSubscribe,
+		encodeResponse,
+		opts...,
+	)
+	r.Get("/channels/:id/messages", subscribeHandler(svc, poll))
+	r.Get("/channels/:id/messages/*", subscribeHandler(svc, poll))
+
 	r.GetFunc("/version", mainflux.Version("http"))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -110,11 +158,12 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	}
 
 	msg := messaging.Message{
-		Protocol: protocol,
-		Channel:  chanID,
-		Subtopic: subtopic,
-		Payload:  payload,
-		Created:  time.Now().UnixNano(),
+		Protocol:    protocol,
+		Channel:     chanID,
+		Subtopic:    subtopic,
+		Payload:     payload,
+		ContentType: parseContentType(r.Header.Get("Content-Type")),
+		Created:     time.Now().UnixNano(),
 	}
 
 	req := publishReq{
@@ -125,6 +174,140 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeBatch(_ context.Context, r *http.Request) (interface{}, error) {
+	req := batchReq{
+		token:  r.Header.Get("Authorization"),
+		chanID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.entries); err != nil {
+		return nil, errMalformedData
+	}
+
+	return req, nil
+}
+
+func decodeLatest(_ context.Context, r *http.Request) (interface{}, error) {
+	subtopic, err := parseSubtopic(r.URL.Query().Get("subtopic"))
+	if err != nil {
+		return nil, err
+	}
+
+	req := latestReq{
+		token:    r.Header.Get("Authorization"),
+		chanID:   bone.GetValue(r, "id"),
+		subtopic: subtopic,
+	}
+
+	return req, nil
+}
+
+func decodeSubscribe(_ context.Context, r *http.Request) (interface{}, error) {
+	channelParts := channelPartRegExp.FindStringSubmatch(r.RequestURI)
+	if len(channelParts) < 2 {
+		return nil, errMalformedData
+	}
+
+	// MQTT wildcards are converted to NATS ones.
+	filter := strings.NewReplacer("+", "*", "#", ">", "%23", ">").Replace(channelParts[2])
+	subtopic, err := parseSubtopic(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := defPollTimeout
+	if t := r.URL.Query().Get(timeoutKey); t != "" {
+		sec, err := strconv.ParseUint(t, 10, 32)
+		if err != nil {
+			return nil, errInvalidTimeout
+		}
+		timeout = time.Duration(sec) * time.Second
+	}
+
+	req := subscribeReq{
+		token:    r.Header.Get("Authorization"),
+		chanID:   bone.GetValue(r, "id"),
+		subtopic: subtopic,
+		timeout:  timeout,
+	}
+
+	return req, nil
+}
+
+// subscribeHandler streams the messages as Server-Sent Events if the client
+// accepts them, and long-polls otherwise.
+func subscribeHandler(svc adapter.Service, poll http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), sseContentType) {
+			poll.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		req, err := decodeSubscribe(ctx, r)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+		if err := stream(ctx, svc, req.(subscribeReq), w); err != nil {
+			encodeError(ctx, err, w)
+		}
+	}
+}
+
+// stream sends the messages to the client until it disconnects. Errors are
+// returned only before the stream is started.
+func stream(ctx context.Context, svc adapter.Service, req subscribeReq, w http.ResponseWriter) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errStreaming
+	}
+
+	sub, err := svc.Subscribe(ctx, req.token, req.chanID, req.subtopic)
+	if err != nil {
+		return err
+	}
+	defer svc.Unsubscribe(ctx, sub)
+
+	w.Header().Set("Content-Type", sseContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg := <-sub.Messages():
+			data, err := json.Marshal(toMessageRes(msg))
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return nil
+			}
+		case <-ticker.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+		flusher.Flush()
+	}
+}
+
+// parseContentType returns the media type without parameters, or an
+// empty string if the Content-Type header is missing or malformed.
+func parseContentType(ct string) string {
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return ""
+	}
+	return mt
+}
+
 func decodePayload(body io.ReadCloser) ([]byte, error) {
 	payload, err := ioutil.ReadAll(body)
 	if err != nil {
@@ -136,16 +319,35 @@ func decodePayload(body io.ReadCloser) ([]byte, error) {
 }
 
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
-	w.WriteHeader(http.StatusAccepted)
-	return nil
+	ar, ok := response.(mainflux.Response)
+	if !ok {
+		w.WriteHeader(http.StatusAccepted)
+		return nil
+	}
+
+	for k, v := range ar.Headers() {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(ar.Code())
+
+	if ar.Empty() {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(response)
 }
 
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch err {
-	case errMalformedData, errMalformedSubtopic:
+	case errMalformedData, errMalformedSubtopic, errInvalidTimeout, errInvalidBatch, codec.ErrShortPayload:
 		w.WriteHeader(http.StatusBadRequest)
+	case errStreaming:
+		w.WriteHeader(http.StatusNotImplemented)
 	case things.ErrUnauthorizedAccess:
 		w.WriteHeader(http.StatusForbidden)
+	case adapter.ErrNotFound:
+		w.WriteHeader(http.StatusNotFound)
 	default:
 		if e, ok := status.FromError(err); ok {
 			switch e.Code() {
@@ -0,0 +1,74 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const chansPrefix = "channels"
+
+var errAlreadySubscribed = errors.New("already subscribed to topic")
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+type pubsub struct {
+	mu       sync.Mutex
+	handlers map[string]messaging.MessageHandler
+}
+
+// NewPubSub returns mock message publisher/subscriber, which delivers the
+// messages to the handlers of the matching subjects.
+func NewPubSub() messaging.PubSub {
+	return &pubsub{
+		handlers: make(map[string]messaging.MessageHandler),
+	}
+}
+
+func (ps *pubsub) Publish(topic string, msg messaging.Message) error {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	ps.mu.Lock()
+	hs := []messaging.MessageHandler{}
+	for topic, h := range ps.handlers {
+		if messaging.MatchSubtopic(topic, subject) {
+			hs = append(hs, h)
+		}
+	}
+	ps.mu.Unlock()
+
+	for _, h := range hs {
+		if err := h(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ps *pubsub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if _, ok := ps.handlers[topic]; ok {
+		return errAlreadySubscribed
+	}
+	ps.handlers[topic] = handler
+
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(topic string) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	delete(ps.handlers, topic)
+	return nil
+}
@@ -61,3 +61,7 @@ func (tc thingsClient) IsChannelOwner(context.Context, *mainflux.ChannelOwnerReq
 func (tc thingsClient) Identify(ctx context.Context, req *mainflux.Token, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
 	panic("not implemented")
 }
+
+func (tc thingsClient) DerivePSK(ctx context.Context, req *mainflux.ThingID, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
@@ -5,15 +5,45 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/mainflux/mainflux/pkg/codec"
+	mferrors "github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/senml"
 )
 
 const (
 	protocol      = "lora"
 	thingSuffix   = "thing"
 	channelSuffix = "channel"
+
+	senmlContentType  = "application/senml+json"
+	binaryContentType = "application/octet-stream"
+
+	// downlinkTopic is LoRa Server topic for sending messages to the device.
+	downlinkTopic = "application/%s/device/%s/tx"
+
+	// downlinkSubtopic is the last token of the <thing_id>.tx subtopic of
+	// the messages sent as downlinks to the device mapped to the thing.
+	downlinkSubtopic = "tx"
+
+	// Headers of the downlink message.
+	fPortHeader     = "f_port"
+	confirmedHeader = "confirmed"
+
+	// Port 0 is reserved for MAC commands, and ports 224 and above are
+	// reserved for the future use.
+	defFPort = 1
+	maxFPort = 223
+
+	// metadataSubtopic is the subtopic of the SenML messages containing the
+	// device status and the gateway metadata.
+	metadataSubtopic = "metadata"
 )
 
 var (
@@ -25,6 +55,18 @@ var (
 
 	// ErrNotFoundApp indicates a non-existent route map for an application ID.
 	ErrNotFoundApp = errors.New("route map not found for this application ID")
+
+	// ErrMalformedDownlink indicates malformed downlink message headers.
+	ErrMalformedDownlink = errors.New("malformed downlink message")
+
+	// ErrUnknownFormat indicates the uplink format without the decoder.
+	ErrUnknownFormat = errors.New("unknown uplink format")
+
+	// ErrUnauthorizedAccess indicates missing or invalid credentials.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrNotFound indicates a non-existent entity or codec.
+	ErrNotFound = errors.New("non-existent entity")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -39,99 +81,377 @@ type Service interface {
 	// RemoveThing removes thingID:devEUI route-map
 	RemoveThing(thingID string) error
 
-	// CreateChannel creates channelID:appID route-map
-	CreateChannel(chanID string, appID string) error
+	// CreateChannel creates channelID:appID route-map, with the uplink
+	// format of the application. Empty format stands for FormatLoRaServer.
+	CreateChannel(chanID, appID, format string) error
 
-	// UpdateChannel updates channelID:appID route-map
-	UpdateChannel(chanID string, appID string) error
+	// UpdateChannel updates channelID:appID route-map and the uplink format
+	UpdateChannel(chanID, appID, format string) error
 
 	// RemoveChannel removes channelID:appID route-map
 	RemoveChannel(chanID string) error
 
-	// Publish forwards messages from the LoRa MQTT broker to Mainflux NATS broker
-	Publish(ctx context.Context, token string, msg Message) error
+	// Publish decodes the uplink of the application using the format of
+	// its channel route, and forwards it from the LoRa MQTT broker to
+	// Mainflux NATS broker.
+	Publish(ctx context.Context, appID string, payload []byte) error
+
+	// SaveCodec stores the payload codec of the thing or the channel of
+	// the given kind, owned by the user identified by the token.
+	SaveCodec(ctx context.Context, token, kind, id string, c codec.Codec) error
+
+	// ViewCodec returns the payload codec of the thing or the channel.
+	ViewCodec(ctx context.Context, token, kind, id string) (codec.Codec, error)
+
+	// RemoveCodec removes the payload codec of the thing or the channel.
+	RemoveCodec(ctx context.Context, token, kind, id string) error
+
+	// Downlink forwards messages from Mainflux NATS broker to the LoRa MQTT
+	// broker. The messages published on the <thing_id>.tx subtopic are sent
+	// to the device mapped to the thing, in the application mapped to the
+	// channel. The messages with malformed downlink headers are dropped.
+	Downlink(ctx context.Context, msg messaging.Message) error
 }
 
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
-	publisher  messaging.Publisher
-	thingsRM   RouteMapRepository
-	channelsRM RouteMapRepository
+	publisher     messaging.Publisher
+	loraPublisher messaging.Publisher
+	thingsRM      RouteMapRepository
+	channelsRM    RouteMapRepository
+	formats       FormatRepository
+	decoders      map[string]Decoder
+	codecs        codec.Repository
+	sdk           mfsdk.SDK
+	metadata      bool
 }
 
-// New instantiates the LoRa adapter implementation.
-func New(publisher messaging.Publisher, thingsRM, channelsRM RouteMapRepository) Service {
+// New instantiates the LoRa adapter implementation. The uplinks are decoded
+// by the decoders of the channel route formats, and the frame payloads by
+// the codecs of the things or the channels. The codec owners are checked
+// using the SDK. If metadata is true, the device status and the gateway
+// metadata are published as SenML messages on the metadata subtopic of the
+// channel.
+func New(publisher, loraPublisher messaging.Publisher, thingsRM, channelsRM RouteMapRepository, formats FormatRepository, decoders map[string]Decoder, codecs codec.Repository, sdk mfsdk.SDK, metadata bool) Service {
 	return &adapterService{
-		publisher:  publisher,
-		thingsRM:   thingsRM,
-		channelsRM: channelsRM,
+		publisher:     publisher,
+		loraPublisher: loraPublisher,
+		thingsRM:      thingsRM,
+		channelsRM:    channelsRM,
+		formats:       formats,
+		decoders:      decoders,
+		codecs:        codecs,
+		sdk:           sdk,
+		metadata:      metadata,
 	}
 }
 
 // Publish forwards messages from Lora MQTT broker to Mainflux NATS broker
-func (as *adapterService) Publish(ctx context.Context, token string, m Message) error {
+func (as *adapterService) Publish(ctx context.Context, appID string, payload []byte) error {
 	// Get route map of lora application
-	thing, err := as.thingsRM.Get(m.DevEUI)
+	channel, err := as.channelsRM.Get(appID)
 	if err != nil {
-		return ErrNotFoundDev
+		return ErrNotFoundApp
 	}
 
-	// Get route map of lora application
-	channel, err := as.channelsRM.Get(m.ApplicationID)
+	format, err := as.formats.Get(channel)
 	if err != nil {
-		return ErrNotFoundApp
+		return err
+	}
+	if format == "" {
+		format = FormatLoRaServer
+	}
+	dec, ok := as.decoders[format]
+	if !ok {
+		return ErrUnknownFormat
+	}
+	up, err := dec.Decode(payload)
+	if err != nil {
+		return err
+	}
+
+	// Get route map of lora device. The device EUIs are stored in lower
+	// case, regardless of the decoder.
+	thing, err := as.thingsRM.Get(strings.ToLower(up.DevEUI))
+	if err != nil {
+		return ErrNotFoundDev
+	}
+
+	created := time.Now()
+	msg := messaging.Message{
+		Publisher: thing,
+		Protocol:  protocol,
+		Channel:   channel,
+		Headers: map[string]string{
+			"dev_eui":        up.DevEUI,
+			"application_id": appID,
+			"f_port":         strconv.Itoa(up.FPort),
+			"f_cnt":          strconv.Itoa(up.FCnt),
+		},
+		Created: created.UnixNano(),
+	}
+
+	if as.metadata {
+		if err := as.publishMetadata(msg, up, created); err != nil {
+			return err
+		}
 	}
 
 	// Use the SenML message decoded on LoRa server application if
-	// field Object isn't empty. Otherwise, decode standard field Data.
-	var payload []byte
-	switch m.Object {
-	case nil:
-		payload, err = base64.StdEncoding.DecodeString(m.Data)
+	// field Object isn't empty. Otherwise, use standard field Data.
+	// The status events contain neither of them.
+	switch {
+	case up.Object != nil:
+		jo, err := json.Marshal(up.Object)
 		if err != nil {
-			return ErrMalformedMessage
+			return err
 		}
-	default:
-		jo, err := json.Marshal(m.Object)
+		msg.Payload = jo
+		msg.ContentType = senmlContentType
+	case len(up.Data) > 0:
+		msg.Payload, msg.ContentType, err = as.decode(thing, channel, up.Data)
 		if err != nil {
 			return err
 		}
-		payload = []byte(jo)
+	default:
+		return nil
 	}
 
-	// Publish on Mainflux NATS broker
-	msg := messaging.Message{
-		Publisher: thing,
-		Protocol:  protocol,
-		Channel:   channel,
-		Payload:   payload,
-		Created:   time.Now().UnixNano(),
+	return as.publisher.Publish(msg.Channel, msg)
+}
+
+// publishMetadata publishes the device status and the gateway metadata as
+// SenML records, with the device EUI as the base name. The gateway records
+// are prefixed with the gateway ID.
+func (as *adapterService) publishMetadata(msg messaging.Message, up Uplink, created time.Time) error {
+	var recs []senml.Record
+	if s := up.Status; s != nil {
+		if s.Battery != nil {
+			recs = append(recs, senmlRecord("battery", "%EL", *s.Battery))
+		}
+		recs = append(recs, senmlRecord("margin", "dB", float64(s.Margin)))
+	}
+	for _, gw := range up.Gateways {
+		recs = append(recs,
+			senmlRecord(gw.ID+":rssi", "dBm", gw.RSSI),
+			senmlRecord(gw.ID+":snr", "dB", gw.SNR),
+		)
+		if l := gw.Location; l != nil {
+			recs = append(recs,
+				senmlRecord(gw.ID+":lat", "lat", l.Latitude),
+				senmlRecord(gw.ID+":lon", "lon", l.Longitude),
+				senmlRecord(gw.ID+":alt", "m", l.Altitude),
+			)
+		}
+	}
+	if len(recs) == 0 {
+		return nil
 	}
+	recs[0].BaseName = up.DevEUI + ":"
+	recs[0].BaseTime = float64(created.UnixNano()) / float64(time.Second)
+
+	payload, err := senml.Encode(senml.Pack{Records: recs}, senml.JSON)
+	if err != nil {
+		return err
+	}
+	msg.Subtopic = metadataSubtopic
+	msg.Payload = payload
+	msg.ContentType = senmlContentType
 
 	return as.publisher.Publish(msg.Channel, msg)
 }
 
+// decode decodes the frame payload to SenML using the codec of the thing or
+// the channel. The payloads without the codec are forwarded as they are.
+func (as *adapterService) decode(thingID, chanID string, data []byte) ([]byte, string, error) {
+	c, err := codec.Resolve(as.codecs, thingID, chanID)
+	switch err {
+	case nil:
+		payload, err := c.Decode(data)
+		return payload, codec.ContentType, err
+	case codec.ErrNotFound:
+		return data, binaryContentType, nil
+	default:
+		return nil, "", err
+	}
+}
+
+func senmlRecord(name, unit string, value float64) senml.Record {
+	return senml.Record{
+		Name:  name,
+		Unit:  unit,
+		Value: &value,
+	}
+}
+
+// Downlink forwards messages from Mainflux NATS broker to Lora MQTT broker
+func (as *adapterService) Downlink(ctx context.Context, msg messaging.Message) error {
+	// Skip the uplinks forwarded by the adapter.
+	if msg.Protocol == protocol {
+		return nil
+	}
+
+	thingID, ok := downlinkThing(msg.Subtopic)
+	if !ok {
+		return nil
+	}
+
+	// Messages of the channels and things without route maps are not
+	// meant for LoRa devices.
+	appID, err := as.channelsRM.GetLoRaID(msg.Channel)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	devEUI, err := as.thingsRM.GetLoRaID(thingID)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Redelivery doesn't fix the headers, so the malformed downlinks are
+	// dropped.
+	dm, err := downlinkMessage(msg)
+	if err != nil {
+		return nil
+	}
+	payload, err := json.Marshal(dm)
+	if err != nil {
+		return err
+	}
+
+	m := messaging.Message{
+		Payload: payload,
+	}
+	return as.loraPublisher.Publish(fmt.Sprintf(downlinkTopic, appID, devEUI), m)
+}
+
+// downlinkThing returns the thing ID of the <thing_id>.tx subtopic.
+func downlinkThing(subtopic string) (string, bool) {
+	tokens := strings.Split(subtopic, ".")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] != downlinkSubtopic {
+		return "", false
+	}
+
+	return tokens[0], true
+}
+
+// downlinkMessage returns the downlink of the message, using the frame port
+// and the confirmation of its headers.
+func downlinkMessage(msg messaging.Message) (DownlinkMessage, error) {
+	dm := DownlinkMessage{
+		FPort: defFPort,
+		Data:  base64.StdEncoding.EncodeToString(msg.Payload),
+	}
+	if v, ok := msg.Headers[fPortHeader]; ok {
+		fPort, err := strconv.Atoi(v)
+		if err != nil || fPort < defFPort || fPort > maxFPort {
+			return DownlinkMessage{}, ErrMalformedDownlink
+		}
+		dm.FPort = fPort
+	}
+	if v, ok := msg.Headers[confirmedHeader]; ok {
+		confirmed, err := strconv.ParseBool(v)
+		if err != nil {
+			return DownlinkMessage{}, ErrMalformedDownlink
+		}
+		dm.Confirmed = confirmed
+	}
+
+	return dm, nil
+}
+
+// CreateThing stores the device EUI in lower case, as normalized by the
+// decoders.
 func (as *adapterService) CreateThing(thingID string, devEUI string) error {
-	return as.thingsRM.Save(thingID, devEUI)
+	return as.thingsRM.Save(thingID, strings.ToLower(devEUI))
 }
 
 func (as *adapterService) UpdateThing(thingID string, devEUI string) error {
-	return as.thingsRM.Save(thingID, devEUI)
+	return as.thingsRM.Save(thingID, strings.ToLower(devEUI))
 }
 
 func (as *adapterService) RemoveThing(thingID string) error {
 	return as.thingsRM.Remove(thingID)
 }
 
-func (as *adapterService) CreateChannel(chanID string, appID string) error {
-	return as.channelsRM.Save(chanID, appID)
+func (as *adapterService) CreateChannel(chanID, appID, format string) error {
+	if _, ok := as.decoders[format]; format != "" && !ok {
+		return ErrUnknownFormat
+	}
+	if err := as.channelsRM.Save(chanID, appID); err != nil {
+		return err
+	}
+	return as.formats.Save(chanID, format)
 }
 
-func (as *adapterService) UpdateChannel(chanID string, appID string) error {
-	return as.channelsRM.Save(chanID, appID)
+func (as *adapterService) UpdateChannel(chanID, appID, format string) error {
+	return as.CreateChannel(chanID, appID, format)
 }
 
 func (as *adapterService) RemoveChannel(chanID string) error {
-	return as.channelsRM.Remove(chanID)
+	if err := as.channelsRM.Remove(chanID); err != nil {
+		return err
+	}
+	return as.formats.Remove(chanID)
+}
+
+func (as *adapterService) SaveCodec(ctx context.Context, token, kind, id string, c codec.Codec) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if err := as.authorize(token, kind, id); err != nil {
+		return err
+	}
+
+	return as.codecs.Save(kind, id, c)
+}
+
+func (as *adapterService) ViewCodec(ctx context.Context, token, kind, id string) (codec.Codec, error) {
+	if err := as.authorize(token, kind, id); err != nil {
+		return codec.Codec{}, err
+	}
+
+	c, err := as.codecs.Retrieve(kind, id)
+	if err == codec.ErrNotFound {
+		return codec.Codec{}, ErrNotFound
+	}
+
+	return c, err
+}
+
+func (as *adapterService) RemoveCodec(ctx context.Context, token, kind, id string) error {
+	if err := as.authorize(token, kind, id); err != nil {
+		return err
+	}
+
+	return as.codecs.Remove(kind, id)
+}
+
+// authorize checks that the user identified by the token owns the thing or
+// the channel, which is fetched from the things service.
+func (as *adapterService) authorize(token, kind, id string) error {
+	if token == "" {
+		return ErrUnauthorizedAccess
+	}
+
+	var err error
+	switch kind {
+	case codec.Thing:
+		_, err = as.sdk.Thing(id, token)
+	case codec.Channel:
+		_, err = as.sdk.Channel(id, token)
+	default:
+		return ErrNotFound
+	}
+	if mferrors.Contains(err, mfsdk.ErrFailedFetch) {
+		return ErrNotFound
+	}
+
+	return err
 }
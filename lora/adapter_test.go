@@ -0,0 +1,166 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/lora"
+	"github.com/mainflux/mainflux/lora/mocks"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	thingID = "thing"
+	chanID  = "channel"
+	appID   = "1"
+	invalid = "invalid"
+)
+
+var errRouteMap = errors.New("route map failure")
+
+// publisher keeps the messages published to the topics.
+type publisher struct {
+	msgs map[string][]messaging.Message
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	p.msgs[topic] = append(p.msgs[topic], msg)
+	return nil
+}
+
+// failingRouteMap fails to get the LoRa ID of the invalid entity.
+type failingRouteMap struct {
+	lora.RouteMapRepository
+}
+
+func (rm failingRouteMap) GetLoRaID(mfxID string) (string, error) {
+	if mfxID == invalid {
+		return "", errRouteMap
+	}
+	return rm.RouteMapRepository.GetLoRaID(mfxID)
+}
+
+func TestDownlink(t *testing.T) {
+	thingsRM := failingRouteMap{mocks.NewRouteMap()}
+	chansRM := mocks.NewRouteMap()
+	pub := &publisher{}
+	svc := lora.New(nil, pub, thingsRM, chansRM, mocks.NewFormatRepository(), lora.Decoders(), nil, nil, false)
+
+	err := svc.CreateThing(thingID, devEUI)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.CreateChannel(chanID, appID, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	topic := fmt.Sprintf("application/%s/device/%s/tx", appID, devEUI)
+	downlink := func(subtopic string, headers map[string]string) messaging.Message {
+		return messaging.Message{
+			Channel:   chanID,
+			Subtopic:  subtopic,
+			Publisher: "publisher",
+			Protocol:  "http",
+			Headers:   headers,
+			Payload:   []byte{1, 2},
+		}
+	}
+
+	cases := []struct {
+		desc    string
+		msg     messaging.Message
+		err     error
+		payload string
+	}{
+		{
+			desc:    "send downlink with default headers",
+			msg:     downlink(thingID+".tx", nil),
+			err:     nil,
+			payload: `{"confirmed":false,"fPort":1,"data":"AQI="}`,
+		},
+		{
+			desc:    "send confirmed downlink to frame port",
+			msg:     downlink(thingID+".tx", map[string]string{"f_port": "223", "confirmed": "true"}),
+			err:     nil,
+			payload: `{"confirmed":true,"fPort":223,"data":"AQI="}`,
+		},
+		{
+			desc: "send downlink to reserved frame port",
+			msg:  downlink(thingID+".tx", map[string]string{"f_port": "0"}),
+			err:  nil,
+		},
+		{
+			desc: "send downlink to frame port out of range",
+			msg:  downlink(thingID+".tx", map[string]string{"f_port": "224"}),
+			err:  nil,
+		},
+		{
+			desc: "send downlink with invalid frame port",
+			msg:  downlink(thingID+".tx", map[string]string{"f_port": invalid}),
+			err:  nil,
+		},
+		{
+			desc: "send downlink with invalid confirmation",
+			msg:  downlink(thingID+".tx", map[string]string{"confirmed": invalid}),
+			err:  nil,
+		},
+		{
+			desc: "send message published by the device thing",
+			msg:  messaging.Message{Channel: chanID, Publisher: thingID, Protocol: "http", Payload: []byte{1, 2}},
+			err:  nil,
+		},
+		{
+			desc: "send message without thing subtopic",
+			msg:  downlink("tx", nil),
+			err:  nil,
+		},
+		{
+			desc: "send message with invalid subtopic",
+			msg:  downlink(thingID+".rx", nil),
+			err:  nil,
+		},
+		{
+			desc: "send message with nested subtopic",
+			msg:  downlink("devices."+thingID+".tx", nil),
+			err:  nil,
+		},
+		{
+			desc: "send uplink forwarded by the adapter",
+			msg:  messaging.Message{Channel: chanID, Subtopic: thingID + ".tx", Protocol: "lora", Payload: []byte{1, 2}},
+			err:  nil,
+		},
+		{
+			desc: "send downlink to channel without route map",
+			msg:  messaging.Message{Channel: "unknown", Subtopic: thingID + ".tx", Payload: []byte{1, 2}},
+			err:  nil,
+		},
+		{
+			desc: "send downlink to thing without route map",
+			msg:  downlink("unknown.tx", nil),
+			err:  nil,
+		},
+		{
+			desc: "send downlink with failing route map",
+			msg:  downlink(invalid+".tx", nil),
+			err:  errRouteMap,
+		},
+	}
+
+	for _, tc := range cases {
+		pub.msgs = make(map[string][]messaging.Message)
+		err := svc.Downlink(context.Background(), tc.msg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if tc.payload == "" {
+			assert.Empty(t, pub.msgs, fmt.Sprintf("%s: expected no downlinks", tc.desc))
+			continue
+		}
+		if assert.Len(t, pub.msgs[topic], 1, fmt.Sprintf("%s: expected downlink on %s", tc.desc, topic)) {
+			payload := string(pub.msgs[topic][0].Payload)
+			assert.JSONEq(t, tc.payload, payload, fmt.Sprintf("%s: expected payload %s got %s", tc.desc, tc.payload, payload))
+		}
+	}
+}
@@ -4,18 +4,128 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 
+	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/lora"
+	"github.com/mainflux/mainflux/pkg/codec"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+const contentType = "application/json"
+
+var errUnsupportedContentType = errors.New("unsupported content type")
+
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler() http.Handler {
+func MakeHandler(svc lora.Service) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
 	r := bone.New()
+
+	for _, kind := range []string{codec.Thing, codec.Channel} {
+		path := "/" + kind + "s/:id/codec"
+
+		r.Put(path, kithttp.NewServer(
+			saveCodecEndpoint(svc),
+			decodeSaveCodec(kind),
+			encodeResponse,
+			opts...,
+		))
+
+		r.Get(path, kithttp.NewServer(
+			viewCodecEndpoint(svc),
+			decodeCodec(kind),
+			encodeResponse,
+			opts...,
+		))
+
+		r.Delete(path, kithttp.NewServer(
+			removeCodecEndpoint(svc),
+			decodeCodec(kind),
+			encodeResponse,
+			opts...,
+		))
+	}
+
 	r.GetFunc("/version", mainflux.Version("lora-adapter"))
 	r.Handle("/metrics", promhttp.Handler())
 
 	return r
 }
+
+func decodeSaveCodec(kind string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+			return nil, errUnsupportedContentType
+		}
+
+		req := saveCodecReq{
+			token: r.Header.Get("Authorization"),
+			kind:  kind,
+			id:    bone.GetValue(r, "id"),
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req.Codec); err != nil {
+			return nil, errors.Wrap(codec.ErrMalformedCodec, err)
+		}
+
+		return req, nil
+	}
+}
+
+func decodeCodec(kind string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		req := codecReq{
+			token: r.Header.Get("Authorization"),
+			kind:  kind,
+			id:    bone.GetValue(r, "id"),
+		}
+
+		return req, nil
+	}
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", contentType)
+
+	switch {
+	case errors.Contains(err, codec.ErrMalformedCodec):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, lora.ErrUnauthorizedAccess):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, lora.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, errUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case err == io.EOF:
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/lora"
+)
+
+func saveCodecEndpoint(svc lora.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(saveCodecReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.SaveCodec(ctx, req.token, req.kind, req.id, req.Codec); err != nil {
+			return nil, err
+		}
+
+		return saveCodecRes{}, nil
+	}
+}
+
+func viewCodecEndpoint(svc lora.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(codecReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		c, err := svc.ViewCodec(ctx, req.token, req.kind, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewCodecRes{Codec: c}, nil
+	}
+}
+
+func removeCodecEndpoint(svc lora.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(codecReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveCodec(ctx, req.token, req.kind, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeCodecRes{}, nil
+	}
+}
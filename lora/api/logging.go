@@ -10,6 +10,8 @@ import (
 
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/lora"
+	"github.com/mainflux/mainflux/pkg/codec"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ lora.Service = (*loggingMiddleware)(nil)
@@ -66,9 +68,9 @@ func (lm loggingMiddleware) RemoveThing(mfxThing string) (err error) {
 	return lm.svc.RemoveThing(mfxThing)
 }
 
-func (lm loggingMiddleware) CreateChannel(mfxChan string, loraApp string) (err error) {
+func (lm loggingMiddleware) CreateChannel(mfxChan, loraApp, format string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("create_channel mfx:lora:%s:%s took %s to complete", mfxChan, loraApp, time.Since(begin))
+		message := fmt.Sprintf("create_channel mfx:lora:%s:%s with format %s took %s to complete", mfxChan, loraApp, format, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -76,12 +78,12 @@ func (lm loggingMiddleware) CreateChannel(mfxChan string, loraApp string) (err e
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CreateChannel(mfxChan, loraApp)
+	return lm.svc.CreateChannel(mfxChan, loraApp, format)
 }
 
-func (lm loggingMiddleware) UpdateChannel(mfxChanID string, loraApp string) (err error) {
+func (lm loggingMiddleware) UpdateChannel(mfxChanID, loraApp, format string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("update_channel mfx:lora:%s:%s took %s to complete", mfxChanID, loraApp, time.Since(begin))
+		message := fmt.Sprintf("update_channel mfx:lora:%s:%s with format %s took %s to complete", mfxChanID, loraApp, format, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -89,7 +91,7 @@ func (lm loggingMiddleware) UpdateChannel(mfxChanID string, loraApp string) (err
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.UpdateChannel(mfxChanID, loraApp)
+	return lm.svc.UpdateChannel(mfxChanID, loraApp, format)
 }
 
 func (lm loggingMiddleware) RemoveChannel(mfxChanID string) (err error) {
@@ -105,9 +107,9 @@ func (lm loggingMiddleware) RemoveChannel(mfxChanID string) (err error) {
 	return lm.svc.RemoveChannel(mfxChanID)
 }
 
-func (lm loggingMiddleware) Publish(ctx context.Context, token string, m lora.Message) (err error) {
+func (lm loggingMiddleware) Publish(ctx context.Context, appID string, payload []byte) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("publish application/%s/device/%s/rx took %s to complete", m.ApplicationID, m.DevEUI, time.Since(begin))
+		message := fmt.Sprintf("publish uplink of application %s took %s to complete", appID, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -115,5 +117,57 @@ func (lm loggingMiddleware) Publish(ctx context.Context, token string, m lora.Me
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Publish(ctx, token, m)
+	return lm.svc.Publish(ctx, appID, payload)
+}
+
+func (lm loggingMiddleware) Downlink(ctx context.Context, msg messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("downlink channels/%s from %s took %s to complete", msg.Channel, msg.Publisher, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Downlink(ctx, msg)
+}
+
+func (lm loggingMiddleware) SaveCodec(ctx context.Context, token, kind, id string, c codec.Codec) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("save_codec of %s %s took %s to complete", kind, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SaveCodec(ctx, token, kind, id, c)
+}
+
+func (lm loggingMiddleware) ViewCodec(ctx context.Context, token, kind, id string) (c codec.Codec, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("view_codec of %s %s took %s to complete", kind, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewCodec(ctx, token, kind, id)
+}
+
+func (lm loggingMiddleware) RemoveCodec(ctx context.Context, token, kind, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("remove_codec of %s %s took %s to complete", kind, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveCodec(ctx, token, kind, id)
 }
@@ -9,6 +9,8 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/mainflux/mainflux/lora"
+	"github.com/mainflux/mainflux/pkg/codec"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ lora.Service = (*metricsMiddleware)(nil)
@@ -55,22 +57,22 @@ func (mm *metricsMiddleware) RemoveThing(mfxDevID string) error {
 	return mm.svc.RemoveThing(mfxDevID)
 }
 
-func (mm *metricsMiddleware) CreateChannel(mfxChanID string, loraApp string) error {
+func (mm *metricsMiddleware) CreateChannel(mfxChanID, loraApp, format string) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "create_channel").Add(1)
 		mm.latency.With("method", "create_channel").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.CreateChannel(mfxChanID, loraApp)
+	return mm.svc.CreateChannel(mfxChanID, loraApp, format)
 }
 
-func (mm *metricsMiddleware) UpdateChannel(mfxChanID string, loraApp string) error {
+func (mm *metricsMiddleware) UpdateChannel(mfxChanID, loraApp, format string) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "update_channel").Add(1)
 		mm.latency.With("method", "update_channel").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.UpdateChannel(mfxChanID, loraApp)
+	return mm.svc.UpdateChannel(mfxChanID, loraApp, format)
 }
 
 func (mm *metricsMiddleware) RemoveChannel(mfxChanID string) error {
@@ -82,11 +84,47 @@ func (mm *metricsMiddleware) RemoveChannel(mfxChanID string) error {
 	return mm.svc.RemoveChannel(mfxChanID)
 }
 
-func (mm *metricsMiddleware) Publish(ctx context.Context, token string, m lora.Message) error {
+func (mm *metricsMiddleware) Publish(ctx context.Context, appID string, payload []byte) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "publish").Add(1)
 		mm.latency.With("method", "publish").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Publish(ctx, token, m)
+	return mm.svc.Publish(ctx, appID, payload)
+}
+
+func (mm *metricsMiddleware) Downlink(ctx context.Context, msg messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "downlink").Add(1)
+		mm.latency.With("method", "downlink").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Downlink(ctx, msg)
+}
+
+func (mm *metricsMiddleware) SaveCodec(ctx context.Context, token, kind, id string, c codec.Codec) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "save_codec").Add(1)
+		mm.latency.With("method", "save_codec").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SaveCodec(ctx, token, kind, id, c)
+}
+
+func (mm *metricsMiddleware) ViewCodec(ctx context.Context, token, kind, id string) (codec.Codec, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_codec").Add(1)
+		mm.latency.With("method", "view_codec").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewCodec(ctx, token, kind, id)
+}
+
+func (mm *metricsMiddleware) RemoveCodec(ctx context.Context, token, kind, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_codec").Add(1)
+		mm.latency.With("method", "remove_codec").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveCodec(ctx, token, kind, id)
 }
@@ -0,0 +1,50 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/mainflux/mainflux/lora"
+	"github.com/mainflux/mainflux/pkg/codec"
+)
+
+type apiReq interface {
+	validate() error
+}
+
+type saveCodecReq struct {
+	token string
+	kind  string
+	id    string
+	codec.Codec
+}
+
+func (req saveCodecReq) validate() error {
+	if req.token == "" {
+		return lora.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return codec.ErrMalformedCodec
+	}
+
+	return req.Codec.Validate()
+}
+
+type codecReq struct {
+	token string
+	kind  string
+	id    string
+}
+
+func (req codecReq) validate() error {
+	if req.token == "" {
+		return lora.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return lora.ErrNotFound
+	}
+
+	return nil
+}
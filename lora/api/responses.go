@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/codec"
+)
+
+var (
+	_ mainflux.Response = (*saveCodecRes)(nil)
+	_ mainflux.Response = (*viewCodecRes)(nil)
+	_ mainflux.Response = (*removeCodecRes)(nil)
+)
+
+type saveCodecRes struct{}
+
+func (res saveCodecRes) Code() int {
+	return http.StatusOK
+}
+
+func (res saveCodecRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res saveCodecRes) Empty() bool {
+	return true
+}
+
+type viewCodecRes struct {
+	codec.Codec
+}
+
+func (res viewCodecRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewCodecRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewCodecRes) Empty() bool {
+	return false
+}
+
+type removeCodecRes struct{}
+
+func (res removeCodecRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeCodecRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeCodecRes) Empty() bool {
+	return true
+}
@@ -0,0 +1,324 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// Uplink formats of the LoRa network servers.
+const (
+	// FormatLoRaServer is the JSON format of LoRa Server and of the
+	// ChirpStack v3 json_v3 marshaler. It is used by the channel routes
+	// without the format.
+	FormatLoRaServer = "loraserver"
+
+	// FormatChirpStackV3 is the ChirpStack v3 json marshaler format.
+	FormatChirpStackV3 = "chirpstack_v3"
+
+	// FormatChirpStackV4 is the ChirpStack v4 JSON format.
+	FormatChirpStackV4 = "chirpstack_v4"
+
+	// FormatTTS is The Things Stack JSON format.
+	FormatTTS = "tts"
+)
+
+// Uplink represents LoRa uplink message normalized by the decoder. The
+// status events contain only the device status.
+type Uplink struct {
+	// DevEUI is the device EUI as lower case hex string.
+	DevEUI string
+	FPort  int
+	FCnt   int
+
+	// Data is the frame payload.
+	Data []byte
+
+	// Object is the payload decoded by the network server, if any.
+	Object interface{}
+
+	Status   *DeviceStatus
+	Gateways []Gateway
+}
+
+// DeviceStatus contains the status reported by the device.
+type DeviceStatus struct {
+	// Battery is the battery level in percents, or nil if the device is
+	// powered externally or the level is unknown.
+	Battery *float64
+	Margin  int
+}
+
+// Gateway contains the metadata of the gateway which received the uplink.
+type Gateway struct {
+	ID       string
+	RSSI     float64
+	SNR      float64
+	Location *Location
+}
+
+// Location represents the location of the gateway.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Altitude  float64 `json:"altitude"`
+}
+
+// Decoder decodes uplink messages of the LoRa network server.
+type Decoder interface {
+	// Decode returns normalized uplink message.
+	Decode(payload []byte) (Uplink, error)
+}
+
+// DecoderFunc is an adapter which allows the use of ordinary functions as
+// decoders.
+type DecoderFunc func(payload []byte) (Uplink, error)
+
+// Decode calls f(payload).
+func (f DecoderFunc) Decode(payload []byte) (Uplink, error) {
+	return f(payload)
+}
+
+// Decoders returns the decoders of the supported formats.
+func Decoders() map[string]Decoder {
+	return map[string]Decoder{
+		FormatLoRaServer:   DecoderFunc(decodeLoRaServer),
+		FormatChirpStackV3: DecoderFunc(decodeChirpStackV3),
+		FormatChirpStackV4: DecoderFunc(decodeChirpStackV4),
+		FormatTTS:          DecoderFunc(decodeTTS),
+	}
+}
+
+// FormatRepository stores uplink formats of the channel routes.
+type FormatRepository interface {
+	// Save stores the format of the channel.
+	Save(chanID, format string) error
+
+	// Get returns the format of the channel, or an empty string if the
+	// channel has no format.
+	Get(chanID string) (string, error)
+
+	// Remove removes the format of the channel.
+	Remove(chanID string) error
+}
+
+func decodeLoRaServer(payload []byte) (Uplink, error) {
+	var m Message
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return Uplink{}, ErrMalformedMessage
+	}
+	if m.DevEUI == "" {
+		return Uplink{}, ErrMalformedMessage
+	}
+
+	up := Uplink{
+		DevEUI: strings.ToLower(m.DevEUI),
+		FPort:  m.FPort,
+		FCnt:   m.FCnt,
+		Data:   m.Data,
+		Object: m.Object,
+	}
+	// LoRaWAN battery level is 0 for external power source, 1 to 254 for
+	// the battery level and 255 if the level is unknown.
+	if m.DeviceStatusBattery != nil || m.DeviceStatusMargin != nil {
+		up.Status = &DeviceStatus{}
+		if b := m.DeviceStatusBattery; b != nil && *b > 0 && *b < 255 {
+			level := *b * 100 / 254
+			up.Status.Battery = &level
+		}
+		if m.DeviceStatusMargin != nil {
+			up.Status.Margin = *m.DeviceStatusMargin
+		}
+	}
+	for _, rx := range m.RxInfo {
+		gw := Gateway{
+			ID:       rx.GatewayID,
+			RSSI:     rx.Rssi,
+			SNR:      rx.LoRaSNR,
+			Location: rx.Location,
+		}
+		if gw.ID == "" {
+			gw.ID = rx.Mac
+		}
+		if gw.Location == nil && (rx.Latitude != 0 || rx.Longitude != 0) {
+			gw.Location = &Location{
+				Latitude:  rx.Latitude,
+				Longitude: rx.Longitude,
+				Altitude:  rx.Altitude,
+			}
+		}
+		up.Gateways = append(up.Gateways, gw)
+	}
+
+	return up, nil
+}
+
+type chirpStackV3Message struct {
+	DevEUI []byte `json:"devEUI"`
+	RxInfo []struct {
+		GatewayID []byte    `json:"gatewayID"`
+		RSSI      float64   `json:"rssi"`
+		LoRaSNR   float64   `json:"loRaSNR"`
+		Location  *Location `json:"location"`
+	} `json:"rxInfo"`
+	FCnt       int    `json:"fCnt"`
+	FPort      int    `json:"fPort"`
+	Data       []byte `json:"data"`
+	ObjectJSON string `json:"objectJSON"`
+
+	// Status event fields.
+	Margin                  *int    `json:"margin"`
+	ExternalPowerSource     bool    `json:"externalPowerSource"`
+	BatteryLevelUnavailable bool    `json:"batteryLevelUnavailable"`
+	BatteryLevel            float64 `json:"batteryLevel"`
+}
+
+// decodeChirpStackV3 decodes the Protobuf JSON mapping, where the byte
+// fields are base64 encoded.
+func decodeChirpStackV3(payload []byte) (Uplink, error) {
+	var m chirpStackV3Message
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return Uplink{}, ErrMalformedMessage
+	}
+	if len(m.DevEUI) == 0 {
+		return Uplink{}, ErrMalformedMessage
+	}
+
+	up := Uplink{
+		DevEUI: hex.EncodeToString(m.DevEUI),
+		FPort:  m.FPort,
+		FCnt:   m.FCnt,
+		Data:   m.Data,
+	}
+	if m.ObjectJSON != "" {
+		if err := json.Unmarshal([]byte(m.ObjectJSON), &up.Object); err != nil {
+			return Uplink{}, ErrMalformedMessage
+		}
+	}
+	if m.Margin != nil {
+		up.Status = deviceStatus(*m.Margin, m.BatteryLevel, m.ExternalPowerSource || m.BatteryLevelUnavailable)
+	}
+	for _, rx := range m.RxInfo {
+		up.Gateways = append(up.Gateways, Gateway{
+			ID:       hex.EncodeToString(rx.GatewayID),
+			RSSI:     rx.RSSI,
+			SNR:      rx.LoRaSNR,
+			Location: rx.Location,
+		})
+	}
+
+	return up, nil
+}
+
+type chirpStackV4Message struct {
+	DeviceInfo struct {
+		DevEUI string `json:"devEui"`
+	} `json:"deviceInfo"`
+	RxInfo []struct {
+		GatewayID string    `json:"gatewayId"`
+		RSSI      float64   `json:"rssi"`
+		SNR       float64   `json:"snr"`
+		Location  *Location `json:"location"`
+	} `json:"rxInfo"`
+	FCnt   int         `json:"fCnt"`
+	FPort  int         `json:"fPort"`
+	Data   []byte      `json:"data"`
+	Object interface{} `json:"object"`
+
+	// Status event fields.
+	Margin                  *int    `json:"margin"`
+	ExternalPowerSource     bool    `json:"externalPowerSource"`
+	BatteryLevelUnavailable bool    `json:"batteryLevelUnavailable"`
+	BatteryLevel            float64 `json:"batteryLevel"`
+}
+
+func decodeChirpStackV4(payload []byte) (Uplink, error) {
+	var m chirpStackV4Message
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return Uplink{}, ErrMalformedMessage
+	}
+	if m.DeviceInfo.DevEUI == "" {
+		return Uplink{}, ErrMalformedMessage
+	}
+
+	up := Uplink{
+		DevEUI: strings.ToLower(m.DeviceInfo.DevEUI),
+		FPort:  m.FPort,
+		FCnt:   m.FCnt,
+		Data:   m.Data,
+		Object: m.Object,
+	}
+	if m.Margin != nil {
+		up.Status = deviceStatus(*m.Margin, m.BatteryLevel, m.ExternalPowerSource || m.BatteryLevelUnavailable)
+	}
+	for _, rx := range m.RxInfo {
+		up.Gateways = append(up.Gateways, Gateway{
+			ID:       rx.GatewayID,
+			RSSI:     rx.RSSI,
+			SNR:      rx.SNR,
+			Location: rx.Location,
+		})
+	}
+
+	return up, nil
+}
+
+type ttsMessage struct {
+	EndDeviceIDs struct {
+		DevEUI string `json:"dev_eui"`
+	} `json:"end_device_ids"`
+	UplinkMessage *struct {
+		FPort          int         `json:"f_port"`
+		FCnt           int         `json:"f_cnt"`
+		FRMPayload     []byte      `json:"frm_payload"`
+		DecodedPayload interface{} `json:"decoded_payload"`
+		RxMetadata     []struct {
+			GatewayIDs struct {
+				GatewayID string `json:"gateway_id"`
+			} `json:"gateway_ids"`
+			RSSI     float64   `json:"rssi"`
+			SNR      float64   `json:"snr"`
+			Location *Location `json:"location"`
+		} `json:"rx_metadata"`
+	} `json:"uplink_message"`
+}
+
+func decodeTTS(payload []byte) (Uplink, error) {
+	var m ttsMessage
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return Uplink{}, ErrMalformedMessage
+	}
+	if m.EndDeviceIDs.DevEUI == "" || m.UplinkMessage == nil {
+		return Uplink{}, ErrMalformedMessage
+	}
+
+	um := m.UplinkMessage
+	up := Uplink{
+		DevEUI: strings.ToLower(m.EndDeviceIDs.DevEUI),
+		FPort:  um.FPort,
+		FCnt:   um.FCnt,
+		Data:   um.FRMPayload,
+		Object: um.DecodedPayload,
+	}
+	for _, rx := range um.RxMetadata {
+		up.Gateways = append(up.Gateways, Gateway{
+			ID:       rx.GatewayIDs.GatewayID,
+			RSSI:     rx.RSSI,
+			SNR:      rx.SNR,
+			Location: rx.Location,
+		})
+	}
+
+	return up, nil
+}
+
+func deviceStatus(margin int, battery float64, unavailable bool) *DeviceStatus {
+	ds := &DeviceStatus{Margin: margin}
+	if !unavailable {
+		ds.Battery = &battery
+	}
+	return ds
+}
@@ -0,0 +1,238 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/lora"
+	"github.com/stretchr/testify/assert"
+)
+
+const devEUI = "0102030405060708"
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestDecode(t *testing.T) {
+	decoders := lora.Decoders()
+	object := map[string]interface{}{"temperature": 21.5}
+	location := &lora.Location{Latitude: 45.25, Longitude: 19.85, Altitude: 80}
+
+	cases := []struct {
+		desc    string
+		format  string
+		payload string
+		up      lora.Uplink
+		err     error
+	}{
+		{
+			desc:    "decode LoRa Server uplink",
+			format:  lora.FormatLoRaServer,
+			payload: `{"applicationID":"1","devEUI":"01020304050607AB","rxInfo":[{"gatewayID":"gw","rssi":-50,"loRaSNR":7.5,"latitude":45.25,"longitude":19.85,"altitude":80}],"fCnt":3,"fPort":2,"data":"AQI=","object":{"temperature":21.5}}`,
+			up: lora.Uplink{
+				DevEUI:   "01020304050607ab",
+				FPort:    2,
+				FCnt:     3,
+				Data:     []byte{1, 2},
+				Object:   object,
+				Gateways: []lora.Gateway{{ID: "gw", RSSI: -50, SNR: 7.5, Location: location}},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode LoRa Server uplink with gateway MAC",
+			format:  lora.FormatLoRaServer,
+			payload: `{"devEUI":"0102030405060708","rxInfo":[{"mac":"0a0b","rssi":-50,"loRaSNR":7.5}],"fCnt":3,"fPort":2,"data":"AQI="}`,
+			up: lora.Uplink{
+				DevEUI:   devEUI,
+				FPort:    2,
+				FCnt:     3,
+				Data:     []byte{1, 2},
+				Gateways: []lora.Gateway{{ID: "0a0b", RSSI: -50, SNR: 7.5}},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode LoRa Server uplink with device status",
+			format:  lora.FormatLoRaServer,
+			payload: `{"devEUI":"0102030405060708","deviceStatusBattery":127,"deviceStatusMargin":10}`,
+			up: lora.Uplink{
+				DevEUI: devEUI,
+				Status: &lora.DeviceStatus{Battery: float(50), Margin: 10},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode LoRa Server uplink with external power source",
+			format:  lora.FormatLoRaServer,
+			payload: `{"devEUI":"0102030405060708","deviceStatusBattery":0,"deviceStatusMargin":10}`,
+			up: lora.Uplink{
+				DevEUI: devEUI,
+				Status: &lora.DeviceStatus{Margin: 10},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode LoRa Server uplink without device EUI",
+			format:  lora.FormatLoRaServer,
+			payload: `{"fPort":2,"data":"AQI="}`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode malformed LoRa Server uplink",
+			format:  lora.FormatLoRaServer,
+			payload: `{"devEUI":`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode ChirpStack v3 uplink",
+			format:  lora.FormatChirpStackV3,
+			payload: `{"devEUI":"AQIDBAUGBwg=","rxInfo":[{"gatewayID":"CgsMDQ4PEBE=","rssi":-60,"loRaSNR":5,"location":{"latitude":45.25,"longitude":19.85,"altitude":80}}],"fCnt":3,"fPort":2,"data":"AQI=","objectJSON":"{\"temperature\":21.5}"}`,
+			up: lora.Uplink{
+				DevEUI:   devEUI,
+				FPort:    2,
+				FCnt:     3,
+				Data:     []byte{1, 2},
+				Object:   object,
+				Gateways: []lora.Gateway{{ID: "0a0b0c0d0e0f1011", RSSI: -60, SNR: 5, Location: location}},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode ChirpStack v3 status event",
+			format:  lora.FormatChirpStackV3,
+			payload: `{"devEUI":"AQIDBAUGBwg=","margin":10,"batteryLevel":75.5}`,
+			up: lora.Uplink{
+				DevEUI: devEUI,
+				Status: &lora.DeviceStatus{Battery: float(75.5), Margin: 10},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode ChirpStack v3 status event with unavailable battery level",
+			format:  lora.FormatChirpStackV3,
+			payload: `{"devEUI":"AQIDBAUGBwg=","margin":10,"batteryLevelUnavailable":true}`,
+			up: lora.Uplink{
+				DevEUI: devEUI,
+				Status: &lora.DeviceStatus{Margin: 10},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode ChirpStack v3 uplink with malformed object",
+			format:  lora.FormatChirpStackV3,
+			payload: `{"devEUI":"AQIDBAUGBwg=","objectJSON":"{"}`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode ChirpStack v3 uplink with invalid device EUI",
+			format:  lora.FormatChirpStackV3,
+			payload: `{"devEUI":"01:02:03:04"}`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode ChirpStack v3 uplink without device EUI",
+			format:  lora.FormatChirpStackV3,
+			payload: `{"fPort":2,"data":"AQI="}`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode ChirpStack v4 uplink",
+			format:  lora.FormatChirpStackV4,
+			payload: `{"deviceInfo":{"devEui":"01020304050607AB"},"rxInfo":[{"gatewayId":"0a0b0c0d0e0f1011","rssi":-60,"snr":5,"location":{"latitude":45.25,"longitude":19.85,"altitude":80}}],"fCnt":3,"fPort":2,"data":"AQI=","object":{"temperature":21.5}}`,
+			up: lora.Uplink{
+				DevEUI:   "01020304050607ab",
+				FPort:    2,
+				FCnt:     3,
+				Data:     []byte{1, 2},
+				Object:   object,
+				Gateways: []lora.Gateway{{ID: "0a0b0c0d0e0f1011", RSSI: -60, SNR: 5, Location: location}},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode ChirpStack v4 status event",
+			format:  lora.FormatChirpStackV4,
+			payload: `{"deviceInfo":{"devEui":"0102030405060708"},"margin":10,"batteryLevel":75.5}`,
+			up: lora.Uplink{
+				DevEUI: devEUI,
+				Status: &lora.DeviceStatus{Battery: float(75.5), Margin: 10},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode ChirpStack v4 status event with external power source",
+			format:  lora.FormatChirpStackV4,
+			payload: `{"deviceInfo":{"devEui":"0102030405060708"},"margin":10,"externalPowerSource":true}`,
+			up: lora.Uplink{
+				DevEUI: devEUI,
+				Status: &lora.DeviceStatus{Margin: 10},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode ChirpStack v4 uplink without device EUI",
+			format:  lora.FormatChirpStackV4,
+			payload: `{"deviceInfo":{},"fPort":2,"data":"AQI="}`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode malformed ChirpStack v4 uplink",
+			format:  lora.FormatChirpStackV4,
+			payload: `[]`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode The Things Stack uplink",
+			format:  lora.FormatTTS,
+			payload: `{"end_device_ids":{"device_id":"dev","dev_eui":"01020304050607AB"},"uplink_message":{"f_port":2,"f_cnt":3,"frm_payload":"AQI=","decoded_payload":{"temperature":21.5},"rx_metadata":[{"gateway_ids":{"gateway_id":"gw"},"rssi":-70,"snr":3,"location":{"latitude":45.25,"longitude":19.85,"altitude":80}}]}}`,
+			up: lora.Uplink{
+				DevEUI:   "01020304050607ab",
+				FPort:    2,
+				FCnt:     3,
+				Data:     []byte{1, 2},
+				Object:   object,
+				Gateways: []lora.Gateway{{ID: "gw", RSSI: -70, SNR: 3, Location: location}},
+			},
+			err: nil,
+		},
+		{
+			desc:    "decode The Things Stack message without uplink",
+			format:  lora.FormatTTS,
+			payload: `{"end_device_ids":{"dev_eui":"0102030405060708"},"join_accept":{}}`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode The Things Stack uplink without device EUI",
+			format:  lora.FormatTTS,
+			payload: `{"end_device_ids":{"device_id":"dev"},"uplink_message":{"f_port":2}}`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode malformed The Things Stack uplink",
+			format:  lora.FormatTTS,
+			payload: `{"end_device_ids":`,
+			up:      lora.Uplink{},
+			err:     lora.ErrMalformedMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		up, err := decoders[tc.format].Decode([]byte(tc.payload))
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.up, up, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.up, up))
+	}
+}
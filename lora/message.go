@@ -2,14 +2,16 @@ package lora
 
 // RxInfo receiver parameters
 type RxInfo []struct {
-	Mac       string  `json:"mac"`
-	Name      string  `json:"name"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Altitude  float64 `json:"altitude"`
-	Time      string  `json:"time"`
-	Rssi      float64 `json:"rssi"`
-	LoRaSNR   float64 `json:"loRaSNR"`
+	GatewayID string    `json:"gatewayID"`
+	Mac       string    `json:"mac"`
+	Name      string    `json:"name"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Altitude  float64   `json:"altitude"`
+	Location  *Location `json:"location"`
+	Time      string    `json:"time"`
+	Rssi      float64   `json:"rssi"`
+	LoRaSNR   float64   `json:"loRaSNR"`
 }
 
 // DataRate lora data rate
@@ -33,12 +35,19 @@ type Message struct {
 	ApplicationName     string      `json:"applicationName"`
 	DeviceName          string      `json:"deviceName"`
 	DevEUI              string      `json:"devEUI"`
-	DeviceStatusBattery string      `json:"deviceStatusBattery"`
-	DeviceStatusMrgin   string      `json:"deviceStatusMargin"`
+	DeviceStatusBattery *float64    `json:"deviceStatusBattery"`
+	DeviceStatusMargin  *int        `json:"deviceStatusMargin"`
 	RxInfo              RxInfo      `json:"rxInfo"`
 	TxInfo              TxInfo      `json:"txInfo"`
 	FCnt                int         `json:"fCnt"`
 	FPort               int         `json:"fPort"`
-	Data                string      `json:"data"`
+	Data                []byte      `json:"data"`
 	Object              interface{} `json:"object"`
 }
+
+// DownlinkMessage lora downlink msg (www.loraserver.io/lora-app-server/integrate/sending-receiving/mqtt/)
+type DownlinkMessage struct {
+	Confirmed bool   `json:"confirmed"`
+	FPort     int    `json:"fPort"`
+	Data      string `json:"data"`
+}
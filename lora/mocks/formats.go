@@ -0,0 +1,47 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/lora"
+)
+
+var _ lora.FormatRepository = (*formatsMock)(nil)
+
+type formatsMock struct {
+	mu      sync.Mutex
+	formats map[string]string
+}
+
+// NewFormatRepository returns in-memory uplink format repository.
+func NewFormatRepository() lora.FormatRepository {
+	return &formatsMock{
+		formats: make(map[string]string),
+	}
+}
+
+func (fm *formatsMock) Save(chanID, format string) error {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	fm.formats[chanID] = format
+	return nil
+}
+
+func (fm *formatsMock) Get(chanID string) (string, error) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	return fm.formats[chanID], nil
+}
+
+func (fm *formatsMock) Remove(chanID string) error {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	delete(fm.formats, chanID)
+	return nil
+}
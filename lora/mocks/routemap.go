@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/lora"
+)
+
+var _ lora.RouteMapRepository = (*routeMapMock)(nil)
+
+type routeMapMock struct {
+	mu      sync.Mutex
+	loraIDs map[string]string
+	mfxIDs  map[string]string
+}
+
+// NewRouteMap returns in-memory route map repository.
+func NewRouteMap() lora.RouteMapRepository {
+	return &routeMapMock{
+		loraIDs: make(map[string]string),
+		mfxIDs:  make(map[string]string),
+	}
+}
+
+func (rm *routeMapMock) Save(mfxID, loraID string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.loraIDs[mfxID] = loraID
+	rm.mfxIDs[loraID] = mfxID
+	return nil
+}
+
+func (rm *routeMapMock) Get(loraID string) (string, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	mfxID, ok := rm.mfxIDs[loraID]
+	if !ok {
+		return "", lora.ErrNotFound
+	}
+	return mfxID, nil
+}
+
+func (rm *routeMapMock) GetLoRaID(mfxID string) (string, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	loraID, ok := rm.loraIDs[mfxID]
+	if !ok {
+		return "", lora.ErrNotFound
+	}
+	return loraID, nil
+}
+
+func (rm *routeMapMock) Remove(mfxID string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	loraID, ok := rm.loraIDs[mfxID]
+	if !ok {
+		return lora.ErrNotFound
+	}
+	delete(rm.loraIDs, mfxID)
+	delete(rm.mfxIDs, loraID)
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mqtt contains the subscriber to the uplinks of the LoRa network
+// servers.
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/lora"
+)
+
+// Uplink topics of the LoRa network servers.
+const (
+	// TopicLoRaServer is LoRa Server and ChirpStack v3 uplink topic.
+	TopicLoRaServer = "application/+/device/+/rx"
+
+	// TopicChirpStackUp is ChirpStack v3 and v4 uplink event topic.
+	TopicChirpStackUp = "application/+/device/+/event/up"
+
+	// TopicChirpStackStatus is ChirpStack v3 and v4 device status event
+	// topic.
+	TopicChirpStackStatus = "application/+/device/+/event/status"
+
+	// TopicTTS is The Things Stack uplink topic.
+	TopicTTS = "v3/+/devices/+/up"
+)
+
+// Topics contains the uplink topics of all the supported network servers.
+var Topics = []string{TopicLoRaServer, TopicChirpStackUp, TopicChirpStackStatus, TopicTTS}
+
+const qos = 0
+
+var (
+	errSubscribeTimeout = errors.New("failed to subscribe due to timeout reached")
+	errMalformedTopic   = errors.New("malformed uplink topic")
+)
+
+// Subscriber represents the LoRa MQTT broker subscriber.
+type Subscriber interface {
+	// Subscribe subscribes to the uplinks published on the given topic.
+	Subscribe(topic string) error
+}
+
+type broker struct {
+	svc     lora.Service
+	client  mqtt.Client
+	timeout time.Duration
+	logger  logger.Logger
+}
+
+// NewBroker returns new LoRa MQTT broker subscriber instance.
+func NewBroker(svc lora.Service, client mqtt.Client, timeout time.Duration, log logger.Logger) Subscriber {
+	return broker{
+		svc:     svc,
+		client:  client,
+		timeout: timeout,
+		logger:  log,
+	}
+}
+
+func (b broker) Subscribe(topic string) error {
+	token := b.client.Subscribe(topic, qos, b.handleMsg)
+	if token.Error() != nil {
+		return token.Error()
+	}
+	ok := token.WaitTimeout(b.timeout)
+	if ok && token.Error() != nil {
+		return token.Error()
+	}
+	if !ok {
+		return errSubscribeTimeout
+	}
+	return nil
+}
+
+// handleMsg forwards the uplink to the service. The application ID is
+// read from the topic, since the decoder depends on the channel route of
+// the application.
+func (b broker) handleMsg(c mqtt.Client, msg mqtt.Message) {
+	appID, err := parseAppID(msg.Topic())
+	if err != nil {
+		b.logger.Warn(fmt.Sprintf("Failed to handle uplink on %s: %s", msg.Topic(), err))
+		return
+	}
+	if err := b.svc.Publish(context.Background(), appID, msg.Payload()); err != nil {
+		b.logger.Warn(fmt.Sprintf("Failed to publish uplink on %s: %s", msg.Topic(), err))
+	}
+}
+
+// parseAppID returns the application ID from ChirpStack topic
+// application/<app_id>/device/..., or The Things Stack topic
+// v3/<app_id>@<tenant_id>/devices/....
+func parseAppID(topic string) (string, error) {
+	parts := strings.Split(topic, "/")
+	if len(parts) < 2 || parts[1] == "" {
+		return "", errMalformedTopic
+	}
+
+	switch parts[0] {
+	case "application":
+		return parts[1], nil
+	case "v3":
+		return strings.Split(parts[1], "@")[0], nil
+	default:
+		return "", errMalformedTopic
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"fmt"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/lora"
+)
+
+const formatPrefix = "channel:format"
+
+var _ lora.FormatRepository = (*formatRepository)(nil)
+
+type formatRepository struct {
+	client *redis.Client
+}
+
+// NewFormatRepository returns redis channel uplink format repository.
+func NewFormatRepository(client *redis.Client) lora.FormatRepository {
+	return &formatRepository{
+		client: client,
+	}
+}
+
+func (fr *formatRepository) Save(chanID, format string) error {
+	key := fmt.Sprintf("%s:%s", formatPrefix, chanID)
+	if format == "" {
+		return fr.client.Del(key).Err()
+	}
+
+	return fr.client.Set(key, format, 0).Err()
+}
+
+func (fr *formatRepository) Get(chanID string) (string, error) {
+	key := fmt.Sprintf("%s:%s", formatPrefix, chanID)
+	format, err := fr.client.Get(key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+
+	return format, err
+}
+
+func (fr *formatRepository) Remove(chanID string) error {
+	key := fmt.Sprintf("%s:%s", formatPrefix, chanID)
+	return fr.client.Del(key).Err()
+}
@@ -5,6 +5,7 @@ package redis
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-redis/redis"
 	"github.com/mainflux/mainflux/lora"
@@ -46,6 +47,9 @@ func (mr *routerMap) Save(mfxID, loraID string) error {
 func (mr *routerMap) Get(mfxID string) (string, error) {
 	lKey := fmt.Sprintf("%s:%s:%s", mr.prefix, loraMapPrefix, mfxID)
 	mval, err := mr.client.Get(lKey).Result()
+	if err == redis.Nil {
+		return "", lora.ErrNotFound
+	}
 	if err != nil {
 		return "", err
 	}
@@ -53,6 +57,19 @@ func (mr *routerMap) Get(mfxID string) (string, error) {
 	return mval, nil
 }
 
+func (mr *routerMap) GetLoRaID(mfxID string) (string, error) {
+	mKey := fmt.Sprintf("%s:%s:%s", mr.prefix, mfxMapPrefix, mfxID)
+	lval, err := mr.client.Get(mKey).Result()
+	if err == redis.Nil {
+		return "", lora.ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return lval, nil
+}
+
 func (mr *routerMap) Remove(mfxID string) error {
 	mkey := fmt.Sprintf("%s:%s:%s", mr.prefix, mfxMapPrefix, mfxID)
 	lval, err := mr.client.Get(mkey).Result()
@@ -63,3 +80,36 @@ func (mr *routerMap) Remove(mfxID string) error {
 	lkey := fmt.Sprintf("%s:%s:%s", mr.prefix, loraMapPrefix, lval)
 	return mr.client.Del(mkey, lkey).Err()
 }
+
+// MigrateLoRaIDs converts the LoRa IDs of the route maps with the given
+// prefix to lower case. It migrates the device EUIs saved before they were
+// normalized, which are otherwise missed on the lookup of the decoded uplinks.
+func MigrateLoRaIDs(client *redis.Client, prefix string) error {
+	mprefix := fmt.Sprintf("%s:%s:", prefix, mfxMapPrefix)
+	iter := client.Scan(0, mprefix+"*", 0).Iterator()
+	for iter.Next() {
+		mkey := iter.Val()
+		lval, err := client.Get(mkey).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		lower := strings.ToLower(lval)
+		if lower == lval {
+			continue
+		}
+
+		mfxID := strings.TrimPrefix(mkey, mprefix)
+		if err := NewRouteMapRepository(client, prefix).Save(mfxID, lower); err != nil {
+			return err
+		}
+		lkey := fmt.Sprintf("%s:%s:%s", prefix, loraMapPrefix, lval)
+		if err := client.Del(lkey).Err(); err != nil {
+			return err
+		}
+	}
+
+	return iter.Err()
+}
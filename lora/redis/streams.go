@@ -14,6 +14,7 @@ const (
 	keyType   = "lora"
 	keyDevEUI = "dev_eui"
 	keyAppID  = "app_id"
+	keyFormat = "format"
 
 	group  = "mainflux.lora"
 	stream = "mainflux.things"
@@ -39,6 +40,8 @@ var (
 	errMetadataAppID = errors.New("application ID not found in channel metadatada")
 
 	errMetadataDevEUI = errors.New("device EUI not found in thing metadatada")
+
+	errMetadataUplinkFormat = errors.New("uplink format in channel metadata is not a string")
 )
 
 // Subscriber represents event source for things and channels provisioning.
@@ -193,6 +196,15 @@ func decodeCreateChannel(event map[string]interface{}) (createChannelEvent, erro
 	}
 
 	cce.loraAppID = val
+
+	if f, ok := lm[keyFormat]; ok {
+		format, ok := f.(string)
+		if !ok {
+			return createChannelEvent{}, errMetadataUplinkFormat
+		}
+		cce.format = format
+	}
+
 	return cce, nil
 }
 
@@ -211,7 +223,7 @@ func (es eventStore) handleRemoveThing(rte removeThingEvent) error {
 }
 
 func (es eventStore) handleCreateChannel(cce createChannelEvent) error {
-	return es.svc.CreateChannel(cce.id, cce.loraAppID)
+	return es.svc.CreateChannel(cce.id, cce.loraAppID, cce.format)
 }
 
 func (es eventStore) handleRemoveChannel(rce removeChannelEvent) error {
@@ -8,9 +8,14 @@ type RouteMapRepository interface {
 	// Save stores/routes pair lora application topic & mainflux channel.
 	Save(string, string) error
 
-	// Channel returns mainflux channel for given lora application.
+	// Channel returns mainflux channel for given lora application, or
+	// ErrNotFound if the route map doesn't exist.
 	Get(string) (string, error)
 
+	// GetLoRaID returns lora application or device for given mainflux
+	// channel or thing, or ErrNotFound if the route map doesn't exist.
+	GetLoRaID(string) (string, error)
+
 	// Removes mapping from cache.
 	Remove(string) error
 }
@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"encoding/json"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const (
+	things       = "things"
+	errorsSuffix = "errors"
+)
+
+// PublishError represents the structured error message sent to the thing
+// when the published message is lost.
+type PublishError struct {
+	Topic   string `json:"topic"`
+	Channel string `json:"channel,omitempty"`
+	Error   string `json:"error"`
+	Created int64  `json:"created"`
+}
+
+// Feedback specifies the API for reporting publish errors back to things.
+type Feedback interface {
+	// Report sends the publish error to the error topic of the thing.
+	Report(thingID string, perr PublishError) error
+}
+
+type feedback struct {
+	pub messaging.Publisher
+}
+
+// NewFeedback returns new Feedback instance which publishes errors using
+// the given publisher. If the publisher is nil, errors are not sent.
+func NewFeedback(pub messaging.Publisher) Feedback {
+	return feedback{pub: pub}
+}
+
+func (f feedback) Report(thingID string, perr PublishError) error {
+	if f.pub == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(perr)
+	if err != nil {
+		return err
+	}
+	msg := messaging.Message{
+		Publisher: thingID,
+		Protocol:  protocol,
+		Payload:   payload,
+		Created:   perr.Created,
+	}
+
+	return f.pub.Publish(ErrorsTopic(thingID), msg)
+}
+
+// ErrorsTopic returns the topic the publish errors of the thing are sent to.
+func ErrorsTopic(thingID string) string {
+	return things + "/" + thingID + "/" + errorsSuffix
+}
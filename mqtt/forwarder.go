@@ -45,12 +45,7 @@ func (f forwarder) handle(pub messaging.Publisher) messaging.MessageHandler {
 		if msg.Protocol == protocol {
 			return nil
 		}
-		// Use concatenation instead of mft.Sprintf for the
-		// sake of simplicity and performance.
-		topic := channels + "/" + msg.Channel + "/" + messages
-		if msg.Subtopic != "" {
-			topic += "/" + strings.ReplaceAll(msg.Subtopic, ".", "/")
-		}
+		topic := mqttTopic(msg)
 		go func() {
 			if err := pub.Publish(topic, msg); err != nil {
 				f.logger.Warn(fmt.Sprintf("Failed to forward message: %s", err))
@@ -59,3 +54,14 @@ func (f forwarder) handle(pub messaging.Publisher) messaging.MessageHandler {
 		return nil
 	}
 }
+
+// mqttTopic returns MQTT topic of the message.
+func mqttTopic(msg messaging.Message) string {
+	// Use concatenation instead of mft.Sprintf for the
+	// sake of simplicity and performance.
+	topic := channels + "/" + msg.Channel + "/" + messages
+	if msg.Subtopic != "" {
+		topic += "/" + strings.ReplaceAll(msg.Subtopic, ".", "/")
+	}
+	return topic
+}
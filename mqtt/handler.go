@@ -4,6 +4,7 @@
 package mqtt
 
 import (
+	"encoding/base64"
 	"errors"
 	"net/url"
 	"regexp"
@@ -11,15 +12,23 @@ import (
 	"time"
 
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/mqtt/proxy"
 	"github.com/mainflux/mainflux/mqtt/redis"
 	"github.com/mainflux/mainflux/pkg/auth"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mproxy/pkg/session"
 )
 
-var _ session.Handler = (*handler)(nil)
+var _ proxy.Handler = (*handler)(nil)
 
-const protocol = "mqtt"
+const (
+	protocol = "mqtt"
+
+	// Message headers set by the handler.
+	clientIDHeader        = "client_id"
+	responseTopicHeader   = "response_topic"
+	correlationDataHeader = "correlation_data"
+)
 
 var (
 	channelRegExp         = regexp.MustCompile(`^\/?channels\/([\w\-]+)\/messages(\/[^?]*)?(\?.*)?$`)
@@ -31,6 +40,7 @@ var (
 	errInvalidConnect     = errors.New("CONNECT request with invalid username or client ID")
 	errNilTopicPub        = errors.New("PUBLISH to nil topic")
 	errNilTopicSub        = errors.New("SUB to nil topic")
+	errFailedPublish      = errors.New("failed to publish message")
 )
 
 // Event implements events.Event interface
@@ -39,16 +49,22 @@ type handler struct {
 	auth       auth.Client
 	logger     logger.Logger
 	es         redis.EventStore
+	feedback   Feedback
+	retained   messaging.RetainedRepository
 }
 
-// NewHandler creates new Handler entity
+// NewHandler creates new Handler entity. Publish errors are reported
+// back to the thing using the given feedback, and the last messages of
+// the subscribed topics are read from the retained repository.
 func NewHandler(publishers []messaging.Publisher, es redis.EventStore,
-	logger logger.Logger, auth auth.Client) session.Handler {
+	logger logger.Logger, auth auth.Client, feedback Feedback, retained messaging.RetainedRepository) proxy.Handler {
 	return &handler{
 		es:         es,
 		logger:     logger,
 		publishers: publishers,
 		auth:       auth,
+		feedback:   feedback,
+		retained:   retained,
 	}
 }
 
@@ -85,7 +101,14 @@ func (h *handler) AuthPublish(c *session.Client, topic *string, payload *[]byte)
 		return errNilTopicPub
 	}
 
-	return h.authAccess(c.Username, *topic)
+	err := h.authAccess(c.Username, *topic)
+	// The message sent to the malformed topic never reaches the broker, so
+	// the thing is notified before the message is rejected.
+	if err == errMalformedTopic {
+		h.report(c, *topic, "", err)
+	}
+
+	return err
 }
 
 // AuthSubscribe is called on device publish,
@@ -99,6 +122,10 @@ func (h *handler) AuthSubscribe(c *session.Client, topics *[]string) error {
 	}
 
 	for _, v := range *topics {
+		// Thing is allowed to subscribe to its own errors topic.
+		if v == ErrorsTopic(c.Username) {
+			continue
+		}
 		if err := h.authAccess(c.Username, v); err != nil {
 			return err
 		}
@@ -124,13 +151,66 @@ func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
 		return
 	}
 	h.logger.Info("Publish - client ID " + c.ID + " to the topic: " + *topic)
+
+	// MQTT 3.1.1 has no content type, so consumers fall back to their
+	// configured format.
+	h.publish(c, *topic, *payload, "", map[string]string{clientIDHeader: c.ID})
+}
+
+// PublishV5 - after MQTT 5 client successfully published
+func (h *handler) PublishV5(c *session.Client, topic *string, payload *[]byte, props proxy.Properties) {
+	if c == nil {
+		h.logger.Error("Nil client publish")
+		return
+	}
+	h.logger.Info("Publish - MQTT 5 client ID " + c.ID + " to the topic: " + *topic)
+
+	headers := make(map[string]string, len(props.User)+3)
+	for k, v := range props.User {
+		headers[k] = v
+	}
+	headers[clientIDHeader] = c.ID
+	if props.ResponseTopic != "" {
+		headers[responseTopicHeader] = props.ResponseTopic
+	}
+	if len(props.CorrelationData) > 0 {
+		headers[correlationDataHeader] = base64.StdEncoding.EncodeToString(props.CorrelationData)
+	}
+
+	h.publish(c, *topic, *payload, props.ContentType, headers)
+}
+
+func (h *handler) publish(c *session.Client, topic string, payload []byte, contentType string, headers map[string]string) {
+	chanID, err := h.forward(c, topic, payload, contentType, headers)
+	if err == nil {
+		return
+	}
+	h.report(c, topic, chanID, err)
+}
+
+// report sends the error of the message the thing published to the topic
+// to the errors topic of the thing.
+func (h *handler) report(c *session.Client, topic, chanID string, err error) {
+	perr := PublishError{
+		Topic:   topic,
+		Channel: chanID,
+		Error:   err.Error(),
+		Created: time.Now().UnixNano(),
+	}
+	if err := h.feedback.Report(c.Username, perr); err != nil {
+		h.logger.Warn("Failed to report publish error to the thing " + c.Username + ": " + err.Error())
+	}
+}
+
+// forward publishes the message to Mainflux and returns the channel ID
+// parsed from the topic.
+func (h *handler) forward(c *session.Client, topic string, payload []byte, contentType string, headers map[string]string) (string, error) {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
-
-	channelParts := channelRegExp.FindStringSubmatch(*topic)
+	channelParts := channelRegExp.FindStringSubmatch(topic)
 	if len(channelParts) < 1 {
-		h.logger.Info("Error in mqtt publish %s" + errMalformedData.Error())
-		return
+		h.logger.Warn("Error in mqtt publish: " + errMalformedTopic.Error())
+		return "", errMalformedTopic
 	}
 
 	chanID := channelParts[1]
@@ -138,24 +218,30 @@ func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
 
 	subtopic, err := parseSubtopic(subtopic)
 	if err != nil {
-		h.logger.Info("Error parsing subtopic: " + err.Error())
-		return
+		h.logger.Warn("Error parsing subtopic: " + err.Error())
+		return chanID, errMalformedSubtopic
 	}
 
 	msg := messaging.Message{
-		Protocol:  protocol,
-		Channel:   chanID,
-		Subtopic:  subtopic,
-		Publisher: c.Username,
-		Payload:   *payload,
-		Created:   time.Now().UnixNano(),
+		Protocol:    protocol,
+		Channel:     chanID,
+		Subtopic:    subtopic,
+		Publisher:   c.Username,
+		Payload:     payload,
+		ContentType: contentType,
+		Headers:     headers,
+		Created:     time.Now().UnixNano(),
 	}
 
+	var ret error
 	for _, pub := range h.publishers {
 		if err := pub.Publish(msg.Channel, msg); err != nil {
-			h.logger.Info("Error publishing to Mainflux " + err.Error())
+			h.logger.Warn("Error publishing to Mainflux " + err.Error())
+			ret = errFailedPublish
 		}
 	}
+
+	return chanID, ret
 }
 
 // Subscribe - after client successfully subscribed
@@ -176,6 +262,47 @@ func (h *handler) Unsubscribe(c *session.Client, topics *[]string) {
 	h.logger.Info("Unsubscribe - client ID: " + c.ID + ", form topics: " + strings.Join(*topics, ","))
 }
 
+// Retained - last messages of the subscribed topics
+func (h *handler) Retained(c *session.Client, topics []string) []proxy.Message {
+	msgs := []proxy.Message{}
+	for _, topic := range topics {
+		channelParts := channelRegExp.FindStringSubmatch(topic)
+		if len(channelParts) < 1 {
+			continue
+		}
+		chanID := channelParts[1]
+
+		// MQTT wildcards are converted to NATS ones.
+		filter := strings.NewReplacer("+", "*", "#", ">").Replace(channelParts[2])
+		subtopic, err := parseSubtopic(filter)
+		if err != nil {
+			continue
+		}
+		subtopics := []string{subtopic}
+		// Multi-level wildcard matches the parent level too.
+		if subtopic == ">" || strings.HasSuffix(subtopic, ".>") {
+			subtopics = append(subtopics, strings.TrimSuffix(strings.TrimSuffix(subtopic, ">"), "."))
+		}
+
+		for _, st := range subtopics {
+			retained, err := h.retained.Retrieve(chanID, st)
+			if err != nil {
+				h.logger.Warn("Failed to retrieve retained messages: " + err.Error())
+				continue
+			}
+			for _, m := range retained {
+				msgs = append(msgs, proxy.Message{
+					Topic:       mqttTopic(m),
+					Payload:     m.Payload,
+					ContentType: m.ContentType,
+				})
+			}
+		}
+	}
+
+	return msgs
+}
+
 // Disconnect - connection with broker or client lost
 func (h *handler) Disconnect(c *session.Client) {
 	if c == nil {
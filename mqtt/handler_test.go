@@ -0,0 +1,258 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/mqtt"
+	"github.com/mainflux/mainflux/mqtt/proxy"
+	"github.com/mainflux/mainflux/mqtt/redis"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/mocks"
+	"github.com/mainflux/mproxy/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errPublish = errors.New("failed to publish")
+
+type publisher struct {
+	msgs   []messaging.Message
+	topics []string
+	err    error
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.msgs = append(p.msgs, msg)
+	p.topics = append(p.topics, topic)
+	return nil
+}
+
+func newLogger(t *testing.T) logger.Logger {
+	logger, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return logger
+}
+
+func TestPublish(t *testing.T) {
+	client := &session.Client{ID: "client", Username: "thing"}
+	topic := "channels/1/messages/temperature"
+	payload := []byte("payload")
+
+	cases := []struct {
+		desc        string
+		v5          bool
+		props       proxy.Properties
+		contentType string
+		headers     map[string]string
+	}{
+		{
+			desc:    "publish using MQTT 3.1.1",
+			headers: map[string]string{"client_id": "client"},
+		},
+		{
+			desc: "publish using MQTT 5",
+			v5:   true,
+			props: proxy.Properties{
+				ContentType:     "application/senml+json",
+				ResponseTopic:   "channels/1/messages/response",
+				CorrelationData: []byte("id"),
+				User:            map[string]string{"key": "value"},
+			},
+			contentType: "application/senml+json",
+			headers: map[string]string{
+				"client_id":        "client",
+				"response_topic":   "channels/1/messages/response",
+				"correlation_data": "aWQ=",
+				"key":              "value",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		pub := &publisher{}
+		h := mqtt.NewHandler([]messaging.Publisher{pub}, redis.EventStore{}, newLogger(t), nil, mqtt.NewFeedback(nil), mocks.NewRetainedRepository())
+		tp, pl := topic, payload
+		if tc.v5 {
+			h.PublishV5(client, &tp, &pl, tc.props)
+		} else {
+			h.Publish(client, &tp, &pl)
+		}
+
+		require.Len(t, pub.msgs, 1, fmt.Sprintf("%s: expected one published message", tc.desc))
+		msg := pub.msgs[0]
+		assert.Equal(t, "1", msg.Channel, fmt.Sprintf("%s: expected channel 1 got %s", tc.desc, msg.Channel))
+		assert.Equal(t, "temperature", msg.Subtopic, fmt.Sprintf("%s: expected subtopic temperature got %s", tc.desc, msg.Subtopic))
+		assert.Equal(t, tc.contentType, msg.ContentType, fmt.Sprintf("%s: expected content type %s got %s", tc.desc, tc.contentType, msg.ContentType))
+		assert.Equal(t, tc.headers, msg.Headers, fmt.Sprintf("%s: expected headers %v got %v", tc.desc, tc.headers, msg.Headers))
+	}
+}
+
+func TestAuthPublishFeedback(t *testing.T) {
+	client := &session.Client{ID: "client", Username: "thing"}
+	topic := "channels/1"
+	payload := []byte("payload")
+
+	fbPub := &publisher{}
+	h := mqtt.NewHandler(nil, redis.EventStore{}, newLogger(t), nil, mqtt.NewFeedback(fbPub), mocks.NewRetainedRepository())
+	err := h.AuthPublish(client, &topic, &payload)
+	assert.NotNil(t, err, "publish to malformed topic: expected error")
+
+	require.Len(t, fbPub.msgs, 1, "publish to malformed topic: expected one feedback message")
+	assert.Equal(t, mqtt.ErrorsTopic("thing"), fbPub.topics[0], fmt.Sprintf("publish to malformed topic: expected topic %s got %s", mqtt.ErrorsTopic("thing"), fbPub.topics[0]))
+
+	var perr mqtt.PublishError
+	err = json.Unmarshal(fbPub.msgs[0].Payload, &perr)
+	require.Nil(t, err, fmt.Sprintf("publish to malformed topic: unexpected error: %s", err))
+	perr.Created = 0
+	expected := mqtt.PublishError{Topic: topic, Error: "malformed topic"}
+	assert.Equal(t, expected, perr, fmt.Sprintf("publish to malformed topic: expected error %v got %v", expected, perr))
+}
+
+func TestPublishFeedback(t *testing.T) {
+	client := &session.Client{ID: "client", Username: "thing"}
+	payload := []byte("payload")
+
+	cases := []struct {
+		desc    string
+		topic   string
+		err     error
+		perr    mqtt.PublishError
+		success bool
+	}{
+		{
+			desc:    "publish successfully",
+			topic:   "channels/1/messages",
+			success: true,
+		},
+		{
+			desc:  "publish to malformed topic",
+			topic: "channels/1",
+			perr: mqtt.PublishError{
+				Topic: "channels/1",
+				Error: "malformed topic",
+			},
+		},
+		{
+			desc:  "publish to malformed subtopic",
+			topic: "channels/1/messages/%",
+			perr: mqtt.PublishError{
+				Topic:   "channels/1/messages/%",
+				Channel: "1",
+				Error:   "malformed subtopic",
+			},
+		},
+		{
+			desc:  "publish with failing publisher",
+			topic: "channels/1/messages",
+			err:   errPublish,
+			perr: mqtt.PublishError{
+				Topic:   "channels/1/messages",
+				Channel: "1",
+				Error:   "failed to publish message",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		fbPub := &publisher{}
+		h := mqtt.NewHandler([]messaging.Publisher{&publisher{err: tc.err}}, redis.EventStore{}, newLogger(t), nil, mqtt.NewFeedback(fbPub), mocks.NewRetainedRepository())
+		tp, pl := tc.topic, payload
+		h.Publish(client, &tp, &pl)
+
+		if tc.success {
+			assert.Empty(t, fbPub.msgs, fmt.Sprintf("%s: expected no feedback", tc.desc))
+			continue
+		}
+		require.Len(t, fbPub.msgs, 1, fmt.Sprintf("%s: expected one feedback message", tc.desc))
+		assert.Equal(t, mqtt.ErrorsTopic("thing"), fbPub.topics[0], fmt.Sprintf("%s: expected topic %s got %s", tc.desc, mqtt.ErrorsTopic("thing"), fbPub.topics[0]))
+
+		var perr mqtt.PublishError
+		err := json.Unmarshal(fbPub.msgs[0].Payload, &perr)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.NotZero(t, perr.Created, fmt.Sprintf("%s: expected created to be set", tc.desc))
+		perr.Created = 0
+		assert.Equal(t, tc.perr, perr, fmt.Sprintf("%s: expected error %v got %v", tc.desc, tc.perr, perr))
+	}
+}
+
+func TestAuthSubscribeErrorsTopic(t *testing.T) {
+	h := mqtt.NewHandler(nil, redis.EventStore{}, newLogger(t), nil, mqtt.NewFeedback(nil), mocks.NewRetainedRepository())
+	client := &session.Client{ID: "client", Username: "thing"}
+	topics := []string{mqtt.ErrorsTopic("thing")}
+
+	err := h.AuthSubscribe(client, &topics)
+	assert.Nil(t, err, fmt.Sprintf("subscribe to own errors topic: unexpected error: %s", err))
+}
+
+func TestRetained(t *testing.T) {
+	retained := mocks.NewRetainedRepository()
+	msgs := []messaging.Message{
+		{Channel: "1", Payload: []byte("root")},
+		{Channel: "1", Subtopic: "a", Payload: []byte("a")},
+		{Channel: "1", Subtopic: "a.b", Payload: []byte("a.b"), ContentType: "application/senml+json"},
+		{Channel: "2", Subtopic: "a", Payload: []byte("other")},
+	}
+	for _, msg := range msgs {
+		err := retained.Save(msg)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	h := mqtt.NewHandler(nil, redis.EventStore{}, newLogger(t), nil, mqtt.NewFeedback(nil), retained)
+	client := &session.Client{ID: "client", Username: "thing"}
+
+	cases := []struct {
+		desc  string
+		topic string
+		msgs  []proxy.Message
+	}{
+		{
+			desc:  "retained message of channel",
+			topic: "channels/1/messages",
+			msgs:  []proxy.Message{{Topic: "channels/1/messages", Payload: []byte("root")}},
+		},
+		{
+			desc:  "retained message of subtopic",
+			topic: "channels/1/messages/a/b",
+			msgs:  []proxy.Message{{Topic: "channels/1/messages/a/b", Payload: []byte("a.b"), ContentType: "application/senml+json"}},
+		},
+		{
+			desc:  "retained messages of single-level wildcard",
+			topic: "channels/1/messages/+",
+			msgs:  []proxy.Message{{Topic: "channels/1/messages/a", Payload: []byte("a")}},
+		},
+		{
+			desc:  "retained messages of multi-level wildcard",
+			topic: "channels/1/messages/a/#",
+			msgs: []proxy.Message{
+				{Topic: "channels/1/messages/a/b", Payload: []byte("a.b"), ContentType: "application/senml+json"},
+				{Topic: "channels/1/messages/a", Payload: []byte("a")},
+			},
+		},
+		{
+			desc:  "retained message of subtopic without messages",
+			topic: "channels/1/messages/c",
+			msgs:  []proxy.Message{},
+		},
+		{
+			desc:  "retained message of errors topic",
+			topic: mqtt.ErrorsTopic("thing"),
+			msgs:  []proxy.Message{},
+		},
+	}
+
+	for _, tc := range cases {
+		msgs := h.Retained(client, []string{tc.topic})
+		assert.ElementsMatch(t, tc.msgs, msgs, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.msgs, msgs))
+	}
+}
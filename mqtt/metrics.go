@@ -0,0 +1,31 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package mqtt
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ Feedback = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	fb      Feedback
+}
+
+// MetricsMiddleware instruments feedback by counting publish errors per thing.
+func MetricsMiddleware(fb Feedback, counter metrics.Counter) Feedback {
+	return &metricsMiddleware{
+		counter: counter,
+		fb:      fb,
+	}
+}
+
+func (mm *metricsMiddleware) Report(thingID string, perr PublishError) error {
+	defer mm.counter.With("thing_id", thingID, "error", perr.Error).Add(1)
+
+	return mm.fb.Report(thingID, perr)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"net"
+	"sync"
+
+	"github.com/eclipse/paho.golang/packets"
+)
+
+// Sent by MQTT 5 client which wants the will message to be published.
+const disconnectWithWill = 0x04
+
+// packetConn is client connection which reads a whole packet at a time,
+// so that the DISCONNECT packet can be detected, and serializes writes,
+// so that the proxy can write to the client along with the session.
+type packetConn struct {
+	net.Conn
+	buf []byte
+
+	// mu guards disconnect which is read after the session ends.
+	mu         sync.Mutex
+	disconnect []byte
+
+	wmu sync.Mutex
+}
+
+// newPacketConn returns packetConn which replays the already read packet.
+func newPacketConn(conn net.Conn, pkt []byte) *packetConn {
+	return &packetConn{
+		Conn: conn,
+		buf:  pkt,
+	}
+}
+
+func (c *packetConn) Read(b []byte) (int, error) {
+	if len(c.buf) == 0 {
+		pkt, err := readRaw(c.Conn)
+		if err != nil {
+			return 0, err
+		}
+		if pkt[0]>>4 == packets.DISCONNECT {
+			c.mu.Lock()
+			c.disconnect = pkt
+			c.mu.Unlock()
+		}
+		c.buf = pkt
+	}
+
+	n := copy(b, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (c *packetConn) Write(b []byte) (int, error) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	return c.Conn.Write(b)
+}
+
+// willExpected reports whether the session ended in a way which requires
+// the will message to be published.
+func (c *packetConn) willExpected(version byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.disconnect == nil {
+		return true
+	}
+	if version != v5 {
+		return false
+	}
+
+	cp, err := packets.ReadPacket(bytes.NewReader(c.disconnect))
+	if err != nil {
+		return true
+	}
+	return cp.Content.(*packets.Disconnect).ReasonCode == disconnectWithWill
+}
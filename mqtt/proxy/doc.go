@@ -0,0 +1,9 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package proxy contains MQTT proxy that supports both MQTT 3.1.1 and
+// MQTT 5 clients. MQTT 3.1.1 sessions are handled by mProxy, while MQTT 5
+// sessions are handled by the proxy itself so that the packet properties
+// can be passed to the handler. The sessions are proxied the same way over
+// TCP and WebSocket.
+package proxy
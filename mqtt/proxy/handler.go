@@ -0,0 +1,140 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"sync/atomic"
+
+	"github.com/eclipse/paho.golang/packets"
+	mqtt3 "github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mproxy/pkg/session"
+)
+
+// Properties contains MQTT 5 PUBLISH properties.
+type Properties struct {
+	// ContentType is the content type of the payload.
+	ContentType string
+
+	// ResponseTopic is the topic the response to the request is expected on.
+	ResponseTopic string
+
+	// CorrelationData is used by the requester to match the response.
+	CorrelationData []byte
+
+	// User contains user properties. If the same key is sent more than
+	// once, the last value is used.
+	User map[string]string
+}
+
+// Message is the message sent to the client by the proxy.
+type Message struct {
+	Topic       string
+	Payload     []byte
+	ContentType string
+}
+
+// Handler extends mProxy handler with MQTT 5 hooks.
+type Handler interface {
+	session.Handler
+
+	// PublishV5 is called instead of Publish after MQTT 5 client
+	// successfully published.
+	PublishV5(client *session.Client, topic *string, payload *[]byte, props Properties)
+
+	// Retained returns retained messages sent to the client after it
+	// successfully subscribed to the topics.
+	Retained(client *session.Client, topics []string) []Message
+}
+
+func newProperties(p *packets.Properties) Properties {
+	if p == nil {
+		return Properties{}
+	}
+
+	props := Properties{
+		ContentType:     p.ContentType,
+		ResponseTopic:   p.ResponseTopic,
+		CorrelationData: p.CorrelationData,
+	}
+	if len(p.User) > 0 {
+		props.User = make(map[string]string, len(p.User))
+		for _, u := range p.User {
+			props.User[u.Key] = u.Value
+		}
+	}
+
+	return props
+}
+
+// sessionHandler decorates the handler of a single session, so that the
+// retained messages are sent to the client after it subscribed.
+type sessionHandler struct {
+	Handler
+	conn      *packetConn
+	version   byte
+	logger    logger.Logger
+	connected int32
+}
+
+func (sh *sessionHandler) Connect(c *session.Client) {
+	atomic.StoreInt32(&sh.connected, 1)
+	sh.Handler.Connect(c)
+}
+
+func (sh *sessionHandler) Subscribe(c *session.Client, topics *[]string) {
+	sh.Handler.Subscribe(c, topics)
+
+	for _, msg := range sh.Handler.Retained(c, *topics) {
+		if err := sh.send(msg); err != nil {
+			sh.logger.Warn("Failed to send retained message to client " + c.ID + ": " + err.Error())
+			return
+		}
+	}
+}
+
+// publishWill publishes the will message of the client which was connected
+// and didn't disconnect gracefully.
+func (sh *sessionHandler) publishWill(c *session.Client, w *will) {
+	if w == nil || atomic.LoadInt32(&sh.connected) == 0 || !sh.conn.willExpected(sh.version) {
+		return
+	}
+	if err := sh.Handler.AuthPublish(c, &w.topic, &w.payload); err != nil {
+		sh.logger.Warn("Will message of client " + c.ID + " not published: " + err.Error())
+		return
+	}
+
+	if sh.version == v5 {
+		sh.Handler.PublishV5(c, &w.topic, &w.payload, w.props)
+		return
+	}
+	sh.Handler.Publish(c, &w.topic, &w.payload)
+}
+
+func (sh *sessionHandler) send(msg Message) error {
+	var buf bytes.Buffer
+	if sh.version == v5 {
+		pub := &packets.Publish{
+			Topic:      msg.Topic,
+			Payload:    msg.Payload,
+			Retain:     true,
+			Properties: &packets.Properties{ContentType: msg.ContentType},
+		}
+		if _, err := pub.WriteTo(&buf); err != nil {
+			return err
+		}
+	} else {
+		pub := mqtt3.NewControlPacket(mqtt3.Publish).(*mqtt3.PublishPacket)
+		pub.TopicName = msg.Topic
+		pub.Payload = msg.Payload
+		pub.Retain = true
+		if err := pub.Write(&buf); err != nil {
+			return err
+		}
+	}
+
+	_, err := sh.conn.Write(buf.Bytes())
+	return err
+}
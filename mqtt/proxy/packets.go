@@ -0,0 +1,77 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"io"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	connectType = 1
+	v5          = 5
+
+	// Remaining length is encoded using at most four bytes.
+	maxLengthBytes = 4
+)
+
+var errMalformedLength = errors.New("malformed remaining length")
+
+// readRaw reads a single MQTT control packet, including the fixed header,
+// without decoding it.
+func readRaw(r io.Reader) ([]byte, error) {
+	buf := make([]byte, 1, 1+maxLengthBytes)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+
+	length, multiplier := 0, 1
+	b := make([]byte, 1)
+	for i := 0; ; i++ {
+		if i == maxLengthBytes {
+			return nil, errMalformedLength
+		}
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		buf = append(buf, b[0])
+		length += int(b[0]&0x7f) * multiplier
+		if b[0]&0x80 == 0 {
+			break
+		}
+		multiplier *= 128
+	}
+
+	pkt := make([]byte, len(buf)+length)
+	copy(pkt, buf)
+	if _, err := io.ReadFull(r, pkt[len(buf):]); err != nil {
+		return nil, err
+	}
+
+	return pkt, nil
+}
+
+// version returns the protocol level of the raw CONNECT packet,
+// or 0 if the packet is too short.
+func version(connect []byte) byte {
+	// Skip the fixed header.
+	i := 1
+	for i < len(connect) && connect[i]&0x80 != 0 {
+		i++
+	}
+	i++
+
+	// Skip the protocol name.
+	if i+2 > len(connect) {
+		return 0
+	}
+	n := int(connect[i])<<8 | int(connect[i+1])
+	i += 2 + n
+	if i >= len(connect) {
+		return 0
+	}
+
+	return connect[i]
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mproxy/pkg/session"
+	mptls "github.com/mainflux/mproxy/pkg/tls"
+)
+
+var (
+	errCreateListener = errors.New("failed creating TLS listener")
+	errFirstPacket    = errors.New("first packet is not CONNECT")
+)
+
+// Proxy is MQTT proxy which forwards MQTT 5 sessions to its own session
+// implementation and all the other sessions to mProxy.
+type Proxy struct {
+	address string
+	target  string
+	handler Handler
+	logger  logger.Logger
+	dialer  net.Dialer
+}
+
+// New returns a new Proxy instance.
+func New(address, target string, handler Handler, logger logger.Logger) *Proxy {
+	return &Proxy{
+		address: address,
+		target:  target,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Listen of the server, this will block.
+func (p Proxy) Listen() error {
+	l, err := net.Listen("tcp", p.address)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	p.accept(l)
+
+	p.logger.Info("Server Exiting...")
+	return nil
+}
+
+// ListenTLS - version of Listen with TLS encryption.
+func (p Proxy) ListenTLS(tlsCfg *tls.Config) error {
+	l, err := tls.Listen("tcp", p.address, tlsCfg)
+	if err != nil {
+		return errors.Wrap(errCreateListener, err)
+	}
+	defer l.Close()
+
+	p.accept(l)
+
+	p.logger.Info("Server Exiting...")
+	return nil
+}
+
+func (p Proxy) accept(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			p.logger.Warn("Accept error " + err.Error())
+			continue
+		}
+
+		p.logger.Info("Accepted new client")
+		go p.handle(conn)
+	}
+}
+
+func (p Proxy) handle(inbound net.Conn) {
+	defer p.close(inbound)
+
+	clientCert, err := mptls.ClientCert(inbound)
+	if err != nil {
+		p.logger.Error("Failed to get client certificate: " + err.Error())
+		return
+	}
+
+	p.serve(inbound, clientCert, func() (net.Conn, error) {
+		return p.dialer.Dial("tcp", p.target)
+	})
+}
+
+// serve reads the CONNECT packet of the client, connects to the broker
+// using the dial function and streams the session until it ends.
+func (p Proxy) serve(inbound net.Conn, clientCert x509.Certificate, dial func() (net.Conn, error)) {
+	// The protocol version is known only after reading CONNECT packet.
+	connect, err := readRaw(inbound)
+	if err != nil {
+		p.logger.Warn("Failed to read CONNECT packet: " + err.Error())
+		return
+	}
+	if connect[0]>>4 != connectType {
+		p.logger.Warn(errFirstPacket.Error())
+		return
+	}
+	v := version(connect)
+	w, err := parseWill(connect, v)
+	if err != nil {
+		p.logger.Warn("Failed to parse CONNECT packet: " + err.Error())
+		return
+	}
+
+	outbound, err := dial()
+	if err != nil {
+		p.logger.Error("Cannot connect to remote broker " + p.target + " due to: " + err.Error())
+		return
+	}
+	defer p.close(outbound)
+
+	// The CONNECT packet is replayed to the session.
+	conn := newPacketConn(inbound, connect)
+	h := &sessionHandler{
+		Handler: p.handler,
+		conn:    conn,
+		version: v,
+		logger:  p.logger,
+	}
+
+	var c *session.Client
+	switch v {
+	case v5:
+		s := newSession(conn, outbound, h, p.logger, clientCert)
+		err = s.stream()
+		c = &s.client
+	default:
+		s := session.New(conn, outbound, h, p.logger, clientCert)
+		err = s.Stream()
+		c = &s.Client
+	}
+	if !errors.Contains(err, io.EOF) {
+		p.logger.Warn("Broken connection for client: " + c.ID + " with error: " + err.Error())
+	}
+
+	h.publishWill(c, w)
+}
+
+func (p Proxy) close(conn net.Conn) {
+	if err := conn.Close(); err != nil {
+		p.logger.Warn(fmt.Sprintf("Error closing connection %s", err.Error()))
+	}
+}
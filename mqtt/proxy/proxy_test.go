@@ -0,0 +1,656 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/packets"
+	mqtt3 "github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mproxy/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	topic             = "channels/1/messages"
+	unauthorizedTopic = "channels/2/messages"
+	willTopic         = "channels/1/messages/will"
+	password          = "password"
+	timeout           = 5 * time.Second
+)
+
+var errUnauthorized = errors.New("unauthorized")
+
+type publication struct {
+	topic   string
+	payload []byte
+	props   Properties
+	v5      bool
+}
+
+// handler authorizes everything except unauthorized topic and
+// invalid password.
+type handler struct {
+	published chan publication
+}
+
+func newHandler() *handler {
+	return &handler{published: make(chan publication, 10)}
+}
+
+func (h *handler) AuthConnect(c *session.Client) error {
+	if string(c.Password) != password {
+		return errUnauthorized
+	}
+	return nil
+}
+
+func (h *handler) AuthPublish(c *session.Client, topic *string, payload *[]byte) error {
+	if *topic == unauthorizedTopic {
+		return errUnauthorized
+	}
+	return nil
+}
+
+func (h *handler) AuthSubscribe(c *session.Client, topics *[]string) error {
+	for _, t := range *topics {
+		if t == unauthorizedTopic {
+			return errUnauthorized
+		}
+	}
+	return nil
+}
+
+func (h *handler) Connect(c *session.Client) {}
+
+func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
+	h.published <- publication{topic: *topic, payload: *payload}
+}
+
+func (h *handler) PublishV5(c *session.Client, topic *string, payload *[]byte, props Properties) {
+	h.published <- publication{topic: *topic, payload: *payload, props: props, v5: true}
+}
+
+func (h *handler) Subscribe(c *session.Client, topics *[]string) {}
+
+func (h *handler) Retained(c *session.Client, topics []string) []Message {
+	return []Message{{Topic: topics[0], Payload: []byte("retained"), ContentType: "text/plain"}}
+}
+
+func (h *handler) Unsubscribe(c *session.Client, topics *[]string) {}
+
+func (h *handler) Disconnect(c *session.Client) {}
+
+// broker is a stand-in for MQTT broker which acknowledges connections and
+// subscriptions and records published packets.
+type broker struct {
+	listener  net.Listener
+	published chan []byte
+}
+
+func newBroker(t *testing.T) *broker {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	b := &broker{
+		listener:  l,
+		published: make(chan []byte, 10),
+	}
+	go b.serve()
+	return b
+}
+
+func (b *broker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+func (b *broker) handle(conn net.Conn) {
+	defer conn.Close()
+	v := byte(0)
+	for {
+		raw, err := readRaw(conn)
+		if err != nil {
+			return
+		}
+		switch raw[0] >> 4 {
+		case packets.CONNECT:
+			v = version(raw)
+			if v != v5 {
+				conn.Write([]byte{packets.CONNACK << 4, 2, 0, 0})
+				continue
+			}
+			ack := &packets.Connack{Properties: &packets.Properties{}}
+			ack.WriteTo(conn)
+		case packets.PUBLISH:
+			b.published <- raw
+		case packets.SUBSCRIBE:
+			cp, err := packets.ReadPacket(bytes.NewReader(raw))
+			if err != nil || v != v5 {
+				return
+			}
+			sub := cp.Content.(*packets.Subscribe)
+			ack := &packets.Suback{
+				PacketID:   sub.PacketID,
+				Reasons:    make([]byte, len(sub.Subscriptions)),
+				Properties: &packets.Properties{},
+			}
+			ack.WriteTo(conn)
+		}
+	}
+}
+
+func newProxy(t *testing.T, h Handler, target string) net.Listener {
+	logger, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	p := New(l.Addr().String(), target, h, logger)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go p.handle(conn)
+		}
+	}()
+	return l
+}
+
+func setup(t *testing.T) (*handler, *broker, string, func()) {
+	h := newHandler()
+	b := newBroker(t)
+	l := newProxy(t, h, b.listener.Addr().String())
+	return h, b, l.Addr().String(), func() {
+		l.Close()
+		b.listener.Close()
+	}
+}
+
+func dial(t *testing.T, addr, pass string) (net.Conn, *packets.Connack) {
+	connect := packets.NewControlPacket(packets.CONNECT).Content.(*packets.Connect)
+	connect.PasswordFlag = true
+	connect.Password = []byte(pass)
+	return dialConnect(t, addr, connect)
+}
+
+func dialConnect(t *testing.T, addr string, connect *packets.Connect) (net.Conn, *packets.Connack) {
+	conn, err := net.Dial("tcp", addr)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	connect.ClientID = "client"
+	connect.UsernameFlag = true
+	connect.Username = "thing"
+	_, err = connect.WriteTo(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return conn, read(t, conn).Content.(*packets.Connack)
+}
+
+func read(t *testing.T, conn net.Conn) *packets.ControlPacket {
+	cp, err := packets.ReadPacket(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return cp
+}
+
+func alias(a uint16) *uint16 {
+	return &a
+}
+
+func TestConnect(t *testing.T) {
+	_, _, addr, close := setup(t)
+	defer close()
+
+	cases := []struct {
+		desc     string
+		password string
+		code     byte
+	}{
+		{
+			desc:     "connect with valid credentials",
+			password: password,
+			code:     0,
+		},
+		{
+			desc:     "connect with invalid credentials",
+			password: "invalid",
+			code:     connackBadCredentials,
+		},
+	}
+
+	for _, tc := range cases {
+		conn, ack := dial(t, addr, tc.password)
+		assert.Equal(t, tc.code, ack.ReasonCode, fmt.Sprintf("%s: expected reason code %d got %d", tc.desc, tc.code, ack.ReasonCode))
+		if tc.code == 0 {
+			require.NotNil(t, ack.Properties.TopicAliasMaximum, fmt.Sprintf("%s: expected topic alias maximum", tc.desc))
+			assert.Equal(t, topicAliasMax, *ack.Properties.TopicAliasMaximum, fmt.Sprintf("%s: expected topic alias maximum %d got %d", tc.desc, topicAliasMax, *ack.Properties.TopicAliasMaximum))
+		}
+		conn.Close()
+	}
+}
+
+func TestPublish(t *testing.T) {
+	h, b, addr, close := setup(t)
+	defer close()
+
+	conn, _ := dial(t, addr, password)
+	defer conn.Close()
+
+	props := &packets.Properties{
+		ContentType:     "application/senml+json",
+		ResponseTopic:   "channels/1/messages/response",
+		CorrelationData: []byte("correlation"),
+		User:            []packets.User{{Key: "key", Value: "value"}},
+	}
+
+	cases := []struct {
+		desc     string
+		topic    string
+		alias    *uint16
+		retain   bool
+		props    *packets.Properties
+		expTopic string
+		expProps Properties
+	}{
+		{
+			desc:     "publish with properties",
+			topic:    topic,
+			retain:   true,
+			props:    props,
+			expTopic: topic,
+			expProps: Properties{
+				ContentType:     "application/senml+json",
+				ResponseTopic:   "channels/1/messages/response",
+				CorrelationData: []byte("correlation"),
+				User:            map[string]string{"key": "value"},
+			},
+		},
+		{
+			desc:     "publish setting topic alias",
+			topic:    topic + "/aliased",
+			alias:    alias(1),
+			props:    &packets.Properties{},
+			expTopic: topic + "/aliased",
+		},
+		{
+			desc:     "publish using topic alias",
+			topic:    "",
+			alias:    alias(1),
+			props:    &packets.Properties{},
+			expTopic: topic + "/aliased",
+		},
+	}
+
+	for i, tc := range cases {
+		tc.props.TopicAlias = tc.alias
+		pub := &packets.Publish{
+			Topic:      tc.topic,
+			QoS:        1,
+			PacketID:   uint16(i + 1),
+			Payload:    []byte("payload"),
+			Retain:     tc.retain,
+			Properties: tc.props,
+		}
+		_, err := pub.WriteTo(conn)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		select {
+		case p := <-h.published:
+			assert.True(t, p.v5, fmt.Sprintf("%s: expected MQTT 5 publish", tc.desc))
+			assert.Equal(t, tc.expTopic, p.topic, fmt.Sprintf("%s: expected topic %s got %s", tc.desc, tc.expTopic, p.topic))
+			assert.Equal(t, tc.expProps, p.props, fmt.Sprintf("%s: expected properties %v got %v", tc.desc, tc.expProps, p.props))
+		case <-time.After(timeout):
+			assert.Fail(t, fmt.Sprintf("%s: handler not called", tc.desc))
+		}
+
+		select {
+		case raw := <-b.published:
+			cp, err := packets.ReadPacket(bytes.NewReader(raw))
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			p := cp.Content.(*packets.Publish)
+			assert.Equal(t, tc.expTopic, p.Topic, fmt.Sprintf("%s: expected topic %s got %s", tc.desc, tc.expTopic, p.Topic))
+			assert.Equal(t, tc.retain, cp.Flags&1 == 1, fmt.Sprintf("%s: expected retain flag %t", tc.desc, tc.retain))
+			assert.Nil(t, p.Properties.TopicAlias, fmt.Sprintf("%s: expected topic alias to be removed", tc.desc))
+		case <-time.After(timeout):
+			assert.Fail(t, fmt.Sprintf("%s: message not forwarded to the broker", tc.desc))
+		}
+	}
+}
+
+func TestPublishRejected(t *testing.T) {
+	_, b, addr, close := setup(t)
+	defer close()
+
+	cases := []struct {
+		desc  string
+		topic string
+		alias *uint16
+		qos   byte
+		typ   byte
+		code  byte
+	}{
+		{
+			desc:  "publish QoS 1 to unauthorized topic",
+			topic: unauthorizedTopic,
+			qos:   1,
+			typ:   packets.PUBACK,
+			code:  packets.PubackNotAuthorized,
+		},
+		{
+			desc:  "publish QoS 2 to unauthorized topic",
+			topic: unauthorizedTopic,
+			qos:   2,
+			typ:   packets.PUBREC,
+			code:  packets.PubrecNotAuthorized,
+		},
+		{
+			desc:  "publish QoS 0 to unauthorized topic",
+			topic: unauthorizedTopic,
+			qos:   0,
+			typ:   packets.DISCONNECT,
+			code:  packets.DisconnectNotAuthorized,
+		},
+		{
+			desc:  "publish using unknown topic alias",
+			topic: "",
+			alias: alias(2),
+			qos:   1,
+			typ:   packets.DISCONNECT,
+			code:  packets.DisconnectTopicAliasInvalid,
+		},
+		{
+			desc:  "publish using topic alias exceeding maximum",
+			topic: topic,
+			alias: alias(topicAliasMax + 1),
+			qos:   1,
+			typ:   packets.DISCONNECT,
+			code:  packets.DisconnectTopicAliasInvalid,
+		},
+	}
+
+	for _, tc := range cases {
+		conn, _ := dial(t, addr, password)
+		pub := &packets.Publish{
+			Topic:      tc.topic,
+			QoS:        tc.qos,
+			PacketID:   1,
+			Payload:    []byte("payload"),
+			Properties: &packets.Properties{TopicAlias: tc.alias},
+		}
+		_, err := pub.WriteTo(conn)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		cp := read(t, conn)
+		assert.Equal(t, tc.typ, cp.Type, fmt.Sprintf("%s: expected packet type %d got %d", tc.desc, tc.typ, cp.Type))
+		var code byte
+		switch p := cp.Content.(type) {
+		case *packets.Puback:
+			code = p.ReasonCode
+		case *packets.Pubrec:
+			code = p.ReasonCode
+		case *packets.Disconnect:
+			code = p.ReasonCode
+		}
+		assert.Equal(t, tc.code, code, fmt.Sprintf("%s: expected reason code %d got %d", tc.desc, tc.code, code))
+		conn.Close()
+	}
+
+	assert.Empty(t, b.published, "expected no messages forwarded to the broker")
+}
+
+func TestSubscribe(t *testing.T) {
+	_, _, addr, close := setup(t)
+	defer close()
+
+	conn, _ := dial(t, addr, password)
+	defer conn.Close()
+
+	cases := []struct {
+		desc  string
+		topic string
+		code  byte
+	}{
+		{
+			desc:  "subscribe to authorized topic",
+			topic: topic,
+			code:  0,
+		},
+		{
+			desc:  "subscribe to unauthorized topic",
+			topic: unauthorizedTopic,
+			code:  subackNotAuthorized,
+		},
+	}
+
+	for i, tc := range cases {
+		sub := packets.NewControlPacket(packets.SUBSCRIBE).Content.(*packets.Subscribe)
+		sub.PacketID = uint16(i + 1)
+		sub.Subscriptions[tc.topic] = packets.SubOptions{QoS: 1}
+		_, err := sub.WriteTo(conn)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		var ack *packets.Suback
+		for ack == nil {
+			cp := read(t, conn)
+			switch p := cp.Content.(type) {
+			case *packets.Suback:
+				ack = p
+			case *packets.Publish:
+				// Retain flag isn't decoded by the packets package.
+				assert.Equal(t, byte(1), cp.Flags&1, fmt.Sprintf("%s: expected retained message", tc.desc))
+				assert.Equal(t, tc.topic, p.Topic, fmt.Sprintf("%s: expected topic %s got %s", tc.desc, tc.topic, p.Topic))
+				assert.Equal(t, "text/plain", p.Properties.ContentType, fmt.Sprintf("%s: expected content type text/plain got %s", tc.desc, p.Properties.ContentType))
+			default:
+				assert.Fail(t, fmt.Sprintf("%s: unexpected packet %s", tc.desc, p))
+			}
+		}
+		assert.Equal(t, sub.PacketID, ack.PacketID, fmt.Sprintf("%s: expected packet ID %d got %d", tc.desc, sub.PacketID, ack.PacketID))
+		assert.Equal(t, []byte{tc.code}, ack.Reasons, fmt.Sprintf("%s: expected reason codes %v got %v", tc.desc, []byte{tc.code}, ack.Reasons))
+	}
+}
+
+func TestMQTT311(t *testing.T) {
+	h, b, addr, close := setup(t)
+	defer close()
+
+	conn, err := net.Dial("tcp", addr)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	connect := mqtt3.NewControlPacket(mqtt3.Connect).(*mqtt3.ConnectPacket)
+	connect.ProtocolName = "MQTT"
+	connect.ProtocolVersion = 4
+	connect.ClientIdentifier = "client"
+	connect.UsernameFlag = true
+	connect.Username = "thing"
+	connect.PasswordFlag = true
+	connect.Password = []byte(password)
+	err = connect.Write(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	pkt, err := mqtt3.ReadPacket(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ack, ok := pkt.(*mqtt3.ConnackPacket)
+	require.True(t, ok, "expected CONNACK")
+	assert.Equal(t, byte(0), ack.ReturnCode, fmt.Sprintf("expected return code 0 got %d", ack.ReturnCode))
+
+	pub := mqtt3.NewControlPacket(mqtt3.Publish).(*mqtt3.PublishPacket)
+	pub.TopicName = topic
+	pub.Payload = []byte("payload")
+	err = pub.Write(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sub := mqtt3.NewControlPacket(mqtt3.Subscribe).(*mqtt3.SubscribePacket)
+	sub.MessageID = 1
+	sub.Topics = []string{topic}
+	sub.Qoss = []byte{0}
+	err = sub.Write(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	pkt, err = mqtt3.ReadPacket(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	retained, ok := pkt.(*mqtt3.PublishPacket)
+	require.True(t, ok, "expected retained message")
+	assert.True(t, retained.Retain, "expected retain flag")
+	assert.Equal(t, topic, retained.TopicName, fmt.Sprintf("expected topic %s got %s", topic, retained.TopicName))
+
+	select {
+	case p := <-h.published:
+		assert.False(t, p.v5, "expected MQTT 3.1.1 publish")
+		assert.Equal(t, topic, p.topic, fmt.Sprintf("expected topic %s got %s", topic, p.topic))
+	case <-time.After(timeout):
+		assert.Fail(t, "handler not called")
+	}
+
+	select {
+	case <-b.published:
+	case <-time.After(timeout):
+		assert.Fail(t, "message not forwarded to the broker")
+	}
+}
+
+func TestWill(t *testing.T) {
+	h, _, addr, close := setup(t)
+	defer close()
+
+	cases := []struct {
+		desc       string
+		password   string
+		disconnect *packets.Disconnect
+		published  bool
+	}{
+		{
+			desc:      "connection lost",
+			password:  password,
+			published: true,
+		},
+		{
+			desc:       "disconnect gracefully",
+			password:   password,
+			disconnect: &packets.Disconnect{Properties: &packets.Properties{}},
+			published:  false,
+		},
+		{
+			desc:       "disconnect with will message",
+			password:   password,
+			disconnect: &packets.Disconnect{ReasonCode: disconnectWithWill, Properties: &packets.Properties{}},
+			published:  true,
+		},
+		{
+			desc:      "connection refused",
+			password:  "invalid",
+			published: false,
+		},
+	}
+
+	for _, tc := range cases {
+		connect := packets.NewControlPacket(packets.CONNECT).Content.(*packets.Connect)
+		connect.PasswordFlag = true
+		connect.Password = []byte(tc.password)
+		connect.WillFlag = true
+		connect.WillTopic = willTopic
+		connect.WillMessage = []byte("offline")
+		connect.WillProperties = &packets.Properties{}
+		conn, _ := dialConnect(t, addr, connect)
+		if tc.disconnect != nil {
+			_, err := tc.disconnect.WriteTo(conn)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		}
+		conn.Close()
+
+		select {
+		case p := <-h.published:
+			assert.True(t, tc.published, fmt.Sprintf("%s: unexpected will message", tc.desc))
+			assert.Equal(t, willTopic, p.topic, fmt.Sprintf("%s: expected topic %s got %s", tc.desc, willTopic, p.topic))
+			assert.True(t, p.v5, fmt.Sprintf("%s: expected MQTT 5 publish", tc.desc))
+		case <-time.After(100 * time.Millisecond):
+			assert.False(t, tc.published, fmt.Sprintf("%s: expected will message", tc.desc))
+		}
+	}
+}
+
+func TestWebSocket(t *testing.T) {
+	h := newHandler()
+	b := &broker{published: make(chan []byte, 10)}
+	bs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		b.handle(newWSConn(conn))
+	}))
+	defer bs.Close()
+
+	logger, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ws := NewWebSocket(strings.TrimPrefix(bs.URL, "http://"), "/mqtt", "ws", h, logger)
+	ps := httptest.NewServer(ws.Handler())
+	defer ps.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"mqtt"}}
+	wc, _, err := dialer.Dial("ws"+strings.TrimPrefix(ps.URL, "http")+"/mqtt", nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	conn := newWSConn(wc)
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	connect := packets.NewControlPacket(packets.CONNECT).Content.(*packets.Connect)
+	connect.ClientID = "client"
+	connect.UsernameFlag = true
+	connect.Username = "thing"
+	connect.PasswordFlag = true
+	connect.Password = []byte(password)
+	_, err = connect.WriteTo(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ack := read(t, conn).Content.(*packets.Connack)
+	assert.Equal(t, byte(0), ack.ReasonCode, fmt.Sprintf("connect: expected reason code 0 got %d", ack.ReasonCode))
+
+	pub := &packets.Publish{
+		Topic:      topic,
+		QoS:        1,
+		PacketID:   1,
+		Payload:    []byte("payload"),
+		Properties: &packets.Properties{ContentType: "application/senml+json"},
+	}
+	_, err = pub.WriteTo(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	select {
+	case p := <-h.published:
+		assert.True(t, p.v5, "publish: expected MQTT 5 publish")
+		assert.Equal(t, "application/senml+json", p.props.ContentType, fmt.Sprintf("publish: expected content type application/senml+json got %s", p.props.ContentType))
+	case <-time.After(timeout):
+		assert.Fail(t, "publish: handler not called")
+	}
+
+	sub := packets.NewControlPacket(packets.SUBSCRIBE).Content.(*packets.Subscribe)
+	sub.PacketID = 2
+	sub.Subscriptions[topic] = packets.SubOptions{QoS: 1}
+	_, err = sub.WriteTo(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	cp := read(t, conn)
+	p, ok := cp.Content.(*packets.Publish)
+	require.True(t, ok, fmt.Sprintf("subscribe: expected retained message got %v", cp.Content))
+	assert.Equal(t, byte(1), cp.Flags&1, "subscribe: expected retained message")
+	assert.Equal(t, "text/plain", p.Properties.ContentType, fmt.Sprintf("subscribe: expected content type text/plain got %s", p.Properties.ContentType))
+}
@@ -0,0 +1,292 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"crypto/x509"
+	"io"
+	"net"
+
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mproxy/pkg/session"
+)
+
+const (
+	// Reason codes not defined by the packets package.
+	connackBadCredentials = 0x86
+	subackNotAuthorized   = 0x87
+
+	// topicAliasMax is the number of topic aliases the client may use.
+	// The proxy resolves the aliases and forwards full topics, so the
+	// limit doesn't depend on the broker.
+	topicAliasMax uint16 = 1024
+)
+
+var (
+	errClient             = errors.New("failed proxying from MQTT client to MQTT broker")
+	errBroker             = errors.New("failed proxying from MQTT broker to MQTT client")
+	errInvalidTopicAlias  = errors.New("invalid topic alias")
+	errUnauthorizedPacket = errors.New("unauthorized packet")
+)
+
+// mqttSession is MQTT 5 session between client and broker.
+type mqttSession struct {
+	logger   logger.Logger
+	inbound  *packetConn
+	outbound net.Conn
+	handler  Handler
+	client   session.Client
+	aliases  map[uint16]string
+}
+
+func newSession(inbound *packetConn, outbound net.Conn, handler Handler, logger logger.Logger, cert x509.Certificate) *mqttSession {
+	return &mqttSession{
+		logger:   logger,
+		inbound:  inbound,
+		outbound: outbound,
+		handler:  handler,
+		client: session.Client{
+			Cert: cert,
+		},
+		aliases: make(map[uint16]string),
+	}
+}
+
+// stream starts proxying traffic between client and broker.
+func (s *mqttSession) stream() error {
+	errs := make(chan error, 2)
+	go s.streamUp(errs)
+	go s.streamDown(errs)
+
+	err := <-errs
+
+	s.handler.Disconnect(&s.client)
+	return err
+}
+
+func (s *mqttSession) streamUp(errs chan error) {
+	for {
+		pkt, err := readRaw(s.inbound)
+		if err != nil {
+			errs <- errors.Wrap(errClient, err)
+			return
+		}
+		if err := s.up(pkt); err != nil {
+			errs <- errors.Wrap(errClient, err)
+			return
+		}
+	}
+}
+
+func (s *mqttSession) streamDown(errs chan error) {
+	for {
+		pkt, err := readRaw(s.outbound)
+		if err != nil {
+			errs <- errors.Wrap(errBroker, err)
+			return
+		}
+		if err := s.down(pkt); err != nil {
+			errs <- errors.Wrap(errBroker, err)
+			return
+		}
+	}
+}
+
+// up authorizes the packet sent by the client and forwards it to the broker.
+func (s *mqttSession) up(raw []byte) error {
+	cp, err := packets.ReadPacket(bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+
+	switch p := cp.Content.(type) {
+	case *packets.Connect:
+		return s.connect(p, raw)
+	case *packets.Publish:
+		// Only QoS is decoded from the fixed header flags.
+		p.Retain = cp.Flags&0x01 != 0
+		p.Duplicate = cp.Flags&0x08 != 0
+		return s.publish(p)
+	case *packets.Subscribe:
+		return s.subscribe(p, raw)
+	case *packets.Unsubscribe:
+		if _, err := s.outbound.Write(raw); err != nil {
+			return err
+		}
+		s.handler.Unsubscribe(&s.client, &p.Topics)
+		return nil
+	default:
+		_, err := s.outbound.Write(raw)
+		return err
+	}
+}
+
+// down forwards the packet sent by the broker to the client.
+func (s *mqttSession) down(raw []byte) error {
+	if raw[0]>>4 != packets.CONNACK {
+		return s.writeClient(raw)
+	}
+
+	cp, err := packets.ReadPacket(bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	ack := cp.Content.(*packets.Connack)
+	aliasMax := topicAliasMax
+	ack.Properties.TopicAliasMaximum = &aliasMax
+
+	var buf bytes.Buffer
+	if _, err := ack.WriteTo(&buf); err != nil {
+		return err
+	}
+	return s.writeClient(buf.Bytes())
+}
+
+func (s *mqttSession) connect(p *packets.Connect, raw []byte) error {
+	s.client.ID = p.ClientID
+	s.client.Username = p.Username
+	s.client.Password = p.Password
+	if err := s.handler.AuthConnect(&s.client); err != nil {
+		ack := &packets.Connack{
+			ReasonCode: connackBadCredentials,
+			Properties: &packets.Properties{},
+		}
+		if err := s.writePacket(ack); err != nil {
+			return err
+		}
+		return err
+	}
+
+	// Copy back to the packet in case values are changed by the handler.
+	if p.ClientID != s.client.ID || p.Username != s.client.Username || !bytes.Equal(p.Password, s.client.Password) {
+		p.ClientID = s.client.ID
+		p.Username = s.client.Username
+		p.Password = s.client.Password
+		p.UsernameFlag = p.Username != ""
+		p.PasswordFlag = len(p.Password) > 0
+		var buf bytes.Buffer
+		if _, err := p.WriteTo(&buf); err != nil {
+			return err
+		}
+		raw = buf.Bytes()
+	}
+
+	if _, err := s.outbound.Write(raw); err != nil {
+		return err
+	}
+	s.handler.Connect(&s.client)
+	return nil
+}
+
+func (s *mqttSession) publish(p *packets.Publish) error {
+	if err := s.resolveAlias(p); err != nil {
+		return s.disconnect(packets.DisconnectTopicAliasInvalid, err)
+	}
+
+	if err := s.handler.AuthPublish(&s.client, &p.Topic, &p.Payload); err != nil {
+		switch p.QoS {
+		case 0:
+			// There is no acknowledgement for QoS 0, so the only way to
+			// report the failure is to close the connection.
+			return s.disconnect(packets.DisconnectNotAuthorized, errors.Wrap(errUnauthorizedPacket, err))
+		case 1:
+			return s.writePacket(&packets.Puback{
+				PacketID:   p.PacketID,
+				ReasonCode: packets.PubackNotAuthorized,
+				Properties: &packets.Properties{},
+			})
+		default:
+			return s.writePacket(&packets.Pubrec{
+				PacketID:   p.PacketID,
+				ReasonCode: packets.PubrecNotAuthorized,
+				Properties: &packets.Properties{},
+			})
+		}
+	}
+
+	// The broker always receives the full topic.
+	p.Properties.TopicAlias = nil
+	if _, err := p.WriteTo(s.outbound); err != nil {
+		return err
+	}
+	s.handler.PublishV5(&s.client, &p.Topic, &p.Payload, newProperties(p.Properties))
+	return nil
+}
+
+func (s *mqttSession) subscribe(p *packets.Subscribe, raw []byte) error {
+	topics := make([]string, 0, len(p.Subscriptions))
+	for t := range p.Subscriptions {
+		topics = append(topics, t)
+	}
+
+	if err := s.handler.AuthSubscribe(&s.client, &topics); err != nil {
+		reasons := make([]byte, len(topics))
+		for i := range reasons {
+			reasons[i] = subackNotAuthorized
+		}
+		return s.writePacket(&packets.Suback{
+			PacketID:   p.PacketID,
+			Reasons:    reasons,
+			Properties: &packets.Properties{},
+		})
+	}
+
+	if _, err := s.outbound.Write(raw); err != nil {
+		return err
+	}
+	s.handler.Subscribe(&s.client, &topics)
+	return nil
+}
+
+// resolveAlias sets the topic of the packet using the topic alias
+// property, and stores new aliases.
+func (s *mqttSession) resolveAlias(p *packets.Publish) error {
+	alias := p.Properties.TopicAlias
+	if alias == nil {
+		return nil
+	}
+	if *alias == 0 || *alias > topicAliasMax {
+		return errInvalidTopicAlias
+	}
+	if p.Topic != "" {
+		s.aliases[*alias] = p.Topic
+		return nil
+	}
+
+	topic, ok := s.aliases[*alias]
+	if !ok {
+		return errInvalidTopicAlias
+	}
+	p.Topic = topic
+	return nil
+}
+
+// disconnect sends DISCONNECT with the reason code to the client and
+// returns the error which terminates the session.
+func (s *mqttSession) disconnect(code byte, err error) error {
+	d := &packets.Disconnect{
+		ReasonCode: code,
+		Properties: &packets.Properties{},
+	}
+	if err := s.writePacket(d); err != nil {
+		return err
+	}
+	return err
+}
+
+func (s *mqttSession) writePacket(p io.WriterTo) error {
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		return err
+	}
+	return s.writeClient(buf.Bytes())
+}
+
+func (s *mqttSession) writeClient(raw []byte) error {
+	_, err := s.inbound.Write(raw)
+	return err
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/logger"
+	mptls "github.com/mainflux/mproxy/pkg/tls"
+)
+
+const handshakeTimeout = 10 * time.Second
+
+var upgrader = websocket.Upgrader{
+	HandshakeTimeout: handshakeTimeout,
+	// Paho JS client expects the mqtt subprotocol in the upgrade response.
+	Subprotocols: []string{"mqttv3.1", "mqtt"},
+	CheckOrigin:  func(r *http.Request) bool { return true },
+}
+
+// WebSocket is MQTT over WebSocket proxy. The sessions are handled the
+// same way as by Proxy, so MQTT 5 and the retained messages are supported
+// over WebSocket as well.
+type WebSocket struct {
+	proxy  Proxy
+	dialer websocket.Dialer
+}
+
+// NewWebSocket returns a new WebSocket proxy which forwards the sessions
+// to the broker WebSocket endpoint with the given target address, path and
+// scheme.
+func NewWebSocket(target, path, scheme string, handler Handler, logger logger.Logger) *WebSocket {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   target,
+		Path:   path,
+	}
+
+	return &WebSocket{
+		proxy: Proxy{
+			target:  u.String(),
+			handler: handler,
+			logger:  logger,
+		},
+		dialer: websocket.Dialer{
+			HandshakeTimeout: handshakeTimeout,
+			Subprotocols:     []string{"mqtt"},
+		},
+	}
+}
+
+// Handler returns HTTP handler which upgrades the connections and proxies
+// the sessions.
+func (ws WebSocket) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			ws.proxy.logger.Warn("Failed to upgrade connection: " + err.Error())
+			return
+		}
+
+		go ws.handle(conn)
+	})
+}
+
+func (ws WebSocket) handle(conn *websocket.Conn) {
+	inbound := newWSConn(conn)
+	defer ws.proxy.close(inbound)
+
+	clientCert, err := mptls.ClientCert(conn.UnderlyingConn())
+	if err != nil {
+		ws.proxy.logger.Error("Failed to get client certificate: " + err.Error())
+		return
+	}
+
+	ws.proxy.serve(inbound, clientCert, func() (net.Conn, error) {
+		conn, _, err := ws.dialer.Dial(ws.proxy.target, nil)
+		if err != nil {
+			return nil, err
+		}
+		return newWSConn(conn), nil
+	})
+}
+
+// wsConn is the stream of the binary WebSocket messages, which carry the
+// MQTT packets.
+type wsConn struct {
+	*websocket.Conn
+	r   io.Reader
+	rmu sync.Mutex
+	wmu sync.Mutex
+}
+
+func newWSConn(conn *websocket.Conn) net.Conn {
+	return &wsConn{Conn: conn}
+}
+
+func (c *wsConn) Read(b []byte) (int, error) {
+	c.rmu.Lock()
+	defer c.rmu.Unlock()
+
+	for {
+		if c.r == nil {
+			_, r, err := c.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			c.r = r
+		}
+		n, err := c.r.Read(b)
+		if err == io.EOF {
+			// The packet may continue in the next message.
+			c.r = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (c *wsConn) Write(b []byte) (int, error) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if err := c.WriteMessage(websocket.BinaryMessage, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (c *wsConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+
+	"github.com/eclipse/paho.golang/packets"
+	mqtt3 "github.com/eclipse/paho.mqtt.golang/packets"
+)
+
+// will is the last will message of the client. The broker publishes it
+// only to MQTT subscribers, so the proxy publishes it to the handler too.
+type will struct {
+	topic   string
+	payload []byte
+	props   Properties
+}
+
+// parseWill returns the will message of the raw CONNECT packet, or nil if
+// the client has no will message.
+func parseWill(connect []byte, version byte) (*will, error) {
+	if version == v5 {
+		cp, err := packets.ReadPacket(bytes.NewReader(connect))
+		if err != nil {
+			return nil, err
+		}
+		c := cp.Content.(*packets.Connect)
+		if !c.WillFlag {
+			return nil, nil
+		}
+		return &will{
+			topic:   c.WillTopic,
+			payload: c.WillMessage,
+			props:   newProperties(c.WillProperties),
+		}, nil
+	}
+
+	cp, err := mqtt3.ReadPacket(bytes.NewReader(connect))
+	if err != nil {
+		return nil, err
+	}
+	c, ok := cp.(*mqtt3.ConnectPacket)
+	if !ok || !c.WillFlag {
+		return nil, nil
+	}
+	return &will{
+		topic:   c.WillTopic,
+		payload: c.WillMessage,
+	}, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis"
+	"github.com/gogo/protobuf/proto"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// Messages of a channel are stored in a hash keyed by the subtopic, and
+// their creation times in the separate hash, so that they can be compared
+// without decoding the messages.
+const (
+	retainedPrefix = "retained"
+	createdSuffix  = "created"
+)
+
+// saveScript stores the message unless the stored one was created later,
+// so that the messages published concurrently over different adapters
+// can't overwrite the newer one. Creation times are compared as decimal
+// strings, since nanoseconds exceed Lua number precision.
+var saveScript = redis.NewScript(`
+local created = redis.call('HGET', KEYS[2], ARGV[1])
+if created and (#created > #ARGV[2] or (#created == #ARGV[2] and created > ARGV[2])) then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[3])
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+return 1
+`)
+
+var _ messaging.RetainedRepository = (*retainedRepository)(nil)
+
+type retainedRepository struct {
+	client *redis.Client
+}
+
+// NewRetainedRepository returns Redis repository of the last message
+// published to each channel and subtopic.
+func NewRetainedRepository(client *redis.Client) messaging.RetainedRepository {
+	return retainedRepository{client: client}
+}
+
+func (rr retainedRepository) Save(msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{retainedKey(msg.Channel), createdKey(msg.Channel)}
+	created := strconv.FormatInt(msg.Created, 10)
+
+	return saveScript.Run(rr.client, keys, msg.Subtopic, created, data).Err()
+}
+
+func (rr retainedRepository) Retrieve(chanID, subtopic string) ([]messaging.Message, error) {
+	key := retainedKey(chanID)
+
+	if !strings.ContainsAny(subtopic, "*>") {
+		data, err := rr.client.HGet(key, subtopic).Bytes()
+		if err == redis.Nil {
+			return []messaging.Message{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		msg, err := decode(data)
+		if err != nil {
+			return nil, err
+		}
+		return []messaging.Message{msg}, nil
+	}
+
+	all, err := rr.client.HGetAll(key).Result()
+	if err != nil {
+		return nil, err
+	}
+	msgs := []messaging.Message{}
+	for st, data := range all {
+		if !messaging.MatchSubtopic(subtopic, st) {
+			continue
+		}
+		msg, err := decode([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+func decode(data []byte) (messaging.Message, error) {
+	var msg messaging.Message
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return messaging.Message{}, err
+	}
+	return msg, nil
+}
+
+func retainedKey(chanID string) string {
+	return retainedPrefix + ":" + chanID
+}
+
+func createdKey(chanID string) string {
+	return retainedKey(chanID) + ":" + createdSuffix
+}
@@ -4,18 +4,38 @@
 package opcua
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mainflux/mainflux/logger"
-	"github.com/mainflux/mainflux/opcua/db"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/senml"
 )
 
-const protocol = "opcua"
+const (
+	protocol            = "opcua"
+	responseContentType = "application/json"
+
+	writeSubtopic     = "write"
+	callSubtopic      = "call"
+	responseSubtopic  = "response"
+	correlationHeader = "correlation_id"
+)
 
 var (
 	// ErrMalformedEntity indicates malformed entity specification.
 	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrMalformedCommand indicates malformed write or call command.
+	ErrMalformedCommand = errors.New("malformed command")
+
+	// ErrNotConnected indicates that the node isn't mapped to the thing
+	// connected to the channel.
+	ErrNotConnected = errors.New("node not connected to channel")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -47,6 +67,12 @@ type Service interface {
 
 	// Browse browses available nodes for a given OPC-UA Server URI and NodeID
 	Browse(serverURI, namespace, identifier string) ([]BrowsedNode, error)
+
+	// Command writes node values or calls the method of the OPC-UA Server
+	// mapped to the message channel, and publishes the result on the
+	// response subtopic of the channel. It returns only the error of
+	// publishing the result.
+	Command(ctx context.Context, msg messaging.Message) error
 }
 
 // Config OPC-UA Server
@@ -65,21 +91,27 @@ var _ Service = (*adapterService)(nil)
 type adapterService struct {
 	subscriber Subscriber
 	browser    Browser
+	writer     Writer
+	publisher  messaging.Publisher
 	thingsRM   RouteMapRepository
 	channelsRM RouteMapRepository
 	connectRM  RouteMapRepository
+	nodes      NodeRepository
 	cfg        Config
 	logger     logger.Logger
 }
 
 // New instantiates the OPC-UA adapter implementation.
-func New(sub Subscriber, brow Browser, thingsRM, channelsRM, connectRM RouteMapRepository, cfg Config, log logger.Logger) Service {
+func New(sub Subscriber, brow Browser, writer Writer, publisher messaging.Publisher, thingsRM, channelsRM, connectRM RouteMapRepository, nodes NodeRepository, cfg Config, log logger.Logger) Service {
 	return &adapterService{
 		subscriber: sub,
 		browser:    brow,
+		writer:     writer,
+		publisher:  publisher,
 		thingsRM:   thingsRM,
 		channelsRM: channelsRM,
 		connectRM:  connectRM,
+		nodes:      nodes,
 		cfg:        cfg,
 		logger:     log,
 	}
@@ -94,6 +126,11 @@ func (as *adapterService) UpdateThing(thingID, nodeID string) error {
 }
 
 func (as *adapterService) RemoveThing(thingID string) error {
+	if err := as.nodes.RemoveThing(thingID); err != nil {
+		return err
+	}
+	as.subscriber.UnsubscribeThing(thingID)
+
 	return as.thingsRM.Remove(thingID)
 }
 
@@ -106,6 +143,11 @@ func (as *adapterService) UpdateChannel(chanID, serverURI string) error {
 }
 
 func (as *adapterService) RemoveChannel(chanID string) error {
+	if err := as.nodes.RemoveChannel(chanID); err != nil {
+		return err
+	}
+	as.subscriber.UnsubscribeChannel(chanID)
+
 	return as.channelsRM.Remove(chanID)
 }
 
@@ -120,22 +162,32 @@ func (as *adapterService) ConnectThing(chanID, thingID string) error {
 		return err
 	}
 
-	as.cfg.NodeID = nodeID
-	as.cfg.ServerURI = serverURI
+	cfg := as.cfg
+	cfg.NodeID = nodeID
+	cfg.ServerURI = serverURI
 
 	c := fmt.Sprintf("%s:%s", chanID, thingID)
 	if err := as.connectRM.Save(c, c); err != nil {
 		return err
 	}
 
+	// Store subscription details
+	node := Node{
+		ChanID:  chanID,
+		ThingID: thingID,
+		Config:  cfg,
+	}
+	if err := as.nodes.Save(node); err != nil {
+		return err
+	}
+
 	go func() {
-		if err := as.subscriber.Subscribe(as.cfg); err != nil {
+		if err := as.subscriber.Subscribe(node); err != nil {
 			as.logger.Warn(fmt.Sprintf("subscription failed: %s", err))
 		}
 	}()
 
-	// Store subscription details
-	return db.Save(serverURI, nodeID)
+	return nil
 }
 
 func (as *adapterService) Browse(serverURI, namespace, identifier string) ([]BrowsedNode, error) {
@@ -149,6 +201,210 @@ func (as *adapterService) Browse(serverURI, namespace, identifier string) ([]Bro
 }
 
 func (as *adapterService) DisconnectThing(chanID, thingID string) error {
+	if err := as.nodes.Remove(chanID, thingID); err != nil {
+		return err
+	}
+	as.subscriber.Unsubscribe(chanID, thingID)
+
 	c := fmt.Sprintf("%s:%s", chanID, thingID)
 	return as.connectRM.Remove(c)
 }
+
+// callReq represents the method call command. The input arguments are the
+// values of the SenML records.
+type callReq struct {
+	ObjectID  string         `json:"object_id"`
+	MethodID  string         `json:"method_id"`
+	Arguments []senml.Record `json:"arguments"`
+}
+
+type writeResult struct {
+	NodeID string `json:"node_id"`
+	Error  string `json:"error,omitempty"`
+}
+
+type commandRes struct {
+	Operation string        `json:"operation"`
+	Results   []writeResult `json:"results,omitempty"`
+	ObjectID  string        `json:"object_id,omitempty"`
+	MethodID  string        `json:"method_id,omitempty"`
+	Outputs   []interface{} `json:"outputs,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+func (as *adapterService) Command(ctx context.Context, msg messaging.Message) error {
+	// Skip the node values and responses published by the adapter.
+	if msg.Protocol == protocol {
+		return nil
+	}
+	if msg.Subtopic != writeSubtopic && msg.Subtopic != callSubtopic {
+		return nil
+	}
+
+	// Messages of the channels without route maps are not meant for
+	// OPC-UA Servers.
+	serverURI, err := as.channelsRM.Get(msg.Channel)
+	if err != nil {
+		return nil
+	}
+	cfg := as.cfg
+	cfg.ServerURI = serverURI
+
+	var res commandRes
+	switch msg.Subtopic {
+	case writeSubtopic:
+		res, err = as.write(cfg, msg)
+	case callSubtopic:
+		res, err = as.call(cfg, msg)
+	}
+	// The failed commands are reported in the response, so only failing
+	// to publish it is returned.
+	if err != nil {
+		res.Error = err.Error()
+	}
+
+	return as.respond(msg, res)
+}
+
+func (as *adapterService) write(cfg Config, msg messaging.Message) (commandRes, error) {
+	res := commandRes{Operation: writeSubtopic}
+
+	// The records aren't validated as SenML, since the node IDs contain
+	// characters which aren't allowed in SenML names.
+	var recs []senml.Record
+	if err := json.Unmarshal(msg.Payload, &recs); err != nil {
+		return res, ErrMalformedCommand
+	}
+
+	var (
+		values []NodeValue
+		bn     string
+	)
+	for _, r := range recs {
+		if r.BaseName != "" {
+			bn = r.BaseName
+		}
+		nodeID := bn + r.Name
+		if nodeID == "" {
+			return res, ErrMalformedCommand
+		}
+		v, err := recordValue(r)
+		if err != nil {
+			return res, err
+		}
+		if err := as.authorize(msg.Channel, nodeID); err != nil {
+			return res, err
+		}
+		values = append(values, NodeValue{NodeID: nodeID, Value: v})
+	}
+	if len(values) == 0 {
+		return res, ErrMalformedCommand
+	}
+
+	errs, err := as.writer.Write(cfg, values)
+	if err != nil {
+		return res, err
+	}
+
+	for i, v := range values {
+		wr := writeResult{NodeID: v.NodeID}
+		if i < len(errs) && errs[i] != nil {
+			wr.Error = errs[i].Error()
+		}
+		res.Results = append(res.Results, wr)
+	}
+
+	return res, nil
+}
+
+func (as *adapterService) call(cfg Config, msg messaging.Message) (commandRes, error) {
+	res := commandRes{Operation: callSubtopic}
+
+	var req callReq
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return res, ErrMalformedCommand
+	}
+	res.ObjectID = req.ObjectID
+	res.MethodID = req.MethodID
+	if req.ObjectID == "" || req.MethodID == "" {
+		return res, ErrMalformedCommand
+	}
+
+	if err := as.authorize(msg.Channel, req.ObjectID); err != nil {
+		return res, err
+	}
+
+	args := []interface{}{}
+	for _, r := range req.Arguments {
+		v, err := recordValue(r)
+		if err != nil {
+			return res, err
+		}
+		args = append(args, v)
+	}
+
+	outputs, err := as.writer.Call(cfg, req.ObjectID, req.MethodID, args)
+	if err != nil {
+		return res, err
+	}
+	res.Outputs = outputs
+
+	return res, nil
+}
+
+// authorize checks that the node is mapped to the thing connected to the
+// channel.
+func (as *adapterService) authorize(chanID, nodeID string) error {
+	thingID, err := as.thingsRM.Get(nodeID)
+	if err != nil {
+		return ErrNotConnected
+	}
+
+	c := fmt.Sprintf("%s:%s", chanID, thingID)
+	if _, err := as.connectRM.Get(c); err != nil {
+		return ErrNotConnected
+	}
+
+	return nil
+}
+
+func (as *adapterService) respond(msg messaging.Message, res commandRes) error {
+	payload, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	m := messaging.Message{
+		Channel:     msg.Channel,
+		Subtopic:    responseSubtopic,
+		Protocol:    protocol,
+		ContentType: responseContentType,
+		Payload:     payload,
+		Created:     time.Now().UnixNano(),
+	}
+	if id, ok := msg.Headers[correlationHeader]; ok {
+		m.Headers = map[string]string{correlationHeader: id}
+	}
+
+	return as.publisher.Publish(msg.Channel, m)
+}
+
+// recordValue returns the value of the SenML record.
+func recordValue(r senml.Record) (interface{}, error) {
+	switch {
+	case r.Value != nil:
+		return *r.Value, nil
+	case r.BoolValue != nil:
+		return *r.BoolValue, nil
+	case r.StringValue != nil:
+		return *r.StringValue, nil
+	case r.DataValue != nil:
+		b, err := base64.StdEncoding.DecodeString(*r.DataValue)
+		if err != nil {
+			return nil, ErrMalformedCommand
+		}
+		return b, nil
+	default:
+		return nil, ErrMalformedCommand
+	}
+}
@@ -4,11 +4,13 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ opcua.Service = (*loggingMiddleware)(nil)
@@ -142,3 +144,16 @@ func (lm loggingMiddleware) Browse(serverURI, namespace, identifier string) (nod
 
 	return lm.svc.Browse(serverURI, namespace, identifier)
 }
+
+func (lm loggingMiddleware) Command(ctx context.Context, msg messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("command %s on channel %s, took %s to complete", msg.Subtopic, msg.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Command(ctx, msg)
+}
@@ -4,10 +4,12 @@
 package api
 
 import (
+	"context"
 	"time"
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
 var _ opcua.Service = (*metricsMiddleware)(nil)
@@ -107,3 +109,12 @@ func (mm *metricsMiddleware) Browse(serverURI, namespace, identifier string) ([]
 
 	return mm.svc.Browse(serverURI, namespace, identifier)
 }
+
+func (mm *metricsMiddleware) Command(ctx context.Context, msg messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "command").Add(1)
+		mm.latency.With("method", "command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Command(ctx, msg)
+}
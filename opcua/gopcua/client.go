@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+
+	opcuaGopcua "github.com/gopcua/opcua"
+	uaGopcua "github.com/gopcua/opcua/ua"
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// connect connects to the OPC-UA Server using the security policy and mode
+// of the configuration.
+func connect(ctx context.Context, cfg opcua.Config) (*opcuaGopcua.Client, error) {
+	opts := []opcuaGopcua.Option{
+		opcuaGopcua.SecurityMode(uaGopcua.MessageSecurityModeNone),
+	}
+
+	if cfg.Mode != "" {
+		endpoints, err := opcuaGopcua.GetEndpoints(cfg.ServerURI)
+		if err != nil {
+			return nil, errors.Wrap(errFailedFetchEndpoint, err)
+		}
+
+		ep := opcuaGopcua.SelectEndpoint(endpoints, cfg.Policy, uaGopcua.MessageSecurityModeFromString(cfg.Mode))
+		if ep == nil {
+			return nil, errFailedFindEndpoint
+		}
+
+		opts = []opcuaGopcua.Option{
+			opcuaGopcua.SecurityPolicy(cfg.Policy),
+			opcuaGopcua.SecurityModeString(cfg.Mode),
+			opcuaGopcua.CertificateFile(cfg.CertFile),
+			opcuaGopcua.PrivateKeyFile(cfg.KeyFile),
+			opcuaGopcua.AuthAnonymous(),
+			opcuaGopcua.SecurityFromEndpoint(ep, uaGopcua.UserTokenTypeAnonymous),
+		}
+	}
+
+	oc := opcuaGopcua.NewClient(cfg.ServerURI, opts...)
+	if err := oc.Connect(ctx); err != nil {
+		return nil, errors.Wrap(errFailedConn, err)
+	}
+
+	return oc, nil
+}
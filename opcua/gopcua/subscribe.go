@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	opcuaGopcua "github.com/gopcua/opcua"
@@ -18,6 +19,7 @@ import (
 )
 
 const protocol = "opcua"
+const contentType = "application/senml+json"
 const token = ""
 
 var (
@@ -45,6 +47,20 @@ type client struct {
 	channelsRM opcua.RouteMapRepository
 	connectRM  opcua.RouteMapRepository
 	logger     logger.Logger
+
+	mu   sync.Mutex
+	subs map[connection]*subscription
+}
+
+// connection identifies the subscription of the thing connected to the
+// channel.
+type connection struct {
+	chanID  string
+	thingID string
+}
+
+type subscription struct {
+	cancel context.CancelFunc
 }
 
 type message struct {
@@ -58,46 +74,37 @@ type message struct {
 
 // NewSubscriber returns new OPC-UA client instance.
 func NewSubscriber(ctx context.Context, publisher messaging.Publisher, thingsRM, channelsRM, connectRM opcua.RouteMapRepository, log logger.Logger) opcua.Subscriber {
-	return client{
+	return &client{
 		ctx:        ctx,
 		publisher:  publisher,
 		thingsRM:   thingsRM,
 		channelsRM: channelsRM,
 		connectRM:  connectRM,
 		logger:     log,
+		subs:       make(map[connection]*subscription),
 	}
 }
 
-// Subscribe subscribes to the OPC-UA Server.
-func (c client) Subscribe(cfg opcua.Config) error {
-	opts := []opcuaGopcua.Option{
-		opcuaGopcua.SecurityMode(uaGopcua.MessageSecurityModeNone),
-	}
-
-	if cfg.Mode != "" {
-		endpoints, err := opcuaGopcua.GetEndpoints(cfg.ServerURI)
-		if err != nil {
-			return errors.Wrap(errFailedFetchEndpoint, err)
-		}
-
-		ep := opcuaGopcua.SelectEndpoint(endpoints, cfg.Policy, uaGopcua.MessageSecurityModeFromString(cfg.Mode))
-		if ep == nil {
-			return errFailedFindEndpoint
-		}
-
-		opts = []opcuaGopcua.Option{
-			opcuaGopcua.SecurityPolicy(cfg.Policy),
-			opcuaGopcua.SecurityModeString(cfg.Mode),
-			opcuaGopcua.CertificateFile(cfg.CertFile),
-			opcuaGopcua.PrivateKeyFile(cfg.KeyFile),
-			opcuaGopcua.AuthAnonymous(),
-			opcuaGopcua.SecurityFromEndpoint(ep, uaGopcua.UserTokenTypeAnonymous),
-		}
+// Subscribe subscribes to the OPC-UA Server. The previous subscription of
+// the connection is cancelled.
+func (c *client) Subscribe(node opcua.Node) error {
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+
+	conn := connection{chanID: node.ChanID, thingID: node.ThingID}
+	s := &subscription{cancel: cancel}
+	c.mu.Lock()
+	if prev, ok := c.subs[conn]; ok {
+		prev.cancel()
 	}
+	c.subs[conn] = s
+	c.mu.Unlock()
+	defer c.remove(conn, s)
 
-	oc := opcuaGopcua.NewClient(cfg.ServerURI, opts...)
-	if err := oc.Connect(c.ctx); err != nil {
-		return errors.Wrap(errFailedConn, err)
+	cfg := node.Config
+	oc, err := connect(ctx, cfg)
+	if err != nil {
+		return err
 	}
 	defer oc.Close()
 
@@ -114,14 +121,58 @@ func (c client) Subscribe(cfg opcua.Config) error {
 	}
 	defer sub.Cancel()
 
-	if err := c.runHandler(sub, cfg.ServerURI, cfg.NodeID); err != nil {
+	if err := c.runHandler(ctx, sub, cfg.ServerURI, cfg.NodeID); err != nil {
 		c.logger.Warn(fmt.Sprintf("Unsubscribed from OPC-UA node %s.%s: %s", cfg.ServerURI, cfg.NodeID, err))
 	}
 
 	return nil
 }
 
-func (c client) runHandler(sub *opcuaGopcua.Subscription, uri, node string) error {
+// Unsubscribe cancels the subscription of the connection.
+func (c *client) Unsubscribe(chanID, thingID string) {
+	c.cancel(func(conn connection) bool {
+		return conn.chanID == chanID && conn.thingID == thingID
+	})
+}
+
+// UnsubscribeThing cancels the subscriptions of the thing connections.
+func (c *client) UnsubscribeThing(thingID string) {
+	c.cancel(func(conn connection) bool {
+		return conn.thingID == thingID
+	})
+}
+
+// UnsubscribeChannel cancels the subscriptions of the channel connections.
+func (c *client) UnsubscribeChannel(chanID string) {
+	c.cancel(func(conn connection) bool {
+		return conn.chanID == chanID
+	})
+}
+
+func (c *client) cancel(match func(connection) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for conn, s := range c.subs {
+		if match(conn) {
+			s.cancel()
+			delete(c.subs, conn)
+		}
+	}
+}
+
+// remove removes the subscription of the connection, unless it's replaced
+// by the new one.
+func (c *client) remove(conn connection, s *subscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.subs[conn] == s {
+		delete(c.subs, conn)
+	}
+}
+
+func (c *client) runHandler(ctx context.Context, sub *opcuaGopcua.Subscription, uri, node string) error {
 	nodeID, err := uaGopcua.ParseNodeID(node)
 	if err != nil {
 		return errors.Wrap(errFailedParseNodeID, err)
@@ -138,13 +189,13 @@ func (c client) runHandler(sub *opcuaGopcua.Subscription, uri, node string) erro
 		return errResponseStatus
 	}
 
-	go sub.Run(c.ctx)
+	go sub.Run(ctx)
 
 	c.logger.Info(fmt.Sprintf("subscribed to server %s and node_id %s", uri, node))
 
 	for {
 		select {
-		case <-c.ctx.Done():
+		case <-ctx.Done():
 			return nil
 		case res := <-sub.Notifs:
 			if res.Error != nil {
@@ -205,7 +256,7 @@ func (c client) runHandler(sub *opcuaGopcua.Subscription, uri, node string) erro
 }
 
 // Publish forwards messages from the OPC-UA Server to Mainflux NATS broker
-func (c client) publish(token string, m message) error {
+func (c *client) publish(token string, m message) error {
 	// Get route-map of the OPC-UA ServerURI
 	chanID, err := c.channelsRM.Get(m.ServerURI)
 	if err != nil {
@@ -229,12 +280,17 @@ func (c client) publish(token string, m message) error {
 	payload := []byte(SenML)
 
 	msg := messaging.Message{
-		Publisher: thingID,
-		Protocol:  protocol,
-		Channel:   chanID,
-		Payload:   payload,
-		Subtopic:  m.NodeID,
-		Created:   time.Now().UnixNano(),
+		Publisher:   thingID,
+		Protocol:    protocol,
+		Channel:     chanID,
+		Payload:     payload,
+		Subtopic:    m.NodeID,
+		ContentType: contentType,
+		Headers: map[string]string{
+			"server_uri": m.ServerURI,
+			"node_id":    m.NodeID,
+		},
+		Created: time.Now().UnixNano(),
 	}
 
 	if err := c.publisher.Publish(msg.Channel, msg); err != nil {
@@ -0,0 +1,355 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package gopcua
+
+import (
+	"context"
+	"math"
+	"time"
+
+	opcuaGopcua "github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	uaGopcua "github.com/gopcua/opcua/ua"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/opcua"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const inputArguments = "InputArguments"
+
+var (
+	errFailedWrite       = errors.New("failed to write")
+	errFailedCall        = errors.New("failed to call method")
+	errUnsupportedType   = errors.New("unsupported node data type")
+	errConversion        = errors.New("value can't be converted to node data type")
+	errArgumentsCount    = errors.New("wrong number of method arguments")
+	errMalformedArgument = errors.New("malformed method input arguments")
+)
+
+// Method input arguments are described by the Argument extension objects,
+// which the client doesn't decode by itself.
+func init() {
+	uaGopcua.RegisterExtensionObject(uaGopcua.NewNumericNodeID(0, id.Argument_Encoding_DefaultBinary), new(uaGopcua.Argument))
+}
+
+var _ opcua.Writer = (*writer)(nil)
+
+type writer struct {
+	ctx    context.Context
+	logger logger.Logger
+}
+
+// NewWriter returns new OPC-UA writer instance.
+func NewWriter(ctx context.Context, log logger.Logger) opcua.Writer {
+	return writer{
+		ctx:    ctx,
+		logger: log,
+	}
+}
+
+func (w writer) Write(cfg opcua.Config, values []opcua.NodeValue) ([]error, error) {
+	oc, err := connect(w.ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer oc.Close()
+
+	errs := make([]error, len(values))
+	var (
+		idx   []int
+		nodes []*uaGopcua.NodeID
+	)
+	for i, v := range values {
+		nid, err := uaGopcua.ParseNodeID(v.NodeID)
+		if err != nil {
+			errs[i] = errors.Wrap(errFailedParseNodeID, err)
+			continue
+		}
+		idx = append(idx, i)
+		nodes = append(nodes, nid)
+	}
+	if len(nodes) == 0 {
+		return errs, nil
+	}
+
+	types, err := dataTypes(oc, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		widx []int
+		req  uaGopcua.WriteRequest
+	)
+	for j, i := range idx {
+		if types[j].err != nil {
+			errs[i] = types[j].err
+			continue
+		}
+		v, err := toVariant(values[i].Value, types[j].id)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		widx = append(widx, i)
+		req.NodesToWrite = append(req.NodesToWrite, &uaGopcua.WriteValue{
+			NodeID:      nodes[j],
+			AttributeID: uaGopcua.AttributeIDValue,
+			Value: &uaGopcua.DataValue{
+				EncodingMask: uaGopcua.DataValueValue,
+				Value:        v,
+			},
+		})
+	}
+	if len(req.NodesToWrite) == 0 {
+		return errs, nil
+	}
+
+	res, err := oc.Write(&req)
+	if err != nil {
+		return nil, errors.Wrap(errFailedWrite, err)
+	}
+	for k, i := range widx {
+		if k < len(res.Results) && res.Results[k] != uaGopcua.StatusOK {
+			errs[i] = errors.Wrap(errFailedWrite, res.Results[k])
+		}
+	}
+
+	return errs, nil
+}
+
+func (w writer) Call(cfg opcua.Config, objectID, methodID string, args []interface{}) ([]interface{}, error) {
+	oid, err := uaGopcua.ParseNodeID(objectID)
+	if err != nil {
+		return nil, errors.Wrap(errFailedParseNodeID, err)
+	}
+	mid, err := uaGopcua.ParseNodeID(methodID)
+	if err != nil {
+		return nil, errors.Wrap(errFailedParseNodeID, err)
+	}
+
+	oc, err := connect(w.ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer oc.Close()
+
+	types, err := argumentTypes(oc, mid)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) != len(args) {
+		return nil, errArgumentsCount
+	}
+
+	req := &uaGopcua.CallMethodRequest{
+		ObjectID: oid,
+		MethodID: mid,
+	}
+	for i, arg := range args {
+		v, err := toVariant(arg, types[i])
+		if err != nil {
+			return nil, err
+		}
+		req.InputArguments = append(req.InputArguments, v)
+	}
+
+	res, err := oc.Call(req)
+	if err != nil {
+		return nil, errors.Wrap(errFailedCall, err)
+	}
+	if res.StatusCode != uaGopcua.StatusOK {
+		return nil, errors.Wrap(errFailedCall, res.StatusCode)
+	}
+
+	outputs := []interface{}{}
+	for _, v := range res.OutputArguments {
+		outputs = append(outputs, v.Value())
+	}
+
+	return outputs, nil
+}
+
+type dataType struct {
+	id  *uaGopcua.NodeID
+	err error
+}
+
+// dataTypes reads the data types of the nodes.
+func dataTypes(oc *opcuaGopcua.Client, nodes []*uaGopcua.NodeID) ([]dataType, error) {
+	req := &uaGopcua.ReadRequest{
+		TimestampsToReturn: uaGopcua.TimestampsToReturnNeither,
+	}
+	for _, nid := range nodes {
+		req.NodesToRead = append(req.NodesToRead, &uaGopcua.ReadValueID{
+			NodeID:      nid,
+			AttributeID: uaGopcua.AttributeIDDataType,
+		})
+	}
+
+	res, err := oc.Read(req)
+	if err != nil {
+		return nil, errors.Wrap(errFailedRead, err)
+	}
+	if len(res.Results) != len(nodes) {
+		return nil, errResponseStatus
+	}
+
+	types := make([]dataType, len(nodes))
+	for i, r := range res.Results {
+		if r.Status != uaGopcua.StatusOK {
+			types[i].err = errors.Wrap(errFailedRead, r.Status)
+			continue
+		}
+		types[i].id = r.Value.NodeID()
+	}
+
+	return types, nil
+}
+
+// argumentTypes returns the data types of the method input arguments.
+func argumentTypes(oc *opcuaGopcua.Client, method *uaGopcua.NodeID) ([]*uaGopcua.NodeID, error) {
+	refs, err := oc.Node(method).References(id.HasProperty, uaGopcua.BrowseDirectionForward, uaGopcua.NodeClassVariable, true)
+	if err != nil {
+		return nil, errors.Wrap(errFailedRead, err)
+	}
+
+	for _, ref := range refs {
+		if ref.BrowseName == nil || ref.BrowseName.Name != inputArguments {
+			continue
+		}
+		v, err := oc.Node(ref.NodeID.NodeID).Value()
+		if err != nil {
+			return nil, errors.Wrap(errFailedRead, err)
+		}
+		eos, ok := v.Value().([]*uaGopcua.ExtensionObject)
+		if !ok {
+			return nil, errMalformedArgument
+		}
+
+		types := make([]*uaGopcua.NodeID, len(eos))
+		for i, eo := range eos {
+			arg, ok := eo.Value.(*uaGopcua.Argument)
+			if !ok {
+				return nil, errMalformedArgument
+			}
+			types[i] = arg.DataType
+		}
+		return types, nil
+	}
+
+	// The methods without arguments have no InputArguments property.
+	return nil, nil
+}
+
+// toVariant converts the value decoded from the SenML record to the variant
+// of the given built-in data type.
+func toVariant(value interface{}, dataType *uaGopcua.NodeID) (*uaGopcua.Variant, error) {
+	if dataType == nil || dataType.Namespace() != 0 {
+		return nil, errUnsupportedType
+	}
+
+	var v interface{}
+	switch t := dataType.IntID(); t {
+	case id.Boolean:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, errConversion
+		}
+		v = b
+	case id.SByte, id.Int16, id.Int32, id.Int64:
+		f, ok := value.(float64)
+		if !ok || f != math.Trunc(f) {
+			return nil, errConversion
+		}
+		switch t {
+		case id.SByte:
+			if f < math.MinInt8 || f > math.MaxInt8 {
+				return nil, errConversion
+			}
+			v = int8(f)
+		case id.Int16:
+			if f < math.MinInt16 || f > math.MaxInt16 {
+				return nil, errConversion
+			}
+			v = int16(f)
+		case id.Int32:
+			if f < math.MinInt32 || f > math.MaxInt32 {
+				return nil, errConversion
+			}
+			v = int32(f)
+		default:
+			if f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, errConversion
+			}
+			v = int64(f)
+		}
+	case id.Byte, id.UInt16, id.UInt32, id.UInt64:
+		f, ok := value.(float64)
+		if !ok || f != math.Trunc(f) || f < 0 {
+			return nil, errConversion
+		}
+		switch t {
+		case id.Byte:
+			if f > math.MaxUint8 {
+				return nil, errConversion
+			}
+			v = uint8(f)
+		case id.UInt16:
+			if f > math.MaxUint16 {
+				return nil, errConversion
+			}
+			v = uint16(f)
+		case id.UInt32:
+			if f > math.MaxUint32 {
+				return nil, errConversion
+			}
+			v = uint32(f)
+		default:
+			if f >= math.MaxUint64 {
+				return nil, errConversion
+			}
+			v = uint64(f)
+		}
+	case id.Float:
+		f, ok := value.(float64)
+		if !ok || math.Abs(f) > math.MaxFloat32 {
+			return nil, errConversion
+		}
+		v = float32(f)
+	case id.Double:
+		f, ok := value.(float64)
+		if !ok {
+			return nil, errConversion
+		}
+		v = f
+	case id.String:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errConversion
+		}
+		v = s
+	case id.ByteString:
+		switch b := value.(type) {
+		case []byte:
+			v = b
+		case string:
+			v = []byte(b)
+		default:
+			return nil, errConversion
+		}
+	case id.DateTime, id.UtcTime:
+		// SenML times are in seconds.
+		f, ok := value.(float64)
+		if !ok {
+			return nil, errConversion
+		}
+		sec, frac := math.Modf(f)
+		v = time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC()
+	default:
+		return nil, errUnsupportedType
+	}
+
+	return uaGopcua.NewVariant(v)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+// Node represents the OPC-UA node subscribed for the connection of the
+// thing to the channel, with the interval and security settings of the
+// subscription.
+type Node struct {
+	ChanID  string
+	ThingID string
+	Config  Config
+}
+
+// NodeRepository stores the subscribed nodes, so that the subscriptions are
+// restored on restart.
+type NodeRepository interface {
+	// Save stores the node subscribed for the connection.
+	Save(Node) error
+
+	// RetrieveAll returns all the stored nodes.
+	RetrieveAll() ([]Node, error)
+
+	// Remove removes the node subscribed for the connection.
+	Remove(chanID, thingID string) error
+
+	// RemoveThing removes the nodes subscribed for the thing connections.
+	RemoveThing(thingID string) error
+
+	// RemoveChannel removes the nodes subscribed for the channel connections.
+	RemoveChannel(chanID string) error
+}
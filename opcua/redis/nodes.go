@@ -0,0 +1,186 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/opcua"
+)
+
+const (
+	nodePrefix = "node"
+	nodesKey   = "nodes"
+)
+
+var _ opcua.NodeRepository = (*nodeRepository)(nil)
+
+type nodeRepository struct {
+	client *redis.Client
+}
+
+type dbNode struct {
+	ChanID    string `json:"chan_id"`
+	ThingID   string `json:"thing_id"`
+	ServerURI string `json:"server_uri"`
+	NodeID    string `json:"node_id"`
+	Interval  string `json:"interval"`
+	Policy    string `json:"policy,omitempty"`
+	Mode      string `json:"mode,omitempty"`
+	CertFile  string `json:"cert_file,omitempty"`
+	KeyFile   string `json:"key_file,omitempty"`
+}
+
+// NewNodeRepository returns redis subscribed nodes repository. The nodes are
+// indexed by the connection, and by the thing and the channel, so that they
+// are removed together with them.
+func NewNodeRepository(client *redis.Client) opcua.NodeRepository {
+	return &nodeRepository{
+		client: client,
+	}
+}
+
+func (nr *nodeRepository) Save(node opcua.Node) error {
+	val, err := json.Marshal(toDBNode(node))
+	if err != nil {
+		return err
+	}
+
+	id := connID(node.ChanID, node.ThingID)
+	_, err = nr.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Set(nodeKey(id), val, 0)
+		pipe.SAdd(nodesKey, id)
+		pipe.SAdd(thingNodesKey(node.ThingID), id)
+		pipe.SAdd(channelNodesKey(node.ChanID), id)
+		return nil
+	})
+
+	return err
+}
+
+func (nr *nodeRepository) RetrieveAll() ([]opcua.Node, error) {
+	ids, err := nr.client.SMembers(nodesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []opcua.Node{}
+	if len(ids) == 0 {
+		return nodes, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = nodeKey(id)
+	}
+	vals, err := nr.client.MGet(keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range vals {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var dbn dbNode
+		if err := json.Unmarshal([]byte(s), &dbn); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, toNode(dbn))
+	}
+
+	return nodes, nil
+}
+
+func (nr *nodeRepository) Remove(chanID, thingID string) error {
+	return nr.remove([]string{connID(chanID, thingID)})
+}
+
+func (nr *nodeRepository) RemoveThing(thingID string) error {
+	ids, err := nr.client.SMembers(thingNodesKey(thingID)).Result()
+	if err != nil {
+		return err
+	}
+
+	return nr.remove(ids)
+}
+
+func (nr *nodeRepository) RemoveChannel(chanID string) error {
+	ids, err := nr.client.SMembers(channelNodesKey(chanID)).Result()
+	if err != nil {
+		return err
+	}
+
+	return nr.remove(ids)
+}
+
+func (nr *nodeRepository) remove(ids []string) error {
+	_, err := nr.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			// The connection ID is "<chanID>:<thingID>", and neither of
+			// the IDs contains colon.
+			parts := strings.SplitN(id, ":", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			pipe.Del(nodeKey(id))
+			pipe.SRem(nodesKey, id)
+			pipe.SRem(channelNodesKey(parts[0]), id)
+			pipe.SRem(thingNodesKey(parts[1]), id)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func connID(chanID, thingID string) string {
+	return fmt.Sprintf("%s:%s", chanID, thingID)
+}
+
+func nodeKey(id string) string {
+	return fmt.Sprintf("%s:%s", nodePrefix, id)
+}
+
+func thingNodesKey(thingID string) string {
+	return fmt.Sprintf("%s:thing:%s", nodesKey, thingID)
+}
+
+func channelNodesKey(chanID string) string {
+	return fmt.Sprintf("%s:channel:%s", nodesKey, chanID)
+}
+
+func toDBNode(node opcua.Node) dbNode {
+	return dbNode{
+		ChanID:    node.ChanID,
+		ThingID:   node.ThingID,
+		ServerURI: node.Config.ServerURI,
+		NodeID:    node.Config.NodeID,
+		Interval:  node.Config.Interval,
+		Policy:    node.Config.Policy,
+		Mode:      node.Config.Mode,
+		CertFile:  node.Config.CertFile,
+		KeyFile:   node.Config.KeyFile,
+	}
+}
+
+func toNode(dbn dbNode) opcua.Node {
+	return opcua.Node{
+		ChanID:  dbn.ChanID,
+		ThingID: dbn.ThingID,
+		Config: opcua.Config{
+			ServerURI: dbn.ServerURI,
+			NodeID:    dbn.NodeID,
+			Interval:  dbn.Interval,
+			Policy:    dbn.Policy,
+			Mode:      dbn.Mode,
+			CertFile:  dbn.CertFile,
+			KeyFile:   dbn.KeyFile,
+		},
+	}
+}
@@ -5,6 +5,17 @@ package opcua
 
 // Subscriber represents the OPC-UA Server client.
 type Subscriber interface {
-	// Subscribes to given NodeID and receives events.
-	Subscribe(Config) error
+	// Subscribes to the node of the connection and receives events, until
+	// the subscription is cancelled.
+	Subscribe(Node) error
+
+	// Unsubscribe cancels the subscription of the connection.
+	Unsubscribe(chanID, thingID string)
+
+	// UnsubscribeThing cancels the subscriptions of the thing connections.
+	UnsubscribeThing(thingID string)
+
+	// UnsubscribeChannel cancels the subscriptions of the channel
+	// connections.
+	UnsubscribeChannel(chanID string)
 }
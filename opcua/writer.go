@@ -0,0 +1,24 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+// NodeValue represents the value written to the OPC-UA node. The value is
+// float64, bool, string or []byte, as decoded from the SenML record, and it
+// is converted to the data type of the node.
+type NodeValue struct {
+	NodeID string
+	Value  interface{}
+}
+
+// Writer represents the OPC-UA Server client which writes node values and
+// calls methods.
+type Writer interface {
+	// Write writes the values to the nodes of the server. The returned
+	// errors correspond to the values.
+	Write(cfg Config, values []NodeValue) ([]error, error)
+
+	// Call calls the method of the object node with the input arguments,
+	// and returns the output arguments.
+	Call(cfg Config, objectID, methodID string, args []interface{}) ([]interface{}, error)
+}
@@ -0,0 +1,190 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package codec contains declarative decoders of binary device payloads to
+// SenML, shared by the adapters.
+package codec
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/senml"
+)
+
+// Field types.
+const (
+	Bool    = "bool"
+	Uint8   = "uint8"
+	Int8    = "int8"
+	Uint16  = "uint16"
+	Int16   = "int16"
+	Uint32  = "uint32"
+	Int32   = "int32"
+	Uint64  = "uint64"
+	Int64   = "int64"
+	Float32 = "float32"
+	Float64 = "float64"
+)
+
+// Byte orders of the fields.
+const (
+	BigEndian    = "big"
+	LittleEndian = "little"
+)
+
+// ContentType is the content type of the decoded payload.
+const ContentType = "application/senml+json"
+
+const (
+	maxFields     = 100
+	maxNameLength = 255
+
+	// maxFrameSize is the maximum size of the decoded payload, which limits
+	// the field offsets.
+	maxFrameSize = 65535
+)
+
+var sizes = map[string]int{
+	Bool:    1,
+	Uint8:   1,
+	Int8:    1,
+	Uint16:  2,
+	Int16:   2,
+	Uint32:  4,
+	Int32:   4,
+	Uint64:  8,
+	Int64:   8,
+	Float32: 4,
+	Float64: 8,
+}
+
+var (
+	// ErrMalformedCodec indicates malformed codec definition.
+	ErrMalformedCodec = errors.New("malformed codec")
+
+	// ErrShortPayload indicates the payload shorter than the codec fields.
+	ErrShortPayload = errors.New("payload is shorter than codec fields")
+
+	// ErrNotFound indicates a non-existent codec.
+	ErrNotFound = errors.New("codec not found")
+)
+
+// Codec describes the binary frame layout of the device payload.
+type Codec struct {
+	Fields []Field `json:"fields"`
+}
+
+// Field describes the value at the given offset of the payload, which is
+// decoded to the SenML record of the same name.
+type Field struct {
+	Name   string `json:"name"`
+	Offset int    `json:"offset"`
+	Type   string `json:"type"`
+
+	// Endianness is either BigEndian, which is default, or LittleEndian.
+	Endianness string `json:"endianness,omitempty"`
+
+	// Scale multiplies the numeric value, if set.
+	Scale float64 `json:"scale,omitempty"`
+
+	// Unit is the SenML unit of the value.
+	Unit string `json:"unit,omitempty"`
+}
+
+// Validate returns ErrMalformedCodec if the codec is not valid.
+func (c Codec) Validate() error {
+	if len(c.Fields) == 0 || len(c.Fields) > maxFields {
+		return ErrMalformedCodec
+	}
+
+	for _, f := range c.Fields {
+		if f.Name == "" || len(f.Name) > maxNameLength || len(f.Unit) > maxNameLength {
+			return ErrMalformedCodec
+		}
+		size, ok := sizes[f.Type]
+		if !ok {
+			return ErrMalformedCodec
+		}
+		if f.Offset < 0 || f.Offset > maxFrameSize-size {
+			return ErrMalformedCodec
+		}
+		if f.Endianness != "" && f.Endianness != BigEndian && f.Endianness != LittleEndian {
+			return ErrMalformedCodec
+		}
+	}
+
+	return nil
+}
+
+// Decode decodes the payload to SenML JSON. The records have no time, so
+// the consumers use the time the message was created.
+func (c Codec) Decode(payload []byte) ([]byte, error) {
+	recs := make([]senml.Record, len(c.Fields))
+	for i, f := range c.Fields {
+		rec, err := f.decode(payload)
+		if err != nil {
+			return nil, err
+		}
+		recs[i] = rec
+	}
+
+	return senml.Encode(senml.Pack{Records: recs}, senml.JSON)
+}
+
+func (f Field) decode(payload []byte) (senml.Record, error) {
+	size, ok := sizes[f.Type]
+	if !ok {
+		return senml.Record{}, ErrMalformedCodec
+	}
+	if f.Offset < 0 || f.Offset > len(payload)-size {
+		return senml.Record{}, ErrShortPayload
+	}
+	b := payload[f.Offset : f.Offset+size]
+
+	rec := senml.Record{
+		Name: f.Name,
+		Unit: f.Unit,
+	}
+	if f.Type == Bool {
+		v := b[0] != 0
+		rec.BoolValue = &v
+		return rec, nil
+	}
+
+	var order binary.ByteOrder = binary.BigEndian
+	if f.Endianness == LittleEndian {
+		order = binary.LittleEndian
+	}
+
+	var v float64
+	switch f.Type {
+	case Uint8:
+		v = float64(b[0])
+	case Int8:
+		v = float64(int8(b[0]))
+	case Uint16:
+		v = float64(order.Uint16(b))
+	case Int16:
+		v = float64(int16(order.Uint16(b)))
+	case Uint32:
+		v = float64(order.Uint32(b))
+	case Int32:
+		v = float64(int32(order.Uint32(b)))
+	case Uint64:
+		v = float64(order.Uint64(b))
+	case Int64:
+		v = float64(int64(order.Uint64(b)))
+	case Float32:
+		v = float64(math.Float32frombits(order.Uint32(b)))
+	case Float64:
+		v = math.Float64frombits(order.Uint64(b))
+	}
+	if f.Scale != 0 {
+		v *= f.Scale
+	}
+	rec.Value = &v
+
+	return rec, nil
+}
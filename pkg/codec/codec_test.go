@@ -0,0 +1,240 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package codec_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/codec"
+	"github.com/mainflux/mainflux/pkg/codec/mocks"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+const maxInt = int(^uint(0) >> 1)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		desc  string
+		codec codec.Codec
+		err   error
+	}{
+		{
+			desc: "validate valid codec",
+			codec: codec.Codec{Fields: []codec.Field{
+				{Name: "temperature", Offset: 0, Type: codec.Int16, Endianness: codec.LittleEndian, Scale: 0.01, Unit: "Cel"},
+				{Name: "open", Offset: 2, Type: codec.Bool},
+			}},
+			err: nil,
+		},
+		{
+			desc:  "validate codec without fields",
+			codec: codec.Codec{},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with unnamed field",
+			codec: codec.Codec{Fields: []codec.Field{{Type: codec.Uint8}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with negative offset",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "v", Offset: -1, Type: codec.Uint8}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with huge offset",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "v", Offset: maxInt - 1, Type: codec.Uint16}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with field past maximum frame size",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "v", Offset: 65534, Type: codec.Uint16}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with huge name length",
+			codec: codec.Codec{Fields: []codec.Field{{Name: strings.Repeat("v", 256), Type: codec.Uint8}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with huge unit length",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "v", Type: codec.Uint8, Unit: strings.Repeat("u", 256)}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with unknown type",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "v", Type: "uint128"}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with unknown endianness",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "v", Type: codec.Uint16, Endianness: "middle"}}},
+			err:   codec.ErrMalformedCodec,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.codec.Validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		desc    string
+		codec   codec.Codec
+		payload []byte
+		res     string
+		err     error
+	}{
+		{
+			desc: "decode big endian fields",
+			codec: codec.Codec{Fields: []codec.Field{
+				{Name: "counter", Offset: 0, Type: codec.Uint16},
+				{Name: "delta", Offset: 2, Type: codec.Int8},
+			}},
+			payload: []byte{0x01, 0x02, 0xff},
+			res:     `[{"n":"counter","v":258},{"n":"delta","v":-1}]`,
+		},
+		{
+			desc: "decode little endian scaled field with unit",
+			codec: codec.Codec{Fields: []codec.Field{
+				{Name: "temperature", Offset: 1, Type: codec.Int16, Endianness: codec.LittleEndian, Scale: 0.5, Unit: "Cel"},
+			}},
+			payload: []byte{0x00, 0x2e, 0x00},
+			res:     `[{"n":"temperature","u":"Cel","v":23}]`,
+		},
+		{
+			desc: "decode float and bool fields",
+			codec: codec.Codec{Fields: []codec.Field{
+				{Name: "level", Offset: 0, Type: codec.Float32},
+				{Name: "open", Offset: 4, Type: codec.Bool},
+			}},
+			payload: []byte{0x3f, 0xc0, 0x00, 0x00, 0x01},
+			res:     `[{"n":"level","v":1.5},{"n":"open","vb":true}]`,
+		},
+		{
+			desc: "decode short payload",
+			codec: codec.Codec{Fields: []codec.Field{
+				{Name: "counter", Offset: 1, Type: codec.Uint32},
+			}},
+			payload: []byte{0x01, 0x02, 0x03},
+			err:     codec.ErrShortPayload,
+		},
+		{
+			desc: "decode field with huge offset",
+			codec: codec.Codec{Fields: []codec.Field{
+				{Name: "counter", Offset: maxInt - 1, Type: codec.Uint16},
+			}},
+			payload: []byte{0x01, 0x02, 0x03},
+			err:     codec.ErrShortPayload,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := tc.codec.Decode(tc.payload)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.JSONEq(t, tc.res, string(res), fmt.Sprintf("%s: unexpected payload", tc.desc))
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	thingCodec := codec.Codec{Fields: []codec.Field{{Name: "thing", Type: codec.Uint8}}}
+	chanCodec := codec.Codec{Fields: []codec.Field{{Name: "channel", Type: codec.Uint8}}}
+
+	repo := mocks.NewRepository()
+	repo.Save(codec.Thing, "1", thingCodec)
+	repo.Save(codec.Channel, "1", chanCodec)
+
+	cases := []struct {
+		desc    string
+		thingID string
+		chanID  string
+		codec   codec.Codec
+		err     error
+	}{
+		{
+			desc:    "resolve codec of thing with codec",
+			thingID: "1",
+			chanID:  "1",
+			codec:   thingCodec,
+		},
+		{
+			desc:    "resolve codec of thing without codec",
+			thingID: "2",
+			chanID:  "1",
+			codec:   chanCodec,
+		},
+		{
+			desc:    "resolve codec of thing and channel without codecs",
+			thingID: "2",
+			chanID:  "2",
+			err:     codec.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := codec.Resolve(repo, tc.thingID, tc.chanID)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.codec, c, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.codec, c))
+	}
+}
+
+func TestDecodeMessage(t *testing.T) {
+	c := codec.Codec{Fields: []codec.Field{{Name: "temperature", Type: codec.Uint8, Unit: "Cel"}}}
+
+	repo := mocks.NewRepository()
+	repo.Save(codec.Channel, "1", c)
+
+	cases := []struct {
+		desc string
+		repo codec.Repository
+		msg  messaging.Message
+		res  messaging.Message
+		err  error
+	}{
+		{
+			desc: "decode binary message with codec",
+			repo: repo,
+			msg:  messaging.Message{Channel: "1", ContentType: codec.BinaryContentType, Payload: []byte{21}},
+			res:  messaging.Message{Channel: "1", ContentType: codec.ContentType, Payload: []byte(`[{"n":"temperature","u":"Cel","v":21}]`)},
+		},
+		{
+			desc: "decode short binary message with codec",
+			repo: repo,
+			msg:  messaging.Message{Channel: "1", ContentType: codec.BinaryContentType, Payload: []byte{}},
+			res:  messaging.Message{Channel: "1", ContentType: codec.BinaryContentType, Payload: []byte{}},
+			err:  codec.ErrShortPayload,
+		},
+		{
+			desc: "decode binary message without codec",
+			repo: repo,
+			msg:  messaging.Message{Channel: "2", ContentType: codec.BinaryContentType, Payload: []byte{21}},
+			res:  messaging.Message{Channel: "2", ContentType: codec.BinaryContentType, Payload: []byte{21}},
+		},
+		{
+			desc: "decode SenML message with codec",
+			repo: repo,
+			msg:  messaging.Message{Channel: "1", ContentType: codec.ContentType, Payload: []byte(`[{"n":"temperature","v":21}]`)},
+			res:  messaging.Message{Channel: "1", ContentType: codec.ContentType, Payload: []byte(`[{"n":"temperature","v":21}]`)},
+		},
+		{
+			desc: "decode binary message without repository",
+			repo: nil,
+			msg:  messaging.Message{Channel: "1", ContentType: codec.BinaryContentType, Payload: []byte{21}},
+			res:  messaging.Message{Channel: "1", ContentType: codec.BinaryContentType, Payload: []byte{21}},
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := codec.DecodeMessage(tc.repo, tc.msg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, res))
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/codec"
+)
+
+var _ codec.Repository = (*repositoryMock)(nil)
+
+type repositoryMock struct {
+	mu     sync.Mutex
+	codecs map[string]codec.Codec
+}
+
+// NewRepository returns in-memory codec repository.
+func NewRepository() codec.Repository {
+	return &repositoryMock{
+		codecs: make(map[string]codec.Codec),
+	}
+}
+
+func (rm *repositoryMock) Save(kind, id string, c codec.Codec) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.codecs[kind+":"+id] = c
+	return nil
+}
+
+func (rm *repositoryMock) Retrieve(kind, id string) (codec.Codec, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	c, ok := rm.codecs[kind+":"+id]
+	if !ok {
+		return codec.Codec{}, codec.ErrNotFound
+	}
+	return c, nil
+}
+
+func (rm *repositoryMock) Remove(kind, id string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	delete(rm.codecs, kind+":"+id)
+	return nil
+}
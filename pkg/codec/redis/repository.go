@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the codec repository implementation using Redis as
+// the underlying database.
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/pkg/codec"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const keyPrefix = "codec"
+
+var _ codec.Repository = (*repository)(nil)
+
+type repository struct {
+	client *redis.Client
+}
+
+// NewRepository returns new Redis codec repository.
+func NewRepository(client *redis.Client) codec.Repository {
+	return &repository{
+		client: client,
+	}
+}
+
+func (r *repository) Save(kind, id string, c codec.Codec) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(key(kind, id), data, 0).Err()
+}
+
+func (r *repository) Retrieve(kind, id string) (codec.Codec, error) {
+	data, err := r.client.Get(key(kind, id)).Bytes()
+	if err == redis.Nil {
+		return codec.Codec{}, codec.ErrNotFound
+	}
+	if err != nil {
+		return codec.Codec{}, err
+	}
+
+	var c codec.Codec
+	if err := json.Unmarshal(data, &c); err != nil {
+		return codec.Codec{}, errors.Wrap(codec.ErrMalformedCodec, err)
+	}
+
+	return c, nil
+}
+
+func (r *repository) Remove(kind, id string) error {
+	return r.client.Del(key(kind, id)).Err()
+}
+
+func key(kind, id string) string {
+	return fmt.Sprintf("%s:%s:%s", keyPrefix, kind, id)
+}
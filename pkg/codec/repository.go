@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package codec
+
+import "github.com/mainflux/mainflux/pkg/messaging"
+
+// BinaryContentType is the content type of the payloads decoded by the
+// adapters.
+const BinaryContentType = "application/octet-stream"
+
+// Kinds of the entities the codecs are assigned to.
+const (
+	Thing   = "thing"
+	Channel = "channel"
+)
+
+// Repository stores the codecs of things and channels.
+type Repository interface {
+	// Save stores the codec of the entity of the given kind.
+	Save(kind, id string, c Codec) error
+
+	// Retrieve returns the codec of the entity of the given kind, or
+	// ErrNotFound if the entity has no codec.
+	Retrieve(kind, id string) (Codec, error)
+
+	// Remove removes the codec of the entity of the given kind.
+	Remove(kind, id string) error
+}
+
+// Resolve returns the codec of the thing if there is one, and the codec of
+// the channel otherwise. ErrNotFound is returned if neither has a codec.
+func Resolve(repo Repository, thingID, chanID string) (Codec, error) {
+	c, err := repo.Retrieve(Thing, thingID)
+	if err != ErrNotFound {
+		return c, err
+	}
+
+	return repo.Retrieve(Channel, chanID)
+}
+
+// DecodeMessage decodes the binary payload of the message using the codec of
+// the publisher or the channel. The other payloads, the payloads without the
+// codec and the messages of the adapters without the repository are returned
+// as they are.
+func DecodeMessage(repo Repository, msg messaging.Message) (messaging.Message, error) {
+	if repo == nil || msg.ContentType != BinaryContentType {
+		return msg, nil
+	}
+
+	c, err := Resolve(repo, msg.Publisher, msg.Channel)
+	switch err {
+	case nil:
+	case ErrNotFound:
+		return msg, nil
+	default:
+		return msg, err
+	}
+
+	payload, err := c.Decode(msg.Payload)
+	if err != nil {
+		return msg, err
+	}
+	msg.Payload = payload
+	msg.ContentType = ContentType
+
+	return msg, nil
+}
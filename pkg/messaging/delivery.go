@@ -0,0 +1,53 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import "strconv"
+
+const (
+	// DeliveryHeader is set by the subscribers that redeliver the messages
+	// which failed to be handled. It holds the number of the current
+	// delivery attempt.
+	DeliveryHeader = "mf-delivery"
+
+	// MaxDeliverHeader holds the maximum number of delivery attempts.
+	MaxDeliverHeader = "mf-max-deliver"
+)
+
+// LastDelivery reports whether the message is delivered for the last time,
+// i.e. whether it is dropped if the handler fails. Messages without the
+// delivery headers are delivered only once.
+func LastDelivery(msg Message) bool {
+	n, err := strconv.ParseUint(msg.Headers[DeliveryHeader], 10, 64)
+	if err != nil {
+		return true
+	}
+	max, err := strconv.ParseUint(msg.Headers[MaxDeliverHeader], 10, 64)
+	if err != nil {
+		return true
+	}
+
+	return n >= max
+}
+
+// StripDelivery removes the delivery headers from the message.
+func StripDelivery(msg Message) Message {
+	if _, ok := msg.Headers[DeliveryHeader]; !ok {
+		return msg
+	}
+
+	headers := make(map[string]string, len(msg.Headers))
+	for k, v := range msg.Headers {
+		if k == DeliveryHeader || k == MaxDeliverHeader {
+			continue
+		}
+		headers[k] = v
+	}
+	if len(headers) == 0 {
+		headers = nil
+	}
+	msg.Headers = headers
+
+	return msg
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastDelivery(t *testing.T) {
+	cases := []struct {
+		desc    string
+		headers map[string]string
+		last    bool
+	}{
+		{
+			desc:    "message without delivery headers",
+			headers: nil,
+			last:    true,
+		},
+		{
+			desc:    "first of several deliveries",
+			headers: map[string]string{messaging.DeliveryHeader: "1", messaging.MaxDeliverHeader: "5"},
+			last:    false,
+		},
+		{
+			desc:    "last delivery",
+			headers: map[string]string{messaging.DeliveryHeader: "5", messaging.MaxDeliverHeader: "5"},
+			last:    true,
+		},
+		{
+			desc:    "delivery with invalid max deliver",
+			headers: map[string]string{messaging.DeliveryHeader: "1", messaging.MaxDeliverHeader: "invalid"},
+			last:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		last := messaging.LastDelivery(messaging.Message{Headers: tc.headers})
+		assert.Equal(t, tc.last, last, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.last, last))
+	}
+}
+
+func TestStripDelivery(t *testing.T) {
+	cases := []struct {
+		desc     string
+		headers  map[string]string
+		expected map[string]string
+	}{
+		{
+			desc:     "strip delivery headers",
+			headers:  map[string]string{messaging.DeliveryHeader: "1", messaging.MaxDeliverHeader: "5", "key": "value"},
+			expected: map[string]string{"key": "value"},
+		},
+		{
+			desc:     "strip delivery headers only",
+			headers:  map[string]string{messaging.DeliveryHeader: "1", messaging.MaxDeliverHeader: "5"},
+			expected: nil,
+		},
+		{
+			desc:     "strip message without delivery headers",
+			headers:  map[string]string{"key": "value"},
+			expected: map[string]string{"key": "value"},
+		},
+	}
+
+	for _, tc := range cases {
+		msg := messaging.StripDelivery(messaging.Message{Headers: tc.headers})
+		assert.Equal(t, tc.expected, msg.Headers, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.expected, msg.Headers))
+	}
+}
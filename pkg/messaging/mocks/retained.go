@@ -0,0 +1,51 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ messaging.RetainedRepository = (*retainedRepositoryMock)(nil)
+
+type retainedRepositoryMock struct {
+	mu   sync.Mutex
+	msgs map[string]map[string]messaging.Message
+}
+
+// NewRetainedRepository returns in-memory retained messages repository.
+func NewRetainedRepository() messaging.RetainedRepository {
+	return &retainedRepositoryMock{
+		msgs: make(map[string]map[string]messaging.Message),
+	}
+}
+
+func (rrm *retainedRepositoryMock) Save(msg messaging.Message) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.msgs[msg.Channel]; !ok {
+		rrm.msgs[msg.Channel] = make(map[string]messaging.Message)
+	}
+	if stored, ok := rrm.msgs[msg.Channel][msg.Subtopic]; ok && stored.Created > msg.Created {
+		return nil
+	}
+	rrm.msgs[msg.Channel][msg.Subtopic] = msg
+	return nil
+}
+
+func (rrm *retainedRepositoryMock) Retrieve(chanID, subtopic string) ([]messaging.Message, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	msgs := []messaging.Message{}
+	for st, msg := range rrm.msgs[chanID] {
+		if messaging.MatchSubtopic(subtopic, st) {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	log "github.com/mainflux/mainflux/logger"
+)
+
+const (
+	defJetStream  = "false"
+	defMaxDeliver = "5"
+	defAckWait    = "30s"
+
+	envJetStream  = "MF_NATS_JETSTREAM"
+	envStream     = "MF_NATS_JETSTREAM_STREAM"
+	envDurable    = "MF_NATS_JETSTREAM_DURABLE"
+	envMaxDeliver = "MF_NATS_JETSTREAM_MAX_DELIVER"
+	envAckWait    = "MF_NATS_JETSTREAM_ACK_WAIT"
+	envStartSeq   = "MF_NATS_JETSTREAM_START_SEQ"
+	envStartTime  = "MF_NATS_JETSTREAM_START_TIME"
+)
+
+// NewPubSubFromEnv returns JetStream publisher/subscriber if the
+// MF_NATS_JETSTREAM environment variable is set to true, and core NATS
+// publisher/subscriber otherwise. Parameter durable is the default durable
+// consumer name, which can be overridden by MF_NATS_JETSTREAM_DURABLE.
+func NewPubSubFromEnv(url, queue, durable string, logger log.Logger) (PubSub, error) {
+	enabled, cfg, err := loadJetStreamConfig(durable)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return NewPubSub(url, queue, logger)
+	}
+
+	return NewJetStreamPubSub(url, queue, cfg, logger)
+}
+
+// NewPublisherFromEnv returns JetStream publisher if the MF_NATS_JETSTREAM
+// environment variable is set to true, and core NATS publisher otherwise.
+func NewPublisherFromEnv(url string) (Publisher, error) {
+	enabled, cfg, err := loadJetStreamConfig("")
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return NewPublisher(url)
+	}
+
+	return NewJetStreamPublisher(url, cfg)
+}
+
+func loadJetStreamConfig(durable string) (bool, JetStreamConfig, error) {
+	enabled, err := strconv.ParseBool(mainflux.Env(envJetStream, defJetStream))
+	if err != nil {
+		return false, JetStreamConfig{}, fmt.Errorf("invalid value of %s: %s", envJetStream, err)
+	}
+	if !enabled {
+		return false, JetStreamConfig{}, nil
+	}
+
+	maxDeliver, err := strconv.Atoi(mainflux.Env(envMaxDeliver, defMaxDeliver))
+	if err != nil {
+		return false, JetStreamConfig{}, fmt.Errorf("invalid value of %s: %s", envMaxDeliver, err)
+	}
+
+	ackWait, err := time.ParseDuration(mainflux.Env(envAckWait, defAckWait))
+	if err != nil {
+		return false, JetStreamConfig{}, fmt.Errorf("invalid value of %s: %s", envAckWait, err)
+	}
+
+	var startSeq uint64
+	if v := mainflux.Env(envStartSeq, ""); v != "" {
+		if startSeq, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return false, JetStreamConfig{}, fmt.Errorf("invalid value of %s: %s", envStartSeq, err)
+		}
+	}
+
+	var startTime time.Time
+	if v := mainflux.Env(envStartTime, ""); v != "" {
+		if startTime, err = time.Parse(time.RFC3339, v); err != nil {
+			return false, JetStreamConfig{}, fmt.Errorf("invalid value of %s: %s", envStartTime, err)
+		}
+	}
+
+	cfg := JetStreamConfig{
+		Stream:     mainflux.Env(envStream, DefStream),
+		Durable:    mainflux.Env(envDurable, durable),
+		MaxDeliver: maxDeliver,
+		AckWait:    ackWait,
+		StartSeq:   startSeq,
+		StartTime:  startTime,
+	}
+	return true, cfg, nil
+}
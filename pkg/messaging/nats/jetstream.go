@@ -0,0 +1,282 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+// DefStream is the name of the JetStream stream that stores all the messages
+// published to the channels.
+const DefStream = "mainflux"
+
+var (
+	_ messaging.PubSub    = (*jsPubSub)(nil)
+	_ messaging.Publisher = (*jsPublisher)(nil)
+)
+
+// durableReplacer turns a subject into a valid durable consumer name.
+var durableReplacer = strings.NewReplacer(".", "_", "*", "any", ">", "all")
+
+// JetStreamConfig contains JetStream stream and consumer configuration.
+type JetStreamConfig struct {
+	// Stream is the name of the stream bound to the channels subjects.
+	Stream string
+	// Durable is the durable consumer name prefix. Consumer created for
+	// a subscription is named after the prefix and the subscribed topic.
+	Durable string
+	// MaxDeliver is the maximum number of delivery attempts.
+	MaxDeliver int
+	// AckWait is the duration after which an unacknowledged message is
+	// redelivered.
+	AckWait time.Duration
+	// StartSeq replays the stream starting from the given sequence.
+	StartSeq uint64
+	// StartTime replays the stream starting from the given time. It is
+	// ignored if StartSeq is set.
+	StartTime time.Time
+}
+
+type jsPublisher struct {
+	conn   *broker.Conn
+	js     broker.JetStreamContext
+	stream string
+}
+
+type jsPubSub struct {
+	jsPublisher
+	cfg           JetStreamConfig
+	logger        log.Logger
+	mu            sync.Mutex
+	queue         string
+	subscriptions map[string]*broker.Subscription
+}
+
+// NewJetStreamPublisher returns NATS JetStream message Publisher. Messages
+// are persisted in the configured stream, which is created if it does not
+// exist.
+func NewJetStreamPublisher(url string, cfg JetStreamConfig) (Publisher, error) {
+	pub, err := newJetStreamPublisher(url, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pub, nil
+}
+
+// NewJetStreamPubSub returns NATS JetStream message publisher/subscriber.
+// Every subscription is backed by a durable consumer, so messages published
+// while the subscriber is down are delivered once it subscribes again.
+// Message is acknowledged when the handler returns no error, otherwise
+// it is redelivered until the MaxDeliver attempts are reached. Parameter
+// queue has the same meaning as in NewPubSub: if set, subscribers sharing
+// the queue (and the durable name) split the messages between them.
+// Note that the start position is applied only when the durable consumer
+// is created, so replaying already consumed messages requires a new
+// durable name.
+func NewJetStreamPubSub(url, queue string, cfg JetStreamConfig, logger log.Logger) (PubSub, error) {
+	if cfg.Durable == "" {
+		return nil, errEmptyDurable
+	}
+
+	pub, err := newJetStreamPublisher(url, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &jsPubSub{
+		jsPublisher:   pub,
+		cfg:           cfg,
+		queue:         queue,
+		logger:        logger,
+		subscriptions: make(map[string]*broker.Subscription),
+	}
+	return ret, nil
+}
+
+func newJetStreamPublisher(url string, cfg JetStreamConfig) (jsPublisher, error) {
+	if cfg.Stream == "" {
+		cfg.Stream = DefStream
+	}
+
+	conn, err := broker.Connect(url)
+	if err != nil {
+		return jsPublisher{}, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return jsPublisher{}, err
+	}
+
+	if _, err := js.StreamInfo(cfg.Stream); err != nil {
+		sc := &broker.StreamConfig{
+			Name:     cfg.Stream,
+			Subjects: []string{SubjectAllChannels},
+			Storage:  broker.FileStorage,
+		}
+		if _, err := js.AddStream(sc); err != nil {
+			conn.Close()
+			return jsPublisher{}, err
+		}
+	}
+
+	ret := jsPublisher{
+		conn:   conn,
+		js:     js,
+		stream: cfg.Stream,
+	}
+	return ret, nil
+}
+
+func (pub *jsPublisher) Publish(topic string, msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+	if _, err := pub.js.Publish(subject, data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (pub *jsPublisher) Close() {
+	pub.conn.Close()
+}
+
+func (ps *jsPubSub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if _, ok := ps.subscriptions[topic]; ok {
+		return errAlreadySubscribed
+	}
+
+	opts := []broker.SubOpt{
+		broker.BindStream(ps.stream),
+		broker.Durable(fmt.Sprintf("%s_%s", ps.cfg.Durable, durableReplacer.Replace(topic))),
+		broker.ManualAck(),
+		broker.AckExplicit(),
+	}
+	if ps.cfg.MaxDeliver > 0 {
+		opts = append(opts, broker.MaxDeliver(ps.cfg.MaxDeliver))
+	}
+	if ps.cfg.AckWait > 0 {
+		opts = append(opts, broker.AckWait(ps.cfg.AckWait))
+	}
+	switch {
+	case ps.cfg.StartSeq > 0:
+		opts = append(opts, broker.StartSequence(ps.cfg.StartSeq))
+	case !ps.cfg.StartTime.IsZero():
+		opts = append(opts, broker.StartTime(ps.cfg.StartTime))
+	default:
+		opts = append(opts, broker.DeliverAll())
+	}
+
+	nh := ps.natsHandler(handler)
+
+	if ps.queue != "" {
+		sub, err := ps.js.QueueSubscribe(topic, ps.queue, nh, opts...)
+		if err != nil {
+			return err
+		}
+		ps.subscriptions[topic] = sub
+		return nil
+	}
+	sub, err := ps.js.Subscribe(topic, nh, opts...)
+	if err != nil {
+		return err
+	}
+	ps.subscriptions[topic] = sub
+	return nil
+}
+
+// Unsubscribe removes the subscription together with its durable consumer.
+// Use Close to stop consuming while preserving the consumer state.
+func (ps *jsPubSub) Unsubscribe(topic string) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub, ok := ps.subscriptions[topic]
+	if !ok {
+		return errNotSubscribed
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		return err
+	}
+
+	delete(ps.subscriptions, topic)
+	return nil
+}
+
+func (ps *jsPubSub) natsHandler(h messaging.MessageHandler) broker.MsgHandler {
+	return func(m *broker.Msg) {
+		var msg messaging.Message
+		if err := proto.Unmarshal(m.Data, &msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+			// Redelivery can't fix malformed message.
+			if err := m.Term(); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to terminate message delivery: %s", err))
+			}
+			return
+		}
+		ps.setDelivery(m, &msg)
+		if err := h(msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+			ps.nak(m)
+			return
+		}
+		if err := m.Ack(); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to acknowledge message: %s", err))
+		}
+	}
+}
+
+// setDelivery lets the handler know whether the message is going to be
+// redelivered if it fails to be handled.
+func (ps *jsPubSub) setDelivery(m *broker.Msg, msg *messaging.Message) {
+	if ps.cfg.MaxDeliver <= 0 {
+		return
+	}
+	meta, err := m.Metadata()
+	if err != nil {
+		return
+	}
+	if msg.Headers == nil {
+		msg.Headers = make(map[string]string)
+	}
+	msg.Headers[messaging.DeliveryHeader] = strconv.FormatUint(meta.NumDelivered, 10)
+	msg.Headers[messaging.MaxDeliverHeader] = strconv.Itoa(ps.cfg.MaxDeliver)
+}
+
+func (ps *jsPubSub) nak(m *broker.Msg) {
+	if meta, err := m.Metadata(); err == nil && ps.cfg.MaxDeliver > 0 && meta.NumDelivered >= uint64(ps.cfg.MaxDeliver) {
+		ps.logger.Error(fmt.Sprintf("Dropping message %d from stream %s after %d delivery attempts", meta.Sequence.Stream, meta.Stream, meta.NumDelivered))
+	}
+	if err := m.Nak(); err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to negatively acknowledge message: %s", err))
+	}
+}
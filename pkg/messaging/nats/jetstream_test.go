@@ -0,0 +1,282 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const timeout = 5 * time.Second
+
+var errHandle = fmt.Errorf("failed to handle message")
+
+func newJetStreamPubSub(t *testing.T, cfg nats.JetStreamConfig) nats.PubSub {
+	ps, err := nats.NewJetStreamPubSub(address, "", cfg, logger)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return ps
+}
+
+func receive(ch chan messaging.Message) (messaging.Message, bool) {
+	select {
+	case msg := <-ch:
+		return msg, true
+	case <-time.After(timeout):
+		return messaging.Message{}, false
+	}
+}
+
+func TestJetStreamPubSub(t *testing.T) {
+	ps := newJetStreamPubSub(t, nats.JetStreamConfig{Durable: "pubsub"})
+	defer ps.Close()
+
+	msgs := make(chan messaging.Message)
+	err := ps.Subscribe(fmt.Sprintf("%s.%s", chansPrefix, "js"), func(msg messaging.Message) error {
+		msgs <- msg
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		channel  string
+		subtopic string
+		payload  []byte
+	}{
+		{
+			desc:    "publish message with nil payload",
+			payload: nil,
+		},
+		{
+			desc:    "publish message with string payload",
+			payload: data,
+		},
+		{
+			desc:    "publish message with channel",
+			payload: data,
+			channel: channel,
+		},
+	}
+
+	for _, tc := range cases {
+		expectedMsg := messaging.Message{
+			Channel:  tc.channel,
+			Subtopic: tc.subtopic,
+			Payload:  tc.payload,
+		}
+		err = ps.Publish("js", expectedMsg)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		receivedMsg, ok := receive(msgs)
+		require.True(t, ok, fmt.Sprintf("%s: message not received", tc.desc))
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expectedMsg, receivedMsg))
+	}
+}
+
+func TestJetStreamSubscribe(t *testing.T) {
+	ps := newJetStreamPubSub(t, nats.JetStreamConfig{Durable: "subscribe"})
+	defer ps.Close()
+
+	_, err := nats.NewJetStreamPubSub(address, "", nats.JetStreamConfig{}, logger)
+	assert.NotNil(t, err, "create pubsub without durable name: expected error got nil")
+
+	cases := []struct {
+		desc  string
+		topic string
+		err   bool
+	}{
+		{
+			desc:  "subscribe to topic",
+			topic: fmt.Sprintf("%s.%s", chansPrefix, "subscribe"),
+			err:   false,
+		},
+		{
+			desc:  "subscribe to the same topic",
+			topic: fmt.Sprintf("%s.%s", chansPrefix, "subscribe"),
+			err:   true,
+		},
+		{
+			desc:  "subscribe to empty topic",
+			topic: "",
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := ps.Subscribe(tc.topic, func(messaging.Message) error { return nil })
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestJetStreamRedelivery(t *testing.T) {
+	cfg := nats.JetStreamConfig{
+		Durable:    "redelivery",
+		MaxDeliver: 3,
+		AckWait:    time.Second,
+	}
+	ps := newJetStreamPubSub(t, cfg)
+	defer ps.Close()
+
+	cases := []struct {
+		desc       string
+		topic      string
+		failures   int
+		deliveries int
+	}{
+		{
+			desc:       "handle message successfully",
+			topic:      "redelivery-ok",
+			failures:   0,
+			deliveries: 1,
+		},
+		{
+			desc:       "handle message after failure",
+			topic:      "redelivery-retry",
+			failures:   1,
+			deliveries: 2,
+		},
+		{
+			desc:       "handle message failing more than max deliver times",
+			topic:      "redelivery-drop",
+			failures:   5,
+			deliveries: cfg.MaxDeliver,
+		},
+	}
+
+	for _, tc := range cases {
+		var mu sync.Mutex
+		deliveries := 0
+		done := make(chan struct{}, 10)
+		failures := tc.failures
+		err := ps.Subscribe(fmt.Sprintf("%s.%s", chansPrefix, tc.topic), func(messaging.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			deliveries++
+			done <- struct{}{}
+			if deliveries <= failures {
+				return errHandle
+			}
+			return nil
+		})
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		err = ps.Publish(tc.topic, messaging.Message{Channel: channel, Payload: data})
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		for i := 0; i < tc.deliveries; i++ {
+			select {
+			case <-done:
+			case <-time.After(timeout):
+				t.Fatalf("%s: expected %d deliveries got %d", tc.desc, tc.deliveries, i)
+			}
+		}
+		// Give the server a chance to deliver unexpected messages.
+		time.Sleep(2 * cfg.AckWait)
+
+		mu.Lock()
+		assert.Equal(t, tc.deliveries, deliveries, fmt.Sprintf("%s: expected %d deliveries got %d", tc.desc, tc.deliveries, deliveries))
+		mu.Unlock()
+	}
+}
+
+func TestJetStreamDurable(t *testing.T) {
+	topic := "durable"
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	cfg := nats.JetStreamConfig{Durable: "durable"}
+
+	msgs := make(chan messaging.Message, 10)
+	handler := func(msg messaging.Message) error {
+		msgs <- msg
+		return nil
+	}
+
+	ps := newJetStreamPubSub(t, cfg)
+	err := ps.Subscribe(subject, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	first := messaging.Message{Channel: channel, Payload: []byte("first")}
+	err = ps.Publish(topic, first)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	msg, ok := receive(msgs)
+	require.True(t, ok, "first message not received")
+	assert.Equal(t, first, msg, fmt.Sprintf("expected %+v got %+v", first, msg))
+	ps.Close()
+
+	// Publish while the subscriber is down.
+	pub, err := nats.NewJetStreamPublisher(address, nats.JetStreamConfig{})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pub.Close()
+	second := messaging.Message{Channel: channel, Payload: []byte("second")}
+	err = pub.Publish(topic, second)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	ps = newJetStreamPubSub(t, cfg)
+	defer ps.Close()
+	err = ps.Subscribe(subject, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msg, ok = receive(msgs)
+	require.True(t, ok, "message published while subscriber was down not received")
+	assert.Equal(t, second, msg, fmt.Sprintf("expected %+v got %+v", second, msg))
+}
+
+func TestJetStreamReplay(t *testing.T) {
+	topic := "replay"
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+
+	pub, err := nats.NewJetStreamPublisher(address, nats.JetStreamConfig{})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pub.Close()
+
+	old := messaging.Message{Channel: channel, Payload: []byte("old")}
+	err = pub.Publish(topic, old)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	recent := messaging.Message{Channel: channel, Payload: []byte("recent")}
+	err = pub.Publish(topic, recent)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		cfg  nats.JetStreamConfig
+		msgs []messaging.Message
+	}{
+		{
+			desc: "replay all messages",
+			cfg:  nats.JetStreamConfig{Durable: "replay-all"},
+			msgs: []messaging.Message{old, recent},
+		},
+		{
+			desc: "replay messages from time",
+			cfg:  nats.JetStreamConfig{Durable: "replay-time", StartTime: start},
+			msgs: []messaging.Message{recent},
+		},
+	}
+
+	for _, tc := range cases {
+		msgs := make(chan messaging.Message, 10)
+		ps := newJetStreamPubSub(t, tc.cfg)
+		err := ps.Subscribe(subject, func(msg messaging.Message) error {
+			msgs <- msg
+			return nil
+		})
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		for _, expected := range tc.msgs {
+			msg, ok := receive(msgs)
+			require.True(t, ok, fmt.Sprintf("%s: message not received", tc.desc))
+			assert.Equal(t, expected, msg, fmt.Sprintf("%s: expected %+v got %+v", tc.desc, expected, msg))
+		}
+		ps.Close()
+	}
+}
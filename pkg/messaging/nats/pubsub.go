@@ -24,6 +24,7 @@ var (
 	errAlreadySubscribed = errors.New("already subscribed to topic")
 	errNotSubscribed     = errors.New("not subscribed")
 	errEmptyTopic        = errors.New("empty topic")
+	errEmptyDurable      = errors.New("empty durable consumer name")
 )
 
 var _ messaging.PubSub = (*pubsub)(nil)
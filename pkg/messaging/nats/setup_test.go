@@ -5,13 +5,13 @@ package nats_test
 
 import (
 	"fmt"
-	"log"
+	stdlog "log"
 	"os"
 	"os/signal"
 	"syscall"
 	"testing"
 
-	"github.com/mainflux/mainflux/logger"
+	log "github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	dockertest "github.com/ory/dockertest/v3"
@@ -20,42 +20,49 @@ import (
 var (
 	publisher messaging.Publisher
 	pubsub    messaging.PubSub
+	address   string
+	logger    log.Logger
 )
 
 func TestMain(m *testing.M) {
 	pool, err := dockertest.NewPool("")
 	if err != nil {
-		log.Fatalf("Could not connect to docker: %s", err)
+		stdlog.Fatalf("Could not connect to docker: %s", err)
 	}
 
-	container, err := pool.Run("nats", "1.3.0", []string{})
+	opts := dockertest.RunOptions{
+		Repository: "nats",
+		Tag:        "2.2.6",
+		Cmd:        []string{"-js"},
+	}
+	container, err := pool.RunWithOptions(&opts)
 	if err != nil {
-		log.Fatalf("Could not start container: %s", err)
+		stdlog.Fatalf("Could not start container: %s", err)
 	}
 	handleInterrupt(pool, container)
 
-	address := fmt.Sprintf("%s:%s", "localhost", container.GetPort("4222/tcp"))
+	address = fmt.Sprintf("%s:%s", "localhost", container.GetPort("4222/tcp"))
 	if err := pool.Retry(func() error {
 		publisher, err = nats.NewPublisher(address)
 		return err
 	}); err != nil {
-		log.Fatalf("Could not connect to docker: %s", err)
+		stdlog.Fatalf("Could not connect to docker: %s", err)
 	}
 
-	logger, err := logger.New(os.Stdout, "error")
+	logger, err = log.New(os.Stdout, "error")
 	if err != nil {
-		log.Fatalf(err.Error())
+		stdlog.Fatalf(err.Error())
 	}
 	if err := pool.Retry(func() error {
 		pubsub, err = nats.NewPubSub(address, "", logger)
 		return err
 	}); err != nil {
-		log.Fatalf("Could not connect to docker: %s", err)
+		stdlog.Fatalf("Could not connect to docker: %s", err)
 	}
 
 	code := m.Run()
 	if err := pool.Purge(container); err != nil {
-		log.Fatalf("Could not purge container: %s", err)
+		stdlog.Fatalf("Could not purge container: %s", err)
 	}
 
 	os.Exit(code)
@@ -67,7 +74,7 @@ func handleInterrupt(pool *dockertest.Pool, container *dockertest.Resource) {
 	go func() {
 		<-c
 		if err := pool.Purge(container); err != nil {
-			log.Fatalf("Could not purge container: %s", err)
+			stdlog.Fatalf("Could not purge container: %s", err)
 		}
 		os.Exit(0)
 	}()
@@ -0,0 +1,44 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import "strings"
+
+// RetainedRepository specifies the API for storing the last message
+// published to each channel and subtopic.
+type RetainedRepository interface {
+	// Save stores the message as the last message of its channel and subtopic,
+	// unless the stored message was created later.
+	Save(msg Message) error
+
+	// Retrieve returns the last messages of the channel whose subtopic
+	// matches the given subtopic. The subtopic may contain `*` and `>`
+	// wildcards, in which case more than one message can be returned.
+	Retrieve(chanID, subtopic string) ([]Message, error)
+}
+
+// MatchSubtopic reports whether the subtopic matches the pattern, which may
+// contain `*` (single element) and `>` (one or more trailing elements)
+// wildcards.
+func MatchSubtopic(pattern, subtopic string) bool {
+	if pattern == "" || subtopic == "" {
+		return pattern == subtopic
+	}
+
+	pelems := strings.Split(pattern, ".")
+	selems := strings.Split(subtopic, ".")
+	for i, p := range pelems {
+		if p == ">" {
+			return len(selems) > i
+		}
+		if i >= len(selems) {
+			return false
+		}
+		if p != "*" && p != selems[i] {
+			return false
+		}
+	}
+
+	return len(pelems) == len(selems)
+}
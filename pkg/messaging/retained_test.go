@@ -0,0 +1,39 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchSubtopic(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		subtopic string
+		match    bool
+	}{
+		{pattern: "", subtopic: "", match: true},
+		{pattern: "", subtopic: "a", match: false},
+		{pattern: "a", subtopic: "", match: false},
+		{pattern: "a.b", subtopic: "a.b", match: true},
+		{pattern: "a.b", subtopic: "a.c", match: false},
+		{pattern: "a.b", subtopic: "a.b.c", match: false},
+		{pattern: "a.*", subtopic: "a.b", match: true},
+		{pattern: "a.*", subtopic: "a.b.c", match: false},
+		{pattern: "*.b", subtopic: "a.b", match: true},
+		{pattern: "a.>", subtopic: "a.b.c", match: true},
+		{pattern: "a.>", subtopic: "a", match: false},
+		{pattern: ">", subtopic: "a.b", match: true},
+		{pattern: ">", subtopic: "", match: false},
+	}
+
+	for _, tc := range cases {
+		match := messaging.MatchSubtopic(tc.pattern, tc.subtopic)
+		assert.Equal(t, tc.match, match, fmt.Sprintf("%s against %s: expected %t got %t", tc.subtopic, tc.pattern, tc.match, match))
+	}
+}
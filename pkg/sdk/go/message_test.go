@@ -13,14 +13,16 @@ import (
 	adapter "github.com/mainflux/mainflux/http"
 	"github.com/mainflux/mainflux/http/api"
 	"github.com/mainflux/mainflux/http/mocks"
+	codecmocks "github.com/mainflux/mainflux/pkg/codec/mocks"
+	msgmocks "github.com/mainflux/mainflux/pkg/messaging/mocks"
 	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 )
 
 func newMessageService(cc mainflux.ThingsServiceClient) adapter.Service {
-	pub := mocks.NewPublisher()
-	return adapter.New(pub, cc)
+	ps := mocks.NewPubSub()
+	return adapter.New(ps, ps, cc, msgmocks.NewRetainedRepository(), codecmocks.NewRepository())
 }
 
 func newMessageServer(svc adapter.Service) *httptest.Server {
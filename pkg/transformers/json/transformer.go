@@ -12,7 +12,12 @@ import (
 	"github.com/mainflux/mainflux/pkg/transformers"
 )
 
-const sep = "/"
+const (
+	// ContentType represents JSON content type.
+	ContentType = "application/json"
+
+	sep = "/"
+)
 
 var keys = [...]string{"publisher", "protocol", "channel", "subtopic"}
 
@@ -45,10 +50,10 @@ func transformer(msg messaging.Message) (interface{}, error) {
 		Subtopic:  msg.Subtopic,
 	}
 	subs := strings.Split(ret.Subtopic, ".")
-	if len(subs) == 0 {
+	format := subs[len(subs)-1]
+	if format == "" {
 		return nil, errors.Wrap(ErrTransform, errUnknownFormat)
 	}
-	format := subs[len(subs)-1]
 	var payload interface{}
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		return nil, errors.Wrap(ErrTransform, err)
@@ -37,6 +37,9 @@ func TestTransformJSON(t *testing.T) {
 	listMsg := msg
 	listMsg.Payload = []byte(listPayload)
 
+	noFormat := msg
+	noFormat.Subtopic = ""
+
 	jsonMsg := json.Messages{
 		Data: []json.Message{
 			{
@@ -112,6 +115,12 @@ func TestTransformJSON(t *testing.T) {
 			json: nil,
 			err:  json.ErrTransform,
 		},
+		{
+			desc: "test transform JSON without subtopic",
+			msg:  noFormat,
+			json: nil,
+			err:  json.ErrTransform,
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,42 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers
+
+import (
+	"mime"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ Transformer = (*mux)(nil)
+
+type mux struct {
+	def          Transformer
+	transformers map[string]Transformer
+}
+
+// NewMux returns the transformer that picks the transformer registered for
+// the message content type. Messages without content type, or with the
+// content type no transformer is registered for, are transformed using the
+// default transformer.
+func NewMux(def Transformer, transformers map[string]Transformer) Transformer {
+	return mux{
+		def:          def,
+		transformers: transformers,
+	}
+}
+
+func (m mux) Transform(msg messaging.Message) (interface{}, error) {
+	ct := msg.ContentType
+	// Content type parameters, such as charset, don't affect the decoding.
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		ct = mt
+	}
+
+	if t, ok := m.transformers[ct]; ok {
+		return t.Transform(msg)
+	}
+
+	return m.def.Transform(msg)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/stretchr/testify/assert"
+)
+
+type transformer string
+
+func (t transformer) Transform(msg messaging.Message) (interface{}, error) {
+	return string(t), nil
+}
+
+func TestMux(t *testing.T) {
+	tr := transformers.NewMux(transformer("default"), map[string]transformers.Transformer{
+		"application/senml+json": transformer("senml"),
+		"application/json":       transformer("json"),
+	})
+
+	cases := []struct {
+		desc        string
+		contentType string
+		res         string
+	}{
+		{
+			desc:        "transform message without content type",
+			contentType: "",
+			res:         "default",
+		},
+		{
+			desc:        "transform message with registered content type",
+			contentType: "application/json",
+			res:         "json",
+		},
+		{
+			desc:        "transform message with registered content type and parameters",
+			contentType: "application/senml+json; charset=utf-8",
+			res:         "senml",
+		},
+		{
+			desc:        "transform message with unknown content type",
+			contentType: "text/plain",
+			res:         "default",
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := tr.Transform(messaging.Message{ContentType: tc.contentType})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.res, res))
+	}
+}
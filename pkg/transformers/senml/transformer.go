@@ -31,7 +31,9 @@ type transformer struct {
 	format senml.Format
 }
 
-// New returns transformer service implementation for SenML messages.
+// New returns transformer service implementation for SenML messages. The
+// given content format is used to decode messages that don't specify the
+// SenML content type.
 func New(contentFormat string) transformers.Transformer {
 	format, ok := formats[contentFormat]
 	if !ok {
@@ -44,7 +46,12 @@ func New(contentFormat string) transformers.Transformer {
 }
 
 func (t transformer) Transform(msg messaging.Message) (interface{}, error) {
-	raw, err := senml.Decode(msg.Payload, t.format)
+	format, ok := formats[msg.ContentType]
+	if !ok {
+		format = t.format
+	}
+
+	raw, err := senml.Decode(msg.Payload, format)
 	if err != nil {
 		return nil, errors.Wrap(errDecode, err)
 	}
@@ -151,3 +151,66 @@ func TestTransformCBOR(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
 	}
 }
+
+func TestTransformContentType(t *testing.T) {
+	// Following hex-encoded bytes correspond to the same content as in
+	// TestTransformCBOR.
+	cborBytes, err := hex.DecodeString("81ac2169626173652d6e616d6522fb40590000000000002369626173652d756e6974200a24fb402400000000000025fb405900000000000000646e616d650164756e697406fb4072c0000000000007fb4062c0000000000002fb404500000000000005fb4024000000000000")
+	require.Nil(t, err, "Decoding CBOR expected to succeed")
+
+	tr := senml.New(senml.JSON)
+	msg := messaging.Message{
+		Channel:     "channel",
+		Subtopic:    "subtopic",
+		Publisher:   "publisher",
+		Protocol:    "protocol",
+		ContentType: senml.CBOR,
+		Payload:     cborBytes,
+	}
+
+	unknown := msg
+	unknown.ContentType = "application/octet-stream"
+
+	val := 52.0
+	sum := 110.0
+	msgs := []senml.Message{
+		{
+			Channel:    "channel",
+			Subtopic:   "subtopic",
+			Publisher:  "publisher",
+			Protocol:   "protocol",
+			Name:       "base-namename",
+			Unit:       "unit",
+			Time:       400,
+			UpdateTime: 150,
+			Value:      &val,
+			Sum:        &sum,
+		},
+	}
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		msgs interface{}
+		err  error
+	}{
+		{
+			desc: "test normalize using message content type",
+			msg:  msg,
+			msgs: msgs,
+			err:  nil,
+		},
+		{
+			desc: "test normalize unknown content type using configured format",
+			msg:  unknown,
+			msgs: nil,
+			err:  errors.New("failed to decode senml"),
+		},
+	}
+
+	for _, tc := range cases {
+		msgs, err := tr.Transform(tc.msg)
+		assert.Equal(t, tc.msgs, msgs, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.msgs, msgs))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+const (
+	// MinKey represents the minimum aggregation key.
+	MinKey = "min"
+	// MaxKey represents the maximum aggregation key.
+	MaxKey = "max"
+	// AvgKey represents the average aggregation key.
+	AvgKey = "avg"
+	// SumKey represents the sum aggregation key.
+	SumKey = "sum"
+	// CountKey represents the count aggregation key.
+	CountKey = "count"
+)
+
+type bucket struct {
+	name      string
+	publisher string
+	time      float64
+}
+
+// Aggregate groups SenML messages with numeric value by name, publisher and
+// the time bucket of the given interval (in seconds), and applies the given
+// aggregation to the values of each group. Aggregated messages carry bucket
+// start time as their time and the aggregated value as their value.
+func Aggregate(msgs []senml.Message, aggregation string, interval float64) []senml.Message {
+	var buckets []bucket
+	values := map[bucket][]float64{}
+	channels := map[bucket]string{}
+	for _, msg := range msgs {
+		if msg.Value == nil {
+			continue
+		}
+		b := bucket{
+			name:      msg.Name,
+			publisher: msg.Publisher,
+			time:      math.Floor(msg.Time/interval) * interval,
+		}
+		if _, ok := values[b]; !ok {
+			buckets = append(buckets, b)
+			channels[b] = msg.Channel
+		}
+		values[b] = append(values[b], *msg.Value)
+	}
+
+	ret := []senml.Message{}
+	for _, b := range buckets {
+		val := aggregate(values[b], aggregation)
+		ret = append(ret, senml.Message{
+			Channel:   channels[b],
+			Publisher: b.publisher,
+			Name:      b.name,
+			Time:      b.time,
+			Value:     &val,
+		})
+	}
+
+	return ret
+}
+
+// AggregatesPage sorts aggregated messages by time in descending order,
+// then by name and publisher, and returns the page described by the
+// given page metadata.
+func AggregatesPage(pm PageMetadata, aggs []senml.Message) MessagesPage {
+	sort.SliceStable(aggs, func(i, j int) bool {
+		if aggs[i].Time != aggs[j].Time {
+			return aggs[i].Time > aggs[j].Time
+		}
+		if aggs[i].Name != aggs[j].Name {
+			return aggs[i].Name < aggs[j].Name
+		}
+		return aggs[i].Publisher < aggs[j].Publisher
+	})
+
+	page := MessagesPage{
+		PageMetadata: pm,
+		Total:        uint64(len(aggs)),
+		Messages:     []Message{},
+	}
+
+	total := uint64(len(aggs))
+	if pm.Offset >= total {
+		return page
+	}
+	end := pm.Offset + pm.Limit
+	if end > total {
+		end = total
+	}
+	for _, agg := range aggs[pm.Offset:end] {
+		page.Messages = append(page.Messages, agg)
+	}
+
+	return page
+}
+
+func aggregate(values []float64, aggregation string) float64 {
+	switch aggregation {
+	case MinKey:
+		ret := values[0]
+		for _, v := range values[1:] {
+			ret = math.Min(ret, v)
+		}
+		return ret
+	case MaxKey:
+		ret := values[0]
+		for _, v := range values[1:] {
+			ret = math.Max(ret, v)
+		}
+		return ret
+	case SumKey, AvgKey:
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		if aggregation == AvgKey {
+			return sum / float64(len(values))
+		}
+		return sum
+	default:
+		return float64(len(values))
+	}
+}
@@ -23,10 +23,49 @@ func listMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 			return nil, err
 		}
 
+		if req.pageMeta.Cursor != "" {
+			return cursorPageRes{
+				PageMetadata: page.PageMetadata,
+				Next:         page.Next,
+				Messages:     page.Messages,
+			}, nil
+		}
+
 		return pageRes{
 			PageMetadata: page.PageMetadata,
 			Total:        page.Total,
+			Next:         page.Next,
 			Messages:     page.Messages,
 		}, nil
 	}
 }
+
+func exportMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportMessagesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		// The first page is read before the response is written, so
+		// that the failure can be reported using the status code.
+		page, err := svc.ReadAll(req.chanID, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		res := exportRes{
+			chanID: req.chanID,
+			output: req.output,
+			page:   page,
+			next: func(cursor string) (readers.MessagesPage, error) {
+				pm := req.pageMeta
+				pm.Cursor = cursor
+				return svc.ReadAll(req.chanID, pm)
+			},
+		}
+
+		return res, nil
+	}
+}
@@ -4,11 +4,13 @@
 package api_test
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,7 +47,8 @@ var (
 )
 
 func newServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient) *httptest.Server {
-	mux := api.MakeHandler(repo, tc, svcName)
+	ac := mocks.NewAuthService(map[string]string{}, map[string]string{})
+	mux := api.MakeHandler(repo, tc, ac, svcName)
 	return httptest.NewServer(mux)
 }
 
@@ -122,6 +125,23 @@ func TestReadAll(t *testing.T) {
 		messages = append(messages, msg)
 	}
 
+	// Messages are a second apart, so every message with numeric
+	// value is aggregated in its own bucket of one second.
+	var count float64 = 1
+	var countMsgs, avgMsgs []senml.Message
+	for _, msg := range valueMsgs {
+		agg := senml.Message{
+			Channel:   msg.Channel,
+			Publisher: msg.Publisher,
+			Name:      msg.Name,
+			Time:      msg.Time,
+		}
+		agg.Value = &count
+		countMsgs = append(countMsgs, agg)
+		agg.Value = &v
+		avgMsgs = append(avgMsgs, agg)
+	}
+
 	svc := mocks.NewThingsService()
 	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
 	ts := newServer(repo, svc)
@@ -416,6 +436,62 @@ func TestReadAll(t *testing.T) {
 				Messages: messages[5:15],
 			},
 		},
+		{
+			desc:   "read page with count aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=%s&interval=%s", ts.URL, chanID, readers.CountKey, "1s"),
+			token:  token,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(countMsgs)),
+				Messages: countMsgs[0:10],
+			},
+		},
+		{
+			desc:   "read page with average aggregation and offset",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=%s&interval=%s&offset=%d", ts.URL, chanID, readers.AvgKey, "1s", 15),
+			token:  token,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(avgMsgs)),
+				Messages: avgMsgs[15:],
+			},
+		},
+		{
+			desc:   "read page with invalid aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=%s&interval=%s", ts.URL, chanID, invalid, "1s"),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation and without interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=%s", ts.URL, chanID, readers.MaxKey),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation and invalid interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=%s&interval=%s", ts.URL, chanID, readers.MaxKey, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation and negative interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=%s&interval=%s", ts.URL, chanID, readers.MaxKey, "-1s"),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with interval and without aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?interval=%s", ts.URL, chanID, "1s"),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation and JSON format",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=%s&interval=%s&format=%s", ts.URL, chanID, readers.MinKey, "1s", "json"),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range cases {
@@ -450,3 +526,323 @@ func fromSenml(in []senml.Message) []readers.Message {
 	}
 	return ret
 }
+
+func TestReadAllFiltered(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	pubs := []string{pubID, pubID2, invalid}
+	subtopics := []string{"a.b.c", "a.b", "a.x.c"}
+	strValues := []string{"temperature-high", "low-temperature", "humidity"}
+
+	now := time.Now().Unix()
+	var messages []senml.Message
+	var rangeMsgs, pubsMsgs, namesMsgs, prefixMsgs, containsMsgs, singleMsgs, multiMsgs []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubs[i%len(pubs)],
+			Subtopic:  subtopics[i%len(subtopics)],
+			Name:      fmt.Sprintf("name-%d", i%4),
+			Time:      float64(now - int64(i)),
+		}
+		if i%2 == 0 {
+			val := float64(i)
+			msg.Value = &val
+			if val > 10 && val < 20 {
+				rangeMsgs = append(rangeMsgs, msg)
+			}
+		} else {
+			msg.StringValue = &strValues[i%len(strValues)]
+			if strings.HasPrefix(*msg.StringValue, "temp") {
+				prefixMsgs = append(prefixMsgs, msg)
+			}
+			if strings.Contains(*msg.StringValue, "temp") {
+				containsMsgs = append(containsMsgs, msg)
+			}
+		}
+		if msg.Publisher != invalid {
+			pubsMsgs = append(pubsMsgs, msg)
+		}
+		if msg.Name == "name-0" || msg.Name == "name-1" {
+			namesMsgs = append(namesMsgs, msg)
+		}
+		if msg.Subtopic != "a.b" {
+			singleMsgs = append(singleMsgs, msg)
+		}
+		multiMsgs = append(multiMsgs, msg)
+		messages = append(messages, msg)
+	}
+
+	ts := newServer(mocks.NewMessageRepository(chanID, fromSenml(messages)), mocks.NewThingsService())
+	defer ts.Close()
+
+	url := fmt.Sprintf("%s/channels/%s/messages?limit=%d", ts.URL, chanID, numOfMessages)
+	cases := []struct {
+		desc   string
+		url    string
+		status int
+		res    []senml.Message
+	}{
+		{
+			desc:   "read page with value range",
+			url:    fmt.Sprintf("%s&v_gt=%d&v_lt=%d", url, 10, 20),
+			status: http.StatusOK,
+			res:    rangeMsgs,
+		},
+		{
+			desc:   "read page with value range and comparator",
+			url:    fmt.Sprintf("%s&v=%d&comparator=%s&v_lt=%d", url, 10, readers.GreaterThanKey, 20),
+			status: http.StatusOK,
+			res:    rangeMsgs,
+		},
+		{
+			desc:   "read page with list of publishers",
+			url:    fmt.Sprintf("%s&publisher=%s,%s", url, pubID, pubID2),
+			status: http.StatusOK,
+			res:    pubsMsgs,
+		},
+		{
+			desc:   "read page with list of names",
+			url:    fmt.Sprintf("%s&name=%s,%s", url, "name-0", "name-1"),
+			status: http.StatusOK,
+			res:    namesMsgs,
+		},
+		{
+			desc:   "read page with string value prefix",
+			url:    fmt.Sprintf("%s&vs=%s&vs_match=%s", url, "temp", readers.PrefixKey),
+			status: http.StatusOK,
+			res:    prefixMsgs,
+		},
+		{
+			desc:   "read page with string value substring",
+			url:    fmt.Sprintf("%s&vs=%s&vs_match=%s", url, "temp", readers.ContainsKey),
+			status: http.StatusOK,
+			res:    containsMsgs,
+		},
+		{
+			desc:   "read page with single token subtopic wildcard",
+			url:    fmt.Sprintf("%s&subtopic=%s", url, "a.*.c"),
+			status: http.StatusOK,
+			res:    singleMsgs,
+		},
+		{
+			desc:   "read page with multiple tokens subtopic wildcard",
+			url:    fmt.Sprintf("%s&subtopic=%s", url, "a.>"),
+			status: http.StatusOK,
+			res:    multiMsgs,
+		},
+		{
+			desc:   "read page with non-float value bound",
+			url:    fmt.Sprintf("%s&v_gt=%s", url, invalid),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with invalid string value match",
+			url:    fmt.Sprintf("%s&vs=%s&vs_match=%s", url, "temp", invalid),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with string value match and without string value",
+			url:    fmt.Sprintf("%s&vs_match=%s", url, readers.PrefixKey),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with invalid subtopic wildcard",
+			url:    fmt.Sprintf("%s&subtopic=%s", url, "a.>.b"),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with empty publisher in the list",
+			url:    fmt.Sprintf("%s&publisher=%s,", url, pubID),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := testRequest{client: ts.Client(), method: http.MethodGet, url: tc.url, token: token}.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page pageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, uint64(len(tc.res)), page.Total, fmt.Sprintf("%s: expected %d got %d", tc.desc, len(tc.res), page.Total))
+		assert.ElementsMatch(t, tc.res, page.Messages, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, page.Messages))
+	}
+}
+
+func TestReadAllByCursor(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		messages = append(messages, senml.Message{
+			Channel: chanID,
+			Time:    float64(now - int64(i)),
+			Value:   &v,
+		})
+	}
+
+	ts := newServer(mocks.NewMessageRepository(chanID, fromSenml(messages)), mocks.NewThingsService())
+	defer ts.Close()
+
+	url := fmt.Sprintf("%s/channels/%s/messages?limit=%d", ts.URL, chanID, 30)
+	res, err := testRequest{client: ts.Client(), method: http.MethodGet, url: url, token: token}.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	var page cursorPageRes
+	err = json.NewDecoder(res.Body).Decode(&page)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	require.NotEmpty(t, page.Next, "expected next page cursor")
+	require.Equal(t, uint64(numOfMessages), *page.Total, fmt.Sprintf("expected total %d got %d", numOfMessages, *page.Total))
+
+	cursor := page.Next
+	read := page.Messages
+	for page.Next != "" {
+		url := fmt.Sprintf("%s/channels/%s/messages?limit=%d&cursor=%s", ts.URL, chanID, 30, page.Next)
+		res, err := testRequest{client: ts.Client(), method: http.MethodGet, url: url, token: token}.make()
+		require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		require.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected %d got %d", http.StatusOK, res.StatusCode))
+
+		page = cursorPageRes{}
+		err = json.NewDecoder(res.Body).Decode(&page)
+		require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		assert.Nil(t, page.Total, "expected total to be omitted for the page read by cursor")
+		read = append(read, page.Messages...)
+	}
+	assert.Equal(t, messages, read, "expected all messages to be read by cursor")
+
+	cases := []struct {
+		desc   string
+		url    string
+		status int
+	}{
+		{
+			desc:   "read page with malformed cursor",
+			url:    fmt.Sprintf("%s/channels/%s/messages?cursor=%s", ts.URL, chanID, invalid),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with cursor and offset",
+			url:    fmt.Sprintf("%s/channels/%s/messages?cursor=%s&offset=%d", ts.URL, chanID, cursor, 10),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with cursor and aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?cursor=%s&aggregation=%s&interval=%s", ts.URL, chanID, cursor, readers.AvgKey, "1s"),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := testRequest{client: ts.Client(), method: http.MethodGet, url: tc.url, token: token}.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestExport(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Export reads messages page by page, so there are more messages
+	// than fit in a single page.
+	n := 2500
+	now := time.Now().Unix()
+	var messages []senml.Message
+	for i := 0; i < n; i++ {
+		messages = append(messages, senml.Message{
+			Channel: chanID,
+			Name:    msgName,
+			Time:    float64(now - int64(i)),
+			Value:   &v,
+		})
+	}
+
+	ts := newServer(mocks.NewMessageRepository(chanID, fromSenml(messages)), mocks.NewThingsService())
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		url         string
+		token       string
+		status      int
+		contentType string
+		lines       int
+	}{
+		{
+			desc:        "export messages as NDJSON",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			lines:       n,
+		},
+		{
+			desc:        "export messages as CSV",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?output=csv", ts.URL, chanID),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			lines:       n + 1,
+		},
+		{
+			desc:        "export messages in time range",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?from=%f&to=%f", ts.URL, chanID, messages[19].Time, messages[4].Time),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			lines:       15,
+		},
+		{
+			desc:   "export messages with invalid output",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?output=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export aggregated messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?aggregation=%s&interval=%s", ts.URL, chanID, readers.AvgKey, "1s"),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := testRequest{client: ts.Client(), method: http.MethodGet, url: tc.url, token: tc.token}.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		assert.Equal(t, tc.contentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: expected content type %s got %s", tc.desc, tc.contentType, res.Header.Get("Content-Type")))
+
+		lines := 0
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			lines++
+		}
+		assert.Equal(t, tc.lines, lines, fmt.Sprintf("%s: expected %d lines got %d", tc.desc, tc.lines, lines))
+	}
+}
+
+type cursorPageRes struct {
+	Total    *uint64         `json:"total"`
+	Next     string          `json:"next"`
+	Messages []senml.Message `json:"messages,omitempty"`
+}
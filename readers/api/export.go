@@ -0,0 +1,184 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+)
+
+const (
+	csvContentType    = "text/csv"
+	ndjsonContentType = "application/x-ndjson"
+)
+
+var (
+	errUnsupportedMessage = errors.New("unsupported message type")
+
+	senmlHeader = []string{"channel", "subtopic", "publisher", "protocol", "name", "unit",
+		"time", "update_time", "value", "string_value", "data_value", "bool_value", "sum"}
+	jsonHeader = []string{"channel", "subtopic", "publisher", "protocol", "created", "payload"}
+)
+
+// messageWriter writes exported messages in the requested output format.
+type messageWriter interface {
+	write(msg readers.Message) error
+	flush() error
+}
+
+// encodeExport streams exported messages page by page, flushing every page
+// to the client, so that the whole result is never kept in memory. Once
+// the response is started, read failures can't change the status code, so
+// the error is appended to the streamed messages.
+func encodeExport(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(exportRes)
+
+	var mw messageWriter
+	switch res.output {
+	case csvOutput:
+		w.Header().Set("Content-Type", csvContentType)
+		mw = &csvWriter{w: csv.NewWriter(w)}
+	default:
+		w.Header().Set("Content-Type", ndjsonContentType)
+		mw = ndjsonWriter{enc: json.NewEncoder(w)}
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, res.chanID, res.output))
+	w.WriteHeader(http.StatusOK)
+
+	page := res.page
+	for {
+		for _, msg := range page.Messages {
+			if err := mw.write(msg); err != nil {
+				return err
+			}
+		}
+		if err := mw.flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		if page.Next == "" {
+			return nil
+		}
+
+		var err error
+		if page, err = res.next(page.Next); err != nil {
+			return err
+		}
+	}
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (nw ndjsonWriter) write(msg readers.Message) error {
+	return nw.enc.Encode(msg)
+}
+
+func (nw ndjsonWriter) flush() error {
+	return nil
+}
+
+// csvWriter writes the header matching the type of the first message.
+// SenML values are written in separate columns and JSON payload is written
+// as a single JSON encoded column.
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (cw *csvWriter) write(msg readers.Message) error {
+	switch m := msg.(type) {
+	case senml.Message:
+		if err := cw.writeHeader(senmlHeader); err != nil {
+			return err
+		}
+		return cw.w.Write([]string{
+			m.Channel,
+			m.Subtopic,
+			m.Publisher,
+			m.Protocol,
+			m.Name,
+			m.Unit,
+			formatFloat(&m.Time),
+			formatFloat(&m.UpdateTime),
+			formatFloat(m.Value),
+			formatString(m.StringValue),
+			formatString(m.DataValue),
+			formatBool(m.BoolValue),
+			formatFloat(m.Sum),
+		})
+	case map[string]interface{}:
+		if err := cw.writeHeader(jsonHeader); err != nil {
+			return err
+		}
+		payload, err := json.Marshal(m["payload"])
+		if err != nil {
+			return err
+		}
+		return cw.w.Write([]string{
+			formatValue(m["channel"]),
+			formatValue(m["subtopic"]),
+			formatValue(m["publisher"]),
+			formatValue(m["protocol"]),
+			formatValue(m["created"]),
+			string(payload),
+		})
+	default:
+		return errUnsupportedMessage
+	}
+}
+
+func (cw *csvWriter) writeHeader(header []string) error {
+	if cw.header {
+		return nil
+	}
+	cw.header = true
+
+	return cw.w.Write(header)
+}
+
+func (cw *csvWriter) flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func formatFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func formatString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func formatBool(v *bool) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatBool(*v)
+}
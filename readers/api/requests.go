@@ -4,10 +4,17 @@
 package api
 
 import (
+	"time"
+
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/readers"
 )
 
+const (
+	csvOutput    = "csv"
+	ndjsonOutput = "ndjson"
+)
+
 type apiReq interface {
 	validate() error
 }
@@ -21,14 +28,114 @@ func (req listMessagesReq) validate() error {
 	if req.pageMeta.Limit < 1 || req.pageMeta.Offset < 0 {
 		return errors.ErrInvalidQueryParams
 	}
-	if req.pageMeta.Comparator != "" &&
-		req.pageMeta.Comparator != readers.EqualKey &&
-		req.pageMeta.Comparator != readers.LowerThanKey &&
-		req.pageMeta.Comparator != readers.LowerThanEqualKey &&
-		req.pageMeta.Comparator != readers.GreaterThanKey &&
-		req.pageMeta.Comparator != readers.GreaterThanEqualKey {
+	if err := validateFilters(req.pageMeta); err != nil {
+		return err
+	}
+
+	if req.pageMeta.Cursor != "" {
+		// Cursor replaces the offset and aggregated messages can't be
+		// paginated using it.
+		if req.pageMeta.Offset > 0 || req.pageMeta.Aggregation != "" {
+			return errors.ErrInvalidQueryParams
+		}
+		if err := validateCursor(req.pageMeta.Cursor); err != nil {
+			return err
+		}
+	}
+
+	return req.validateAggregation()
+}
+
+func (req listMessagesReq) validateAggregation() error {
+	if req.pageMeta.Aggregation == "" {
+		if req.pageMeta.Interval != "" {
+			return errors.ErrInvalidQueryParams
+		}
+		return nil
+	}
+
+	switch req.pageMeta.Aggregation {
+	case readers.MinKey,
+		readers.MaxKey,
+		readers.AvgKey,
+		readers.SumKey,
+		readers.CountKey:
+	default:
+		return errors.ErrInvalidQueryParams
+	}
+
+	// Only SenML messages have numeric values to aggregate.
+	if req.pageMeta.Format != defFormat {
+		return errors.ErrInvalidQueryParams
+	}
+
+	interval, err := time.ParseDuration(req.pageMeta.Interval)
+	if err != nil || interval <= 0 {
+		return errors.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+type exportMessagesReq struct {
+	chanID   string
+	output   string
+	pageMeta readers.PageMetadata
+}
+
+func (req exportMessagesReq) validate() error {
+	if req.output != csvOutput && req.output != ndjsonOutput {
+		return errors.ErrInvalidQueryParams
+	}
+	if req.pageMeta.Aggregation != "" || req.pageMeta.Interval != "" {
 		return errors.ErrInvalidQueryParams
 	}
+	if err := validateFilters(req.pageMeta); err != nil {
+		return err
+	}
+	if req.pageMeta.Cursor != "" {
+		return validateCursor(req.pageMeta.Cursor)
+	}
 
 	return nil
 }
+
+func validateFilters(pm readers.PageMetadata) error {
+	if pm.Comparator != "" &&
+		pm.Comparator != readers.EqualKey &&
+		pm.Comparator != readers.LowerThanKey &&
+		pm.Comparator != readers.LowerThanEqualKey &&
+		pm.Comparator != readers.GreaterThanKey &&
+		pm.Comparator != readers.GreaterThanEqualKey {
+		return errors.ErrInvalidQueryParams
+	}
+
+	if pm.StringValueMatch != "" {
+		if pm.StringValue == "" ||
+			(pm.StringValueMatch != readers.PrefixKey &&
+				pm.StringValueMatch != readers.ContainsKey) {
+			return errors.ErrInvalidQueryParams
+		}
+	}
+
+	if !readers.ValidSubtopicPattern(pm.Subtopic) {
+		return errors.ErrInvalidQueryParams
+	}
+
+	for _, vals := range [][]string{pm.Publishers, pm.Names} {
+		for _, v := range vals {
+			if v == "" {
+				return errors.ErrInvalidQueryParams
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCursor checks that the cursor is well-formed. Its content is
+// validated by the repository it was issued by.
+func validateCursor(cursor string) error {
+	var pos map[string]interface{}
+	return readers.DecodeCursor(cursor, &pos)
+}
@@ -10,11 +10,15 @@ import (
 	"github.com/mainflux/mainflux/readers"
 )
 
-var _ mainflux.Response = (*pageRes)(nil)
+var (
+	_ mainflux.Response = (*pageRes)(nil)
+	_ mainflux.Response = (*cursorPageRes)(nil)
+)
 
 type pageRes struct {
 	readers.PageMetadata
 	Total    uint64            `json:"total"`
+	Next     string            `json:"next,omitempty"`
 	Messages []readers.Message `json:"messages,omitempty"`
 }
 
@@ -30,6 +34,35 @@ func (res pageRes) Empty() bool {
 	return false
 }
 
+// cursorPageRes represents the page read using the cursor, which doesn't
+// contain the total number of messages.
+type cursorPageRes struct {
+	readers.PageMetadata
+	Next     string            `json:"next,omitempty"`
+	Messages []readers.Message `json:"messages,omitempty"`
+}
+
+func (res cursorPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res cursorPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res cursorPageRes) Empty() bool {
+	return false
+}
+
+// exportRes contains the first page of exported messages and the function
+// reading the page that follows the given cursor.
+type exportRes struct {
+	chanID string
+	output string
+	page   readers.MessagesPage
+	next   func(cursor string) (readers.MessagesPage, error)
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
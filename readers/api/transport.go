@@ -31,24 +31,41 @@ const (
 	protocolKey    = "protocol"
 	nameKey        = "name"
 	valueKey       = "v"
+	valueGtKey     = "v_gt"
+	valueGeKey     = "v_ge"
+	valueLtKey     = "v_lt"
+	valueLeKey     = "v_le"
+	vsMatchKey     = "vs_match"
 	stringValueKey = "vs"
 	dataValueKey   = "vd"
 	comparatorKey  = "comparator"
 	fromKey        = "from"
 	toKey          = "to"
+	aggregationKey = "aggregation"
+	intervalKey    = "interval"
+	cursorKey      = "cursor"
+	outputKey      = "output"
 	defLimit       = 10
 	defOffset      = 0
 	defFormat      = "messages"
+	defOutput      = ndjsonOutput
+	// exportLimit is the number of messages read at once while exporting.
+	exportLimit = 1000
+	// readAction is the auth service policy action allowing to read the
+	// channel messages.
+	readAction = "read"
 )
 
 var (
 	errUnauthorizedAccess = errors.New("missing or invalid credentials provided")
-	auth                  mainflux.ThingsServiceClient
+	things                mainflux.ThingsServiceClient
+	auth                  mainflux.AuthServiceClient
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, svcName string) http.Handler {
-	auth = tc
+func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, svcName string) http.Handler {
+	things = tc
+	auth = ac
 
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
@@ -62,6 +79,13 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 		opts...,
 	))
 
+	mux.Get("/channels/:chanID/messages/export", kithttp.NewServer(
+		exportMessagesEndpoint(svc),
+		decodeExport,
+		encodeExport,
+		opts...,
+	))
+
 	mux.GetFunc("/version", mainflux.Version(svcName))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -78,99 +102,185 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
-	offset, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	pm, err := decodePageMetadata(r)
 	if err != nil {
 		return nil, err
 	}
 
-	limit, err := httputil.ReadUintQuery(r, limitKey, defLimit)
-	if err != nil {
+	req := listMessagesReq{
+		chanID:   chanID,
+		pageMeta: pm,
+	}
+
+	return req, nil
+}
+
+func decodeExport(_ context.Context, r *http.Request) (interface{}, error) {
+	chanID := bone.GetValue(r, "chanID")
+	if chanID == "" {
+		return nil, errors.ErrInvalidQueryParams
+	}
+
+	if err := authorize(r, chanID); err != nil {
 		return nil, err
 	}
 
-	format, err := httputil.ReadStringQuery(r, formatKey, defFormat)
+	pm, err := decodePageMetadata(r)
 	if err != nil {
 		return nil, err
 	}
 
-	subtopic, err := httputil.ReadStringQuery(r, subtopicKey, "")
+	output, err := httputil.ReadStringQuery(r, outputKey, defOutput)
 	if err != nil {
 		return nil, err
 	}
 
-	publisher, err := httputil.ReadStringQuery(r, publisherKey, "")
+	// Messages are exported in full, reading one page at the time.
+	pm.Offset = 0
+	pm.Limit = exportLimit
+
+	req := exportMessagesReq{
+		chanID:   chanID,
+		output:   output,
+		pageMeta: pm,
+	}
+
+	return req, nil
+}
+
+func decodePageMetadata(r *http.Request) (readers.PageMetadata, error) {
+	offset, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	limit, err := httputil.ReadUintQuery(r, limitKey, defLimit)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
-	protocol, err := httputil.ReadStringQuery(r, protocolKey, "")
+	format, err := httputil.ReadStringQuery(r, formatKey, defFormat)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
-	name, err := httputil.ReadStringQuery(r, nameKey, "")
+	subtopic, err := httputil.ReadStringQuery(r, subtopicKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
+	}
+
+	protocol, err := httputil.ReadStringQuery(r, protocolKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
 	}
 
 	v, err := httputil.ReadFloatQuery(r, valueKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	comparator, err := httputil.ReadStringQuery(r, comparatorKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	vs, err := httputil.ReadStringQuery(r, stringValueKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	vd, err := httputil.ReadStringQuery(r, dataValueKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	from, err := httputil.ReadFloatQuery(r, fromKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	to, err := httputil.ReadFloatQuery(r, toKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
-	req := listMessagesReq{
-		chanID: chanID,
-		pageMeta: readers.PageMetadata{
-			Offset:      offset,
-			Limit:       limit,
-			Format:      format,
-			Subtopic:    subtopic,
-			Publisher:   publisher,
-			Protocol:    protocol,
-			Name:        name,
-			Value:       v,
-			Comparator:  comparator,
-			StringValue: vs,
-			DataValue:   vd,
-			From:        from,
-			To:          to,
-		},
+	aggregation, err := httputil.ReadStringQuery(r, aggregationKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	interval, err := httputil.ReadStringQuery(r, intervalKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	cursor, err := httputil.ReadStringQuery(r, cursorKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	vsMatch, err := httputil.ReadStringQuery(r, vsMatchKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	pm := readers.PageMetadata{
+		Offset:      offset,
+		Limit:       limit,
+		Format:      format,
+		Subtopic:    subtopic,
+		Protocol:    protocol,
+		Value:       v,
+		Comparator:  comparator,
+		StringValue: vs,
+		DataValue:   vd,
+		From:        from,
+		To:          to,
+		Aggregation: aggregation,
+		Interval:    interval,
+		Cursor:      cursor,
+
+		StringValueMatch: vsMatch,
+	}
+
+	// Comma separated or repeated publishers and names are matched as lists.
+	switch publishers := bone.GetQuery(r, publisherKey); len(publishers) {
+	case 0:
+	case 1:
+		pm.Publisher = publishers[0]
+	default:
+		pm.Publishers = publishers
+	}
+	switch names := bone.GetQuery(r, nameKey); len(names) {
+	case 0:
+	case 1:
+		pm.Name = names[0]
+	default:
+		pm.Names = names
+	}
+
+	if pm.ValueGt, err = readValueBoundQuery(r, valueGtKey); err != nil {
+		return readers.PageMetadata{}, err
+	}
+	if pm.ValueGe, err = readValueBoundQuery(r, valueGeKey); err != nil {
+		return readers.PageMetadata{}, err
+	}
+	if pm.ValueLt, err = readValueBoundQuery(r, valueLtKey); err != nil {
+		return readers.PageMetadata{}, err
+	}
+	if pm.ValueLe, err = readValueBoundQuery(r, valueLeKey); err != nil {
+		return readers.PageMetadata{}, err
 	}
 
 	vb, err := readBoolValueQuery(r, "vb")
 	if err != nil && err != errors.ErrNotFoundParam {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 	if err == nil {
-		req.pageMeta.BoolValue = vb
+		pm.BoolValue = vb
 	}
 
-	return req, nil
+	return pm, nil
 }
 
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
@@ -194,7 +304,8 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, nil):
-	case errors.Contains(err, errors.ErrInvalidQueryParams):
+	case errors.Contains(err, errors.ErrInvalidQueryParams),
+		errors.Contains(err, readers.ErrInvalidCursor):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errUnauthorizedAccess):
 		w.WriteHeader(http.StatusForbidden)
@@ -210,6 +321,9 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	}
 }
 
+// authorize checks whether the channel messages can be read using the
+// thing key, or the token of the user who owns the channel or is allowed
+// to read it by the auth service policies.
 func authorize(r *http.Request, chanID string) error {
 	token := r.Header.Get("Authorization")
 	if token == "" {
@@ -219,15 +333,31 @@ func authorize(r *http.Request, chanID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	_, err := auth.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: token, ChanID: chanID})
-	if err != nil {
-		e, ok := status.FromError(err)
-		if ok && e.Code() == codes.PermissionDenied {
-			return errUnauthorizedAccess
-		}
+	_, err := things.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: token, ChanID: chanID})
+	if err == nil {
+		return nil
+	}
+	e, ok := status.FromError(err)
+	if !ok || e.Code() != codes.PermissionDenied {
 		return err
 	}
 
+	user, err := auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errUnauthorizedAccess
+	}
+	if _, err := things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user.GetEmail(), ChanID: chanID}); err == nil {
+		return nil
+	}
+
+	res, err := auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Obj: chanID, Act: readAction})
+	if err != nil {
+		return errors.Wrap(errUnauthorizedAccess, err)
+	}
+	if !res.GetAuthorized() {
+		return errUnauthorizedAccess
+	}
+
 	return nil
 }
 
@@ -248,3 +378,23 @@ func readBoolValueQuery(r *http.Request, key string) (bool, error) {
 
 	return b, nil
 }
+
+// readValueBoundQuery returns nil if the value bound is not set, since
+// zero is a valid bound.
+func readValueBoundQuery(r *http.Request, key string) (*float64, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return nil, errors.ErrInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(vals[0], 64)
+	if err != nil {
+		return nil, errors.ErrInvalidQueryParams
+	}
+
+	return &f, nil
+}
@@ -6,6 +6,8 @@ package cassandra
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -14,12 +16,19 @@ import (
 	"github.com/mainflux/mainflux/readers"
 )
 
-var errReadMessages = errors.New("failed to read messages from cassandra database")
+var (
+	errReadMessages = errors.New("failed to read messages from cassandra database")
+	errNoTimeRange  = errors.New("aggregation requires both from and to")
+	errScanLimit    = errors.New("too many messages to scan, narrow down the time range")
+)
 
 const (
 	format = "format"
 	// Table for SenML messages
 	defTable = "messages"
+	// maxScanRows is the maximum number of rows the reader scans in order to
+	// count or aggregate the messages.
+	maxScanRows = 100000
 )
 
 var _ readers.MessageRepository = (*cassandraRepository)(nil)
@@ -42,70 +51,275 @@ func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 	}
 
 	q, vals := buildQuery(chanID, rpm)
+	f := newFilter(rpm)
 
-	selectCQL := fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, name, unit,
-		value, string_value, bool_value, data_value, sum, time,
-		update_time FROM messages WHERE channel = ? %s LIMIT ?
-		ALLOW FILTERING`, q)
-	countCQL := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? %s ALLOW FILTERING`, format, q)
+	if rpm.Aggregation != "" {
+		return cr.aggregate(rpm, q, vals, f)
+	}
 
-	if format != defTable {
-		selectCQL = fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, created, payload FROM %s WHERE channel = ? %s LIMIT ?
-			ALLOW FILTERING`, format, q)
-		countCQL = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? %s ALLOW FILTERING`, format, q)
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
 	}
 
-	iter := cr.session.Query(selectCQL, vals...).Iter()
-	defer iter.Close()
-	scanner := iter.Scanner()
+	var last cursor
+	var err error
+	switch rpm.Cursor {
+	case "":
+		last, err = cr.read(format, q, vals, f, rpm.Offset, rpm.Limit, &page)
+	default:
+		last, err = cr.readAfter(chanID, format, rpm, f, &page)
+	}
+	if err != nil {
+		return readers.MessagesPage{}, err
+	}
 
-	// skip first OFFSET rows
-	for i := uint64(0); i < rpm.Offset; i++ {
-		if !scanner.Next() {
-			break
+	if uint64(len(page.Messages)) == rpm.Limit {
+		if page.Next, err = readers.EncodeCursor(last); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 		}
 	}
 
-	page := readers.MessagesPage{
-		PageMetadata: rpm,
-		Messages:     []readers.Message{},
+	// Counting is skipped when reading by cursor, since it's expensive
+	// for large tables.
+	if rpm.Cursor != "" {
+		return page, nil
+	}
+
+	if page.Total, err = cr.count(format, q, vals, f); err != nil {
+		return readers.MessagesPage{}, err
 	}
 
-	switch format {
-	case defTable:
-		for scanner.Next() {
-			var msg senml.Message
-			err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol,
-				&msg.Name, &msg.Unit, &msg.Value, &msg.StringValue, &msg.BoolValue,
-				&msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime)
+	return page, nil
+}
+
+// readAfter reads the page following the message the cursor points to.
+// Since messages are clustered by time in descending and by ID in ascending
+// order, the page continues with the messages of the same time and greater
+// ID, followed by the older messages.
+func (cr cassandraRepository) readAfter(chanID, format string, rpm readers.PageMetadata, f *filter, page *readers.MessagesPage) (cursor, error) {
+	var c cursor
+	if err := readers.DecodeCursor(rpm.Cursor, &c); err != nil {
+		return cursor{}, errors.Wrap(errReadMessages, err)
+	}
+	if _, err := gocql.ParseUUID(c.ID); err != nil {
+		return cursor{}, errors.Wrap(errReadMessages, readers.ErrInvalidCursor)
+	}
+
+	order := "time"
+	var pos interface{} = c.Time
+	if format != defTable {
+		order = "created"
+		pos = c.Created
+	}
+
+	// Time can't be restricted by both equality and range, and the cursor
+	// position is already in the requested range.
+	tied := rpm
+	tied.From, tied.To = 0, 0
+	q, vals := buildQuery(chanID, tied)
+	q = fmt.Sprintf(`%s AND %s = ? AND id > ?`, q, order)
+	vals = append(vals, pos, c.ID)
+
+	last, err := cr.read(format, q, vals, f, 0, rpm.Limit, page)
+	if err != nil {
+		return cursor{}, err
+	}
+
+	n := uint64(len(page.Messages))
+	if n == rpm.Limit {
+		return last, nil
+	}
+
+	older := rpm
+	older.To = 0
+	q, vals = buildQuery(chanID, older)
+	q = fmt.Sprintf(`%s AND %s < ?`, q, order)
+	vals = append(vals, pos)
+
+	l, err := cr.read(format, q, vals, f, 0, rpm.Limit-n, page)
+	if err != nil {
+		return cursor{}, err
+	}
+	if uint64(len(page.Messages)) > n {
+		last = l
+	}
+
+	return last, nil
+}
+
+// read appends the messages matching the given condition and filter to the
+// page, skipping the given number of messages, and returns the position of
+// the last message read. If the messages are filtered by the reader, the
+// query can't be limited, so reading stops once the limit is reached.
+func (cr cassandraRepository) read(format, q string, vals []interface{}, f *filter, offset, limit uint64, page *readers.MessagesPage) (cursor, error) {
+	var limitCQL string
+	if f == nil {
+		limitCQL = "LIMIT ?"
+		vals = append(vals[:len(vals):len(vals)], offset+limit)
+	}
+
+	selectCQL := fmt.Sprintf(`SELECT id, channel, subtopic, publisher, protocol, name, unit,
+		value, string_value, bool_value, data_value, sum, time,
+		update_time FROM messages WHERE channel = ? %s %s
+		ALLOW FILTERING`, q, limitCQL)
+
+	if format != defTable {
+		selectCQL = fmt.Sprintf(`SELECT id, channel, subtopic, publisher, protocol, created, payload FROM %s WHERE channel = ? %s %s
+			ALLOW FILTERING`, format, q, limitCQL)
+	}
+
+	iter := cr.session.Query(selectCQL, vals...).Iter()
+	defer iter.Close()
+	scanner := iter.Scanner()
+
+	var last cursor
+	var skipped, n uint64
+	for n < limit && scanner.Next() {
+		var msg readers.Message
+		var pos cursor
+		switch format {
+		case defTable:
+			var m senml.Message
+			err := scanner.Scan(&pos.ID, &m.Channel, &m.Subtopic, &m.Publisher, &m.Protocol,
+				&m.Name, &m.Unit, &m.Value, &m.StringValue, &m.BoolValue,
+				&m.DataValue, &m.Sum, &m.Time, &m.UpdateTime)
 			if err != nil {
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+				return cursor{}, errors.Wrap(errReadMessages, err)
 			}
-			page.Messages = append(page.Messages, msg)
-		}
-	default:
-		for scanner.Next() {
-			var msg jsonMessage
-			err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Created, &msg.Payload)
+			if !f.match(m.Publisher, m.Subtopic, m.Name, m.StringValue) {
+				continue
+			}
+			pos.Time = m.Time
+			msg = m
+		default:
+			var m jsonMessage
+			err := scanner.Scan(&m.ID, &m.Channel, &m.Subtopic, &m.Publisher, &m.Protocol, &m.Created, &m.Payload)
 			if err != nil {
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+				return cursor{}, errors.Wrap(errReadMessages, err)
+			}
+			if !f.match(m.Publisher, m.Subtopic, "", nil) {
+				continue
 			}
-			m, err := msg.toMap()
+			mp, err := m.toMap()
 			if err != nil {
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+				return cursor{}, errors.Wrap(errReadMessages, err)
 			}
-			m["payload"] = jsont.ParseFlat(m["payload"])
-			page.Messages = append(page.Messages, m)
+			mp["payload"] = jsont.ParseFlat(mp["payload"])
+			pos.ID, pos.Created = m.ID, m.Created
+			msg = mp
+		}
+
+		// skip first OFFSET rows
+		if skipped < offset {
+			skipped++
+			continue
 		}
+		page.Messages = append(page.Messages, msg)
+		last = pos
+		n++
 	}
 
-	if err := cr.session.Query(countCQL, vals[:len(vals)-1]...).Scan(&page.Total); err != nil {
+	return last, nil
+}
+
+// count returns the number of messages matching the given condition and
+// filter. Filtered messages are counted by the reader, which scans at most
+// maxScanRows messages.
+func (cr cassandraRepository) count(format, q string, vals []interface{}, f *filter) (uint64, error) {
+	if f == nil {
+		var total uint64
+		countCQL := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? %s ALLOW FILTERING`, format, q)
+		if err := cr.session.Query(countCQL, vals...).Scan(&total); err != nil {
+			return 0, errors.Wrap(errReadMessages, err)
+		}
+		return total, nil
+	}
+
+	cols := "publisher, subtopic, name, string_value"
+	if format != defTable {
+		cols = "publisher, subtopic"
+	}
+	selectCQL := fmt.Sprintf(`SELECT %s FROM %s WHERE channel = ? %s LIMIT ? ALLOW FILTERING`, cols, format, q)
+	vals = append(vals[:len(vals):len(vals)], maxScanRows+1)
+
+	iter := cr.session.Query(selectCQL, vals...).Iter()
+	scanner := iter.Scanner()
+
+	var total, scanned uint64
+	for scanner.Next() {
+		if scanned++; scanned > maxScanRows {
+			iter.Close()
+			return 0, errors.Wrap(errors.ErrInvalidQueryParams, errScanLimit)
+		}
+		var publisher, subtopic, name string
+		var vs *string
+		dest := []interface{}{&publisher, &subtopic, &name, &vs}
+		if format != defTable {
+			dest = dest[:2]
+		}
+		if err := scanner.Scan(dest...); err != nil {
+			iter.Close()
+			return 0, errors.Wrap(errReadMessages, err)
+		}
+		if f.match(publisher, subtopic, name, vs) {
+			total++
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return 0, errors.Wrap(errReadMessages, err)
+	}
+
+	return total, nil
+}
+
+// aggregate returns the page of aggregated values. Since Cassandra supports
+// grouping by primary key columns only, matching messages are aggregated
+// by the reader. In order to bound the scan, the time range is required
+// and at most maxScanRows messages are aggregated.
+func (cr cassandraRepository) aggregate(rpm readers.PageMetadata, q string, vals []interface{}, f *filter) (readers.MessagesPage, error) {
+	if rpm.From == 0 || rpm.To == 0 {
+		return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, errNoTimeRange)
+	}
+
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
 
-	return page, nil
+	selectCQL := fmt.Sprintf(`SELECT channel, subtopic, publisher, name, value, string_value, time FROM %s
+		WHERE channel = ? %s LIMIT ? ALLOW FILTERING`, defTable, q)
+	vals = append(vals[:len(vals):len(vals)], maxScanRows+1)
+
+	iter := cr.session.Query(selectCQL, vals...).Iter()
+	scanner := iter.Scanner()
+
+	var msgs []senml.Message
+	var scanned uint64
+	for scanner.Next() {
+		if scanned++; scanned > maxScanRows {
+			iter.Close()
+			return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, errScanLimit)
+		}
+		var msg senml.Message
+		if err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Name, &msg.Value, &msg.StringValue, &msg.Time); err != nil {
+			iter.Close()
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		if f.match(msg.Publisher, msg.Subtopic, msg.Name, msg.StringValue) {
+			msgs = append(msgs, msg)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+
+	aggs := readers.Aggregate(msgs, rpm.Aggregation, interval.Seconds())
+	return readers.AggregatesPage(rpm, aggs), nil
 }
 
+// buildQuery returns CQL condition and its values. Conditions on regular
+// columns that CQL doesn't support are left to the filter.
 func buildQuery(chanID string, rpm readers.PageMetadata) (string, []interface{}) {
 	var condCQL string
 	vals := []interface{}{chanID}
@@ -119,9 +333,14 @@ func buildQuery(chanID string, rpm readers.PageMetadata) (string, []interface{})
 
 	for name, val := range query {
 		switch name {
+		case "subtopic":
+			if readers.HasWildcard(rpm.Subtopic) {
+				continue
+			}
+			vals = append(vals, val)
+			condCQL = fmt.Sprintf(`%s AND %s = ?`, condCQL, name)
 		case
 			"channel",
-			"subtopic",
 			"publisher",
 			"name",
 			"protocol":
@@ -131,10 +350,25 @@ func buildQuery(chanID string, rpm readers.PageMetadata) (string, []interface{})
 			vals = append(vals, val)
 			comparator := readers.ParseValueComparator(query)
 			condCQL = fmt.Sprintf(`%s AND value %s ?`, condCQL, comparator)
+		case "v_gt":
+			vals = append(vals, val)
+			condCQL = fmt.Sprintf(`%s AND value > ?`, condCQL)
+		case "v_ge":
+			vals = append(vals, val)
+			condCQL = fmt.Sprintf(`%s AND value >= ?`, condCQL)
+		case "v_lt":
+			vals = append(vals, val)
+			condCQL = fmt.Sprintf(`%s AND value < ?`, condCQL)
+		case "v_le":
+			vals = append(vals, val)
+			condCQL = fmt.Sprintf(`%s AND value <= ?`, condCQL)
 		case "vb":
 			vals = append(vals, val)
 			condCQL = fmt.Sprintf(`%s AND bool_value = ?`, condCQL)
 		case "vs":
+			if rpm.StringValueMatch != "" {
+				continue
+			}
 			vals = append(vals, val)
 			condCQL = fmt.Sprintf(`%s AND string_value = ?`, condCQL)
 		case "vd":
@@ -148,11 +382,72 @@ func buildQuery(chanID string, rpm readers.PageMetadata) (string, []interface{})
 			condCQL = fmt.Sprintf(`%s AND time < ?`, condCQL)
 		}
 	}
-	vals = append(vals, rpm.Offset+rpm.Limit)
 
 	return condCQL, vals
 }
 
+// filter matches messages by the conditions on regular columns that CQL
+// doesn't support, which are IN lists and pattern matching.
+type filter struct {
+	publishers map[string]bool
+	names      map[string]bool
+	subtopic   *regexp.Regexp
+	vs         *regexp.Regexp
+}
+
+// newFilter returns nil if there are no conditions to filter by.
+func newFilter(rpm readers.PageMetadata) *filter {
+	var f filter
+	var ok bool
+	if len(rpm.Publishers) > 0 {
+		f.publishers, ok = toSet(rpm.Publishers), true
+	}
+	if len(rpm.Names) > 0 {
+		f.names, ok = toSet(rpm.Names), true
+	}
+	if readers.HasWildcard(rpm.Subtopic) {
+		f.subtopic, ok = regexp.MustCompile(readers.SubtopicRegex(rpm.Subtopic)), true
+	}
+	if rpm.StringValue != "" && rpm.StringValueMatch != "" {
+		f.vs, ok = regexp.MustCompile(readers.StringValueRegex(rpm.StringValue, rpm.StringValueMatch)), true
+	}
+	if !ok {
+		return nil
+	}
+
+	return &f
+}
+
+func (f *filter) match(publisher, subtopic, name string, vs *string) bool {
+	switch {
+	case f == nil:
+		return true
+	case f.publishers != nil && !f.publishers[publisher],
+		f.names != nil && !f.names[name],
+		f.subtopic != nil && !f.subtopic.MatchString(subtopic),
+		f.vs != nil && (vs == nil || !f.vs.MatchString(*vs)):
+		return false
+	default:
+		return true
+	}
+}
+
+func toSet(vals []string) map[string]bool {
+	set := make(map[string]bool, len(vals))
+	for _, v := range vals {
+		set[v] = true
+	}
+
+	return set
+}
+
+// cursor represents the position of the last message of the page.
+type cursor struct {
+	ID      string  `json:"id"`
+	Time    float64 `json:"time,omitempty"`
+	Created int64   `json:"created,omitempty"`
+}
+
 type jsonMessage struct {
 	ID        string
 	Channel   string
@@ -5,14 +5,17 @@ package cassandra_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
 	writer "github.com/mainflux/mainflux/consumers/writers/cassandra"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/readers"
 	reader "github.com/mainflux/mainflux/readers/cassandra"
+	"github.com/mainflux/mainflux/readers/readerstest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -367,6 +370,239 @@ func TestReadSenml(t *testing.T) {
 	}
 }
 
+func TestReadAggregated(t *testing.T) {
+	session, err := reader.Connect(reader.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	defer session.Close()
+	writer := writer.New(session)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	interval := 10 * time.Minute
+	secs := interval.Seconds()
+	start := math.Floor(float64(time.Now().Add(-time.Hour).Unix())/secs) * secs
+
+	// Every bucket contains values from 1 to 4 and a message without
+	// numeric value which is ignored by the aggregation.
+	buckets := 3
+	messages := []senml.Message{}
+	for b := 0; b < buckets; b++ {
+		bucket := start + float64(b)*secs
+		for i := 1; i <= 4; i++ {
+			value := float64(i)
+			messages = append(messages, senml.Message{
+				Channel:   chanID,
+				Publisher: pubID,
+				Protocol:  mqttProt,
+				Name:      msgName,
+				Time:      bucket + float64(i*60),
+				Value:     &value,
+			})
+		}
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      bucket,
+			BoolValue: &vb,
+		})
+	}
+
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := reader.New(session)
+
+	aggregated := func(value float64, buckets ...int) []readers.Message {
+		var ret []readers.Message
+		for _, b := range buckets {
+			val := value
+			ret = append(ret, senml.Message{
+				Channel:   chanID,
+				Publisher: pubID,
+				Name:      msgName,
+				Time:      start + float64(b)*secs,
+				Value:     &val,
+			})
+		}
+		return ret
+	}
+
+	from, to := start, start+float64(buckets)*secs
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+		err      error
+	}{
+		"read minimum values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				From:        from,
+				To:          to,
+				Aggregation: readers.MinKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(1, 2, 1, 0),
+			},
+		},
+		"read maximum values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				From:        from,
+				To:          to,
+				Aggregation: readers.MaxKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(4, 2, 1, 0),
+			},
+		},
+		"read average values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				From:        from,
+				To:          to,
+				Aggregation: readers.AvgKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(2.5, 2, 1, 0),
+			},
+		},
+		"read sum of values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				From:        from,
+				To:          to,
+				Aggregation: readers.SumKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(10, 2, 1, 0),
+			},
+		},
+		"read count of values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				From:        from,
+				To:          to,
+				Aggregation: readers.CountKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(4, 2, 1, 0),
+			},
+		},
+		"read aggregated values with offset and limit": {
+			pageMeta: readers.PageMetadata{
+				Offset:      1,
+				Limit:       1,
+				From:        from,
+				To:          to,
+				Aggregation: readers.AvgKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(2.5, 1),
+			},
+		},
+		"read aggregated values with from/to": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				From:        start + secs,
+				To:          start + 2*secs,
+				Aggregation: readers.SumKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    1,
+				Messages: aggregated(10, 1),
+			},
+		},
+		"read aggregated values without time range": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				Aggregation: readers.SumKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{},
+			err:  errors.ErrInvalidQueryParams,
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(chanID, tc.pageMeta)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", desc, tc.err, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestReadByCursor(t *testing.T) {
+	session, err := reader.Connect(reader.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	defer session.Close()
+	writer := writer.New(session)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Pairs of messages share the time, so the cursor has to break the ties.
+	n := 25
+	now := float64(time.Now().Unix())
+	messages := []senml.Message{}
+	for i := 0; i < n; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      fmt.Sprintf("%s-%d", msgName, i%2),
+			Time:      now - float64(i/2),
+			Value:     &v,
+		})
+	}
+
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := reader.New(session)
+
+	page, err := reader.ReadAll(chanID, readers.PageMetadata{Limit: limit})
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, uint64(n), page.Total, fmt.Sprintf("expected total %d got %d", n, page.Total))
+
+	read := page.Messages
+	for page.Next != "" {
+		page, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: limit, Cursor: page.Next})
+		require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+		read = append(read, page.Messages...)
+	}
+	assert.ElementsMatch(t, fromSenml(messages), read, fmt.Sprintf("expected %v got %v", messages, read))
+
+	_, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: limit, Cursor: "invalid"})
+	assert.True(t, errors.Contains(err, readers.ErrInvalidCursor), fmt.Sprintf("expected %s got %s", readers.ErrInvalidCursor, err))
+}
+
 func fromSenml(in []senml.Message) []readers.Message {
 	var ret []readers.Message
 	for _, m := range in {
@@ -374,3 +610,14 @@ func fromSenml(in []senml.Message) []readers.Message {
 	}
 	return ret
 }
+
+func TestReadFiltered(t *testing.T) {
+	session, err := reader.Connect(reader.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	defer session.Close()
+
+	readerstest.TestFilters(t, writer.New(session), reader.New(session))
+}
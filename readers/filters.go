@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// SingleWildcard matches exactly one subtopic token.
+	SingleWildcard = "*"
+	// MultiWildcard matches one or more trailing subtopic tokens.
+	MultiWildcard = ">"
+
+	subtopicSep = "."
+)
+
+// HasWildcard reports whether the subtopic contains wildcard tokens.
+func HasWildcard(subtopic string) bool {
+	for _, token := range strings.Split(subtopic, subtopicSep) {
+		if token == SingleWildcard || token == MultiWildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidSubtopicPattern reports whether the multi-token wildcard, if any,
+// is the last subtopic token.
+func ValidSubtopicPattern(subtopic string) bool {
+	tokens := strings.Split(subtopic, subtopicSep)
+	for i, token := range tokens {
+		if token == MultiWildcard && i != len(tokens)-1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SubtopicRegex returns the anchored regular expression that matches the
+// subtopics matched by the subtopic pattern. Wildcards have the same
+// semantics as in the message broker subjects.
+func SubtopicRegex(subtopic string) string {
+	tokens := strings.Split(subtopic, subtopicSep)
+	for i, token := range tokens {
+		switch token {
+		case SingleWildcard:
+			tokens[i] = `[^.]+`
+		case MultiWildcard:
+			tokens[i] = `.+`
+		default:
+			tokens[i] = regexp.QuoteMeta(token)
+		}
+	}
+
+	return "^" + strings.Join(tokens, `\.`) + "$"
+}
+
+// StringValueRegex returns the regular expression that matches the string
+// values starting with or containing the given value, depending on the
+// match type.
+func StringValueRegex(value, match string) string {
+	re := regexp.QuoteMeta(value)
+	if match == PrefixKey {
+		return "^" + re
+	}
+
+	return re
+}
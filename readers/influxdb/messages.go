@@ -24,6 +24,14 @@ const (
 
 var errReadMessages = errors.New("failed to read messages from influxdb database")
 
+var aggregations = map[string]string{
+	readers.MinKey:   "MIN",
+	readers.MaxKey:   "MAX",
+	readers.AvgKey:   "MEAN",
+	readers.SumKey:   "SUM",
+	readers.CountKey: "COUNT",
+}
+
 var _ readers.MessageRepository = (*influxRepository)(nil)
 
 type influxRepository struct {
@@ -47,7 +55,24 @@ func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 
 	condition := fmtCondition(chanID, rpm)
 
-	cmd := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY time DESC LIMIT %d OFFSET %d`, format, condition, rpm.Limit, rpm.Offset)
+	if rpm.Aggregation != "" {
+		return repo.aggregate(chanID, condition, rpm)
+	}
+
+	var after cursor
+	offset := rpm.Offset
+	query := condition
+	if rpm.Cursor != "" {
+		if err := readers.DecodeCursor(rpm.Cursor, &after); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		// Points have no ID, so the messages of the cursor time that
+		// were already read are skipped.
+		offset = after.Skip
+		query = fmt.Sprintf(`%s AND time <= %d`, condition, after.Time)
+	}
+
+	cmd := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY time DESC LIMIT %d OFFSET %d`, format, query, rpm.Limit, offset)
 	q := influxdata.Query{
 		Command:  cmd,
 		Database: repo.database,
@@ -72,20 +97,107 @@ func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		ret = append(ret, parseMessage(format, result.Columns, v))
 	}
 
-	total, err := repo.count(format, condition)
-	if err != nil {
-		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
-	}
-
 	page := readers.MessagesPage{
 		PageMetadata: rpm,
-		Total:        total,
 		Messages:     ret,
 	}
 
+	if uint64(len(ret)) == rpm.Limit {
+		last := lastPosition(result.Columns, result.Values)
+		if rpm.Cursor != "" && last.Time == after.Time {
+			last.Skip += after.Skip
+		}
+		if page.Next, err = readers.EncodeCursor(last); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+	}
+
+	// Counting is skipped when reading by cursor, since it's expensive
+	// for large measurements.
+	if rpm.Cursor != "" {
+		return page, nil
+	}
+
+	if page.Total, err = repo.count(format, condition); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+
 	return page, nil
 }
 
+// cursor represents the position of the last message of the page as its
+// time in nanoseconds and the number of read messages of the same time.
+type cursor struct {
+	Time int64  `json:"time"`
+	Skip uint64 `json:"skip"`
+}
+
+func lastPosition(names []string, rows [][]interface{}) cursor {
+	idx := 0
+	for i, name := range names {
+		if name == "time" {
+			idx = i
+			break
+		}
+	}
+
+	var c cursor
+	for i := len(rows) - 1; i >= 0; i-- {
+		s, _ := rows[i][idx].(string)
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			break
+		}
+		if c.Skip > 0 && t.UnixNano() != c.Time {
+			break
+		}
+		c.Time = t.UnixNano()
+		c.Skip++
+	}
+
+	return c
+}
+
+// aggregate returns the page of aggregated values. Since InfluxDB applies
+// LIMIT and OFFSET to every series separately, all the groups are fetched
+// and paginated afterwards.
+func (repo *influxRepository) aggregate(chanID, condition string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+
+	cmd := fmt.Sprintf(`SELECT %s(value) AS value FROM %s WHERE %s GROUP BY time(%dns), "name", "publisher" fill(none)`,
+		aggregations[rpm.Aggregation], defMeasurement, condition, interval.Nanoseconds())
+	q := influxdata.Query{
+		Command:  cmd,
+		Database: repo.database,
+	}
+
+	resp, err := repo.client.Query(q)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	if resp.Error() != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, resp.Error())
+	}
+
+	aggs := []senml.Message{}
+	for _, res := range resp.Results {
+		for _, series := range res.Series {
+			for _, v := range series.Values {
+				msg := parseSenml(series.Columns, v).(senml.Message)
+				msg.Channel = chanID
+				msg.Name = series.Tags["name"]
+				msg.Publisher = series.Tags["publisher"]
+				aggs = append(aggs, msg)
+			}
+		}
+	}
+
+	return readers.AggregatesPage(rpm, aggs), nil
+}
+
 func (repo *influxRepository) count(measurement, condition string) (uint64, error) {
 	cmd := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, measurement, condition)
 	q := influxdata.Query{
@@ -140,19 +252,41 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 
 	for name, value := range query {
 		switch name {
+		case "subtopic":
+			if readers.HasWildcard(rpm.Subtopic) {
+				condition = fmt.Sprintf(`%s AND "subtopic" =~ %s`, condition, fmtRegex(readers.SubtopicRegex(rpm.Subtopic)))
+				continue
+			}
+			condition = fmt.Sprintf(`%s AND "%s"='%s'`, condition, name, value)
 		case
 			"channel",
-			"subtopic",
 			"publisher",
 			"name",
 			"protocol":
 			condition = fmt.Sprintf(`%s AND "%s"='%s'`, condition, name, value)
+		case "publishers":
+			condition = fmt.Sprintf(`%s AND %s`, condition, fmtIn("publisher", rpm.Publishers))
+		case "names":
+			condition = fmt.Sprintf(`%s AND %s`, condition, fmtIn("name", rpm.Names))
 		case "v":
 			comparator := readers.ParseValueComparator(query)
 			condition = fmt.Sprintf(`%s AND value %s %f`, condition, comparator, value)
+		case "v_gt":
+			condition = fmt.Sprintf(`%s AND value > %f`, condition, value)
+		case "v_ge":
+			condition = fmt.Sprintf(`%s AND value >= %f`, condition, value)
+		case "v_lt":
+			condition = fmt.Sprintf(`%s AND value < %f`, condition, value)
+		case "v_le":
+			condition = fmt.Sprintf(`%s AND value <= %f`, condition, value)
 		case "vb":
 			condition = fmt.Sprintf(`%s AND boolValue = %t`, condition, value)
 		case "vs":
+			if rpm.StringValueMatch != "" {
+				re := readers.StringValueRegex(rpm.StringValue, rpm.StringValueMatch)
+				condition = fmt.Sprintf(`%s AND stringValue =~ %s`, condition, fmtRegex(re))
+				continue
+			}
 			condition = fmt.Sprintf(`%s AND stringValue = '%s'`, condition, value)
 		case "vd":
 			condition = fmt.Sprintf(`%s AND dataValue = '%s'`, condition, value)
@@ -167,6 +301,22 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	return condition
 }
 
+// fmtIn returns the condition matching any of the tag values, since
+// InfluxQL doesn't support IN operator.
+func fmtIn(tag string, values []string) string {
+	var conds []string
+	for _, v := range values {
+		conds = append(conds, fmt.Sprintf(`"%s"='%s'`, tag, v))
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(conds, " OR "))
+}
+
+// fmtRegex returns the regular expression literal.
+func fmtRegex(re string) string {
+	return fmt.Sprintf("/%s/", strings.ReplaceAll(re, "/", `\/`))
+}
+
 // ParseMessage and parseValues are util methods. Since InfluxDB client returns
 // results in form of rows and columns, this obscure message conversion is needed
 // to return actual []broker.Message from the query result.
@@ -3,7 +3,11 @@
 
 package readers
 
-import "errors"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
 
 const (
 	// EqualKey represents the equal comparison operator key.
@@ -16,10 +20,19 @@ const (
 	GreaterThanKey = "gt"
 	// GreaterThanEqualKey represents the greater-than-or-equal comparison operator key.
 	GreaterThanEqualKey = "ge"
+	// PrefixKey represents the string value prefix match key.
+	PrefixKey = "prefix"
+	// ContainsKey represents the string value substring match key.
+	ContainsKey = "contains"
 )
 
-// ErrNotFound indicates that requested entity doesn't exist.
-var ErrNotFound = errors.New("entity not found")
+var (
+	// ErrNotFound indicates that requested entity doesn't exist.
+	ErrNotFound = errors.New("entity not found")
+
+	// ErrInvalidCursor indicates malformed page cursor.
+	ErrInvalidCursor = errors.New("invalid page cursor")
+)
 
 // MessageRepository specifies message reader API.
 type MessageRepository interface {
@@ -32,10 +45,12 @@ type MessageRepository interface {
 type Message interface{}
 
 // MessagesPage contains page related metadata as well as list of messages that
-// belong to this page.
+// belong to this page. Next is the cursor of the following page, set if the
+// page is full. Total is not calculated for the pages read using the cursor.
 type MessagesPage struct {
 	PageMetadata
 	Total    uint64
+	Next     string
 	Messages []Message
 }
 
@@ -55,6 +70,46 @@ type PageMetadata struct {
 	From        float64 `json:"from,omitempty"`
 	To          float64 `json:"to,omitempty"`
 	Format      string  `json:"format,omitempty"`
+	Aggregation string  `json:"aggregation,omitempty"`
+	Interval    string  `json:"interval,omitempty"`
+	Cursor      string  `json:"cursor,omitempty"`
+	// Publishers and Names are the lists of the accepted publishers and
+	// names, used instead of Publisher and Name to match any of them.
+	Publishers []string `json:"publishers,omitempty"`
+	Names      []string `json:"names,omitempty"`
+	// Value bounds are combined with each other and with Value.
+	ValueGt *float64 `json:"v_gt,omitempty"`
+	ValueGe *float64 `json:"v_ge,omitempty"`
+	ValueLt *float64 `json:"v_lt,omitempty"`
+	ValueLe *float64 `json:"v_le,omitempty"`
+	// StringValueMatch is the StringValue match type, which is either
+	// prefix or contains. String values are matched exactly by default.
+	StringValueMatch string `json:"vs_match,omitempty"`
+}
+
+// EncodeCursor encodes the position of the last message of the page into
+// the opaque cursor. The position representation is up to the repository.
+func EncodeCursor(pos interface{}) (string, error) {
+	data, err := json.Marshal(pos)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes the opaque cursor into the position of the last
+// message of the previous page.
+func DecodeCursor(cursor string, pos interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, pos); err != nil {
+		return ErrInvalidCursor
+	}
+
+	return nil
 }
 
 // ParseValueComparator convert comparison operator keys into mathematic anotation
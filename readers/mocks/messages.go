@@ -5,7 +5,10 @@ package mocks
 
 import (
 	"encoding/json"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
@@ -51,9 +54,26 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 		for name := range query {
 			switch name {
 			case "subtopic":
+				if readers.HasWildcard(rpm.Subtopic) {
+					re := regexp.MustCompile(readers.SubtopicRegex(rpm.Subtopic))
+					ok = re.MatchString(senml.Subtopic)
+					break
+				}
 				if rpm.Subtopic != senml.Subtopic {
 					ok = false
 				}
+			case "publishers":
+				ok = contains(rpm.Publishers, senml.Publisher)
+			case "names":
+				ok = contains(rpm.Names, senml.Name)
+			case "v_gt":
+				ok = senml.Value != nil && *senml.Value > *rpm.ValueGt
+			case "v_ge":
+				ok = senml.Value != nil && *senml.Value >= *rpm.ValueGe
+			case "v_lt":
+				ok = senml.Value != nil && *senml.Value < *rpm.ValueLt
+			case "v_le":
+				ok = senml.Value != nil && *senml.Value <= *rpm.ValueLe
 			case "publisher":
 				if rpm.Publisher != senml.Publisher {
 					ok = false
@@ -109,10 +129,15 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 					ok = false
 				}
 			case "vs":
-				if senml.StringValue == nil ||
-					(senml.StringValue != nil &&
-						*senml.StringValue != rpm.StringValue) {
+				switch {
+				case senml.StringValue == nil:
 					ok = false
+				case rpm.StringValueMatch == readers.PrefixKey:
+					ok = strings.HasPrefix(*senml.StringValue, rpm.StringValue)
+				case rpm.StringValueMatch == readers.ContainsKey:
+					ok = strings.Contains(*senml.StringValue, rpm.StringValue)
+				default:
+					ok = *senml.StringValue == rpm.StringValue
 				}
 			case "vd":
 				if senml.DataValue == nil ||
@@ -140,9 +165,33 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 		}
 	}
 
+	if rpm.Aggregation != "" {
+		interval, err := time.ParseDuration(rpm.Interval)
+		if err != nil {
+			return readers.MessagesPage{}, err
+		}
+		var senmlMsgs []senml.Message
+		for _, m := range msgs {
+			senmlMsgs = append(senmlMsgs, m.(senml.Message))
+		}
+		aggs := readers.Aggregate(senmlMsgs, rpm.Aggregation, interval.Seconds())
+		return readers.AggregatesPage(rpm, aggs), nil
+	}
+
+	// Mock cursor points to the message offset, since mocked messages
+	// have no IDs.
+	offset := rpm.Offset
+	if rpm.Cursor != "" {
+		var c cursor
+		if err := readers.DecodeCursor(rpm.Cursor, &c); err != nil {
+			return readers.MessagesPage{}, err
+		}
+		offset = c.Offset
+	}
+
 	numOfMessages := uint64(len(msgs))
 
-	if rpm.Offset >= numOfMessages {
+	if offset >= numOfMessages {
 		return readers.MessagesPage{}, nil
 	}
 
@@ -150,14 +199,39 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 		return readers.MessagesPage{}, nil
 	}
 
-	end := rpm.Offset + rpm.Limit
-	if rpm.Offset+rpm.Limit > numOfMessages {
+	end := offset + rpm.Limit
+	if offset+rpm.Limit > numOfMessages {
 		end = numOfMessages
 	}
 
-	return readers.MessagesPage{
+	page := readers.MessagesPage{
 		PageMetadata: rpm,
-		Total:        uint64(len(msgs)),
-		Messages:     msgs[rpm.Offset:end],
-	}, nil
+		Messages:     msgs[offset:end],
+	}
+	if rpm.Cursor == "" {
+		page.Total = numOfMessages
+	}
+	if end-offset == rpm.Limit {
+		next, err := readers.EncodeCursor(cursor{Offset: end})
+		if err != nil {
+			return readers.MessagesPage{}, err
+		}
+		page.Next = next
+	}
+
+	return page, nil
+}
+
+type cursor struct {
+	Offset uint64 `json:"offset"`
+}
+
+func contains(vals []string, val string) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+
+	return false
 }
@@ -42,10 +42,15 @@ func (svc thingsServiceMock) CanAccessByID(context.Context, *mainflux.AccessByID
 	panic("not implemented")
 }
 
+// IsChannelOwner denies the access, since the mock channels have no owners.
 func (svc thingsServiceMock) IsChannelOwner(context.Context, *mainflux.ChannelOwnerReq, ...grpc.CallOption) (*empty.Empty, error) {
-	panic("not implemented")
+	return nil, errUnauthorized
 }
 
 func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
 	panic("not implemented")
 }
+
+func (svc thingsServiceMock) DerivePSK(context.Context, *mainflux.ThingID, ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
@@ -6,12 +6,14 @@ package mongodb
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
 	jsont "github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -24,6 +26,14 @@ const (
 
 var errReadMessages = errors.New("failed to read messages from mongodb database")
 
+var aggregations = map[string]interface{}{
+	readers.MinKey:   bson.M{"$min": "$value"},
+	readers.MaxKey:   bson.M{"$max": "$value"},
+	readers.AvgKey:   bson.M{"$avg": "$value"},
+	readers.SumKey:   bson.M{"$sum": "$value"},
+	readers.CountKey: bson.M{"$sum": 1},
+}
+
 var _ readers.MessageRepository = (*mongoRepository)(nil)
 
 type mongoRepository struct {
@@ -45,49 +55,210 @@ func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (re
 
 	col := repo.db.Collection(format)
 
-	sortMap := map[string]interface{}{
-		"time": -1,
+	if rpm.Aggregation != "" {
+		return repo.aggregate(col, chanID, rpm)
+	}
+
+	order := "time"
+	if format != defCollection {
+		order = "created"
+	}
+	sort := bson.D{
+		{Key: order, Value: -1},
+		{Key: "_id", Value: -1},
 	}
+
 	// Remove format filter and format the rest properly.
 	filter := fmtCondition(chanID, rpm)
-	cursor, err := col.Find(context.Background(), filter, options.Find().SetSort(sortMap).SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
+	if rpm.Cursor != "" {
+		after, err := fmtCursor(rpm.Cursor, order, format)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		filter = bson.D{{Key: "$and", Value: bson.A{filter, after}}}
+	}
+
+	cur, err := col.Find(context.Background(), filter, options.Find().SetSort(sort).SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
-	defer cursor.Close(context.Background())
+	defer cur.Close(context.Background())
 
 	var messages []readers.Message
+	var last cursor
 	switch format {
 	case defCollection:
-		for cursor.Next(context.Background()) {
-			var m senml.Message
-			if err := cursor.Decode(&m); err != nil {
+		for cur.Next(context.Background()) {
+			var m dbMessage
+			if err := cur.Decode(&m); err != nil {
 				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 			}
 
-			messages = append(messages, m)
+			messages = append(messages, m.Message)
+			last = cursor{ID: m.ID.Hex(), Time: m.Time}
 		}
 	default:
-		for cursor.Next(context.Background()) {
+		for cur.Next(context.Background()) {
 			var m map[string]interface{}
-			if err := cursor.Decode(&m); err != nil {
+			if err := cur.Decode(&m); err != nil {
 				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 			}
 			m["payload"] = jsont.ParseFlat(m["payload"])
 
 			messages = append(messages, m)
+			last = cursor{}
+			if id, ok := m["_id"].(primitive.ObjectID); ok {
+				last.ID = id.Hex()
+			}
+			if created, ok := m["created"].(int64); ok {
+				last.Created = created
+			}
 		}
 	}
 
+	mp := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     messages,
+	}
+
+	if uint64(len(messages)) == rpm.Limit {
+		if mp.Next, err = readers.EncodeCursor(last); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+	}
+
+	// Counting is skipped when reading by cursor, since it's expensive
+	// for large collections.
+	if rpm.Cursor != "" {
+		return mp, nil
+	}
+
 	total, err := col.CountDocuments(context.Background(), filter)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
+	mp.Total = uint64(total)
+
+	return mp, nil
+}
+
+// cursor represents the position of the last message of the page.
+type cursor struct {
+	ID      string  `json:"id"`
+	Time    float64 `json:"time,omitempty"`
+	Created int64   `json:"created,omitempty"`
+}
+
+type dbMessage struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	senml.Message `bson:",inline"`
+}
+
+// fmtCursor returns the filter matching the messages that follow the
+// message the cursor points to, using the ID to break the ties.
+func fmtCursor(c, order, format string) (bson.M, error) {
+	var pos cursor
+	if err := readers.DecodeCursor(c, &pos); err != nil {
+		return nil, err
+	}
+	id, err := primitive.ObjectIDFromHex(pos.ID)
+	if err != nil {
+		return nil, readers.ErrInvalidCursor
+	}
+
+	var val interface{} = pos.Time
+	if format != defCollection {
+		val = pos.Created
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{order: bson.M{"$lt": val}},
+		bson.M{order: val, "_id": bson.M{"$lt": id}},
+	}}, nil
+}
+
+type aggregatesPage struct {
+	Total []struct {
+		Count uint64 `bson:"count"`
+	} `bson:"total"`
+	Messages []struct {
+		ID struct {
+			Name      string  `bson:"name"`
+			Publisher string  `bson:"publisher"`
+			Time      float64 `bson:"time"`
+		} `bson:"_id"`
+		Value float64 `bson:"value"`
+	} `bson:"messages"`
+}
+
+func (repo mongoRepository) aggregate(col *mongo.Collection, chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	secs := interval.Seconds()
+
+	filter := bson.D{{Key: "$and", Value: bson.A{
+		fmtCondition(chanID, rpm),
+		bson.M{"value": bson.M{"$ne": nil}},
+	}}}
+	group := bson.D{
+		{Key: "_id", Value: bson.M{
+			"name":      "$name",
+			"publisher": "$publisher",
+			"time":      bson.M{"$subtract": bson.A{"$time", bson.M{"$mod": bson.A{"$time", secs}}}},
+		}},
+		{Key: "value", Value: aggregations[rpm.Aggregation]},
+	}
+	sort := bson.D{
+		{Key: "_id.time", Value: -1},
+		{Key: "_id.name", Value: 1},
+		{Key: "_id.publisher", Value: 1},
+	}
+	facet := bson.M{
+		"total": bson.A{bson.M{"$count": "count"}},
+		"messages": bson.A{
+			bson.M{"$skip": int64(rpm.Offset)},
+			bson.M{"$limit": int64(rpm.Limit)},
+		},
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: group}},
+		{{Key: "$sort", Value: sort}},
+		{{Key: "$facet", Value: facet}},
+	}
+
+	cursor, err := col.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
 
 	mp := readers.MessagesPage{
 		PageMetadata: rpm,
-		Total:        uint64(total),
-		Messages:     messages,
+		Messages:     []readers.Message{},
+	}
+	if !cursor.Next(context.Background()) {
+		return mp, nil
+	}
+
+	var ap aggregatesPage
+	if err := cursor.Decode(&ap); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	if len(ap.Total) > 0 {
+		mp.Total = ap.Total[0].Count
+	}
+	for _, m := range ap.Messages {
+		value := m.Value
+		mp.Messages = append(mp.Messages, senml.Message{
+			Channel:   chanID,
+			Name:      m.ID.Name,
+			Publisher: m.ID.Publisher,
+			Time:      m.ID.Time,
+			Value:     &value,
+		})
 	}
 
 	return mp, nil
@@ -108,36 +279,57 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 	}
 	json.Unmarshal(meta, &query)
 
+	valueFilter := bson.M{}
 	for name, value := range query {
 		switch name {
+		case "subtopic":
+			if readers.HasWildcard(rpm.Subtopic) {
+				filter = append(filter, bson.E{Key: name, Value: bson.M{"$regex": readers.SubtopicRegex(rpm.Subtopic)}})
+				continue
+			}
+			filter = append(filter, bson.E{Key: name, Value: value})
 		case
 			"channel",
-			"subtopic",
 			"publisher",
 			"name",
 			"protocol":
 			filter = append(filter, bson.E{Key: name, Value: value})
+		case "publishers":
+			filter = append(filter, bson.E{Key: "publisher", Value: bson.M{"$in": rpm.Publishers}})
+		case "names":
+			filter = append(filter, bson.E{Key: "name", Value: bson.M{"$in": rpm.Names}})
 		case "v":
-			bsonFilter := value
+			op := "$eq"
 			val, ok := query["comparator"]
 			if ok {
 				switch val.(string) {
-				case readers.EqualKey:
-					bsonFilter = value
 				case readers.LowerThanKey:
-					bsonFilter = bson.M{"$lt": value}
+					op = "$lt"
 				case readers.LowerThanEqualKey:
-					bsonFilter = bson.M{"$lte": value}
+					op = "$lte"
 				case readers.GreaterThanKey:
-					bsonFilter = bson.M{"$gt": value}
+					op = "$gt"
 				case readers.GreaterThanEqualKey:
-					bsonFilter = bson.M{"$gte": value}
+					op = "$gte"
 				}
 			}
-			filter = append(filter, bson.E{Key: "value", Value: bsonFilter})
+			valueFilter[op] = value
+		case "v_gt":
+			valueFilter["$gt"] = value
+		case "v_ge":
+			valueFilter["$gte"] = value
+		case "v_lt":
+			valueFilter["$lt"] = value
+		case "v_le":
+			valueFilter["$lte"] = value
 		case "vb":
 			filter = append(filter, bson.E{Key: "bool_value", Value: value})
 		case "vs":
+			if rpm.StringValueMatch != "" {
+				re := readers.StringValueRegex(rpm.StringValue, rpm.StringValueMatch)
+				filter = append(filter, bson.E{Key: "string_value", Value: bson.M{"$regex": re}})
+				continue
+			}
 			filter = append(filter, bson.E{Key: "string_value", Value: value})
 		case "vd":
 			filter = append(filter, bson.E{Key: "data_value", Value: value})
@@ -147,6 +339,11 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 			filter = append(filter, bson.E{Key: "time", Value: bson.M{"$lt": value}})
 		}
 	}
+	// Value operators are merged, since the filter can't contain
+	// the same key more than once.
+	if len(valueFilter) > 0 {
+		filter = append(filter, bson.E{Key: "value", Value: valueFilter})
+	}
 
 	return filter
 }
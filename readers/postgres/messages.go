@@ -6,8 +6,10 @@ package postgres
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx" // required for DB access
+	"github.com/lib/pq"
 	"github.com/mainflux/mainflux/pkg/errors"
 	jsont "github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
@@ -24,6 +26,14 @@ const (
 
 var errReadMessages = errors.New("failed to read messages from postgres database")
 
+var aggregations = map[string]string{
+	readers.MinKey:   "MIN",
+	readers.MaxKey:   "MAX",
+	readers.AvgKey:   "AVG",
+	readers.SumKey:   "SUM",
+	readers.CountKey: "COUNT",
+}
+
 var _ readers.MessageRepository = (*postgresRepository)(nil)
 
 type postgresRepository struct {
@@ -41,14 +51,21 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 	order := "time"
 	format := defTable
 
-	if rpm.Format != "" {
+	if rpm.Format != "" && rpm.Format != defTable {
 		order = "created"
 		format = rpm.Format
 	}
 
+	condition := fmtCondition(chanID, rpm)
+	if rpm.Cursor != "" {
+		// Keyset pagination continues after the last message of the
+		// previous page, using message ID to break the time ties.
+		condition = fmt.Sprintf(`%s AND (%s, id) < (:cursor_order, :cursor_id)`, condition, order)
+	}
+
 	q := fmt.Sprintf(`SELECT * FROM %s
-    WHERE %s ORDER BY %s DESC
-	LIMIT :limit OFFSET :offset;`, format, fmtCondition(chanID, rpm), order)
+    WHERE %s ORDER BY %s DESC, id DESC
+	LIMIT :limit OFFSET :offset;`, format, condition, order)
 
 	params := map[string]interface{}{
 		"channel":      chanID,
@@ -64,6 +81,30 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 		"data_value":   rpm.DataValue,
 		"from":         rpm.From,
 		"to":           rpm.To,
+		"publishers":   pq.Array(rpm.Publishers),
+		"names":        pq.Array(rpm.Names),
+		"v_gt":         rpm.ValueGt,
+		"v_ge":         rpm.ValueGe,
+		"v_lt":         rpm.ValueLt,
+		"v_le":         rpm.ValueLe,
+		"subtopic_re":  readers.SubtopicRegex(rpm.Subtopic),
+		"vs_re":        readers.StringValueRegex(rpm.StringValue, rpm.StringValueMatch),
+	}
+
+	if rpm.Aggregation != "" {
+		return tr.aggregate(chanID, rpm, params)
+	}
+
+	if rpm.Cursor != "" {
+		var c cursor
+		if err := readers.DecodeCursor(rpm.Cursor, &c); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		params["cursor_id"] = c.ID
+		params["cursor_order"] = c.Time
+		if format != defTable {
+			params["cursor_order"] = c.Created
+		}
 	}
 
 	rows, err := tr.db.NamedQuery(q, params)
@@ -76,6 +117,7 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 		PageMetadata: rpm,
 		Messages:     []readers.Message{},
 	}
+	var last cursor
 	switch format {
 	case defTable:
 		for rows.Next() {
@@ -85,6 +127,7 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 			}
 
 			page.Messages = append(page.Messages, msg.Message)
+			last = cursor{ID: msg.ID, Time: msg.Time}
 		}
 	default:
 		for rows.Next() {
@@ -98,8 +141,21 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 			}
 			m["payload"] = jsont.ParseFlat(m["payload"])
 			page.Messages = append(page.Messages, m)
+			last = cursor{ID: msg.ID, Created: msg.Created}
+		}
+
+	}
+
+	if uint64(len(page.Messages)) == rpm.Limit {
+		if page.Next, err = readers.EncodeCursor(last); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 		}
+	}
 
+	// Counting is skipped when reading by cursor, since it's expensive
+	// for large tables.
+	if rpm.Cursor != "" {
+		return page, nil
 	}
 
 	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, fmtCondition(chanID, rpm))
@@ -120,6 +176,58 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 	return page, nil
 }
 
+func (tr postgresRepository) aggregate(chanID string, rpm readers.PageMetadata, params map[string]interface{}) (readers.MessagesPage, error) {
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	params["interval"] = interval.Seconds()
+
+	// Buckets are calculated in the subquery, since every occurrence of the
+	// named parameter is bound separately and the grouping expression would
+	// not match the selected one.
+	buckets := fmt.Sprintf(`SELECT name, publisher, value, FLOOR(time / :interval) * :interval AS bucket
+	FROM %s WHERE %s AND value IS NOT NULL`, defTable, fmtCondition(chanID, rpm))
+
+	q := fmt.Sprintf(`SELECT name, publisher, bucket, %s(value) FROM (%s) AS buckets
+	GROUP BY name, publisher, bucket ORDER BY bucket DESC, name, publisher
+	LIMIT :limit OFFSET :offset;`, aggregations[rpm.Aggregation], buckets)
+
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for rows.Next() {
+		var value float64
+		msg := senml.Message{Channel: chanID, Value: &value}
+		if err := rows.Scan(&msg.Name, &msg.Publisher, &msg.Time, &value); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		page.Messages = append(page.Messages, msg)
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT DISTINCT name, publisher, bucket FROM (%s) AS buckets) AS groups;`, buckets)
+	rows, err = tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&page.Total); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+	}
+
+	return page, nil
+}
+
 func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	condition := `channel = :channel`
 
@@ -132,18 +240,39 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 
 	for name := range query {
 		switch name {
+		case "subtopic":
+			if readers.HasWildcard(rpm.Subtopic) {
+				condition = fmt.Sprintf(`%s AND subtopic ~ :subtopic_re`, condition)
+				continue
+			}
+			condition = fmt.Sprintf(`%s AND subtopic = :subtopic`, condition)
 		case
-			"subtopic",
 			"publisher",
 			"name",
 			"protocol":
 			condition = fmt.Sprintf(`%s AND %s = :%s`, condition, name, name)
+		case "publishers":
+			condition = fmt.Sprintf(`%s AND CAST(publisher AS TEXT) = ANY(:publishers)`, condition)
+		case "names":
+			condition = fmt.Sprintf(`%s AND name = ANY(:names)`, condition)
 		case "v":
 			comparator := readers.ParseValueComparator(query)
 			condition = fmt.Sprintf(`%s AND value %s :value`, condition, comparator)
+		case "v_gt":
+			condition = fmt.Sprintf(`%s AND value > :v_gt`, condition)
+		case "v_ge":
+			condition = fmt.Sprintf(`%s AND value >= :v_ge`, condition)
+		case "v_lt":
+			condition = fmt.Sprintf(`%s AND value < :v_lt`, condition)
+		case "v_le":
+			condition = fmt.Sprintf(`%s AND value <= :v_le`, condition)
 		case "vb":
 			condition = fmt.Sprintf(`%s AND bool_value = :bool_value`, condition)
 		case "vs":
+			if rpm.StringValueMatch != "" {
+				condition = fmt.Sprintf(`%s AND string_value ~ :vs_re`, condition)
+				continue
+			}
 			condition = fmt.Sprintf(`%s AND string_value = :string_value`, condition)
 		case "vd":
 			condition = fmt.Sprintf(`%s AND data_value = :data_value`, condition)
@@ -156,6 +285,13 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	return condition
 }
 
+// cursor represents the position of the last message of the page.
+type cursor struct {
+	ID      string  `json:"id"`
+	Time    float64 `json:"time,omitempty"`
+	Created int64   `json:"created,omitempty"`
+}
+
 type dbMessage struct {
 	ID string `db:"id"`
 	senml.Message
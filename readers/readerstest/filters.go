@@ -0,0 +1,214 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package readerstest contains the tests shared by the message repository
+// implementations.
+package readerstest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	msgsNum  = 30
+	mqttProt = "mqtt"
+)
+
+var (
+	subtopics = []string{"a.b.c", "a.b", "a.x.c", "d.b.c"}
+	strValues = []string{"temperature-high", "low-temperature", "humidity"}
+)
+
+// TestFilters saves messages using the writer and checks that the reader
+// applies compound filters the same way regardless of the database.
+func TestFilters(t *testing.T, writer consumers.Consumer, reader readers.MessageRepository) {
+	idProvider := uuid.New()
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	var pubs []string
+	for i := 0; i < 3; i++ {
+		pubID, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		pubs = append(pubs, pubID)
+	}
+
+	now := float64(time.Now().Unix())
+	messages := []senml.Message{}
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubs[i%len(pubs)],
+			Protocol:  mqttProt,
+			Subtopic:  subtopics[i%len(subtopics)],
+			Name:      fmt.Sprintf("name-%d", i%4),
+			Time:      now - float64(i),
+		}
+		switch i % 2 {
+		case 0:
+			v := float64(i)
+			msg.Value = &v
+		default:
+			vs := strValues[i%len(strValues)]
+			msg.StringValue = &vs
+		}
+		messages = append(messages, msg)
+	}
+
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	value := func(m senml.Message, cond func(v float64) bool) bool {
+		return m.Value != nil && cond(*m.Value)
+	}
+	float := func(v float64) *float64 {
+		return &v
+	}
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		match    func(m senml.Message) bool
+	}{
+		"read messages of the list of publishers": {
+			pageMeta: readers.PageMetadata{
+				Publishers: pubs[:2],
+			},
+			match: func(m senml.Message) bool {
+				return m.Publisher == pubs[0] || m.Publisher == pubs[1]
+			},
+		},
+		"read messages of the list of names": {
+			pageMeta: readers.PageMetadata{
+				Names: []string{"name-0", "name-3"},
+			},
+			match: func(m senml.Message) bool {
+				return m.Name == "name-0" || m.Name == "name-3"
+			},
+		},
+		"read messages with value in open range": {
+			pageMeta: readers.PageMetadata{
+				ValueGt: float(4),
+				ValueLt: float(16),
+			},
+			match: func(m senml.Message) bool {
+				return value(m, func(v float64) bool { return v > 4 && v < 16 })
+			},
+		},
+		"read messages with value in closed range": {
+			pageMeta: readers.PageMetadata{
+				ValueGe: float(4),
+				ValueLe: float(16),
+			},
+			match: func(m senml.Message) bool {
+				return value(m, func(v float64) bool { return v >= 4 && v <= 16 })
+			},
+		},
+		"read messages with value greater than zero": {
+			pageMeta: readers.PageMetadata{
+				ValueGt: float(0),
+			},
+			match: func(m senml.Message) bool {
+				return value(m, func(v float64) bool { return v > 0 })
+			},
+		},
+		"read messages with value comparator and bound": {
+			pageMeta: readers.PageMetadata{
+				Value:      10,
+				Comparator: readers.GreaterThanEqualKey,
+				ValueLt:    float(20),
+			},
+			match: func(m senml.Message) bool {
+				return value(m, func(v float64) bool { return v >= 10 && v < 20 })
+			},
+		},
+		"read messages with string value prefix": {
+			pageMeta: readers.PageMetadata{
+				StringValue:      "temp",
+				StringValueMatch: readers.PrefixKey,
+			},
+			match: func(m senml.Message) bool {
+				return m.StringValue != nil && strings.HasPrefix(*m.StringValue, "temp")
+			},
+		},
+		"read messages with string value substring": {
+			pageMeta: readers.PageMetadata{
+				StringValue:      "temp",
+				StringValueMatch: readers.ContainsKey,
+			},
+			match: func(m senml.Message) bool {
+				return m.StringValue != nil && strings.Contains(*m.StringValue, "temp")
+			},
+		},
+		"read messages with single token subtopic wildcard": {
+			pageMeta: readers.PageMetadata{
+				Subtopic: "a.*.c",
+			},
+			match: func(m senml.Message) bool {
+				return m.Subtopic == "a.b.c" || m.Subtopic == "a.x.c"
+			},
+		},
+		"read messages with multiple tokens subtopic wildcard": {
+			pageMeta: readers.PageMetadata{
+				Subtopic: "a.>",
+			},
+			match: func(m senml.Message) bool {
+				return strings.HasPrefix(m.Subtopic, "a.")
+			},
+		},
+		"read messages with both subtopic wildcards": {
+			pageMeta: readers.PageMetadata{
+				Subtopic: "*.b.>",
+			},
+			match: func(m senml.Message) bool {
+				return m.Subtopic == "a.b.c" || m.Subtopic == "d.b.c"
+			},
+		},
+		"read messages with combined filters": {
+			pageMeta: readers.PageMetadata{
+				Publishers: pubs[1:],
+				Subtopic:   "a.>",
+				ValueGe:    float(6),
+			},
+			match: func(m senml.Message) bool {
+				return (m.Publisher == pubs[1] || m.Publisher == pubs[2]) &&
+					strings.HasPrefix(m.Subtopic, "a.") &&
+					value(m, func(v float64) bool { return v >= 6 })
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		var expected []readers.Message
+		for _, m := range messages {
+			if tc.match(m) {
+				expected = append(expected, m)
+			}
+		}
+
+		tc.pageMeta.Limit = msgsNum
+		page, err := reader.ReadAll(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, expected, page.Messages, fmt.Sprintf("%s: expected %v got %v", desc, expected, page.Messages))
+		assert.Equal(t, uint64(len(expected)), page.Total, fmt.Sprintf("%s: expected total %d got %d", desc, len(expected), page.Total))
+
+		// Filtered messages are paginated as well.
+		if len(expected) < 3 {
+			continue
+		}
+		tc.pageMeta.Offset, tc.pageMeta.Limit = 1, 2
+		page, err = reader.ReadAll(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, expected[1:3], page.Messages, fmt.Sprintf("%s: expected %v got %v", desc, expected[1:3], page.Messages))
+		assert.Equal(t, uint64(len(expected)), page.Total, fmt.Sprintf("%s: expected total %d got %d", desc, len(expected), page.Total))
+	}
+}
@@ -0,0 +1,414 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx" // required for DB access
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	jsont "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+)
+
+const (
+	// Table for SenML messages
+	defTable = "messages"
+	// Continuous aggregate of SenML messages values
+	hourlyView = "messages_hourly"
+
+	senmlColumns = `id, channel, subtopic, publisher, protocol, name, unit, value,
+	string_value, bool_value, data_value, sum, EXTRACT(EPOCH FROM time) AS time, update_time`
+)
+
+var errReadMessages = errors.New("failed to read messages from timescale database")
+
+var aggregations = map[string]string{
+	readers.MinKey:   "MIN(value)",
+	readers.MaxKey:   "MAX(value)",
+	readers.AvgKey:   "AVG(value)",
+	readers.SumKey:   "SUM(value)",
+	readers.CountKey: "COUNT(value)",
+}
+
+// rollups combine hourly aggregates into the aggregates of longer intervals.
+var rollups = map[string]string{
+	readers.MinKey:   "MIN(min_value)",
+	readers.MaxKey:   "MAX(max_value)",
+	readers.AvgKey:   "SUM(sum_value) / SUM(count_value)",
+	readers.SumKey:   "SUM(sum_value)",
+	readers.CountKey: "SUM(count_value)",
+}
+
+var _ readers.MessageRepository = (*timescaleRepository)(nil)
+
+type timescaleRepository struct {
+	db *sqlx.DB
+}
+
+// New returns new TimescaleDB reader.
+func New(db *sqlx.DB) readers.MessageRepository {
+	return &timescaleRepository{
+		db: db,
+	}
+}
+
+func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	order := "time"
+	cursorOrder := "to_timestamp(:cursor_order)"
+	columns := senmlColumns
+	format := defTable
+
+	if rpm.Format != "" && rpm.Format != defTable {
+		order = "created"
+		cursorOrder = ":cursor_order"
+		columns = "*"
+		format = rpm.Format
+	}
+
+	condition := fmtCondition(chanID, rpm, format)
+	if rpm.Cursor != "" {
+		// Keyset pagination continues after the last message of the
+		// previous page, using message ID to break the time ties.
+		condition = fmt.Sprintf(`%s AND (%s, id) < (%s, :cursor_id)`, condition, order, cursorOrder)
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM %s
+    WHERE %s ORDER BY %s DESC, id DESC
+	LIMIT :limit OFFSET :offset;`, columns, format, condition, order)
+
+	params := map[string]interface{}{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+		"publishers":   pq.Array(rpm.Publishers),
+		"names":        pq.Array(rpm.Names),
+		"v_gt":         rpm.ValueGt,
+		"v_ge":         rpm.ValueGe,
+		"v_lt":         rpm.ValueLt,
+		"v_le":         rpm.ValueLe,
+		"subtopic_re":  readers.SubtopicRegex(rpm.Subtopic),
+		"vs_re":        readers.StringValueRegex(rpm.StringValue, rpm.StringValueMatch),
+	}
+
+	if rpm.Aggregation != "" {
+		return tr.aggregate(chanID, rpm, params)
+	}
+
+	if rpm.Cursor != "" {
+		var c cursor
+		if err := readers.DecodeCursor(rpm.Cursor, &c); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		params["cursor_id"] = c.ID
+		params["cursor_order"] = c.Time
+		if format != defTable {
+			params["cursor_order"] = c.Created
+		}
+	}
+
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	var last cursor
+	switch format {
+	case defTable:
+		for rows.Next() {
+			msg := dbMessage{Message: senml.Message{}}
+			if err := rows.StructScan(&msg); err != nil {
+				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+			}
+
+			page.Messages = append(page.Messages, msg.Message)
+			last = cursor{ID: msg.ID, Time: msg.Time}
+		}
+	default:
+		for rows.Next() {
+			msg := jsonMessage{}
+			if err := rows.StructScan(&msg); err != nil {
+				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+			}
+			m, err := msg.toMap()
+			if err != nil {
+				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+			}
+			m["payload"] = jsont.ParseFlat(m["payload"])
+			page.Messages = append(page.Messages, m)
+			last = cursor{ID: msg.ID, Created: msg.Created}
+		}
+	}
+
+	if uint64(len(page.Messages)) == rpm.Limit {
+		if page.Next, err = readers.EncodeCursor(last); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+	}
+
+	// Counting is skipped when reading by cursor, since it's expensive
+	// for large tables.
+	if rpm.Cursor != "" {
+		return page, nil
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, fmtCondition(chanID, rpm, format))
+	rows, err = tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return page, err
+		}
+	}
+	page.Total = total
+
+	return page, nil
+}
+
+// aggregate groups values in buckets aligned to the Unix epoch. Hourly
+// continuous aggregate is used instead of the raw messages whenever the
+// request can be answered by combining its buckets.
+func (tr timescaleRepository) aggregate(chanID string, rpm readers.PageMetadata, params map[string]interface{}) (readers.MessagesPage, error) {
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	params["interval"] = interval.Seconds()
+
+	// Buckets are calculated in the subquery, since every occurrence of the
+	// named parameter is bound separately and the grouping expression would
+	// not match the selected one.
+	buckets := fmt.Sprintf(`SELECT name, publisher, value,
+	time_bucket(make_interval(secs => :interval), time, to_timestamp(0)) AS bucket
+	FROM %s WHERE %s AND value IS NOT NULL`, defTable, fmtCondition(chanID, rpm, defTable))
+	value := aggregations[rpm.Aggregation]
+	if hourly(rpm, interval) {
+		buckets = fmt.Sprintf(`SELECT name, publisher, min_value, max_value, sum_value, count_value,
+		time_bucket(make_interval(secs => :interval), bucket, to_timestamp(0)) AS bucket
+		FROM %s WHERE %s`, hourlyView, fmtCondition(chanID, rpm, hourlyView))
+		value = rollups[rpm.Aggregation]
+	}
+
+	q := fmt.Sprintf(`SELECT name, publisher, EXTRACT(EPOCH FROM bucket), %s FROM (%s) AS buckets
+	GROUP BY name, publisher, bucket ORDER BY bucket DESC, name, publisher
+	LIMIT :limit OFFSET :offset;`, value, buckets)
+
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for rows.Next() {
+		var value float64
+		msg := senml.Message{Channel: chanID, Value: &value}
+		if err := rows.Scan(&msg.Name, &msg.Publisher, &msg.Time, &value); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		page.Messages = append(page.Messages, msg)
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT DISTINCT name, publisher, bucket FROM (%s) AS buckets) AS groups;`, buckets)
+	rows, err = tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&page.Total); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+	}
+
+	return page, nil
+}
+
+// hourly reports whether the aggregation can be calculated from the hourly
+// continuous aggregate. That's the case if the interval consists of whole
+// hours, the time range boundaries are full hours and the messages are
+// filtered only by the columns the continuous aggregate is grouped by.
+func hourly(rpm readers.PageMetadata, interval time.Duration) bool {
+	hour := time.Hour.Seconds()
+	if interval%time.Hour != 0 || math.Mod(rpm.From, hour) != 0 || math.Mod(rpm.To, hour) != 0 {
+		return false
+	}
+
+	var query map[string]interface{}
+	meta, err := json.Marshal(rpm)
+	if err != nil {
+		return false
+	}
+	json.Unmarshal(meta, &query)
+
+	for name := range query {
+		switch name {
+		case
+			"offset",
+			"limit",
+			"format",
+			"aggregation",
+			"interval",
+			"publisher",
+			"publishers",
+			"name",
+			"names",
+			"from",
+			"to":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// fmtCondition returns the condition of the query of the given table. Time
+// range is compared to the time of SenML messages, the bucket start time
+// of hourly aggregates and the creation time of JSON messages.
+func fmtCondition(chanID string, rpm readers.PageMetadata, table string) string {
+	condition := `channel = :channel`
+
+	var query map[string]interface{}
+	meta, err := json.Marshal(rpm)
+	if err != nil {
+		return condition
+	}
+	json.Unmarshal(meta, &query)
+
+	for name := range query {
+		switch name {
+		case "subtopic":
+			if readers.HasWildcard(rpm.Subtopic) {
+				condition = fmt.Sprintf(`%s AND subtopic ~ :subtopic_re`, condition)
+				continue
+			}
+			condition = fmt.Sprintf(`%s AND subtopic = :subtopic`, condition)
+		case
+			"publisher",
+			"name",
+			"protocol":
+			condition = fmt.Sprintf(`%s AND %s = :%s`, condition, name, name)
+		case "publishers":
+			condition = fmt.Sprintf(`%s AND CAST(publisher AS TEXT) = ANY(:publishers)`, condition)
+		case "names":
+			condition = fmt.Sprintf(`%s AND name = ANY(:names)`, condition)
+		case "v":
+			comparator := readers.ParseValueComparator(query)
+			condition = fmt.Sprintf(`%s AND value %s :value`, condition, comparator)
+		case "v_gt":
+			condition = fmt.Sprintf(`%s AND value > :v_gt`, condition)
+		case "v_ge":
+			condition = fmt.Sprintf(`%s AND value >= :v_ge`, condition)
+		case "v_lt":
+			condition = fmt.Sprintf(`%s AND value < :v_lt`, condition)
+		case "v_le":
+			condition = fmt.Sprintf(`%s AND value <= :v_le`, condition)
+		case "vb":
+			condition = fmt.Sprintf(`%s AND bool_value = :bool_value`, condition)
+		case "vs":
+			if rpm.StringValueMatch != "" {
+				condition = fmt.Sprintf(`%s AND string_value ~ :vs_re`, condition)
+				continue
+			}
+			condition = fmt.Sprintf(`%s AND string_value = :string_value`, condition)
+		case "vd":
+			condition = fmt.Sprintf(`%s AND data_value = :data_value`, condition)
+		case "from":
+			condition = fmt.Sprintf(`%s AND %s >= %s`, condition, timeColumn(table), timeParam(table, "from"))
+		case "to":
+			condition = fmt.Sprintf(`%s AND %s < %s`, condition, timeColumn(table), timeParam(table, "to"))
+		}
+	}
+	return condition
+}
+
+func timeColumn(table string) string {
+	switch table {
+	case defTable:
+		return "time"
+	case hourlyView:
+		return "bucket"
+	default:
+		return "created"
+	}
+}
+
+// timeParam converts the time in seconds to the type of the time column.
+func timeParam(table, param string) string {
+	switch table {
+	case defTable, hourlyView:
+		return fmt.Sprintf("to_timestamp(:%s)", param)
+	default:
+		return fmt.Sprintf("CAST(:%s * 1000000000 AS BIGINT)", param)
+	}
+}
+
+// cursor represents the position of the last message of the page.
+type cursor struct {
+	ID      string  `json:"id"`
+	Time    float64 `json:"time,omitempty"`
+	Created int64   `json:"created,omitempty"`
+}
+
+type dbMessage struct {
+	ID string `db:"id"`
+	senml.Message
+}
+
+type jsonMessage struct {
+	ID        string `db:"id"`
+	Channel   string `db:"channel"`
+	Created   int64  `db:"created"`
+	Subtopic  string `db:"subtopic"`
+	Publisher string `db:"publisher"`
+	Protocol  string `db:"protocol"`
+	Payload   []byte `db:"payload"`
+}
+
+func (msg jsonMessage) toMap() (map[string]interface{}, error) {
+	ret := map[string]interface{}{
+		"id":        msg.ID,
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]interface{}{},
+	}
+	pld := make(map[string]interface{})
+	if err := json.Unmarshal(msg.Payload, &pld); err != nil {
+		return nil, err
+	}
+	ret["payload"] = pld
+	return ret, nil
+}
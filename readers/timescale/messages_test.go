@@ -0,0 +1,618 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	twriter "github.com/mainflux/mainflux/consumers/writers/timescale"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/readers"
+	"github.com/mainflux/mainflux/readers/readerstest"
+	treader "github.com/mainflux/mainflux/readers/timescale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subtopic    = "subtopic"
+	msgsNum     = 100
+	limit       = 10
+	valueFields = 5
+	mqttProt    = "mqtt"
+	httpProt    = "http"
+	msgName     = "temperature"
+)
+
+var (
+	v   float64 = 5
+	vs          = "value"
+	vb          = true
+	vd          = "dataValue"
+	sum float64 = 42
+
+	idProvider = uuid.New()
+)
+
+func TestReadSenml(t *testing.T) {
+	writer := twriter.New(db, 0)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	wrongID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	m := senml.Message{
+		Channel:   chanID,
+		Publisher: pubID,
+		Protocol:  mqttProt,
+	}
+
+	messages := []senml.Message{}
+	valueMsgs := []senml.Message{}
+	boolMsgs := []senml.Message{}
+	stringMsgs := []senml.Message{}
+	dataMsgs := []senml.Message{}
+	queryMsgs := []senml.Message{}
+
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		msg := m
+		msg.Time = now - float64(i)
+
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Value = &v
+			valueMsgs = append(valueMsgs, msg)
+		case 1:
+			msg.BoolValue = &vb
+			boolMsgs = append(boolMsgs, msg)
+		case 2:
+			msg.StringValue = &vs
+			stringMsgs = append(stringMsgs, msg)
+		case 3:
+			msg.DataValue = &vd
+			dataMsgs = append(dataMsgs, msg)
+		case 4:
+			msg.Sum = &sum
+			msg.Subtopic = subtopic
+			msg.Protocol = httpProt
+			msg.Publisher = pubID2
+			msg.Name = msgName
+			queryMsgs = append(queryMsgs, msg)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	err = writer.Consume(messages)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	// Since messages are not saved in natural order,
+	// cases that return subset of messages are only
+	// checking data result set size, but not content.
+	cases := map[string]struct {
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read message page for existing channel": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages),
+			},
+		},
+		"read message page for non-existent channel": {
+			chanID: wrongID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		"read message last page": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: msgsNum - 20,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages[msgsNum-20 : msgsNum]),
+			},
+		},
+		"read message with non-existent subtopic": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   0,
+				Limit:    msgsNum,
+				Subtopic: "not-present",
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		"read message with subtopic": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   0,
+				Limit:    uint64(len(queryMsgs)),
+				Subtopic: subtopic,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read message with publisher": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     uint64(len(queryMsgs)),
+				Publisher: pubID2,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read message with protocol": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   0,
+				Limit:    uint64(len(queryMsgs)),
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read message with name": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  limit,
+				Name:   msgName,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs[0:limit]),
+			},
+		},
+		"read message with value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  limit,
+				Value:  v,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and equal comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v,
+				Comparator: readers.EqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and lower-than comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and lower-than-or-equal comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and greater-than comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v - 1,
+				Comparator: readers.GreaterThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and greater-than-or-equal comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v - 1,
+				Comparator: readers.GreaterThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with boolean value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     limit,
+				BoolValue: vb,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(boolMsgs)),
+				Messages: fromSenml(boolMsgs[0:limit]),
+			},
+		},
+		"read message with string value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:      0,
+				Limit:       limit,
+				StringValue: vs,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(stringMsgs)),
+				Messages: fromSenml(stringMsgs[0:limit]),
+			},
+		},
+		"read message with data value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     limit,
+				DataValue: vd,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(dataMsgs)),
+				Messages: fromSenml(dataMsgs[0:limit]),
+			},
+		},
+		"read message with from": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  uint64(len(messages[0:21])),
+				From:   messages[20].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(messages[0:21])),
+				Messages: fromSenml(messages[0:21]),
+			},
+		},
+		"read message with to": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  uint64(len(messages[21:])),
+				To:     messages[20].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(messages[21:])),
+				Messages: fromSenml(messages[21:]),
+			},
+		},
+		"read message with from/to": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  limit,
+				From:   messages[5].Time,
+				To:     messages[0].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    5,
+				Messages: fromSenml(messages[1:6]),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestReadAggregated(t *testing.T) {
+	writer := twriter.New(db, 0)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	interval := 10 * time.Minute
+	secs := interval.Seconds()
+	start := math.Floor(float64(time.Now().Add(-time.Hour).Unix())/secs) * secs
+
+	// Every bucket contains values from 1 to 4 and a message without
+	// numeric value which is ignored by the aggregation.
+	buckets := 3
+	messages := []senml.Message{}
+	for b := 0; b < buckets; b++ {
+		bucket := start + float64(b)*secs
+		for i := 1; i <= 4; i++ {
+			value := float64(i)
+			messages = append(messages, senml.Message{
+				Channel:   chanID,
+				Publisher: pubID,
+				Protocol:  mqttProt,
+				Name:      msgName,
+				Time:      bucket + float64(i*60),
+				Value:     &value,
+			})
+		}
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      bucket,
+			BoolValue: &vb,
+		})
+	}
+
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	aggregated := func(value float64, buckets ...int) []readers.Message {
+		var ret []readers.Message
+		for _, b := range buckets {
+			val := value
+			ret = append(ret, senml.Message{
+				Channel:   chanID,
+				Publisher: pubID,
+				Name:      msgName,
+				Time:      start + float64(b)*secs,
+				Value:     &val,
+			})
+		}
+		return ret
+	}
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read minimum values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				Aggregation: readers.MinKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(1, 2, 1, 0),
+			},
+		},
+		"read maximum values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				Aggregation: readers.MaxKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(4, 2, 1, 0),
+			},
+		},
+		"read average values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				Aggregation: readers.AvgKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(2.5, 2, 1, 0),
+			},
+		},
+		"read sum of values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				Aggregation: readers.SumKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(10, 2, 1, 0),
+			},
+		},
+		"read count of values": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				Aggregation: readers.CountKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(4, 2, 1, 0),
+			},
+		},
+		"read aggregated values with offset and limit": {
+			pageMeta: readers.PageMetadata{
+				Offset:      1,
+				Limit:       1,
+				Aggregation: readers.AvgKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(buckets),
+				Messages: aggregated(2.5, 1),
+			},
+		},
+		"read aggregated values with from/to": {
+			pageMeta: readers.PageMetadata{
+				Limit:       limit,
+				From:        start + secs,
+				To:          start + 2*secs,
+				Aggregation: readers.SumKey,
+				Interval:    interval.String(),
+			},
+			page: readers.MessagesPage{
+				Total:    1,
+				Messages: aggregated(10, 1),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestReadByCursor(t *testing.T) {
+	writer := twriter.New(db, 0)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Pairs of messages share the time, so the cursor has to break the ties.
+	n := 25
+	now := float64(time.Now().Unix())
+	messages := []senml.Message{}
+	for i := 0; i < n; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      fmt.Sprintf("%s-%d", msgName, i%2),
+			Time:      now - float64(i/2),
+			Value:     &v,
+		})
+	}
+
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	page, err := reader.ReadAll(chanID, readers.PageMetadata{Limit: limit})
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, uint64(n), page.Total, fmt.Sprintf("expected total %d got %d", n, page.Total))
+
+	read := page.Messages
+	for page.Next != "" {
+		page, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: limit, Cursor: page.Next})
+		require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+		read = append(read, page.Messages...)
+	}
+	assert.ElementsMatch(t, fromSenml(messages), read, fmt.Sprintf("expected %v got %v", messages, read))
+
+	_, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: limit, Cursor: "invalid"})
+	assert.True(t, errors.Contains(err, readers.ErrInvalidCursor), fmt.Sprintf("expected %s got %s", readers.ErrInvalidCursor, err))
+}
+
+func fromSenml(in []senml.Message) []readers.Message {
+	var ret []readers.Message
+	for _, m := range in {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func TestReadFiltered(t *testing.T) {
+	readerstest.TestFilters(t, twriter.New(db, 0), treader.New(db))
+}
+
+func TestReadRollup(t *testing.T) {
+	writer := twriter.New(db, 0)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Two hours intervals are combined from the hourly continuous aggregate.
+	interval := 2 * time.Hour
+	secs := interval.Seconds()
+	start := math.Floor(float64(time.Now().Add(-6*time.Hour).Unix())/secs) * secs
+
+	messages := []senml.Message{}
+	for i := 0; i < 6*6; i++ {
+		value := float64(i % 7)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      start + float64(i*600),
+			Value:     &value,
+		})
+	}
+
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	for _, agg := range []string{readers.MinKey, readers.MaxKey, readers.AvgKey, readers.SumKey, readers.CountKey} {
+		pm := readers.PageMetadata{
+			Limit:       limit,
+			From:        start,
+			Aggregation: agg,
+			Interval:    interval.String(),
+		}
+		expected := readers.AggregatesPage(pm, readers.Aggregate(messages, agg, secs))
+
+		result, err := reader.ReadAll(chanID, pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", agg, err))
+		assert.ElementsMatch(t, expected.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", agg, expected.Messages, result.Messages))
+		assert.Equal(t, expected.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", agg, expected.Total, result.Total))
+	}
+}
@@ -24,6 +24,7 @@ type grpcClient struct {
 	canAccessByID  endpoint.Endpoint
 	isChannelOwner endpoint.Endpoint
 	identify       endpoint.Endpoint
+	derivePSK      endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -64,6 +65,14 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeIdentityResponse,
 			mainflux.ThingID{},
 		).Endpoint()),
+		derivePSK: kitot.TraceClient(tracer, "derive_psk")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"DerivePSK",
+			encodeDerivePSKRequest,
+			decodePSKResponse,
+			mainflux.Token{},
+		).Endpoint()),
 	}
 }
 
@@ -119,6 +128,19 @@ func (client grpcClient) Identify(ctx context.Context, req *mainflux.Token, _ ..
 	return &mainflux.ThingID{Value: ir.id}, nil
 }
 
+func (client grpcClient) DerivePSK(ctx context.Context, req *mainflux.ThingID, _ ...grpc.CallOption) (*mainflux.Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.derivePSK(ctx, derivePSKReq{id: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	pr := res.(pskRes)
+	return &mainflux.Token{Value: pr.psk}, nil
+}
+
 func encodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(AccessByKeyReq)
 	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID}, nil
@@ -139,11 +161,21 @@ func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 	return &mainflux.Token{Value: req.key}, nil
 }
 
+func encodeDerivePSKRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(derivePSKReq)
+	return &mainflux.ThingID{Value: req.id}, nil
+}
+
 func decodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*mainflux.ThingID)
 	return identityRes{id: res.GetValue()}, nil
 }
 
+func decodePSKResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Token)
+	return pskRes{psk: res.GetValue()}, nil
+}
+
 func decodeEmptyResponse(_ context.Context, _ interface{}) (interface{}, error) {
 	return emptyRes{}, nil
 }
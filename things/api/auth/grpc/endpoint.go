@@ -62,3 +62,18 @@ func identifyEndpoint(svc things.Service) endpoint.Endpoint {
 		return identityRes{id: id}, nil
 	}
 }
+
+func derivePSKEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(derivePSKReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		psk, err := svc.DerivePSK(ctx, req.id)
+		if err != nil {
+			return pskRes{}, err
+		}
+		return pskRes{psk: psk}, nil
+	}
+}
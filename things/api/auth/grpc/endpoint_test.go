@@ -5,6 +5,9 @@ package grpc_test
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"testing"
 	"time"
@@ -185,3 +188,51 @@ func TestIdentify(t *testing.T) {
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
 	}
 }
+
+func TestDerivePSK(t *testing.T) {
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	sth := ths[0]
+
+	mac := hmac.New(sha256.New, []byte(sth.Key))
+	mac.Write([]byte(sth.ID))
+	psk := hex.EncodeToString(mac.Sum(nil))
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		id   string
+		psk  string
+		code codes.Code
+	}{
+		"derive PSK of existing thing": {
+			id:   sth.ID,
+			psk:  psk,
+			code: codes.OK,
+		},
+		"derive PSK of non-existent thing": {
+			id:   wrong,
+			psk:  "",
+			code: codes.NotFound,
+		},
+		"derive PSK with empty thing ID": {
+			id:   wrongID,
+			psk:  "",
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		res, err := cli.DerivePSK(ctx, &mainflux.ThingID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.psk, res.GetValue(), fmt.Sprintf("%s: expected %s got %s", desc, tc.psk, res.GetValue()))
+		assert.NotEqual(t, sth.Key, res.GetValue(), fmt.Sprintf("%s: expected thing key not to be returned", desc))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
@@ -44,6 +44,18 @@ func (req channelOwnerReq) validate() error {
 	return nil
 }
 
+type derivePSKReq struct {
+	id string
+}
+
+func (req derivePSKReq) validate() error {
+	if req.id == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type identifyReq struct {
 	key string
 }
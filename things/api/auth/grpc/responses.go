@@ -7,6 +7,10 @@ type identityRes struct {
 	id string
 }
 
+type pskRes struct {
+	psk string
+}
+
 type emptyRes struct {
 	err error
 }
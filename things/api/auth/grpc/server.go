@@ -23,6 +23,7 @@ type grpcServer struct {
 	canAccessByID  kitgrpc.Handler
 	isChannelOwner kitgrpc.Handler
 	identify       kitgrpc.Handler
+	derivePSK      kitgrpc.Handler
 }
 
 // NewServer returns new ThingsServiceServer instance.
@@ -48,6 +49,11 @@ func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsSer
 			decodeIdentifyRequest,
 			encodeIdentityResponse,
 		),
+		derivePSK: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "derive_psk")(derivePSKEndpoint(svc)),
+			decodeDerivePSKRequest,
+			encodePSKResponse,
+		),
 	}
 }
 
@@ -87,6 +93,15 @@ func (gs *grpcServer) Identify(ctx context.Context, req *mainflux.Token) (*mainf
 	return res.(*mainflux.ThingID), nil
 }
 
+func (gs *grpcServer) DerivePSK(ctx context.Context, req *mainflux.ThingID) (*mainflux.Token, error) {
+	_, res, err := gs.derivePSK.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.Token), nil
+}
+
 func decodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByKeyReq)
 	return AccessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID()}, nil
@@ -107,11 +122,21 @@ func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 	return identifyReq{key: req.GetValue()}, nil
 }
 
+func decodeDerivePSKRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ThingID)
+	return derivePSKReq{id: req.GetValue()}, nil
+}
+
 func encodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identityRes)
 	return &mainflux.ThingID{Value: res.id}, nil
 }
 
+func encodePSKResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(pskRes)
+	return &mainflux.Token{Value: res.psk}, nil
+}
+
 func encodeEmptyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(emptyRes)
 	return &empty.Empty{}, encodeError(res.err)
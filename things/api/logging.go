@@ -120,6 +120,19 @@ func (lm *loggingMiddleware) RemoveThing(ctx context.Context, token, id string)
 	return lm.svc.RemoveThing(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ViewStatus(ctx context.Context, token, id string) (st things.Status, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_status for token %s and thing %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewStatus(ctx, token, id)
+}
+
 func (lm *loggingMiddleware) CreateChannels(ctx context.Context, token string, channels ...things.Channel) (saved []things.Channel, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method create_channels for token %s and channels %s took %s to complete", token, saved, time.Since(begin))
@@ -279,6 +292,19 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id strin
 	return lm.svc.Identify(ctx, key)
 }
 
+func (lm *loggingMiddleware) DerivePSK(ctx context.Context, id string) (psk string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method derive_psk for thing %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DerivePSK(ctx, id)
+}
+
 func (lm *loggingMiddleware) ListMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (tp things.Page, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method list_members for token %s and group id %s took %s to complete", token, groupID, time.Since(begin))
@@ -291,3 +317,42 @@ func (lm *loggingMiddleware) ListMembers(ctx context.Context, token, groupID str
 
 	return lm.svc.ListMembers(ctx, token, groupID,  pm)
 }
+
+func (lm *loggingMiddleware) Share(ctx context.Context, token string, share things.Share) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method share for %s %s and user %s with %s access took %s to complete", share.EntityType, share.EntityID, share.User, share.Access, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Share(ctx, token, share)
+}
+
+func (lm *loggingMiddleware) Unshare(ctx context.Context, token string, share things.Share) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unshare for %s %s and user %s took %s to complete", share.EntityType, share.EntityID, share.User, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Unshare(ctx, token, share)
+}
+
+func (lm *loggingMiddleware) ListShares(ctx context.Context, token, entityType, id string) (_ []things.Share, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_shares for %s %s took %s to complete", entityType, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListShares(ctx, token, entityType, id)
+}
@@ -93,6 +93,15 @@ func (ms *metricsMiddleware) RemoveThing(ctx context.Context, token, id string)
 	return ms.svc.RemoveThing(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ViewStatus(ctx context.Context, token, id string) (things.Status, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_status").Add(1)
+		ms.latency.With("method", "view_status").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewStatus(ctx, token, id)
+}
+
 func (ms *metricsMiddleware) CreateChannels(ctx context.Context, token string, channels ...things.Channel) (saved []things.Channel, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "create_channels").Add(1)
@@ -201,6 +210,15 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string,
 	return ms.svc.Identify(ctx, key)
 }
 
+func (ms *metricsMiddleware) DerivePSK(ctx context.Context, id string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "derive_psk").Add(1)
+		ms.latency.With("method", "derive_psk").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DerivePSK(ctx, id)
+}
+
 func (ms *metricsMiddleware) ListMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (tp things.Page, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_members").Add(1)
@@ -209,3 +227,30 @@ func (ms *metricsMiddleware) ListMembers(ctx context.Context, token, groupID str
 
 	return ms.svc.ListMembers(ctx, token, groupID,  pm)
 }
+
+func (ms *metricsMiddleware) Share(ctx context.Context, token string, share things.Share) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "share").Add(1)
+		ms.latency.With("method", "share").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Share(ctx, token, share)
+}
+
+func (ms *metricsMiddleware) Unshare(ctx context.Context, token string, share things.Share) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unshare").Add(1)
+		ms.latency.With("method", "unshare").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Unshare(ctx, token, share)
+}
+
+func (ms *metricsMiddleware) ListShares(ctx context.Context, token, entityType, id string) ([]things.Share, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_shares").Add(1)
+		ms.latency.With("method", "list_shares").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListShares(ctx, token, entityType, id)
+}
@@ -145,6 +145,31 @@ func viewThingEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func viewStatusEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		st, err := svc.ViewStatus(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := statusRes{
+			ID:       req.id,
+			Online:   st.Online,
+			Protocol: st.Protocol,
+		}
+		if !st.LastSeen.IsZero() {
+			res.LastSeen = &st.LastSeen
+		}
+		return res, nil
+	}
+}
+
 func listThingsEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listResourcesReq)
@@ -508,6 +533,76 @@ func listMembersEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func shareEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sh := things.Share{
+			EntityID:   req.id,
+			EntityType: req.entityType,
+			User:       req.User,
+			Access:     req.Access,
+		}
+		if err := svc.Share(ctx, req.token, sh); err != nil {
+			return nil, err
+		}
+
+		return shareRes{}, nil
+	}
+}
+
+func unshareEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(unshareReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sh := things.Share{
+			EntityID:   req.id,
+			EntityType: req.entityType,
+			User:       req.user,
+		}
+		if err := svc.Unshare(ctx, req.token, sh); err != nil {
+			return nil, err
+		}
+
+		return unshareRes{}, nil
+	}
+}
+
+func listSharesEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listSharesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		shs, err := svc.ListShares(ctx, req.token, req.entityType, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := sharesRes{
+			Shares: []viewShareRes{},
+		}
+		for _, sh := range shs {
+			res.Shares = append(res.Shares, viewShareRes{
+				User:   sh.User,
+				Access: sh.Access,
+			})
+		}
+
+		return res, nil
+	}
+}
+
 func buildThingsResponse(up things.Page) thingsPageRes {
 	res := thingsPageRes{
 		pageRes: pageRes{
@@ -83,11 +83,12 @@ func newService(tokens map[string]string) things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	statusRepo := mocks.NewStatusRepository()
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, statusRepo, chanCache, thingCache, idProvider, mocks.ClaimKey)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -637,6 +638,71 @@ func TestViewThing(t *testing.T) {
 	}
 }
 
+func TestViewStatus(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	th := ths[0]
+
+	data := fmt.Sprintf(`{"id":"%s","online":false}`, th.ID)
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+		res    string
+	}{
+		{
+			desc:   "view status of existing thing",
+			id:     th.ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    data,
+		},
+		{
+			desc:   "view status of non-existent thing",
+			id:     strconv.FormatUint(wrongID, 10),
+			auth:   token,
+			status: http.StatusNotFound,
+			res:    notFoundRes,
+		},
+		{
+			desc:   "view status by passing invalid token",
+			id:     th.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+			res:    unauthRes,
+		},
+		{
+			desc:   "view status by passing empty token",
+			id:     th.ID,
+			auth:   "",
+			status: http.StatusUnauthorized,
+			res:    unauthRes,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s/status", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data := strings.Trim(string(body), "\n")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, data))
+	}
+}
+
 func TestListThings(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -698,6 +764,13 @@ func TestListThings(t *testing.T) {
 			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=name&dir=wrong", thingURL, 0, 5),
 			res:    nil,
 		},
+		{
+			desc:   "get a list of things with invalid status",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&status=wrong", thingURL, 0, 5),
+			res:    nil,
+		},
 		{
 			desc:   "get a list of things with invalid token",
 			auth:   wrongValue,
@@ -2510,6 +2583,264 @@ func TestDisconnnect(t *testing.T) {
 	}
 }
 
+func TestShare(t *testing.T) {
+	otherToken := "other_token"
+	otherEmail := "other_user@example.com"
+	svc := newService(map[string]string{
+		token:      email,
+		otherToken: otherEmail,
+	})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, _ := svc.CreateThings(context.Background(), token, thing)
+	th := ths[0]
+	chs, _ := svc.CreateChannels(context.Background(), token, channel)
+	ch := chs[0]
+
+	data := toJSON(shareReq{User: otherEmail, Access: things.ReadAccess})
+	invalidData := toJSON(shareReq{User: otherEmail, Access: wrongValue})
+
+	cases := []struct {
+		desc        string
+		url         string
+		req         string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "share thing",
+			url:         fmt.Sprintf("%s/things/%s/shares", ts.URL, th.ID),
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "share channel",
+			url:         fmt.Sprintf("%s/channels/%s/shares", ts.URL, ch.ID),
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "share channel with invalid access",
+			url:         fmt.Sprintf("%s/channels/%s/shares", ts.URL, ch.ID),
+			req:         invalidData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share channel with invalid request format",
+			url:         fmt.Sprintf("%s/channels/%s/shares", ts.URL, ch.ID),
+			req:         "}",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share channel without content type",
+			url:         fmt.Sprintf("%s/channels/%s/shares", ts.URL, ch.ID),
+			req:         data,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "share non-existent channel",
+			url:         fmt.Sprintf("%s/channels/%s/shares", ts.URL, strconv.FormatUint(wrongID, 10)),
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "share channel by user without admin access",
+			url:         fmt.Sprintf("%s/channels/%s/shares", ts.URL, ch.ID),
+			req:         toJSON(shareReq{User: wrongValue, Access: things.ReadAccess}),
+			contentType: contentType,
+			auth:        otherToken,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "share channel with invalid token",
+			url:         fmt.Sprintf("%s/channels/%s/shares", ts.URL, ch.ID),
+			req:         data,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "share channel with empty token",
+			url:         fmt.Sprintf("%s/channels/%s/shares", ts.URL, ch.ID),
+			req:         data,
+			contentType: contentType,
+			auth:        "",
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         tc.url,
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestUnshare(t *testing.T) {
+	otherToken := "other_token"
+	otherEmail := "other_user@example.com"
+	svc := newService(map[string]string{
+		token:      email,
+		otherToken: otherEmail,
+	})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	chs, _ := svc.CreateChannels(context.Background(), token, channel)
+	ch := chs[0]
+	err := svc.Share(context.Background(), token, things.Share{EntityID: ch.ID, EntityType: things.ChannelEntity, User: otherEmail, Access: things.ReadAccess})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		user   string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "unshare channel with invalid token",
+			id:     ch.ID,
+			user:   otherEmail,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "unshare channel by user without admin access",
+			id:     ch.ID,
+			user:   otherEmail,
+			auth:   otherToken,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "unshare channel",
+			id:     ch.ID,
+			user:   otherEmail,
+			auth:   token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "unshare unshared channel",
+			id:     ch.ID,
+			user:   otherEmail,
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/channels/%s/shares/%s", ts.URL, tc.id, tc.user),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListShares(t *testing.T) {
+	otherToken := "other_token"
+	otherEmail := "other_user@example.com"
+	svc := newService(map[string]string{
+		token:      email,
+		otherToken: otherEmail,
+	})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, _ := svc.CreateThings(context.Background(), token, thing)
+	th := ths[0]
+	share := things.Share{EntityID: th.ID, EntityType: things.ThingEntity, User: otherEmail, Access: things.WriteAccess}
+	err := svc.Share(context.Background(), token, share)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+		res    []shareReq
+	}{
+		{
+			desc:   "list thing shares",
+			id:     th.ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    []shareReq{{User: otherEmail, Access: things.WriteAccess}},
+		},
+		{
+			desc:   "list thing shares by user without admin access",
+			id:     th.ID,
+			auth:   otherToken,
+			status: http.StatusUnauthorized,
+			res:    nil,
+		},
+		{
+			desc:   "list shares of non-existent thing",
+			id:     strconv.FormatUint(wrongID, 10),
+			auth:   token,
+			status: http.StatusNotFound,
+			res:    nil,
+		},
+		{
+			desc:   "list thing shares with invalid token",
+			id:     th.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+			res:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s/shares", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var body sharesRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.ElementsMatch(t, tc.res, body.Shares, fmt.Sprintf("%s: expected shares %v got %v", tc.desc, tc.res, body.Shares))
+	}
+}
+
+type shareReq struct {
+	User   string `json:"user"`
+	Access string `json:"access"`
+}
+
+type sharesRes struct {
+	Shares []shareReq `json:"shares"`
+}
+
 type thingRes struct {
 	ID       string                 `json:"id"`
 	Name     string                 `json:"name,omitempty"`
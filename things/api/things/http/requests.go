@@ -213,6 +213,11 @@ func (req *listResourcesReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
+	if req.pageMetadata.Status != "" &&
+		req.pageMetadata.Status != things.StatusOnline && req.pageMetadata.Status != things.StatusOffline {
+		return things.ErrMalformedEntity
+	}
+
 	return nil
 }
 
@@ -295,6 +300,67 @@ func (req createConnectionsReq) validate() error {
 	return nil
 }
 
+type shareReq struct {
+	token      string
+	entityType string
+	id         string
+	User       string `json:"user"`
+	Access     string `json:"access"`
+}
+
+func (req shareReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.User == "" {
+		return things.ErrMalformedEntity
+	}
+
+	if !things.ValidAccess(req.Access) {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type unshareReq struct {
+	token      string
+	entityType string
+	id         string
+	user       string
+}
+
+func (req unshareReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.user == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type listSharesReq struct {
+	token      string
+	entityType string
+	id         string
+}
+
+func (req listSharesReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type listThingsGroupReq struct {
 	token        string
 	groupID      string
@@ -6,6 +6,7 @@ package http
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/mainflux/mainflux"
 )
@@ -14,12 +15,16 @@ var (
 	_ mainflux.Response = (*removeRes)(nil)
 	_ mainflux.Response = (*thingRes)(nil)
 	_ mainflux.Response = (*viewThingRes)(nil)
+	_ mainflux.Response = (*statusRes)(nil)
 	_ mainflux.Response = (*thingsPageRes)(nil)
 	_ mainflux.Response = (*channelRes)(nil)
 	_ mainflux.Response = (*viewChannelRes)(nil)
 	_ mainflux.Response = (*channelsPageRes)(nil)
 	_ mainflux.Response = (*connectionRes)(nil)
 	_ mainflux.Response = (*disconnectionRes)(nil)
+	_ mainflux.Response = (*shareRes)(nil)
+	_ mainflux.Response = (*unshareRes)(nil)
+	_ mainflux.Response = (*sharesRes)(nil)
 )
 
 type removeRes struct{}
@@ -108,6 +113,25 @@ func (res viewThingRes) Empty() bool {
 	return false
 }
 
+type statusRes struct {
+	ID       string     `json:"id"`
+	Online   bool       `json:"online"`
+	Protocol string     `json:"protocol,omitempty"`
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+}
+
+func (res statusRes) Code() int {
+	return http.StatusOK
+}
+
+func (res statusRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res statusRes) Empty() bool {
+	return false
+}
+
 type thingsPageRes struct {
 	pageRes
 	Things []viewThingRes `json:"things"`
@@ -257,6 +281,55 @@ func (res disconnectionRes) Empty() bool {
 	return true
 }
 
+type shareRes struct{}
+
+func (res shareRes) Code() int {
+	return http.StatusOK
+}
+
+func (res shareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res shareRes) Empty() bool {
+	return true
+}
+
+type unshareRes struct{}
+
+func (res unshareRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unshareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unshareRes) Empty() bool {
+	return true
+}
+
+type viewShareRes struct {
+	User   string `json:"user"`
+	Access string `json:"access"`
+}
+
+type sharesRes struct {
+	Shares []viewShareRes `json:"shares"`
+}
+
+func (res sharesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res sharesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res sharesRes) Empty() bool {
+	return false
+}
+
 type pageRes struct {
 	Total  uint64 `json:"total"`
 	Offset uint64 `json:"offset"`
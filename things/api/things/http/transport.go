@@ -31,6 +31,8 @@ const (
 	dirKey      = "dir"
 	metadataKey = "metadata"
 	disconnKey  = "disconnected"
+	sharedKey   = "shared"
+	statusKey   = "status"
 	defOffset   = 0
 	defLimit    = 10
 )
@@ -85,6 +87,13 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Get("/things/:id/status", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_status")(viewStatusEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/things/:id/channels", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_channels_by_thing")(listChannelsByThingEndpoint(svc)),
 		decodeListByConnection,
@@ -92,6 +101,27 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/things/:id/shares", kithttp.NewServer(
+		kitot.TraceServer(tracer, "share_thing")(shareEndpoint(svc)),
+		decodeShare(things.ThingEntity),
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/things/:id/shares", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_thing_shares")(listSharesEndpoint(svc)),
+		decodeListShares(things.ThingEntity),
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/things/:id/shares/:user", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unshare_thing")(unshareEndpoint(svc)),
+		decodeUnshare(things.ThingEntity),
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/things", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_things")(listThingsEndpoint(svc)),
 		decodeList,
@@ -148,6 +178,27 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/channels/:id/shares", kithttp.NewServer(
+		kitot.TraceServer(tracer, "share_channel")(shareEndpoint(svc)),
+		decodeShare(things.ChannelEntity),
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/channels/:id/shares", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_channel_shares")(listSharesEndpoint(svc)),
+		decodeListShares(things.ChannelEntity),
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/channels/:id/shares/:user", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unshare_channel")(unshareEndpoint(svc)),
+		decodeUnshare(things.ChannelEntity),
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/channels", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_channels")(listChannelsEndpoint(svc)),
 		decodeList,
@@ -330,6 +381,16 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
+	s, err := httputil.ReadBoolQuery(r, sharedKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := httputil.ReadStringQuery(r, statusKey, "")
+	if err != nil {
+		return nil, err
+	}
+
 	req := listResourcesReq{
 		token: r.Header.Get("Authorization"),
 		pageMetadata: things.PageMetadata{
@@ -339,6 +400,8 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 			Order:    or,
 			Dir:      d,
 			Metadata: m,
+			Shared:   s,
+			Status:   st,
 		},
 	}
 
@@ -418,6 +481,50 @@ func decodeCreateConnections(_ context.Context, r *http.Request) (interface{}, e
 	return req, nil
 }
 
+func decodeShare(entityType string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+			return nil, errors.ErrUnsupportedContentType
+		}
+
+		req := shareReq{
+			token:      r.Header.Get("Authorization"),
+			entityType: entityType,
+			id:         bone.GetValue(r, "id"),
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.Wrap(things.ErrMalformedEntity, err)
+		}
+
+		return req, nil
+	}
+}
+
+func decodeUnshare(entityType string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		req := unshareReq{
+			token:      r.Header.Get("Authorization"),
+			entityType: entityType,
+			id:         bone.GetValue(r, "id"),
+			user:       bone.GetValue(r, "user"),
+		}
+
+		return req, nil
+	}
+}
+
+func decodeListShares(entityType string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		req := listSharesReq{
+			token:      r.Header.Get("Authorization"),
+			entityType: entityType,
+			id:         bone.GetValue(r, "id"),
+		}
+
+		return req, nil
+	}
+}
+
 func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	o, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
@@ -495,6 +602,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 			errors.Contains(errorVal, things.ErrRemoveEntity),
 			errors.Contains(errorVal, things.ErrConnect),
 			errors.Contains(errorVal, things.ErrDisconnect),
+			errors.Contains(errorVal, things.ErrOwnerMismatch),
 			errors.Contains(errorVal, auth.ErrCreateGroup):
 			w.WriteHeader(http.StatusBadRequest)
 
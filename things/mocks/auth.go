@@ -5,32 +5,45 @@ package mocks
 
 import (
 	"context"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/users"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const adminAction = "admin"
+
+// ClaimKey is the claim key accepted by the mock.
+const ClaimKey = "claimKey"
+
 var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 
 type authServiceMock struct {
-	users map[string]string
+	mu       sync.Mutex
+	users    map[string]string
+	policies map[string]map[string]map[string]bool
 }
 
 // NewAuthService creates mock of users service.
 func NewAuthService(users map[string]string) mainflux.AuthServiceClient {
-	return &authServiceMock{users}
+	return &authServiceMock{
+		users:    users,
+		policies: map[string]map[string]map[string]bool{},
+	}
 }
 
-func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+func (svc *authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	if id, ok := svc.users[in.Value]; ok {
 		return &mainflux.UserIdentity{Id: id, Email: id}, nil
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
 
-func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+func (svc *authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
 	if id, ok := svc.users[in.GetEmail()]; ok {
 		switch in.Type {
 		default:
@@ -40,14 +53,157 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 	return nil, users.ErrUnauthorizedAccess
 }
 
-func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
-	panic("not implemented")
+func (svc *authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
+	id, ok := svc.users[req.GetToken()]
+	if !ok {
+		return &mainflux.AuthorizeRes{}, status.Error(codes.Unauthenticated, users.ErrUnauthorizedAccess.Error())
+	}
+	sub := req.GetSub()
+	if sub == "" {
+		sub = id
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	return &mainflux.AuthorizeRes{Authorized: svc.allowed(sub, req.GetObj(), req.GetAct())}, nil
+}
+
+func (svc *authServiceMock) Granted(ctx context.Context, req *mainflux.GrantedReq, _ ...grpc.CallOption) (*mainflux.GrantedRes, error) {
+	id, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, users.ErrUnauthorizedAccess.Error())
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	for _, act := range req.GetActs() {
+		if svc.allowed(id, req.GetObj(), act) {
+			return &mainflux.GrantedRes{Act: act}, nil
+		}
+	}
+	return &mainflux.GrantedRes{}, nil
 }
 
-func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
+func (svc *authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
 	panic("not implemented")
 }
 
-func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+func (svc *authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc *authServiceMock) Claim(ctx context.Context, req *mainflux.ClaimReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	if req.GetKey() != ClaimKey {
+		return nil, status.Error(codes.Unauthenticated, users.ErrUnauthorizedAccess.Error())
+	}
+	id := req.GetSub()
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	// The mock repositories generate the same IDs for things and channels,
+	// so the object may already be claimed by the same user.
+	subs := svc.policies[req.GetObj()]
+	if len(subs) > 0 && !(len(subs) == 1 && subs[id][adminAction]) {
+		return nil, status.Error(codes.AlreadyExists, "object already claimed")
+	}
+	svc.save(id, req.GetObj(), adminAction)
+
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if err := svc.canManage(req.GetToken(), req.GetObj()); err != nil {
+		return nil, err
+	}
+	svc.save(req.GetSub(), req.GetObj(), req.GetAct())
+
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if err := svc.canManage(req.GetToken(), req.GetObj()); err != nil {
+		return nil, err
+	}
+	if !svc.policies[req.GetObj()][req.GetSub()][req.GetAct()] {
+		return nil, status.Error(codes.NotFound, "policy not found")
+	}
+	delete(svc.policies[req.GetObj()][req.GetSub()], req.GetAct())
+
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) ListPolicies(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*mainflux.PoliciesRes, error) {
+	id, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, users.ErrUnauthorizedAccess.Error())
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	sub := req.GetSub()
+	if req.GetObj() != "" {
+		if err := svc.canManage(req.GetToken(), req.GetObj()); err != nil {
+			return nil, err
+		}
+	} else if sub == "" {
+		sub = id
+	}
+
+	res := &mainflux.PoliciesRes{}
+	for obj, subs := range svc.policies {
+		if req.GetObj() != "" && obj != req.GetObj() {
+			continue
+		}
+		for s, acts := range subs {
+			if sub != "" && s != sub {
+				continue
+			}
+			for act := range acts {
+				if req.GetAct() != "" && act != req.GetAct() {
+					continue
+				}
+				res.Policies = append(res.Policies, &mainflux.Policy{Sub: s, Obj: obj, Act: act})
+			}
+		}
+	}
+	res.Total = uint64(len(res.Policies))
+
+	return res, nil
+}
+
+func (svc *authServiceMock) canManage(token, obj string) error {
+	id, ok := svc.users[token]
+	if !ok {
+		return status.Error(codes.Unauthenticated, users.ErrUnauthorizedAccess.Error())
+	}
+	if !svc.allowed(id, obj, adminAction) {
+		return status.Error(codes.Unauthenticated, users.ErrUnauthorizedAccess.Error())
+	}
+
+	return nil
+}
+
+func (svc *authServiceMock) allowed(sub, obj, act string) bool {
+	acts := svc.policies[obj][sub]
+	return acts[act] || acts[adminAction]
+}
+
+func (svc *authServiceMock) save(sub, obj, act string) {
+	if _, ok := svc.policies[obj]; !ok {
+		svc.policies[obj] = map[string]map[string]bool{}
+	}
+	if _, ok := svc.policies[obj][sub]; !ok {
+		svc.policies[obj][sub] = map[string]bool{}
+	}
+	svc.policies[obj][sub][act] = true
+}
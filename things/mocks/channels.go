@@ -122,6 +122,50 @@ func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, p
 	return page, nil
 }
 
+func (crm *channelRepositoryMock) RetrieveByIDs(_ context.Context, chIDs []string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	if pm.Limit == 0 {
+		return things.ChannelsPage{}, nil
+	}
+
+	// This obscure way to examine map keys is enforced by the key structure
+	// itself (see mocks/commons.go).
+	chs := []things.Channel{}
+	for _, id := range chIDs {
+		suffix := fmt.Sprintf("-%s", id)
+		for k, v := range crm.channels {
+			if strings.HasSuffix(k, suffix) {
+				chs = append(chs, v)
+			}
+		}
+	}
+
+	chs = sortChannels(pm, chs)
+
+	first := pm.Offset
+	last := first + pm.Limit
+	total := uint64(len(chs))
+	if last > total {
+		last = total
+	}
+	if first > last {
+		first = last
+	}
+
+	page := things.ChannelsPage{
+		Channels: chs[first:last],
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
 func (crm *channelRepositoryMock) RetrieveByThing(_ context.Context, owner, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	if pm.Limit <= 0 {
 		return things.ChannelsPage{}, nil
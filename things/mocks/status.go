@@ -0,0 +1,127 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/things"
+)
+
+var _ things.StatusRepository = (*statusRepositoryMock)(nil)
+
+type statusRepositoryMock struct {
+	mu       sync.Mutex
+	statuses map[string]things.Status
+	// conns counts the connections of the things per protocol.
+	conns map[string]map[string]int
+}
+
+// NewStatusRepository creates in-memory thing status repository.
+func NewStatusRepository() things.StatusRepository {
+	return &statusRepositoryMock{
+		statuses: make(map[string]things.Status),
+		conns:    make(map[string]map[string]int),
+	}
+}
+
+func (srm *statusRepositoryMock) Save(_ context.Context, st things.Status) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	srm.statuses[st.ThingID] = st
+	return nil
+}
+
+func (srm *statusRepositoryMock) Seen(_ context.Context, thingID, protocol string, at time.Time) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	st, ok := srm.statuses[thingID]
+	if !ok || !st.Connected {
+		st = things.Status{
+			ThingID:  thingID,
+			Online:   true,
+			Protocol: protocol,
+		}
+	}
+	st.LastSeen = at
+	srm.statuses[thingID] = st
+	return nil
+}
+
+func (srm *statusRepositoryMock) Connect(_ context.Context, thingID, protocol string, at time.Time) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	if _, ok := srm.conns[thingID]; !ok {
+		srm.conns[thingID] = make(map[string]int)
+	}
+	srm.conns[thingID][protocol]++
+	srm.statuses[thingID] = things.Status{
+		ThingID:   thingID,
+		Online:    true,
+		Connected: true,
+		Protocol:  protocol,
+		LastSeen:  at,
+	}
+	return nil
+}
+
+func (srm *statusRepositoryMock) Disconnect(_ context.Context, thingID, protocol string, at time.Time) (things.Status, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	conns := srm.conns[thingID]
+	if conns[protocol] > 1 {
+		conns[protocol]--
+	} else {
+		delete(conns, protocol)
+	}
+
+	st := things.Status{
+		ThingID:  thingID,
+		Protocol: protocol,
+		LastSeen: at,
+	}
+	for p := range conns {
+		st.Online = true
+		st.Connected = true
+		st.Protocol = p
+		break
+	}
+	if len(conns) == 0 {
+		delete(srm.conns, thingID)
+	}
+	srm.statuses[thingID] = st
+	return st, nil
+}
+
+func (srm *statusRepositoryMock) Retrieve(_ context.Context, thingID string) (things.Status, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	st, ok := srm.statuses[thingID]
+	if !ok {
+		return things.Status{}, things.ErrNotFound
+	}
+
+	return st, nil
+}
+
+func (srm *statusRepositoryMock) RetrieveExpired(_ context.Context, before time.Time) ([]things.Status, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	sts := []things.Status{}
+	for _, st := range srm.statuses {
+		if st.Online && !st.Connected && st.LastSeen.Before(before) {
+			sts = append(sts, st)
+		}
+	}
+
+	return sts, nil
+}
@@ -159,16 +159,12 @@ func (trm *thingRepositoryMock) RetrieveByIDs(_ context.Context, thingIDs []stri
 		return things.Page{}, nil
 	}
 
-	first := uint64(pm.Offset) + 1
-	last := first + uint64(pm.Limit)
-
 	// This obscure way to examine map keys is enforced by the key structure
 	// itself (see mocks/commons.go).
 	for _, id := range thingIDs {
 		suffix := fmt.Sprintf("-%s", id)
 		for k, v := range trm.things {
-			id, _ := strconv.ParseUint(v.ID, 10, 64)
-			if strings.HasSuffix(k, suffix) && id >= first && id < last {
+			if strings.HasSuffix(k, suffix) {
 				items = append(items, v)
 			}
 		}
@@ -176,10 +172,20 @@ func (trm *thingRepositoryMock) RetrieveByIDs(_ context.Context, thingIDs []stri
 
 	items = sortThings(pm, items)
 
+	first := pm.Offset
+	last := first + pm.Limit
+	total := uint64(len(items))
+	if last > total {
+		last = total
+	}
+	if first > last {
+		first = last
+	}
+
 	page := things.Page{
-		Things: items,
+		Things: items[first:last],
 		PageMetadata: things.PageMetadata{
-			Total:  trm.counter,
+			Total:  total,
 			Offset: pm.Offset,
 			Limit:  pm.Limit,
 		},
@@ -264,6 +270,19 @@ func (trm *thingRepositoryMock) RetrieveByKey(_ context.Context, key string) (st
 	return "", things.ErrNotFound
 }
 
+func (trm *thingRepositoryMock) RetrieveKey(_ context.Context, id string) (string, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, thing := range trm.things {
+		if thing.ID == id {
+			return thing.Key, nil
+		}
+	}
+
+	return "", things.ErrNotFound
+}
+
 func (trm *thingRepositoryMock) connect(conn Connection) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
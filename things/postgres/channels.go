@@ -177,6 +177,65 @@ func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, pm th
 	return page, nil
 }
 
+func (cr channelRepository) RetrieveByIDs(ctx context.Context, chIDs []string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	if len(chIDs) == 0 {
+		return things.ChannelsPage{}, nil
+	}
+
+	nq, name := getNameQuery(pm.Name)
+	oq := getOrderQuery(pm.Order)
+	dq := getDirQuery(pm.Dir)
+	meta, mq, err := getMetadataQuery(pm.Metadata)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner, name, metadata FROM channels
+	      WHERE id = ANY(:ids) %s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, mq, nq, oq, dq)
+
+	params := map[string]interface{}{
+		"ids":      pq.Array(chIDs),
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+		"name":     name,
+		"metadata": meta,
+	}
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.Channel{}
+	for rows.Next() {
+		dbch := dbChannel{}
+		if err := rows.StructScan(&dbch); err != nil {
+			return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+		}
+		items = append(items, toChannel(dbch))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM channels WHERE id = ANY(:ids) %s%s;`, mq, nq)
+
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	page := things.ChannelsPage{
+		Channels: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Order:  pm.Order,
+			Dir:    pm.Dir,
+		},
+	}
+
+	return page, nil
+}
+
 func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	oq := getConnOrderQuery(pm.Order, "ch")
 	dq := getDirQuery(pm.Dir)
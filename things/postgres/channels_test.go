@@ -367,6 +367,76 @@ func TestMultiChannelRetrieval(t *testing.T) {
 	}
 }
 
+func TestChannelRetrievalByIDs(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	email := "channel-retrieval-by-ids@example.com"
+	name := "channel_name"
+
+	n := uint64(10)
+	ids := []string{}
+	for i := uint64(0); i < n; i++ {
+		chID, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		ch := things.Channel{
+			ID:    chID,
+			Owner: email,
+		}
+		if i%2 == 0 {
+			ch.Name = name
+		}
+
+		_, err = chanRepo.Save(context.Background(), ch)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ids = append(ids, chID)
+	}
+
+	cases := map[string]struct {
+		ids          []string
+		size         uint64
+		total        uint64
+		pageMetadata things.PageMetadata
+	}{
+		"retrieve channels by ids": {
+			ids:          ids,
+			size:         n,
+			total:        n,
+			pageMetadata: things.PageMetadata{Offset: 0, Limit: n},
+		},
+		"retrieve subset of channels by ids": {
+			ids:          ids,
+			size:         n / 2,
+			total:        n,
+			pageMetadata: things.PageMetadata{Offset: n / 2, Limit: n},
+		},
+		"retrieve channels by ids filtered by name": {
+			ids:          ids,
+			size:         n / 2,
+			total:        n / 2,
+			pageMetadata: things.PageMetadata{Offset: 0, Limit: n, Name: name},
+		},
+		"retrieve channels by empty ids": {
+			ids:          []string{},
+			size:         0,
+			total:        0,
+			pageMetadata: things.PageMetadata{Offset: 0, Limit: n},
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := chanRepo.RetrieveByIDs(context.Background(), tc.ids, tc.pageMetadata)
+		size := uint64(len(page.Channels))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %d\n", desc, err))
+		for _, ch := range page.Channels {
+			assert.Equal(t, email, ch.Owner, fmt.Sprintf("%s: expected owner %s got %s\n", desc, email, ch.Owner))
+		}
+	}
+}
+
 func TestRetrieveByThing(t *testing.T) {
 	email := "channel-multi-retrieval-by-thing@example.com"
 	dbMiddleware := postgres.NewDatabase(db)
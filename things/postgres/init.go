@@ -99,6 +99,59 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE IF EXISTS things ADD CONSTRAINT things_id_key UNIQUE (id)`,
 				},
 			},
+			{
+				Id: "things_5",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS shares (
+						entity_id   UUID,
+						entity_type VARCHAR(16) NOT NULL,
+						owner       VARCHAR(254) NOT NULL,
+						user_email  VARCHAR(254),
+						access      VARCHAR(16) NOT NULL,
+						PRIMARY KEY (entity_id, user_email)
+					)`,
+					`CREATE INDEX IF NOT EXISTS shares_user_idx ON shares (user_email, entity_type)`,
+				},
+				Down: []string{
+					"DROP TABLE shares",
+				},
+			},
+			{
+				Id: "things_6",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS thing_status (
+						thing_id  UUID PRIMARY KEY REFERENCES things (id) ON DELETE CASCADE,
+						online    BOOLEAN NOT NULL,
+						connected BOOLEAN NOT NULL,
+						protocol  VARCHAR(32),
+						last_seen TIMESTAMP WITH TIME ZONE NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS thing_status_online_idx ON thing_status (online)`,
+				},
+				Down: []string{
+					"DROP TABLE thing_status",
+				},
+			},
+			{
+				Id: "things_7",
+				Up: []string{
+					`DROP TABLE IF EXISTS shares`,
+				},
+			},
+			{
+				Id: "things_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS thing_connections (
+						thing_id    UUID REFERENCES things (id) ON DELETE CASCADE,
+						protocol    VARCHAR(32),
+						connections INTEGER NOT NULL,
+						PRIMARY KEY (thing_id, protocol)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE thing_connections",
+				},
+			},
 		},
 	}
 
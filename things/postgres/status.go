@@ -0,0 +1,257 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things"
+)
+
+var _ things.StatusRepository = (*statusRepository)(nil)
+
+type statusRepository struct {
+	db Database
+}
+
+// NewStatusRepository instantiates a PostgreSQL implementation of thing
+// status repository.
+func NewStatusRepository(db Database) things.StatusRepository {
+	return &statusRepository{
+		db: db,
+	}
+}
+
+const saveStatusQuery = `INSERT INTO thing_status (thing_id, online, connected, protocol, last_seen)
+	VALUES (:thing_id, :online, :connected, :protocol, :last_seen)
+	ON CONFLICT (thing_id) DO UPDATE SET online = EXCLUDED.online,
+	connected = EXCLUDED.connected, protocol = EXCLUDED.protocol,
+	last_seen = EXCLUDED.last_seen;`
+
+func (sr statusRepository) Save(ctx context.Context, st things.Status) error {
+	if _, err := sr.db.NamedExecContext(ctx, saveStatusQuery, toDBStatus(st)); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return things.ErrMalformedEntity
+			case errFK:
+				return things.ErrNotFound
+			}
+		}
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (sr statusRepository) Seen(ctx context.Context, thingID, protocol string, at time.Time) error {
+	// Messages sent over other protocols don't affect the connection.
+	q := `INSERT INTO thing_status (thing_id, online, connected, protocol, last_seen)
+	      VALUES (:thing_id, TRUE, FALSE, :protocol, :last_seen)
+	      ON CONFLICT (thing_id) DO UPDATE SET last_seen = EXCLUDED.last_seen,
+	      online = thing_status.online OR NOT thing_status.connected,
+	      protocol = CASE WHEN thing_status.connected THEN thing_status.protocol
+	      ELSE EXCLUDED.protocol END;`
+
+	dbst := dbStatus{
+		ThingID:  thingID,
+		Protocol: protocol,
+		LastSeen: at,
+	}
+	if _, err := sr.db.NamedExecContext(ctx, q, dbst); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return things.ErrMalformedEntity
+			case errFK:
+				return things.ErrNotFound
+			}
+		}
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (sr statusRepository) Connect(ctx context.Context, thingID, protocol string, at time.Time) error {
+	tx, err := sr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	// Saving the status first locks it until the connection is counted.
+	st := things.Status{
+		ThingID:   thingID,
+		Online:    true,
+		Connected: true,
+		Protocol:  protocol,
+		LastSeen:  at,
+	}
+	if _, err := tx.NamedExecContext(ctx, saveStatusQuery, toDBStatus(st)); err != nil {
+		tx.Rollback()
+		return statusError(err)
+	}
+
+	q := `INSERT INTO thing_connections (thing_id, protocol, connections)
+	      VALUES (:thing_id, :protocol, 1)
+	      ON CONFLICT (thing_id, protocol) DO UPDATE
+	      SET connections = thing_connections.connections + 1;`
+
+	if _, err := tx.NamedExecContext(ctx, q, dbThingConnection{ThingID: thingID, Protocol: protocol}); err != nil {
+		tx.Rollback()
+		return statusError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (sr statusRepository) Disconnect(ctx context.Context, thingID, protocol string, at time.Time) (things.Status, error) {
+	tx, err := sr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return things.Status{}, errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	// Locking the status serializes the connection changes of the thing,
+	// so the remaining connections are counted correctly.
+	var id string
+	q := `SELECT thing_id FROM thing_status WHERE thing_id = $1 FOR UPDATE;`
+	if err := tx.QueryRowxContext(ctx, q, thingID).Scan(&id); err != nil && err != sql.ErrNoRows {
+		tx.Rollback()
+		return things.Status{}, statusError(err)
+	}
+
+	dbc := dbThingConnection{ThingID: thingID, Protocol: protocol}
+	q = `UPDATE thing_connections SET connections = connections - 1
+	     WHERE thing_id = :thing_id AND protocol = :protocol;`
+	if _, err := tx.NamedExecContext(ctx, q, dbc); err != nil {
+		tx.Rollback()
+		return things.Status{}, statusError(err)
+	}
+	q = `DELETE FROM thing_connections WHERE thing_id = :thing_id AND connections <= 0;`
+	if _, err := tx.NamedExecContext(ctx, q, dbc); err != nil {
+		tx.Rollback()
+		return things.Status{}, statusError(err)
+	}
+
+	st := things.Status{
+		ThingID:  thingID,
+		Protocol: protocol,
+		LastSeen: at,
+	}
+	q = `SELECT protocol FROM thing_connections WHERE thing_id = $1 ORDER BY protocol LIMIT 1;`
+	switch err := tx.QueryRowxContext(ctx, q, thingID).Scan(&st.Protocol); err {
+	case nil:
+		st.Online = true
+		st.Connected = true
+	case sql.ErrNoRows:
+	default:
+		tx.Rollback()
+		return things.Status{}, statusError(err)
+	}
+
+	if _, err := tx.NamedExecContext(ctx, saveStatusQuery, toDBStatus(st)); err != nil {
+		tx.Rollback()
+		return things.Status{}, statusError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return things.Status{}, errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	return st, nil
+}
+
+func (sr statusRepository) Retrieve(ctx context.Context, thingID string) (things.Status, error) {
+	q := `SELECT thing_id, online, connected, protocol, last_seen FROM thing_status
+	      WHERE thing_id = $1;`
+
+	dbst := dbStatus{}
+	if err := sr.db.QueryRowxContext(ctx, q, thingID).StructScan(&dbst); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return things.Status{}, things.ErrNotFound
+		}
+		return things.Status{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	return toStatus(dbst), nil
+}
+
+func (sr statusRepository) RetrieveExpired(ctx context.Context, before time.Time) ([]things.Status, error) {
+	q := `SELECT thing_id, online, connected, protocol, last_seen FROM thing_status
+	      WHERE online AND NOT connected AND last_seen < :last_seen;`
+
+	rows, err := sr.db.NamedQueryContext(ctx, q, dbStatus{LastSeen: before})
+	if err != nil {
+		return nil, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.Status{}
+	for rows.Next() {
+		dbst := dbStatus{}
+		if err := rows.StructScan(&dbst); err != nil {
+			return nil, errors.Wrap(things.ErrSelectEntity, err)
+		}
+		items = append(items, toStatus(dbst))
+	}
+
+	return items, nil
+}
+
+type dbThingConnection struct {
+	ThingID  string `db:"thing_id"`
+	Protocol string `db:"protocol"`
+}
+
+type dbStatus struct {
+	ThingID   string    `db:"thing_id"`
+	Online    bool      `db:"online"`
+	Connected bool      `db:"connected"`
+	Protocol  string    `db:"protocol"`
+	LastSeen  time.Time `db:"last_seen"`
+}
+
+func toDBStatus(st things.Status) dbStatus {
+	return dbStatus{
+		ThingID:   st.ThingID,
+		Online:    st.Online,
+		Connected: st.Connected,
+		Protocol:  st.Protocol,
+		LastSeen:  st.LastSeen,
+	}
+}
+
+func toStatus(dbst dbStatus) things.Status {
+	return things.Status{
+		ThingID:   dbst.ThingID,
+		Online:    dbst.Online,
+		Connected: dbst.Connected,
+		Protocol:  dbst.Protocol,
+		LastSeen:  dbst.LastSeen,
+	}
+}
+
+func statusError(err error) error {
+	pqErr, ok := err.(*pq.Error)
+	if ok {
+		switch pqErr.Code.Name() {
+		case errInvalid, errTruncation:
+			return things.ErrMalformedEntity
+		case errFK:
+			return things.ErrNotFound
+		}
+	}
+	return errors.Wrap(things.ErrUpdateEntity, err)
+}
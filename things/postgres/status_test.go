@@ -0,0 +1,347 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things"
+	"github.com/mainflux/mainflux/things/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveThing(t *testing.T, owner string) things.Thing {
+	thingRepo := postgres.NewThingRepository(postgres.NewDatabase(db))
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	ths, err := thingRepo.Save(context.Background(), things.Thing{ID: id, Owner: owner, Key: key})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return ths[0]
+}
+
+func TestStatusSave(t *testing.T) {
+	statusRepo := postgres.NewStatusRepository(postgres.NewDatabase(db))
+
+	th := saveThing(t, "status-save@example.com")
+	nonexistentID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		status things.Status
+		err    error
+	}{
+		{
+			desc:   "save new status",
+			status: things.Status{ThingID: th.ID, Online: true, Connected: true, Protocol: "mqtt", LastSeen: time.Now()},
+			err:    nil,
+		},
+		{
+			desc:   "save existing status",
+			status: things.Status{ThingID: th.ID, Protocol: "mqtt", LastSeen: time.Now()},
+			err:    nil,
+		},
+		{
+			desc:   "save status of non-existing thing",
+			status: things.Status{ThingID: nonexistentID, Online: true, Protocol: "http", LastSeen: time.Now()},
+			err:    things.ErrNotFound,
+		},
+		{
+			desc:   "save status with invalid thing ID",
+			status: things.Status{ThingID: wrongValue, Online: true, Protocol: "http", LastSeen: time.Now()},
+			err:    things.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := statusRepo.Save(context.Background(), tc.status)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	st, err := statusRepo.Retrieve(context.Background(), th.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve saved status: unexpected error: %s", err))
+	assert.False(t, st.Online, "retrieve saved status: expected thing to be offline")
+}
+
+func TestStatusSeen(t *testing.T) {
+	statusRepo := postgres.NewStatusRepository(postgres.NewDatabase(db))
+
+	owner := "status-seen@example.com"
+	now := time.Now().Round(time.Millisecond)
+	unseen := saveThing(t, owner)
+	offline := saveThing(t, owner)
+	connected := saveThing(t, owner)
+	nonexistentID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	statuses := []things.Status{
+		{ThingID: offline.ID, Protocol: "http", LastSeen: now.Add(-time.Hour)},
+		{ThingID: connected.ID, Online: true, Connected: true, Protocol: "mqtt", LastSeen: now.Add(-time.Hour)},
+	}
+	for _, st := range statuses {
+		err := statusRepo.Save(context.Background(), st)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc    string
+		thingID string
+		status  things.Status
+		err     error
+	}{
+		{
+			desc:    "see thing which was never seen",
+			thingID: unseen.ID,
+			status:  things.Status{ThingID: unseen.ID, Online: true, Protocol: "coap", LastSeen: now},
+			err:     nil,
+		},
+		{
+			desc:    "see offline thing",
+			thingID: offline.ID,
+			status:  things.Status{ThingID: offline.ID, Online: true, Protocol: "coap", LastSeen: now},
+			err:     nil,
+		},
+		{
+			desc:    "see connected thing",
+			thingID: connected.ID,
+			status:  things.Status{ThingID: connected.ID, Online: true, Connected: true, Protocol: "mqtt", LastSeen: now},
+			err:     nil,
+		},
+		{
+			desc:    "see non-existing thing",
+			thingID: nonexistentID,
+			err:     things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := statusRepo.Seen(context.Background(), tc.thingID, "coap", now)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		st, err := statusRepo.Retrieve(context.Background(), tc.thingID)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.True(t, tc.status.LastSeen.Equal(st.LastSeen), fmt.Sprintf("%s: expected last seen %s got %s\n", tc.desc, tc.status.LastSeen, st.LastSeen))
+		st.LastSeen = tc.status.LastSeen
+		assert.Equal(t, tc.status, st, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.status, st))
+	}
+}
+
+func TestStatusRetrieve(t *testing.T) {
+	statusRepo := postgres.NewStatusRepository(postgres.NewDatabase(db))
+
+	th := saveThing(t, "status-retrieve@example.com")
+	unseen := saveThing(t, "status-retrieve@example.com")
+	status := things.Status{ThingID: th.ID, Online: true, Protocol: "coap", LastSeen: time.Now().Round(time.Millisecond)}
+	err := statusRepo.Save(context.Background(), status)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		thingID string
+		status  things.Status
+		err     error
+	}{
+		{
+			desc:    "retrieve existing status",
+			thingID: th.ID,
+			status:  status,
+			err:     nil,
+		},
+		{
+			desc:    "retrieve status of thing which was never seen",
+			thingID: unseen.ID,
+			status:  things.Status{},
+			err:     things.ErrNotFound,
+		},
+		{
+			desc:    "retrieve status with invalid thing ID",
+			thingID: wrongValue,
+			status:  things.Status{},
+			err:     things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		st, err := statusRepo.Retrieve(context.Background(), tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.True(t, tc.status.LastSeen.Equal(st.LastSeen), fmt.Sprintf("%s: expected last seen %s got %s\n", tc.desc, tc.status.LastSeen, st.LastSeen))
+		st.LastSeen = tc.status.LastSeen
+		assert.Equal(t, tc.status, st, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.status, st))
+	}
+}
+
+func TestStatusConnect(t *testing.T) {
+	statusRepo := postgres.NewStatusRepository(postgres.NewDatabase(db))
+
+	th := saveThing(t, "status-connect@example.com")
+	nonexistentID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "connect existing thing",
+			thingID: th.ID,
+			err:     nil,
+		},
+		{
+			desc:    "connect non-existing thing",
+			thingID: nonexistentID,
+			err:     things.ErrNotFound,
+		},
+		{
+			desc:    "connect thing with invalid ID",
+			thingID: wrongValue,
+			err:     things.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := statusRepo.Connect(context.Background(), tc.thingID, "mqtt", time.Now())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	st, err := statusRepo.Retrieve(context.Background(), th.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve connected status: unexpected error: %s", err))
+	assert.True(t, st.Online && st.Connected, "retrieve connected status: expected thing to be online and connected")
+}
+
+func TestStatusDisconnect(t *testing.T) {
+	statusRepo := postgres.NewStatusRepository(postgres.NewDatabase(db))
+
+	th := saveThing(t, "status-disconnect@example.com")
+	for _, protocol := range []string{"mqtt", "mqtt", "ws"} {
+		err := statusRepo.Connect(context.Background(), th.ID, protocol, time.Now())
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc     string
+		protocol string
+		online   bool
+		status   string
+	}{
+		{
+			desc:     "disconnect one of two mqtt connections",
+			protocol: "mqtt",
+			online:   true,
+			status:   "mqtt",
+		},
+		{
+			desc:     "disconnect last mqtt connection",
+			protocol: "mqtt",
+			online:   true,
+			status:   "ws",
+		},
+		{
+			desc:     "disconnect last connection",
+			protocol: "ws",
+			online:   false,
+			status:   "ws",
+		},
+		{
+			desc:     "disconnect disconnected thing",
+			protocol: "ws",
+			online:   false,
+			status:   "ws",
+		},
+	}
+
+	for _, tc := range cases {
+		st, err := statusRepo.Disconnect(context.Background(), th.ID, tc.protocol, time.Now())
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.online, st.Online, fmt.Sprintf("%s: expected online %t got %t\n", tc.desc, tc.online, st.Online))
+		assert.Equal(t, tc.online, st.Connected, fmt.Sprintf("%s: expected connected %t got %t\n", tc.desc, tc.online, st.Connected))
+		assert.Equal(t, tc.status, st.Protocol, fmt.Sprintf("%s: expected protocol %s got %s\n", tc.desc, tc.status, st.Protocol))
+	}
+}
+
+func TestStatusRetrieveExpired(t *testing.T) {
+	statusRepo := postgres.NewStatusRepository(postgres.NewDatabase(db))
+
+	owner := "status-expired@example.com"
+	now := time.Now()
+	expired := saveThing(t, owner)
+	seen := saveThing(t, owner)
+	connected := saveThing(t, owner)
+	statuses := []things.Status{
+		{ThingID: expired.ID, Online: true, Protocol: "http", LastSeen: now.Add(-time.Hour)},
+		{ThingID: seen.ID, Online: true, Protocol: "http", LastSeen: now},
+		{ThingID: connected.ID, Online: true, Connected: true, Protocol: "mqtt", LastSeen: now.Add(-time.Hour)},
+	}
+	for _, st := range statuses {
+		err := statusRepo.Save(context.Background(), st)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	sts, err := statusRepo.RetrieveExpired(context.Background(), now.Add(-time.Minute))
+	assert.Nil(t, err, fmt.Sprintf("retrieve expired statuses: unexpected error: %s", err))
+	ids := []string{}
+	for _, st := range sts {
+		ids = append(ids, st.ThingID)
+	}
+	assert.Contains(t, ids, expired.ID, "expected expired thing to be retrieved")
+	assert.NotContains(t, ids, seen.ID, "expected recently seen thing not to be retrieved")
+	assert.NotContains(t, ids, connected.ID, "expected connected thing not to be retrieved")
+}
+
+func TestThingRetrieveAllByStatus(t *testing.T) {
+	thingRepo := postgres.NewThingRepository(postgres.NewDatabase(db))
+	statusRepo := postgres.NewStatusRepository(postgres.NewDatabase(db))
+
+	owner := "status-list@example.com"
+	online := saveThing(t, owner)
+	offline := saveThing(t, owner)
+	unseen := saveThing(t, owner)
+	for _, st := range []things.Status{
+		{ThingID: online.ID, Online: true, Protocol: "http", LastSeen: time.Now()},
+		{ThingID: offline.ID, Protocol: "http", LastSeen: time.Now()},
+	} {
+		err := statusRepo.Save(context.Background(), st)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := map[string]struct {
+		status string
+		ids    []string
+	}{
+		"retrieve all things": {
+			status: "",
+			ids:    []string{online.ID, offline.ID, unseen.ID},
+		},
+		"retrieve online things": {
+			status: things.StatusOnline,
+			ids:    []string{online.ID},
+		},
+		"retrieve offline things": {
+			status: things.StatusOffline,
+			ids:    []string{offline.ID, unseen.ID},
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := thingRepo.RetrieveAll(context.Background(), owner, things.PageMetadata{Limit: 10, Status: tc.status})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		ids := []string{}
+		for _, th := range page.Things {
+			ids = append(ids, th.ID)
+		}
+		assert.ElementsMatch(t, tc.ids, ids, fmt.Sprintf("%s: expected %v got %v", desc, tc.ids, ids))
+		assert.Equal(t, uint64(len(tc.ids)), page.Total, fmt.Sprintf("%s: expected total %d got %d", desc, len(tc.ids), page.Total))
+	}
+}
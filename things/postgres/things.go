@@ -177,6 +177,21 @@ func (tr thingRepository) RetrieveByKey(ctx context.Context, key string) (string
 	return id, nil
 }
 
+func (tr thingRepository) RetrieveKey(ctx context.Context, id string) (string, error) {
+	q := `SELECT key FROM things WHERE id = $1;`
+
+	var key string
+	if err := tr.db.QueryRowxContext(ctx, q, id).Scan(&key); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return "", errors.Wrap(things.ErrNotFound, err)
+		}
+		return "", errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	return key, nil
+}
+
 func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string, pm things.PageMetadata) (things.Page, error) {
 	if len(thingIDs) == 0 {
 		return things.Page{}, nil
@@ -185,6 +200,7 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 	nq, name := getNameQuery(pm.Name)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
+	sq := getStatusQuery(pm.Status)
 	idq := fmt.Sprintf("WHERE id IN ('%s') ", strings.Join(thingIDs, "','"))
 
 	m, mq, err := getMetadataQuery(pm.Metadata)
@@ -193,7 +209,7 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 	}
 
 	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata FROM things
-					   %s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, idq, mq, nq, oq, dq)
+					   %s%s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, idq, mq, nq, sq, oq, dq)
 
 	params := map[string]interface{}{
 		"limit":    pm.Limit,
@@ -223,7 +239,7 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 		items = append(items, th)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things %s%s%s;`, idq, mq, nq)
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things %s%s%s%s;`, idq, mq, nq, sq)
 
 	total, err := total(ctx, tr.db, cq, params)
 	if err != nil {
@@ -248,13 +264,14 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm thin
 	nq, name := getNameQuery(pm.Name)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
+	sq := getStatusQuery(pm.Status)
 	m, mq, err := getMetadataQuery(pm.Metadata)
 	if err != nil {
 		return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
 	q := fmt.Sprintf(`SELECT id, name, key, metadata FROM things
-	      WHERE owner = :owner %s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, mq, nq, oq, dq)
+	      WHERE owner = :owner %s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, mq, nq, sq, oq, dq)
 	params := map[string]interface{}{
 		"owner":    owner,
 		"limit":    pm.Limit,
@@ -284,7 +301,7 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm thin
 		items = append(items, th)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things WHERE owner = :owner %s%s;`, nq, mq)
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things WHERE owner = :owner %s%s%s;`, nq, mq, sq)
 
 	total, err := total(ctx, tr.db, cq, params)
 	if err != nil {
@@ -415,6 +432,19 @@ type dbThing struct {
 	Metadata []byte `db:"metadata"`
 }
 
+// getStatusQuery returns the condition which filters things by status.
+// Things which were never seen have no status and are offline.
+func getStatusQuery(status string) string {
+	switch status {
+	case things.StatusOnline:
+		return ` AND id IN (SELECT thing_id FROM thing_status WHERE online)`
+	case things.StatusOffline:
+		return ` AND id NOT IN (SELECT thing_id FROM thing_status WHERE online)`
+	default:
+		return ""
+	}
+}
+
 func toDBThing(th things.Thing) (dbThing, error) {
 	data := []byte("{}")
 	if len(th.Metadata) > 0 {
@@ -0,0 +1,174 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const (
+	presenceProtocol = "things"
+	offlineSubtopic  = "offline"
+	jsonContentType  = "application/json"
+
+	// The last seen time of the thing is updated at most once per this
+	// fraction of the timeout, to avoid writing on every message.
+	seenResolution = 10
+)
+
+// OfflineEvent is published to the presence channel when the thing goes
+// offline.
+type OfflineEvent struct {
+	ThingID  string    `json:"thing_id"`
+	Protocol string    `json:"protocol"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Presence keeps track of the things connection status, based on the
+// connection events and the messages things send.
+type Presence interface {
+	// Connect marks the thing online while it holds the connection over
+	// the given protocol.
+	Connect(ctx context.Context, thingID, protocol string) error
+
+	// Disconnect marks the thing offline after it closed the last of its
+	// connections, over any protocol.
+	Disconnect(ctx context.Context, thingID, protocol string) error
+
+	// Seen records the activity of the thing over the given protocol.
+	Seen(ctx context.Context, thingID, protocol string) error
+
+	// Expire marks offline the things which are not connected and were
+	// not seen for the timeout.
+	Expire(ctx context.Context) error
+}
+
+var _ Presence = (*presence)(nil)
+
+type presence struct {
+	statuses StatusRepository
+	pub      messaging.Publisher
+	chanID   string
+	timeout  time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewPresence returns Presence which publishes offline events to the
+// channel with the given ID. If the channel ID is empty, events are not
+// published.
+func NewPresence(statuses StatusRepository, pub messaging.Publisher, chanID string, timeout time.Duration) Presence {
+	return &presence{
+		statuses: statuses,
+		pub:      pub,
+		chanID:   chanID,
+		timeout:  timeout,
+		seen:     make(map[string]time.Time),
+	}
+}
+
+func (p *presence) Connect(ctx context.Context, thingID, protocol string) error {
+	return p.statuses.Connect(ctx, thingID, protocol, time.Now())
+}
+
+func (p *presence) Disconnect(ctx context.Context, thingID, protocol string) error {
+	st, err := p.statuses.Disconnect(ctx, thingID, protocol, time.Now())
+	if err != nil {
+		return err
+	}
+	// The thing is still connected over the other protocols.
+	if st.Online {
+		return nil
+	}
+	p.forget(thingID)
+
+	return p.publish(st)
+}
+
+func (p *presence) Seen(ctx context.Context, thingID, protocol string) error {
+	now := time.Now()
+
+	p.mu.Lock()
+	last, ok := p.seen[thingID]
+	if ok && now.Sub(last) < p.timeout/seenResolution {
+		p.mu.Unlock()
+		return nil
+	}
+	p.seen[thingID] = now
+	p.mu.Unlock()
+
+	return p.statuses.Seen(ctx, thingID, protocol, now)
+}
+
+func (p *presence) Expire(ctx context.Context) error {
+	sts, err := p.statuses.RetrieveExpired(ctx, time.Now().Add(-p.timeout))
+	if err != nil {
+		return err
+	}
+
+	for _, st := range sts {
+		p.forget(st.ThingID)
+
+		st.Online = false
+		if err := p.statuses.Save(ctx, st); err != nil {
+			return err
+		}
+		if err := p.publish(st); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ActivityHandler returns the handler which records the activity of the
+// things publishing messages.
+func ActivityHandler(p Presence) messaging.MessageHandler {
+	return func(msg messaging.Message) error {
+		// Offline events are published on behalf of the thing.
+		if msg.Protocol == presenceProtocol || msg.Publisher == "" {
+			return nil
+		}
+		return p.Seen(context.Background(), msg.Publisher, msg.Protocol)
+	}
+}
+
+func (p *presence) forget(thingID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.seen, thingID)
+}
+
+func (p *presence) publish(st Status) error {
+	if p.chanID == "" {
+		return nil
+	}
+
+	payload, err := json.Marshal(OfflineEvent{
+		ThingID:  st.ThingID,
+		Protocol: st.Protocol,
+		LastSeen: st.LastSeen,
+	})
+	if err != nil {
+		return err
+	}
+	msg := messaging.Message{
+		Channel:     p.chanID,
+		Subtopic:    offlineSubtopic,
+		Publisher:   st.ThingID,
+		Protocol:    presenceProtocol,
+		ContentType: jsonContentType,
+		Payload:     payload,
+		Created:     time.Now().UnixNano(),
+	}
+
+	return p.pub.Publish(p.chanID, msg)
+}
@@ -0,0 +1,173 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
+	"github.com/mainflux/mainflux/things/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	thingID         = "thing"
+	presenceChannel = "presence"
+	timeout         = 50 * time.Millisecond
+)
+
+type publisher struct {
+	mu   sync.Mutex
+	msgs []messaging.Message
+}
+
+func (pub *publisher) Publish(_ string, msg messaging.Message) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.msgs = append(pub.msgs, msg)
+	return nil
+}
+
+func (pub *publisher) events() []things.OfflineEvent {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	evs := []things.OfflineEvent{}
+	for _, msg := range pub.msgs {
+		var ev things.OfflineEvent
+		if err := json.Unmarshal(msg.Payload, &ev); err == nil {
+			evs = append(evs, ev)
+		}
+	}
+	return evs
+}
+
+func TestPresenceConnection(t *testing.T) {
+	statuses := mocks.NewStatusRepository()
+	pub := &publisher{}
+	p := things.NewPresence(statuses, pub, presenceChannel, timeout)
+
+	err := p.Connect(context.Background(), thingID, "mqtt")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// Connected thing doesn't expire and keeps the protocol.
+	err = p.Seen(context.Background(), thingID, "http")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	time.Sleep(2 * timeout)
+	err = p.Expire(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	st, err := statuses.Retrieve(context.Background(), thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, st.Online, "connected thing expected to be online")
+	assert.Equal(t, "mqtt", st.Protocol, fmt.Sprintf("expected protocol mqtt got %s", st.Protocol))
+	assert.Empty(t, pub.events(), "expected no offline events")
+
+	err = p.Disconnect(context.Background(), thingID, "mqtt")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	st, err = statuses.Retrieve(context.Background(), thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, st.Online, "disconnected thing expected to be offline")
+	evs := pub.events()
+	require.Len(t, evs, 1, fmt.Sprintf("expected 1 offline event got %d", len(evs)))
+	assert.Equal(t, thingID, evs[0].ThingID, fmt.Sprintf("expected thing %s got %s", thingID, evs[0].ThingID))
+}
+
+func TestPresenceExpire(t *testing.T) {
+	statuses := mocks.NewStatusRepository()
+	pub := &publisher{}
+	p := things.NewPresence(statuses, pub, presenceChannel, timeout)
+	handle := things.ActivityHandler(p)
+
+	err := handle(messaging.Message{Publisher: thingID, Protocol: "coap"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	st, err := statuses.Retrieve(context.Background(), thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, st.Online, "seen thing expected to be online")
+	assert.Equal(t, "coap", st.Protocol, fmt.Sprintf("expected protocol coap got %s", st.Protocol))
+
+	err = p.Expire(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	st, err = statuses.Retrieve(context.Background(), thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, st.Online, "recently seen thing expected to be online")
+
+	time.Sleep(2 * timeout)
+	err = p.Expire(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	st, err = statuses.Retrieve(context.Background(), thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, st.Online, "expired thing expected to be offline")
+
+	evs := pub.events()
+	require.Len(t, evs, 1, fmt.Sprintf("expected 1 offline event got %d", len(evs)))
+	assert.Equal(t, "coap", evs[0].Protocol, fmt.Sprintf("expected protocol coap got %s", evs[0].Protocol))
+
+	// Offline events don't make the thing online again.
+	pub.mu.Lock()
+	msg := pub.msgs[0]
+	pub.mu.Unlock()
+	err = handle(msg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	st, err = statuses.Retrieve(context.Background(), thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, st.Online, "thing expected to stay offline")
+}
+
+func TestPresenceMultipleConnections(t *testing.T) {
+	statuses := mocks.NewStatusRepository()
+	pub := &publisher{}
+	p := things.NewPresence(statuses, pub, presenceChannel, timeout)
+
+	for _, protocol := range []string{"mqtt", "mqtt", "ws"} {
+		err := p.Connect(context.Background(), thingID, protocol)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc     string
+		protocol string
+		online   bool
+	}{
+		{
+			desc:     "close one of two mqtt connections",
+			protocol: "mqtt",
+			online:   true,
+		},
+		{
+			desc:     "close last mqtt connection",
+			protocol: "mqtt",
+			online:   true,
+		},
+		{
+			desc:     "close last connection",
+			protocol: "ws",
+			online:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := p.Disconnect(context.Background(), thingID, tc.protocol)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		st, err := statuses.Retrieve(context.Background(), thingID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.online, st.Online, fmt.Sprintf("%s: expected online %t got %t", tc.desc, tc.online, st.Online))
+		assert.Equal(t, tc.online, st.Connected, fmt.Sprintf("%s: expected connected %t got %t", tc.desc, tc.online, st.Connected))
+	}
+
+	evs := pub.events()
+	require.Len(t, evs, 1, fmt.Sprintf("expected 1 offline event got %d", len(evs)))
+	assert.Equal(t, "ws", evs[0].Protocol, fmt.Sprintf("expected protocol ws got %s", evs[0].Protocol))
+}
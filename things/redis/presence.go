@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/things"
+)
+
+const (
+	mqttStream   = "mainflux.mqtt"
+	mqttProtocol = "mqtt"
+	presenceGrp  = "mainflux.things.presence"
+
+	connectEvent    = "connect"
+	disconnectEvent = "disconnect"
+
+	groupExists = "BUSYGROUP Consumer Group name already exists"
+
+	// The stream is read blocking for at most readTimeout, so that the
+	// context cancellation is noticed, and read again after the backoff
+	// if reading fails.
+	readTimeout = time.Second
+	backoff     = 5 * time.Second
+)
+
+// PresenceSubscriber consumes connection events of the MQTT adapter.
+type PresenceSubscriber interface {
+	// Subscribe receives connection events and updates the presence of
+	// the things. It blocks until the context is canceled.
+	Subscribe(ctx context.Context) error
+}
+
+type presenceSubscriber struct {
+	presence things.Presence
+	client   *redis.Client
+	consumer string
+	logger   logger.Logger
+}
+
+// NewPresenceSubscriber returns new connection events subscriber.
+func NewPresenceSubscriber(presence things.Presence, client *redis.Client, consumer string, logger logger.Logger) PresenceSubscriber {
+	return presenceSubscriber{
+		presence: presence,
+		client:   client,
+		consumer: consumer,
+		logger:   logger,
+	}
+}
+
+func (ps presenceSubscriber) Subscribe(ctx context.Context) error {
+	err := ps.client.XGroupCreateMkStream(mqttStream, presenceGrp, "$").Err()
+	if err != nil && err.Error() != groupExists {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		streams, err := ps.client.XReadGroup(&redis.XReadGroupArgs{
+			Group:    presenceGrp,
+			Consumer: ps.consumer,
+			Streams:  []string{mqttStream, ">"},
+			Count:    100,
+			Block:    readTimeout,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to read connection events: %s", err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(backoff):
+			}
+			continue
+		}
+		if len(streams) == 0 {
+			continue
+		}
+
+		for _, msg := range streams[0].Messages {
+			thingID := read(msg.Values, "thing_id", "")
+
+			var err error
+			switch read(msg.Values, "event_type", "") {
+			case connectEvent:
+				err = ps.presence.Connect(ctx, thingID, mqttProtocol)
+			case disconnectEvent:
+				err = ps.presence.Disconnect(ctx, thingID, mqttProtocol)
+			}
+			if err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to handle connection event of thing %s: %s", thingID, err))
+			}
+			ps.client.XAck(mqttStream, presenceGrp, msg.ID)
+		}
+	}
+
+	return ctx.Err()
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
@@ -112,6 +112,10 @@ func (es eventStore) RemoveThing(ctx context.Context, token, id string) error {
 	return nil
 }
 
+func (es eventStore) ViewStatus(ctx context.Context, token, id string) (things.Status, error) {
+	return es.svc.ViewStatus(ctx, token, id)
+}
+
 func (es eventStore) CreateChannels(ctx context.Context, token string, channels ...things.Channel) ([]things.Channel, error) {
 	schs, err := es.svc.CreateChannels(ctx, token, channels...)
 	if err != nil {
@@ -244,6 +248,22 @@ func (es eventStore) Identify(ctx context.Context, key string) (string, error) {
 	return es.svc.Identify(ctx, key)
 }
 
+func (es eventStore) DerivePSK(ctx context.Context, id string) (string, error) {
+	return es.svc.DerivePSK(ctx, id)
+}
+
 func (es eventStore) ListMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.Page, error) {
 	return es.svc.ListMembers(ctx, token, groupID, pm)
 }
+
+func (es eventStore) Share(ctx context.Context, token string, share things.Share) error {
+	return es.svc.Share(ctx, token, share)
+}
+
+func (es eventStore) Unshare(ctx context.Context, token string, share things.Share) error {
+	return es.svc.Unshare(ctx, token, share)
+}
+
+func (es eventStore) ListShares(ctx context.Context, token, entityType, id string) ([]things.Share, error) {
+	return es.svc.ListShares(ctx, token, entityType, id)
+}
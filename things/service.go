@@ -5,8 +5,13 @@ package things
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 
 	"github.com/mainflux/mainflux/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/ulid"
@@ -73,6 +78,11 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	RemoveThing(ctx context.Context, token, id string) error
 
+	// ViewStatus retrieves the connection status of the thing identified
+	// with the provided ID, that belongs to the user identified by the
+	// provided key.
+	ViewStatus(ctx context.Context, token, id string) (Status, error)
+
 	// CreateChannels adds channels to the user identified by the provided key.
 	CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error)
 
@@ -119,8 +129,28 @@ type Service interface {
 	// Identify returns thing ID for given thing key.
 	Identify(ctx context.Context, key string) (string, error)
 
+	// DerivePSK returns the hex encoded pre-shared key of the thing with
+	// the given ID, used by the adapters authenticating things during the
+	// DTLS handshake. It is the HMAC-SHA256 of the thing ID keyed by the
+	// thing key, so the thing key never leaves the things service.
+	DerivePSK(ctx context.Context, id string) (string, error)
+
 	// ListMembers retrieves everything that is assigned to a group identified by groupID.
 	ListMembers(ctx context.Context, token, groupID string, pm PageMetadata) (Page, error)
+
+	// Share grants the user access to the thing or the channel by adding
+	// the auth service policies. Only the entity owner and users with admin
+	// access may share it.
+	Share(ctx context.Context, token string, share Share) error
+
+	// Unshare revokes the user access to the thing or the channel. The same
+	// rules as for Share apply.
+	Unshare(ctx context.Context, token string, share Share) error
+
+	// ListShares retrieves shares of the thing or the channel identified by
+	// the provided ID with the users other than the caller. The same rules
+	// as for Share apply.
+	ListShares(ctx context.Context, token, entityType, id string) ([]Share, error)
 }
 
 // PageMetadata contains page metadata that helps navigation.
@@ -132,6 +162,8 @@ type PageMetadata struct {
 	Order        string                 `json:"order,omitempty"`
 	Dir          string                 `json:"dir,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Shared       bool                   `json:"shared,omitempty"` // Used for lists of entities the user is granted access to by the policies
+	Status       string                 `json:"status,omitempty"` // Used for lists of online or offline things
 	Disconnected bool                   // Used for connected or disconnected lists
 }
 
@@ -141,22 +173,27 @@ type thingsService struct {
 	auth         mainflux.AuthServiceClient
 	things       ThingRepository
 	channels     ChannelRepository
+	statuses     StatusRepository
 	channelCache ChannelCache
 	thingCache   ThingCache
 	idProvider   mainflux.IDProvider
 	ulidProvider mainflux.IDProvider
+	claimKey     string
 }
 
-// New instantiates the things service implementation.
-func New(auth mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository, ccache ChannelCache, tcache ThingCache, idp mainflux.IDProvider) Service {
+// New instantiates the things service implementation. The entities are
+// claimed for their owners in the auth service using the claim key.
+func New(auth mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository, statuses StatusRepository, ccache ChannelCache, tcache ThingCache, idp mainflux.IDProvider, claimKey string) Service {
 	return &thingsService{
 		auth:         auth,
 		things:       things,
 		channels:     channels,
+		statuses:     statuses,
 		channelCache: ccache,
 		thingCache:   tcache,
 		idProvider:   idp,
 		ulidProvider: ulid.New(),
+		claimKey:     claimKey,
 	}
 }
 
@@ -180,9 +217,26 @@ func (ts *thingsService) CreateThings(ctx context.Context, token string, things
 				return []Thing{}, errors.Wrap(ErrCreateUUID, err)
 			}
 		}
+
+	}
+
+	ths, err := ts.things.Save(ctx, things...)
+	if err != nil {
+		return []Thing{}, err
+	}
+
+	for _, th := range ths {
+		if err := ts.claim(ctx, res.GetId(), th.ID); err != nil {
+			// Remove the things whose access couldn't be managed.
+			for _, th := range ths {
+				ts.revoke(ctx, token, "", th.ID)
+				ts.things.Remove(ctx, th.Owner, th.ID)
+			}
+			return []Thing{}, err
+		}
 	}
 
-	return ts.things.Save(ctx, things...)
+	return ths, nil
 }
 
 func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Thing) error {
@@ -191,7 +245,11 @@ func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Th
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	thing.Owner = res.GetEmail()
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), thing.ID, WriteAccess)
+	if err != nil {
+		return err
+	}
+	thing.Owner = sh.Owner
 
 	return ts.things.Update(ctx, thing)
 }
@@ -202,9 +260,12 @@ func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) e
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	owner := res.GetEmail()
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), id, WriteAccess)
+	if err != nil {
+		return err
+	}
 
-	return ts.things.UpdateKey(ctx, owner, id, key)
+	return ts.things.UpdateKey(ctx, sh.Owner, id, key)
 }
 
 func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing, error) {
@@ -213,7 +274,20 @@ func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing
 		return Thing{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.things.RetrieveByID(ctx, res.GetEmail(), id)
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), id, ReadAccess)
+	if err != nil {
+		return Thing{}, err
+	}
+
+	th, err := ts.things.RetrieveByID(ctx, sh.Owner, id)
+	if err != nil {
+		return Thing{}, err
+	}
+	if !sh.Allows(WriteAccess) {
+		th.Key = ""
+	}
+
+	return th, nil
 }
 
 func (ts *thingsService) ListThings(ctx context.Context, token string, pm PageMetadata) (Page, error) {
@@ -222,7 +296,32 @@ func (ts *thingsService) ListThings(ctx context.Context, token string, pm PageMe
 		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.things.RetrieveAll(ctx, res.GetEmail(), pm)
+	if !pm.Shared {
+		return ts.things.RetrieveAll(ctx, res.GetEmail(), pm)
+	}
+
+	access, err := ts.granted(ctx, token)
+	if err != nil {
+		return Page{}, err
+	}
+
+	ids := make([]string, 0, len(access))
+	for id := range access {
+		ids = append(ids, id)
+	}
+
+	page, err := ts.things.RetrieveByIDs(ctx, ids, pm)
+	if err != nil {
+		return Page{}, err
+	}
+	for i, th := range page.Things {
+		sh := Share{Access: access[th.ID]}
+		if th.Owner != res.GetEmail() && !sh.Allows(WriteAccess) {
+			page.Things[i].Key = ""
+		}
+	}
+
+	return page, nil
 }
 
 func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, chID string, pm PageMetadata) (Page, error) {
@@ -231,7 +330,24 @@ func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, chID st
 		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.things.RetrieveByChannel(ctx, res.GetEmail(), chID, pm)
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), chID, ReadAccess)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page, err := ts.things.RetrieveByChannel(ctx, sh.Owner, chID, pm)
+	if err != nil {
+		return Page{}, err
+	}
+	// Keys of the things listed through a shared channel are hidden, since
+	// sharing the channel doesn't share the things connected to it.
+	if sh.Owner != res.GetEmail() {
+		for i := range page.Things {
+			page.Things[i].Key = ""
+		}
+	}
+
+	return page, nil
 }
 
 func (ts *thingsService) RemoveThing(ctx context.Context, token, id string) error {
@@ -240,10 +356,49 @@ func (ts *thingsService) RemoveThing(ctx context.Context, token, id string) erro
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), id, AdminAccess)
+	if err != nil {
+		return err
+	}
+
 	if err := ts.thingCache.Remove(ctx, id); err != nil {
 		return err
 	}
-	return ts.things.Remove(ctx, res.GetEmail(), id)
+	if err := ts.things.Remove(ctx, sh.Owner, id); err != nil {
+		return err
+	}
+
+	// Nonexistent and unclaimed entities have no policies to revoke.
+	err = ts.revoke(ctx, token, "", id)
+	if err != nil && !errors.Contains(err, ErrNotFound) && !errors.Contains(err, ErrUnauthorizedAccess) {
+		return err
+	}
+	return nil
+}
+
+func (ts *thingsService) ViewStatus(ctx context.Context, token, id string) (Status, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Status{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), id, ReadAccess)
+	if err != nil {
+		return Status{}, err
+	}
+	if _, err := ts.things.RetrieveByID(ctx, sh.Owner, id); err != nil {
+		return Status{}, err
+	}
+
+	st, err := ts.statuses.Retrieve(ctx, id)
+	if err != nil {
+		if errors.Contains(err, ErrNotFound) {
+			return Status{ThingID: id}, nil
+		}
+		return Status{}, err
+	}
+
+	return st, nil
 }
 
 func (ts *thingsService) CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error) {
@@ -261,7 +416,23 @@ func (ts *thingsService) CreateChannels(ctx context.Context, token string, chann
 		channels[i].Owner = res.GetEmail()
 	}
 
-	return ts.channels.Save(ctx, channels...)
+	chs, err := ts.channels.Save(ctx, channels...)
+	if err != nil {
+		return []Channel{}, err
+	}
+
+	for _, ch := range chs {
+		if err := ts.claim(ctx, res.GetId(), ch.ID); err != nil {
+			// Remove the channels whose access couldn't be managed.
+			for _, ch := range chs {
+				ts.revoke(ctx, token, "", ch.ID)
+				ts.channels.Remove(ctx, ch.Owner, ch.ID)
+			}
+			return []Channel{}, err
+		}
+	}
+
+	return chs, nil
 }
 
 func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channel Channel) error {
@@ -270,7 +441,12 @@ func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channe
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	channel.Owner = res.GetEmail()
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), channel.ID, WriteAccess)
+	if err != nil {
+		return err
+	}
+
+	channel.Owner = sh.Owner
 	return ts.channels.Update(ctx, channel)
 }
 
@@ -280,7 +456,12 @@ func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Cha
 		return Channel{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.RetrieveByID(ctx, res.GetEmail(), id)
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), id, ReadAccess)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	return ts.channels.RetrieveByID(ctx, sh.Owner, id)
 }
 
 func (ts *thingsService) ListChannels(ctx context.Context, token string, pm PageMetadata) (ChannelsPage, error) {
@@ -289,7 +470,21 @@ func (ts *thingsService) ListChannels(ctx context.Context, token string, pm Page
 		return ChannelsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.RetrieveAll(ctx, res.GetEmail(), pm)
+	if !pm.Shared {
+		return ts.channels.RetrieveAll(ctx, res.GetEmail(), pm)
+	}
+
+	access, err := ts.granted(ctx, token)
+	if err != nil {
+		return ChannelsPage{}, err
+	}
+
+	ids := make([]string, 0, len(access))
+	for id := range access {
+		ids = append(ids, id)
+	}
+
+	return ts.channels.RetrieveByIDs(ctx, ids, pm)
 }
 
 func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thID string, pm PageMetadata) (ChannelsPage, error) {
@@ -298,7 +493,12 @@ func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thID st
 		return ChannelsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.RetrieveByThing(ctx, res.GetEmail(), thID, pm)
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), thID, ReadAccess)
+	if err != nil {
+		return ChannelsPage{}, err
+	}
+
+	return ts.channels.RetrieveByThing(ctx, sh.Owner, thID, pm)
 }
 
 func (ts *thingsService) RemoveChannel(ctx context.Context, token, id string) error {
@@ -307,11 +507,25 @@ func (ts *thingsService) RemoveChannel(ctx context.Context, token, id string) er
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
+	sh, err := ts.authorize(ctx, token, res.GetEmail(), id, AdminAccess)
+	if err != nil {
+		return err
+	}
+
 	if err := ts.channelCache.Remove(ctx, id); err != nil {
 		return err
 	}
 
-	return ts.channels.Remove(ctx, res.GetEmail(), id)
+	if err := ts.channels.Remove(ctx, sh.Owner, id); err != nil {
+		return err
+	}
+
+	// Nonexistent and unclaimed entities have no policies to revoke.
+	err = ts.revoke(ctx, token, "", id)
+	if err != nil && !errors.Contains(err, ErrNotFound) && !errors.Contains(err, ErrUnauthorizedAccess) {
+		return err
+	}
+	return nil
 }
 
 func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
@@ -320,7 +534,12 @@ func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.Connect(ctx, res.GetEmail(), chIDs, thIDs)
+	owner, err := ts.commonOwner(ctx, token, res.GetEmail(), append(append([]string{}, chIDs...), thIDs...)...)
+	if err != nil {
+		return err
+	}
+
+	return ts.channels.Connect(ctx, owner, chIDs, thIDs)
 }
 
 func (ts *thingsService) Disconnect(ctx context.Context, token, chanID, thingID string) error {
@@ -329,11 +548,16 @@ func (ts *thingsService) Disconnect(ctx context.Context, token, chanID, thingID
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
+	owner, err := ts.commonOwner(ctx, token, res.GetEmail(), chanID, thingID)
+	if err != nil {
+		return err
+	}
+
 	if err := ts.channelCache.Disconnect(ctx, chanID, thingID); err != nil {
 		return err
 	}
 
-	return ts.channels.Disconnect(ctx, res.GetEmail(), chanID, thingID)
+	return ts.channels.Disconnect(ctx, owner, chanID, thingID)
 }
 
 func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey string) (string, error) {
@@ -395,6 +619,17 @@ func (ts *thingsService) Identify(ctx context.Context, key string) (string, erro
 	return id, nil
 }
 
+func (ts *thingsService) DerivePSK(ctx context.Context, id string) (string, error) {
+	key, err := ts.things.RetrieveKey(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
 func (ts *thingsService) hasThing(ctx context.Context, chanID, thingKey string) (string, error) {
 	thingID, err := ts.thingCache.ID(ctx, thingKey)
 	if err != nil {
@@ -435,3 +670,268 @@ func (ts *thingsService) members(ctx context.Context, token, groupID, groupType
 	}
 	return res.Members, nil
 }
+
+func (ts *thingsService) Share(ctx context.Context, token string, share Share) error {
+	if !ValidAccess(share.Access) {
+		return ErrMalformedEntity
+	}
+
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if share.User == res.GetId() {
+		return ErrMalformedEntity
+	}
+
+	if _, err := ts.shareOwner(ctx, token, res, share.EntityType, share.EntityID); err != nil {
+		return err
+	}
+
+	// Sharing the entity with the same user again overwrites the access.
+	if err := ts.revoke(ctx, token, share.User, share.EntityID); err != nil && !errors.Contains(err, ErrNotFound) {
+		return err
+	}
+	for _, act := range shareActions[share.Access] {
+		req := &mainflux.PolicyReq{Token: token, Sub: share.User, Obj: share.EntityID, Act: act}
+		if _, err := ts.auth.AddPolicy(ctx, req); err != nil {
+			return errors.Wrap(ErrCreateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (ts *thingsService) Unshare(ctx context.Context, token string, share Share) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if _, err := ts.shareOwner(ctx, token, res, share.EntityType, share.EntityID); err != nil {
+		return err
+	}
+
+	return ts.revoke(ctx, token, share.User, share.EntityID)
+}
+
+func (ts *thingsService) ListShares(ctx context.Context, token, entityType, id string) ([]Share, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	owner, err := ts.shareOwner(ctx, token, res, entityType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	pp, err := ts.policies(ctx, &mainflux.PolicyReq{Token: token, Obj: id})
+	if err != nil {
+		return nil, err
+	}
+
+	shs := []Share{}
+	users := map[string]int{}
+	for _, p := range pp {
+		if p.GetSub() == res.GetId() || !ValidAccess(p.GetAct()) {
+			continue
+		}
+		i, ok := users[p.GetSub()]
+		if !ok {
+			users[p.GetSub()] = len(shs)
+			shs = append(shs, Share{EntityID: id, EntityType: entityType, Owner: owner, User: p.GetSub(), Access: p.GetAct()})
+			continue
+		}
+		if !shs[i].Allows(p.GetAct()) {
+			shs[i].Access = p.GetAct()
+		}
+	}
+
+	return shs, nil
+}
+
+// shareOwner returns the owner of the entity whose shares the user identified
+// by the token is allowed to manage.
+func (ts *thingsService) shareOwner(ctx context.Context, token string, user *mainflux.UserIdentity, entityType, id string) (string, error) {
+	sh, err := ts.authorize(ctx, token, user.GetEmail(), id, AdminAccess)
+	if err != nil {
+		return "", err
+	}
+
+	switch entityType {
+	case ThingEntity:
+		_, err = ts.things.RetrieveByID(ctx, sh.Owner, id)
+	case ChannelEntity:
+		_, err = ts.channels.RetrieveByID(ctx, sh.Owner, id)
+	default:
+		return "", ErrMalformedEntity
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// The entities created before their access was managed by the policies
+	// are claimed by their owners once they start sharing them.
+	if sh.Owner == user.GetEmail() {
+		_, err := ts.auth.Claim(ctx, &mainflux.ClaimReq{Key: ts.claimKey, Sub: user.GetId(), Obj: id})
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			return "", errors.Wrap(ErrUnauthorizedAccess, err)
+		}
+	}
+
+	return sh.Owner, nil
+}
+
+// claim grants the user the admin policy over the newly created entity, so
+// that its access can be managed through the auth service policies.
+func (ts *thingsService) claim(ctx context.Context, userID, id string) error {
+	if _, err := ts.auth.Claim(ctx, &mainflux.ClaimReq{Key: ts.claimKey, Sub: userID, Obj: id}); err != nil {
+		return errors.Wrap(ErrCreateEntity, err)
+	}
+	return nil
+}
+
+// revoke removes the policies of the user over the entity, or all the entity
+// policies if no user is provided. Revoking nonexistent policies returns
+// ErrNotFound.
+func (ts *thingsService) revoke(ctx context.Context, token, user, id string) error {
+	pp, err := ts.policies(ctx, &mainflux.PolicyReq{Token: token, Sub: user, Obj: id})
+	if err != nil {
+		return err
+	}
+	if len(pp) == 0 {
+		return ErrNotFound
+	}
+
+	for _, p := range pp {
+		req := &mainflux.PolicyReq{Token: token, Sub: p.GetSub(), Obj: p.GetObj(), Act: p.GetAct()}
+		if _, err := ts.auth.DeletePolicy(ctx, req); err != nil {
+			return errors.Wrap(ErrRemoveEntity, err)
+		}
+	}
+
+	return nil
+}
+
+// granted returns the highest access level granted to the user identified
+// by the token over each of the entities, according to the user policies.
+func (ts *thingsService) granted(ctx context.Context, token string) (map[string]string, error) {
+	pp, err := ts.policies(ctx, &mainflux.PolicyReq{Token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	access := map[string]string{}
+	for _, p := range pp {
+		if !ValidAccess(p.GetAct()) {
+			continue
+		}
+		sh := Share{Access: access[p.GetObj()]}
+		if !sh.Allows(p.GetAct()) {
+			access[p.GetObj()] = p.GetAct()
+		}
+	}
+
+	return access, nil
+}
+
+// policies retrieves all the policies matching the request.
+func (ts *thingsService) policies(ctx context.Context, req *mainflux.PolicyReq) ([]*mainflux.Policy, error) {
+	req.Limit = policiesLimit
+
+	var pp []*mainflux.Policy
+	for {
+		res, err := ts.auth.ListPolicies(ctx, req)
+		if err != nil {
+			if status.Code(err) == codes.Unauthenticated {
+				return nil, errors.Wrap(ErrUnauthorizedAccess, err)
+			}
+			return nil, errors.Wrap(ErrViewEntity, err)
+		}
+		pp = append(pp, res.GetPolicies()...)
+
+		req.Offset += uint64(len(res.GetPolicies()))
+		if len(res.GetPolicies()) == 0 || req.Offset >= res.GetTotal() {
+			return pp, nil
+		}
+	}
+}
+
+// authorize returns the share through which the user identified by the
+// token and the email is allowed to access the entity with the given access
+// level. Apart from the entity owner, the access is granted by the auth
+// service policies. Users without any access act on their own behalf,
+// leaving the ownership check to the repositories.
+func (ts *thingsService) authorize(ctx context.Context, token, user, id, access string) (Share, error) {
+	own := Share{EntityID: id, Owner: user, User: user, Access: AdminAccess}
+
+	owner, err := ts.owner(ctx, id)
+	if err != nil {
+		if errors.Contains(err, ErrNotFound) {
+			return own, nil
+		}
+		return Share{}, err
+	}
+	if owner == user {
+		return own, nil
+	}
+
+	// Access levels are ordered from the highest one, since it implies
+	// the lower ones.
+	req := &mainflux.GrantedReq{Token: token, Obj: id, Acts: []string{AdminAccess, WriteAccess, ReadAccess}}
+	res, err := ts.auth.Granted(ctx, req)
+	if err != nil {
+		return Share{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if res.GetAct() == "" {
+		return own, nil
+	}
+
+	sh := Share{EntityID: id, Owner: owner, User: user, Access: res.GetAct()}
+	if !sh.Allows(access) {
+		return Share{}, ErrUnauthorizedAccess
+	}
+	return sh, nil
+}
+
+// owner returns the owner of the thing or the channel with the given ID.
+func (ts *thingsService) owner(ctx context.Context, id string) (string, error) {
+	pm := PageMetadata{Limit: 1}
+
+	tp, err := ts.things.RetrieveByIDs(ctx, []string{id}, pm)
+	if err != nil {
+		return "", err
+	}
+	if len(tp.Things) > 0 {
+		return tp.Things[0].Owner, nil
+	}
+
+	cp, err := ts.channels.RetrieveByIDs(ctx, []string{id}, pm)
+	if err != nil {
+		return "", err
+	}
+	if len(cp.Channels) > 0 {
+		return cp.Channels[0].Owner, nil
+	}
+
+	return "", ErrNotFound
+}
+
+// commonOwner returns the owner of all the entities the user is allowed to
+// connect, since connections can't span entities of different owners.
+func (ts *thingsService) commonOwner(ctx context.Context, token, user string, ids ...string) (string, error) {
+	owner := ""
+	for _, id := range ids {
+		sh, err := ts.authorize(ctx, token, user, id, WriteAccess)
+		if err != nil {
+			return "", err
+		}
+		if owner != "" && owner != sh.Owner {
+			return "", ErrOwnerMismatch
+		}
+		owner = sh.Owner
+	}
+
+	return owner, nil
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things"
@@ -21,6 +22,7 @@ const (
 	wrongID    = ""
 	wrongValue = "wrong-value"
 	email      = "user@example.com"
+	email2     = "john.doe@email.net"
 	token      = "token"
 	token2     = "token2"
 	n          = uint64(10)
@@ -32,15 +34,19 @@ var (
 )
 
 func newService(tokens map[string]string) things.Service {
-	auth := mocks.NewAuthService(tokens)
+	return newServiceWithAuth(mocks.NewAuthService(tokens))
+}
+
+func newServiceWithAuth(auth mainflux.AuthServiceClient) things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	statusRepo := mocks.NewStatusRepository()
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, statusRepo, chanCache, thingCache, idProvider, mocks.ClaimKey)
 }
 
 func TestCreateThings(t *testing.T) {
@@ -188,6 +194,43 @@ func TestViewThing(t *testing.T) {
 	}
 }
 
+func TestViewStatus(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := map[string]struct {
+		id     string
+		token  string
+		status things.Status
+		err    error
+	}{
+		"view status of thing which was never seen": {
+			id:     th.ID,
+			token:  token,
+			status: things.Status{ThingID: th.ID},
+			err:    nil,
+		},
+		"view status with wrong credentials": {
+			id:    th.ID,
+			token: wrongValue,
+			err:   things.ErrUnauthorizedAccess,
+		},
+		"view status of non-existing thing": {
+			id:    wrongID,
+			token: token,
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		st, err := svc.ViewStatus(context.Background(), tc.token, tc.id)
+		assert.Equal(t, tc.status, st, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.status, st))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
 func TestListThings(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
@@ -1012,7 +1055,7 @@ func TestRemoveChannel(t *testing.T) {
 }
 
 func TestConnect(t *testing.T) {
-	svc := newService(map[string]string{token: email})
+	svc := newService(map[string]string{token: email, token2: email2})
 
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
@@ -1021,6 +1064,24 @@ func TestConnect(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
 
+	// Mock repositories assign thing and channel IDs using separate counters,
+	// so the shared channels are created after a spare one to keep their IDs
+	// different from the shared thing ID.
+	ths, err = svc.CreateThings(context.Background(), token2, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	sharedTh := ths[0]
+	chs, err = svc.CreateChannels(context.Background(), token2, channel, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	sharedCh, readCh := chs[1], chs[2]
+	for _, sh := range []things.Share{
+		{EntityID: sharedTh.ID, EntityType: things.ThingEntity, User: email, Access: things.WriteAccess},
+		{EntityID: sharedCh.ID, EntityType: things.ChannelEntity, User: email, Access: things.AdminAccess},
+		{EntityID: readCh.ID, EntityType: things.ChannelEntity, User: email, Access: things.ReadAccess},
+	} {
+		err := svc.Share(context.Background(), token2, sh)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
 	cases := []struct {
 		desc    string
 		token   string
@@ -1056,6 +1117,27 @@ func TestConnect(t *testing.T) {
 			thingID: wrongID,
 			err:     things.ErrNotFound,
 		},
+		{
+			desc:    "connect shared thing to shared channel",
+			token:   token,
+			chanID:  sharedCh.ID,
+			thingID: sharedTh.ID,
+			err:     nil,
+		},
+		{
+			desc:    "connect owned thing to shared channel",
+			token:   token,
+			chanID:  sharedCh.ID,
+			thingID: th.ID,
+			err:     things.ErrOwnerMismatch,
+		},
+		{
+			desc:    "connect shared thing to channel shared for reading",
+			token:   token,
+			chanID:  readCh.ID,
+			thingID: sharedTh.ID,
+			err:     things.ErrUnauthorizedAccess,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1221,9 +1303,12 @@ func TestIsChannelOwner(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ownedCh := chs[0]
-	chs, err = svc.CreateChannels(context.Background(), token2, channel)
+	chs, err = svc.CreateChannels(context.Background(), token2, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	nonOwnedCh := chs[0]
+	sharedCh := chs[1]
+	err = svc.Share(context.Background(), token2, things.Share{EntityID: sharedCh.ID, EntityType: things.ChannelEntity, User: email, Access: things.ReadAccess})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
 		channel string
@@ -1233,6 +1318,10 @@ func TestIsChannelOwner(t *testing.T) {
 			channel: ownedCh.ID,
 			err:     nil,
 		},
+		"user has channel shared without owning it": {
+			channel: sharedCh.ID,
+			err:     things.ErrNotFound,
+		},
 		"user does not own channel": {
 			channel: nonOwnedCh.ID,
 			err:     things.ErrNotFound,
@@ -1315,3 +1404,268 @@ func testSortChannels(t *testing.T, pm things.PageMetadata, chs []things.Channel
 		break
 	}
 }
+
+func TestShare(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[1]
+
+	cases := []struct {
+		desc  string
+		token string
+		share things.Share
+		err   error
+	}{
+		{
+			desc:  "share thing",
+			token: token,
+			share: things.Share{EntityID: th.ID, EntityType: things.ThingEntity, User: email2, Access: things.ReadAccess},
+			err:   nil,
+		},
+		{
+			desc:  "share channel",
+			token: token,
+			share: things.Share{EntityID: ch.ID, EntityType: things.ChannelEntity, User: email2, Access: things.WriteAccess},
+			err:   nil,
+		},
+		{
+			desc:  "share channel by user without admin access",
+			token: token2,
+			share: things.Share{EntityID: ch.ID, EntityType: things.ChannelEntity, User: wrongValue, Access: things.ReadAccess},
+			err:   things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "share channel with owner",
+			token: token,
+			share: things.Share{EntityID: ch.ID, EntityType: things.ChannelEntity, User: email, Access: things.ReadAccess},
+			err:   things.ErrMalformedEntity,
+		},
+		{
+			desc:  "share channel with invalid access",
+			token: token,
+			share: things.Share{EntityID: ch.ID, EntityType: things.ChannelEntity, User: email2, Access: wrongValue},
+			err:   things.ErrMalformedEntity,
+		},
+		{
+			desc:  "share channel as thing",
+			token: token,
+			share: things.Share{EntityID: ch.ID, EntityType: things.ThingEntity, User: email2, Access: things.ReadAccess},
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "share non-existing thing",
+			token: token,
+			share: things.Share{EntityID: wrongID, EntityType: things.ThingEntity, User: email2, Access: things.ReadAccess},
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "share thing with wrong credentials",
+			token: wrongValue,
+			share: things.Share{EntityID: th.ID, EntityType: things.ThingEntity, User: email2, Access: things.ReadAccess},
+			err:   things.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Share(context.Background(), tc.token, tc.share)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUnshare(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	share := things.Share{EntityID: ch.ID, EntityType: things.ChannelEntity, User: email2, Access: things.WriteAccess}
+	err = svc.Share(context.Background(), token, share)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		share things.Share
+		err   error
+	}{
+		{
+			desc:  "unshare channel by user without admin access",
+			token: token2,
+			share: share,
+			err:   things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "unshare channel with wrong credentials",
+			token: wrongValue,
+			share: share,
+			err:   things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "unshare channel",
+			token: token,
+			share: share,
+			err:   nil,
+		},
+		{
+			desc:  "unshare unshared channel",
+			token: token,
+			share: share,
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Unshare(context.Background(), tc.token, tc.share)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.ViewChannel(context.Background(), token2, ch.ID)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("view unshared channel: expected %s got %s\n", things.ErrNotFound, err))
+}
+
+func TestListShares(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	for _, user := range []string{email2, wrongValue} {
+		err := svc.Share(context.Background(), token, things.Share{EntityID: ch.ID, EntityType: things.ChannelEntity, User: user, Access: things.ReadAccess})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	cases := []struct {
+		desc       string
+		token      string
+		entityType string
+		id         string
+		size       int
+		err        error
+	}{
+		{
+			desc:       "list channel shares",
+			token:      token,
+			entityType: things.ChannelEntity,
+			id:         ch.ID,
+			size:       2,
+			err:        nil,
+		},
+		{
+			desc:       "list channel shares by user without admin access",
+			token:      token2,
+			entityType: things.ChannelEntity,
+			id:         ch.ID,
+			size:       0,
+			err:        things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:       "list shares of non-existing channel",
+			token:      token,
+			entityType: things.ChannelEntity,
+			id:         wrongID,
+			size:       0,
+			err:        things.ErrNotFound,
+		},
+		{
+			desc:       "list shares of invalid entity type",
+			token:      token,
+			entityType: wrongValue,
+			id:         ch.ID,
+			size:       0,
+			err:        things.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		shs, err := svc.ListShares(context.Background(), tc.token, tc.entityType, tc.id)
+		assert.Equal(t, tc.size, len(shs), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(shs)))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSharedAccess(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	readTh, writeTh := ths[0], ths[1]
+	for _, sh := range []things.Share{
+		{EntityID: readTh.ID, EntityType: things.ThingEntity, User: email2, Access: things.ReadAccess},
+		{EntityID: writeTh.ID, EntityType: things.ThingEntity, User: email2, Access: things.WriteAccess},
+	} {
+		err := svc.Share(context.Background(), token, sh)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	th, err := svc.ViewThing(context.Background(), token2, readTh.ID)
+	assert.Nil(t, err, fmt.Sprintf("view thing shared for reading: unexpected error: %s\n", err))
+	assert.Empty(t, th.Key, "view thing shared for reading: expected key to be hidden\n")
+
+	th, err = svc.ViewThing(context.Background(), token2, writeTh.ID)
+	assert.Nil(t, err, fmt.Sprintf("view thing shared for writing: unexpected error: %s\n", err))
+	assert.Equal(t, writeTh.Key, th.Key, fmt.Sprintf("view thing shared for writing: expected key %s got %s\n", writeTh.Key, th.Key))
+
+	err = svc.UpdateThing(context.Background(), token2, things.Thing{ID: readTh.ID, Name: "updated"})
+	assert.True(t, errors.Contains(err, things.ErrUnauthorizedAccess), fmt.Sprintf("update thing shared for reading: expected %s got %s\n", things.ErrUnauthorizedAccess, err))
+
+	err = svc.UpdateThing(context.Background(), token2, things.Thing{ID: writeTh.ID, Name: "updated"})
+	assert.Nil(t, err, fmt.Sprintf("update thing shared for writing: unexpected error: %s\n", err))
+
+	err = svc.RemoveThing(context.Background(), token2, writeTh.ID)
+	assert.True(t, errors.Contains(err, things.ErrUnauthorizedAccess), fmt.Sprintf("remove thing shared for writing: expected %s got %s\n", things.ErrUnauthorizedAccess, err))
+
+	page, err := svc.ListThings(context.Background(), token2, things.PageMetadata{Offset: 0, Limit: n, Shared: true})
+	assert.Nil(t, err, fmt.Sprintf("list shared things: unexpected error: %s\n", err))
+	assert.Equal(t, 2, len(page.Things), fmt.Sprintf("list shared things: expected %d got %d\n", 2, len(page.Things)))
+
+	page, err = svc.ListThings(context.Background(), token2, things.PageMetadata{Offset: 0, Limit: n})
+	assert.Nil(t, err, fmt.Sprintf("list owned things: unexpected error: %s\n", err))
+	assert.Equal(t, 0, len(page.Things), fmt.Sprintf("list owned things: expected %d got %d\n", 0, len(page.Things)))
+}
+
+func TestPolicyAccess(t *testing.T) {
+	auth := mocks.NewAuthService(map[string]string{token: email, token2: email2})
+	svc := newServiceWithAuth(auth)
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	_, err = svc.ViewThing(context.Background(), token2, th.ID)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("view thing without policy: expected %s got %s\n", things.ErrNotFound, err))
+
+	// Created things are claimed by their owners, who may grant
+	// the access to the other users.
+	_, err = auth.AddPolicy(context.Background(), &mainflux.PolicyReq{Token: token, Sub: email2, Obj: th.ID, Act: things.ReadAccess})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	res, err := svc.ViewThing(context.Background(), token2, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("view thing with read policy: unexpected error: %s\n", err))
+	assert.Equal(t, th.ID, res.ID, fmt.Sprintf("view thing with read policy: expected %s got %s\n", th.ID, res.ID))
+	assert.Empty(t, res.Key, "view thing with read policy: expected key to be hidden\n")
+
+	err = svc.UpdateThing(context.Background(), token2, things.Thing{ID: th.ID, Name: "updated"})
+	assert.True(t, errors.Contains(err, things.ErrUnauthorizedAccess), fmt.Sprintf("update thing with read policy: expected %s got %s\n", things.ErrUnauthorizedAccess, err))
+}
+
+func TestCreateClaimedThings(t *testing.T) {
+	auth := mocks.NewAuthService(map[string]string{token: email, token2: email2})
+	svc := newServiceWithAuth(auth)
+
+	// The mock repositories generate sequential IDs, so the second thing
+	// gets the ID claimed by the other user beforehand.
+	_, err := auth.Claim(context.Background(), &mainflux.ClaimReq{Key: mocks.ClaimKey, Sub: email2, Obj: "002"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.CreateThings(context.Background(), token, thing, thing)
+	assert.True(t, errors.Contains(err, things.ErrCreateEntity), fmt.Sprintf("create claimed things: expected %s got %s\n", things.ErrCreateEntity, err))
+
+	page, err := svc.ListThings(context.Background(), token, things.PageMetadata{Offset: 0, Limit: n})
+	assert.Nil(t, err, fmt.Sprintf("list things: unexpected error: %s\n", err))
+	assert.Equal(t, 0, len(page.Things), fmt.Sprintf("list things after failed claim: expected %d got %d\n", 0, len(page.Things)))
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import "github.com/mainflux/mainflux/pkg/errors"
+
+const (
+	// ReadAccess allows viewing the entity, listing its connections and
+	// reading channel messages.
+	ReadAccess = "read"
+	// WriteAccess additionally allows updating the entity and managing
+	// its connections.
+	WriteAccess = "write"
+	// AdminAccess additionally allows removing the entity and managing
+	// its shares.
+	AdminAccess = "admin"
+)
+
+const (
+	// ThingEntity denotes a shared thing.
+	ThingEntity = "thing"
+	// ChannelEntity denotes a shared channel.
+	ChannelEntity = "channel"
+)
+
+// ErrOwnerMismatch indicates that the entities being connected belong to
+// different owners.
+var ErrOwnerMismatch = errors.New("entities belong to different owners")
+
+// policiesLimit is the number of policies retrieved at once from the auth
+// service.
+const policiesLimit = 100
+
+var accessLevels = map[string]int{
+	ReadAccess:  1,
+	WriteAccess: 2,
+	AdminAccess: 3,
+}
+
+// shareActions are the auth service policy actions a share is stored as.
+// Write access is stored with the read action as well, since it implies it.
+var shareActions = map[string][]string{
+	ReadAccess:  {ReadAccess},
+	WriteAccess: {ReadAccess, WriteAccess},
+	AdminAccess: {AdminAccess},
+}
+
+// Share grants the user access to the thing or the channel owned by
+// another user. Shares are stored as the auth service policies, so the
+// owner is identified by email, while the user is identified by ID.
+type Share struct {
+	EntityID   string
+	EntityType string
+	Owner      string
+	User       string
+	Access     string
+}
+
+// Allows checks whether the share grants the given access level.
+func (sh Share) Allows(access string) bool {
+	lvl, ok := accessLevels[access]
+	return ok && accessLevels[sh.Access] >= lvl
+}
+
+// ValidAccess checks whether the access level is supported.
+func ValidAccess(access string) bool {
+	_, ok := accessLevels[access]
+	return ok
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// StatusOnline denotes things which are online.
+	StatusOnline = "online"
+	// StatusOffline denotes things which are offline or were never seen.
+	StatusOffline = "offline"
+)
+
+// Status represents the connection status of a thing.
+type Status struct {
+	ThingID string
+	Online  bool
+	// Connected is set while the thing holds a connection, e.g. over MQTT,
+	// in which case it stays online until it disconnects. Things using
+	// connectionless protocols, such as HTTP and CoAP, go offline after
+	// they are not seen for a while.
+	Connected bool
+	Protocol  string
+	LastSeen  time.Time
+}
+
+// StatusRepository specifies a thing status persistence API.
+type StatusRepository interface {
+	// Save persists the status of the thing, replacing the previous one.
+	Save(ctx context.Context, st Status) error
+
+	// Seen atomically updates the last seen time of the thing and, unless
+	// the thing holds a connection, marks it online over the given
+	// protocol. The status is created if the thing was never seen.
+	Seen(ctx context.Context, thingID, protocol string, at time.Time) error
+
+	// Connect atomically records the connection of the thing over the
+	// given protocol, and marks it online and connected.
+	Connect(ctx context.Context, thingID, protocol string, at time.Time) error
+
+	// Disconnect atomically records that the connection of the thing over
+	// the given protocol was closed, and returns the updated status. The
+	// thing stays online and connected while it holds any connections,
+	// over any protocol.
+	Disconnect(ctx context.Context, thingID, protocol string, at time.Time) (Status, error)
+
+	// Retrieve retrieves the status of the thing. ErrNotFound is returned
+	// if the thing was never seen.
+	Retrieve(ctx context.Context, thingID string) (Status, error)
+
+	// RetrieveExpired retrieves the statuses of the online things which
+	// are not connected and were last seen before the given time.
+	RetrieveExpired(ctx context.Context, before time.Time) ([]Status, error)
+}
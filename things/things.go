@@ -73,6 +73,9 @@ type ThingRepository interface {
 	// RetrieveByKey returns thing ID for given thing key.
 	RetrieveByKey(ctx context.Context, key string) (string, error)
 
+	// RetrieveKey returns thing key for given thing ID.
+	RetrieveKey(ctx context.Context, id string) (string, error)
+
 	// RetrieveAll retrieves the subset of things owned by the specified user
 	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (Page, error)
 
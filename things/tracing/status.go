@@ -0,0 +1,85 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/things"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveStatusOp            = "save_status"
+	seenStatusOp            = "seen_status"
+	connectStatusOp         = "connect_status"
+	disconnectStatusOp      = "disconnect_status"
+	retrieveStatusOp        = "retrieve_status"
+	retrieveExpiredStatusOp = "retrieve_expired_status"
+)
+
+var _ things.StatusRepository = (*statusRepositoryMiddleware)(nil)
+
+type statusRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   things.StatusRepository
+}
+
+// StatusRepositoryMiddleware tracks request and their latency, and adds
+// spans to context.
+func StatusRepositoryMiddleware(tracer opentracing.Tracer, repo things.StatusRepository) things.StatusRepository {
+	return statusRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (srm statusRepositoryMiddleware) Save(ctx context.Context, st things.Status) error {
+	span := createSpan(ctx, srm.tracer, saveStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Save(ctx, st)
+}
+
+func (srm statusRepositoryMiddleware) Seen(ctx context.Context, thingID, protocol string, at time.Time) error {
+	span := createSpan(ctx, srm.tracer, seenStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Seen(ctx, thingID, protocol, at)
+}
+
+func (srm statusRepositoryMiddleware) Connect(ctx context.Context, thingID, protocol string, at time.Time) error {
+	span := createSpan(ctx, srm.tracer, connectStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Connect(ctx, thingID, protocol, at)
+}
+
+func (srm statusRepositoryMiddleware) Disconnect(ctx context.Context, thingID, protocol string, at time.Time) (things.Status, error) {
+	span := createSpan(ctx, srm.tracer, disconnectStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Disconnect(ctx, thingID, protocol, at)
+}
+
+func (srm statusRepositoryMiddleware) Retrieve(ctx context.Context, thingID string) (things.Status, error) {
+	span := createSpan(ctx, srm.tracer, retrieveStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Retrieve(ctx, thingID)
+}
+
+func (srm statusRepositoryMiddleware) RetrieveExpired(ctx context.Context, before time.Time) ([]things.Status, error) {
+	span := createSpan(ctx, srm.tracer, retrieveExpiredStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.RetrieveExpired(ctx, before)
+}
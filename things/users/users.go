@@ -62,6 +62,10 @@ func (repo singleUserRepo) Authorize(ctx context.Context, req *mainflux.Authoriz
 	return &mainflux.AuthorizeRes{}, errUnsupported
 }
 
+func (repo singleUserRepo) Granted(ctx context.Context, req *mainflux.GrantedReq, _ ...grpc.CallOption) (*mainflux.GrantedRes, error) {
+	return &mainflux.GrantedRes{}, errUnsupported
+}
+
 func (repo singleUserRepo) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
 	return &mainflux.MembersRes{}, errUnsupported
 
@@ -70,3 +74,20 @@ func (repo singleUserRepo) Members(ctx context.Context, req *mainflux.MembersReq
 func (repo singleUserRepo) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	return &empty.Empty{}, errUnsupported
 }
+
+// Claim is a no-op, since the single user owns all the entities.
+func (repo singleUserRepo) Claim(ctx context.Context, req *mainflux.ClaimReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (repo singleUserRepo) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, errUnsupported
+}
+
+func (repo singleUserRepo) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, errUnsupported
+}
+
+func (repo singleUserRepo) ListPolicies(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*mainflux.PoliciesRes, error) {
+	return &mainflux.PoliciesRes{}, errUnsupported
+}
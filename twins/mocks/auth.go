@@ -12,6 +12,9 @@ import (
 	"google.golang.org/grpc"
 )
 
+// ClaimKey is the claim key accepted by the mock.
+const ClaimKey = "claimKey"
+
 var _ mainflux.AuthServiceClient = (*authServiceClient)(nil)
 
 type authServiceClient struct {
@@ -34,7 +37,15 @@ func (svc *authServiceClient) Issue(ctx context.Context, in *mainflux.IssueReq,
 	return new(mainflux.Token), nil
 }
 
+// Authorize grants no access, since the mock has no policies.
 func (svc *authServiceClient) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
+	if _, ok := svc.users[req.GetToken()]; !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	return &mainflux.AuthorizeRes{Authorized: false}, nil
+}
+
+func (svc *authServiceClient) Granted(ctx context.Context, req *mainflux.GrantedReq, _ ...grpc.CallOption) (*mainflux.GrantedRes, error) {
 	panic("not implemented")
 }
 
@@ -45,3 +56,22 @@ func (svc *authServiceClient) Members(ctx context.Context, req *mainflux.Members
 func (svc *authServiceClient) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc *authServiceClient) Claim(ctx context.Context, req *mainflux.ClaimReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	if req.GetKey() != ClaimKey {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceClient) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceClient) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceClient) ListPolicies(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*mainflux.PoliciesRes, error) {
+	panic("not implemented")
+}
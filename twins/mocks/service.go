@@ -25,7 +25,7 @@ func NewService(tokens map[string]string) twins.Service {
 	subs := map[string]string{"chanID": "chanID"}
 	broker := NewBroker(subs)
 
-	return twins.New(broker, auth, twinsRepo, twinCache, statesRepo, idProvider, "chanID", nil)
+	return twins.New(broker, auth, twinsRepo, twinCache, statesRepo, idProvider, "chanID", ClaimKey, nil)
 }
 
 // CreateDefinition creates twin definition
@@ -18,7 +18,14 @@ import (
 	"github.com/mainflux/senml"
 )
 
-const publisher = "twins"
+const (
+	publisher = "twins"
+
+	// Actions checked against the auth service policies.
+	readAction   = "read"
+	writeAction  = "write"
+	deleteAction = "delete"
+)
 
 var (
 	// ErrMalformedEntity indicates malformed entity specification (e.g.
@@ -96,13 +103,15 @@ type twinsService struct {
 	idProvider mainflux.IDProvider
 	channelID  string
 	twinCache  TwinCache
+	claimKey   string
 	logger     logger.Logger
 }
 
 var _ Service = (*twinsService)(nil)
 
-// New instantiates the twins service implementation.
-func New(publisher messaging.Publisher, auth mainflux.AuthServiceClient, twins TwinRepository, tcache TwinCache, sr StateRepository, idp mainflux.IDProvider, chann string, logger logger.Logger) Service {
+// New instantiates the twins service implementation. The twins are claimed
+// for their owners in the auth service using the claim key.
+func New(publisher messaging.Publisher, auth mainflux.AuthServiceClient, twins TwinRepository, tcache TwinCache, sr StateRepository, idp mainflux.IDProvider, chann, claimKey string, logger logger.Logger) Service {
 	return &twinsService{
 		publisher:  publisher,
 		auth:       auth,
@@ -111,6 +120,7 @@ func New(publisher messaging.Publisher, auth mainflux.AuthServiceClient, twins T
 		states:     sr,
 		idProvider: idp,
 		channelID:  chann,
+		claimKey:   claimKey,
 		logger:     logger,
 	}
 }
@@ -152,6 +162,12 @@ func (ts *twinsService) AddTwin(ctx context.Context, token string, twin Twin, de
 		return Twin{}, err
 	}
 
+	// Remove the twin whose access couldn't be managed.
+	if _, err = ts.auth.Claim(ctx, &mainflux.ClaimReq{Key: ts.claimKey, Sub: res.GetId(), Obj: twin.ID}); err != nil {
+		ts.twins.Remove(ctx, twin.ID)
+		return Twin{}, err
+	}
+
 	id = twin.ID
 	b, err = json.Marshal(twin)
 
@@ -163,12 +179,7 @@ func (ts *twinsService) UpdateTwin(ctx context.Context, token string, twin Twin,
 	var id string
 	defer ts.publish(&id, &err, crudOp["updateSucc"], crudOp["updateFail"], &b)
 
-	_, err = ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return ErrUnauthorizedAccess
-	}
-
-	tw, err := ts.twins.RetrieveByID(ctx, twin.ID)
+	tw, err := ts.authorize(ctx, token, twin.ID, writeAction)
 	if err != nil {
 		return err
 	}
@@ -213,12 +224,7 @@ func (ts *twinsService) ViewTwin(ctx context.Context, token, twinID string) (tw
 	var b []byte
 	defer ts.publish(&twinID, &err, crudOp["getSucc"], crudOp["getFail"], &b)
 
-	_, err = ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return Twin{}, ErrUnauthorizedAccess
-	}
-
-	twin, err := ts.twins.RetrieveByID(ctx, twinID)
+	twin, err := ts.authorize(ctx, token, twinID, readAction)
 	if err != nil {
 		return Twin{}, err
 	}
@@ -232,9 +238,9 @@ func (ts *twinsService) RemoveTwin(ctx context.Context, token, twinID string) (e
 	var b []byte
 	defer ts.publish(&twinID, &err, crudOp["removeSucc"], crudOp["removeFail"], &b)
 
-	_, err = ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return ErrUnauthorizedAccess
+	// Removal of the non-existent twins succeeds.
+	if _, err = ts.authorize(ctx, token, twinID, deleteAction); err != nil && !errors.Contains(err, ErrNotFound) {
+		return err
 	}
 
 	if err := ts.twins.Remove(ctx, twinID); err != nil {
@@ -254,14 +260,37 @@ func (ts *twinsService) ListTwins(ctx context.Context, token string, offset uint
 }
 
 func (ts *twinsService) ListStates(ctx context.Context, token string, offset uint64, limit uint64, twinID string) (StatesPage, error) {
-	_, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return StatesPage{}, ErrUnauthorizedAccess
+	if _, err := ts.authorize(ctx, token, twinID, readAction); err != nil && !errors.Contains(err, ErrNotFound) {
+		return StatesPage{}, err
 	}
 
 	return ts.states.RetrieveAll(ctx, offset, limit, twinID)
 }
 
+// authorize returns the twin if the user identified by the token owns it,
+// or is granted the action over it by the auth service policies.
+func (ts *twinsService) authorize(ctx context.Context, token, twinID, action string) (Twin, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Twin{}, ErrUnauthorizedAccess
+	}
+
+	twin, err := ts.twins.RetrieveByID(ctx, twinID)
+	if err != nil {
+		return Twin{}, err
+	}
+	if twin.Owner == res.GetEmail() {
+		return twin, nil
+	}
+
+	ar, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Obj: twinID, Act: action})
+	if err != nil || !ar.GetAuthorized() {
+		return Twin{}, ErrUnauthorizedAccess
+	}
+
+	return twin, nil
+}
+
 func (ts *twinsService) SaveStates(msg *messaging.Message) error {
 	var ids []string
 
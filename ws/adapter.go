@@ -0,0 +1,196 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ws contains the domain concept definitions needed to support
+// Mainflux WebSocket adapter service functionality.
+package ws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const (
+	chansPrefix = "channels"
+
+	// Actions checked against the auth service policies.
+	readAction = "read"
+)
+
+var (
+	// ErrUnauthorized indicates that the client is not allowed to access
+	// the channel.
+	ErrUnauthorized = errors.New("unauthorized access")
+
+	// ErrSubscribe indicates that the subscription to the channel failed.
+	ErrSubscribe = errors.New("failed to subscribe to channel")
+)
+
+// Service specifies WebSocket service API.
+type Service interface {
+	// Publish publishes the message on behalf of the thing with the given
+	// key.
+	Publish(ctx context.Context, key string, msg messaging.Message) error
+
+	// Subscribe authorizes the thing key or the user token and forwards
+	// the messages published to the channel and subtopic to the client.
+	Subscribe(ctx context.Context, token, chanID, subtopic string, c *Client) error
+
+	// Unsubscribe stops forwarding the messages of the channel and
+	// subtopic to the client.
+	Unsubscribe(ctx context.Context, chanID, subtopic string, c *Client) error
+}
+
+var _ Service = (*adapterService)(nil)
+
+type adapterService struct {
+	things     mainflux.ThingsServiceClient
+	auth       mainflux.AuthServiceClient
+	publisher  messaging.Publisher
+	subscriber messaging.Subscriber
+
+	mu      sync.Mutex
+	clients map[string]map[*Client]struct{}
+}
+
+// New instantiates the WebSocket adapter implementation. Since the
+// subscriber handles a single subscription per topic, the adapter
+// subscribes once per topic and forwards the messages to all the clients.
+func New(things mainflux.ThingsServiceClient, auth mainflux.AuthServiceClient, pub messaging.Publisher, sub messaging.Subscriber) Service {
+	return &adapterService{
+		things:     things,
+		auth:       auth,
+		publisher:  pub,
+		subscriber: sub,
+		clients:    make(map[string]map[*Client]struct{}),
+	}
+}
+
+func (as *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
+	ar := &mainflux.AccessByKeyReq{
+		Token:  key,
+		ChanID: msg.Channel,
+	}
+	thid, err := as.things.CanAccessByKey(ctx, ar)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorized, err)
+	}
+	msg.Publisher = thid.GetValue()
+
+	return as.publisher.Publish(msg.Channel, msg)
+}
+
+func (as *adapterService) Subscribe(ctx context.Context, token, chanID, subtopic string, c *Client) error {
+	id, err := as.authorize(ctx, token, chanID)
+	if err != nil {
+		return err
+	}
+	c.id = id
+
+	topic := subject(chanID, subtopic)
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if cs, ok := as.clients[topic]; ok {
+		cs[c] = struct{}{}
+		return nil
+	}
+	if err := as.subscriber.Subscribe(topic, as.handle(topic)); err != nil {
+		return errors.Wrap(ErrSubscribe, err)
+	}
+	as.clients[topic] = map[*Client]struct{}{c: {}}
+
+	return nil
+}
+
+func (as *adapterService) Unsubscribe(ctx context.Context, chanID, subtopic string, c *Client) error {
+	topic := subject(chanID, subtopic)
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	cs, ok := as.clients[topic]
+	if !ok {
+		return nil
+	}
+	delete(cs, c)
+	if len(cs) > 0 {
+		return nil
+	}
+	delete(as.clients, topic)
+
+	return as.subscriber.Unsubscribe(topic)
+}
+
+// authorize returns the ID of the thing with the given key, or an empty
+// string if the token belongs to the user who owns the channel or is
+// allowed to read it by the auth service policies.
+func (as *adapterService) authorize(ctx context.Context, token, chanID string) (string, error) {
+	ar := &mainflux.AccessByKeyReq{
+		Token:  token,
+		ChanID: chanID,
+	}
+	thid, err := as.things.CanAccessByKey(ctx, ar)
+	if err == nil {
+		return thid.GetValue(), nil
+	}
+
+	ui, uerr := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if uerr != nil {
+		return "", errors.Wrap(ErrUnauthorized, err)
+	}
+	cr := &mainflux.ChannelOwnerReq{
+		Owner:  ui.GetEmail(),
+		ChanID: chanID,
+	}
+	if _, err := as.things.IsChannelOwner(ctx, cr); err == nil {
+		return "", nil
+	}
+
+	req := &mainflux.AuthorizeReq{
+		Token: token,
+		Obj:   chanID,
+		Act:   readAction,
+	}
+	res, err := as.auth.Authorize(ctx, req)
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorized, err)
+	}
+	if !res.GetAuthorized() {
+		return "", ErrUnauthorized
+	}
+
+	return "", nil
+}
+
+func (as *adapterService) handle(topic string) messaging.MessageHandler {
+	return func(msg messaging.Message) error {
+		as.mu.Lock()
+		cs := make([]*Client, 0, len(as.clients[topic]))
+		for c := range as.clients[topic] {
+			cs = append(cs, c)
+		}
+		as.mu.Unlock()
+
+		// The clients which can't keep up are closed, so the message is
+		// still delivered to the others without delay.
+		for _, c := range cs {
+			c.Handle(msg)
+		}
+		return nil
+	}
+}
+
+func subject(chanID, subtopic string) string {
+	s := fmt.Sprintf("%s.%s", chansPrefix, chanID)
+	if subtopic != "" {
+		s = fmt.Sprintf("%s.%s", s, subtopic)
+	}
+	return s
+}
@@ -0,0 +1,227 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/ws"
+	"github.com/mainflux/mainflux/ws/api"
+	"github.com/mainflux/mainflux/ws/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID     = "1"
+	thingKey   = "thing_key"
+	thingID    = "thing_id"
+	otherKey   = "other_key"
+	otherID    = "other_id"
+	userToken  = "user_token"
+	email      = "user@example.com"
+	invalidKey = "invalid_key"
+	readWait   = time.Second
+)
+
+func newService() ws.Service {
+	tc := mocks.NewThingsClient(
+		map[string]string{thingKey: thingID, otherKey: otherID},
+		map[string]string{chanID: email},
+	)
+	auth := mocks.NewAuthService(map[string]string{userToken: email})
+	ps := mocks.NewPubSub()
+	return ws.New(tc, auth, ps, ps)
+}
+
+func newServer(svc ws.Service) *httptest.Server {
+	logger, _ := logger.New(os.Stdout, logger.Error.String())
+	mux := api.MakeHandler(svc, logger)
+	return httptest.NewServer(mux)
+}
+
+func dial(ts *httptest.Server, path, token string) (*websocket.Conn, *http.Response, error) {
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + path
+	header := http.Header{}
+	if token != "" {
+		header.Set("Authorization", token)
+	}
+	return websocket.DefaultDialer.Dial(url, header)
+}
+
+func TestHandshake(t *testing.T) {
+	ts := newServer(newService())
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		path   string
+		token  string
+		status int
+		code   int
+	}{
+		{
+			desc:   "connect using thing key",
+			path:   fmt.Sprintf("/channels/%s/messages", chanID),
+			token:  thingKey,
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect to subtopic using thing key",
+			path:   fmt.Sprintf("/channels/%s/messages/sub/topic", chanID),
+			token:  thingKey,
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect using user token",
+			path:   fmt.Sprintf("/channels/%s/messages", chanID),
+			token:  userToken,
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect using authorization query",
+			path:   fmt.Sprintf("/channels/%s/messages?authorization=%s", chanID, thingKey),
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect using invalid key",
+			path:   fmt.Sprintf("/channels/%s/messages", chanID),
+			token:  invalidKey,
+			status: http.StatusSwitchingProtocols,
+			code:   websocket.ClosePolicyViolation,
+		},
+		{
+			desc:   "connect to other user's channel",
+			path:   "/channels/2/messages",
+			token:  userToken,
+			status: http.StatusSwitchingProtocols,
+			code:   websocket.ClosePolicyViolation,
+		},
+		{
+			desc:   "connect without authorization",
+			path:   fmt.Sprintf("/channels/%s/messages", chanID),
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "connect to malformed subtopic",
+			path:   fmt.Sprintf("/channels/%s/messages/sub>", chanID),
+			token:  thingKey,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		conn, res, _ := dial(ts, tc.path, tc.token)
+		require.NotNil(t, res, fmt.Sprintf("%s: unexpected nil response", tc.desc))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if conn == nil {
+			continue
+		}
+		if tc.code != 0 {
+			_, _, err := conn.ReadMessage()
+			assert.True(t, websocket.IsCloseError(err, tc.code), fmt.Sprintf("%s: expected close code %d got %s", tc.desc, tc.code, err))
+		}
+		conn.Close()
+	}
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	ts := newServer(newService())
+	defer ts.Close()
+
+	path := fmt.Sprintf("/channels/%s/messages/sub", chanID)
+	payload := `[{"n":"current","t":-1,"v":1.6}]`
+
+	thing, _, err := dial(ts, path, thingKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer thing.Close()
+
+	user, _, err := dial(ts, path, userToken)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer user.Close()
+
+	other, _, err := dial(ts, fmt.Sprintf("/channels/%s/messages/other", chanID), otherKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer other.Close()
+
+	// The subscriptions are made after the handshake, so wait for the
+	// server to subscribe the clients.
+	time.Sleep(100 * time.Millisecond)
+
+	err = thing.WriteMessage(websocket.TextMessage, []byte(payload))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	user.SetReadDeadline(time.Now().Add(readWait))
+	typ, data, err := user.ReadMessage()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, websocket.TextMessage, typ, fmt.Sprintf("expected message type %d got %d", websocket.TextMessage, typ))
+	assert.Equal(t, payload, string(data), fmt.Sprintf("expected payload %s got %s", payload, data))
+
+	thing.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, _, err = thing.ReadMessage()
+	assert.NotNil(t, err, "expected own message not to be sent back")
+
+	other.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, _, err = other.ReadMessage()
+	assert.NotNil(t, err, "expected message not to be sent to other subtopic")
+
+	err = user.WriteMessage(websocket.TextMessage, []byte(payload))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	user.SetReadDeadline(time.Now().Add(readWait))
+	_, _, err = user.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), fmt.Sprintf("publish using user token: expected close code %d got %s", websocket.ClosePolicyViolation, err))
+}
+
+func TestSlowClient(t *testing.T) {
+	ts := newServer(newService())
+	defer ts.Close()
+
+	path := fmt.Sprintf("/channels/%s/messages", chanID)
+	payload := strings.Repeat("a", 64*1024)
+	count := 256
+
+	thing, _, err := dial(ts, path, thingKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer thing.Close()
+
+	fast, _, err := dial(ts, path, userToken)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer fast.Close()
+
+	slow, _, err := dial(ts, path, userToken)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer slow.Close()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// The fast client receives each message before the next one is sent,
+	// while the slow one doesn't read until all of them are sent.
+	for i := 0; i < count; i++ {
+		err := thing.WriteMessage(websocket.TextMessage, []byte(payload))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+		fast.SetReadDeadline(time.Now().Add(readWait))
+		_, _, err = fast.ReadMessage()
+		require.Nil(t, err, fmt.Sprintf("fast client: unexpected error after %d messages: %s", i, err))
+	}
+
+	received := 0
+	for {
+		slow.SetReadDeadline(time.Now().Add(readWait))
+		if _, _, err = slow.ReadMessage(); err != nil {
+			break
+		}
+		received++
+	}
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseAbnormalClosure), fmt.Sprintf("slow client: expected close code %d got %s", websocket.CloseAbnormalClosure, err))
+	assert.Less(t, received, count, fmt.Sprintf("slow client: expected less than %d messages got %d", count, received))
+}
@@ -0,0 +1,187 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-zoo/bone"
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/ws"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	protocol  = "websocket"
+	authQuery = "authorization"
+)
+
+var (
+	errMalformedData     = errors.New("malformed request data")
+	errMalformedSubtopic = errors.New("malformed subtopic")
+	errUnauthorized      = errors.New("missing authorization")
+)
+
+var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Browsers send the Origin header, while the access is controlled by
+	// the key or the token.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc ws.Service, logger log.Logger) http.Handler {
+	r := bone.New()
+	r.GetFunc("/channels/:id/messages", handshake(svc, logger))
+	r.GetFunc("/channels/:id/messages/*", handshake(svc, logger))
+	r.GetFunc("/version", mainflux.Version(protocol))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
+
+type connReq struct {
+	token    string
+	chanID   string
+	subtopic string
+}
+
+func handshake(svc ws.Service, logger log.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decodeRequest(r)
+		if err != nil {
+			encodeError(w, err)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to upgrade connection to websocket: %s", err))
+			return
+		}
+		c := ws.NewClient(conn)
+
+		ctx := context.Background()
+		if err := svc.Subscribe(ctx, req.token, req.chanID, req.subtopic, c); err != nil {
+			c.Close(closeCode(err), err.Error())
+			return
+		}
+		defer svc.Unsubscribe(ctx, req.chanID, req.subtopic, c)
+		defer c.Close(websocket.CloseNormalClosure, "")
+
+		listen(ctx, svc, req, conn, c)
+	}
+}
+
+// listen publishes the messages received from the client until the
+// connection is closed.
+func listen(ctx context.Context, svc ws.Service, req connReq, conn *websocket.Conn, c *ws.Client) {
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		msg := messaging.Message{
+			Protocol: protocol,
+			Channel:  req.chanID,
+			Subtopic: req.subtopic,
+			Payload:  payload,
+			Created:  time.Now().UnixNano(),
+		}
+		if err := svc.Publish(ctx, req.token, msg); errors.Contains(err, ws.ErrUnauthorized) {
+			c.Close(websocket.ClosePolicyViolation, err.Error())
+			return
+		}
+	}
+}
+
+func decodeRequest(r *http.Request) (connReq, error) {
+	channelParts := channelPartRegExp.FindStringSubmatch(r.RequestURI)
+	if len(channelParts) < 2 {
+		return connReq{}, errMalformedData
+	}
+
+	subtopic, err := parseSubtopic(channelParts[2])
+	if err != nil {
+		return connReq{}, err
+	}
+
+	// Browsers can't set the headers of WebSocket requests.
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		token = r.URL.Query().Get(authQuery)
+	}
+	if token == "" {
+		return connReq{}, errUnauthorized
+	}
+
+	req := connReq{
+		token:    token,
+		chanID:   bone.GetValue(r, "id"),
+		subtopic: subtopic,
+	}
+
+	return req, nil
+}
+
+func parseSubtopic(subtopic string) (string, error) {
+	if subtopic == "" {
+		return subtopic, nil
+	}
+
+	subtopic, err := url.QueryUnescape(subtopic)
+	if err != nil {
+		return "", errMalformedSubtopic
+	}
+	subtopic = strings.Replace(subtopic, "/", ".", -1)
+
+	elems := strings.Split(subtopic, ".")
+	filteredElems := []string{}
+	for _, elem := range elems {
+		if elem == "" {
+			continue
+		}
+
+		if len(elem) > 1 && (strings.Contains(elem, "*") || strings.Contains(elem, ">")) {
+			return "", errMalformedSubtopic
+		}
+
+		filteredElems = append(filteredElems, elem)
+	}
+
+	subtopic = strings.Join(filteredElems, ".")
+	return subtopic, nil
+}
+
+func closeCode(err error) int {
+	if errors.Contains(err, ws.ErrUnauthorized) {
+		return websocket.ClosePolicyViolation
+	}
+	return websocket.CloseInternalServerErr
+}
+
+func encodeError(w http.ResponseWriter, err error) {
+	switch err {
+	case errMalformedData, errMalformedSubtopic:
+		w.WriteHeader(http.StatusBadRequest)
+	case errUnauthorized:
+		w.WriteHeader(http.StatusForbidden)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
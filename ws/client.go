@@ -0,0 +1,119 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const (
+	writeWait = 10 * time.Second
+
+	// The connection is closed if the client doesn't receive the messages
+	// fast enough.
+	clientBuffer = 64
+)
+
+var (
+	// ErrSlowClient indicates that the client didn't receive the messages
+	// fast enough, so its connection was closed.
+	ErrSlowClient = errors.New("client is too slow")
+
+	// ErrClientClosed indicates that the client connection is closed.
+	ErrClientClosed = errors.New("client is closed")
+)
+
+// Client forwards the messages to the WebSocket connection. The messages
+// are queued and written by the goroutine of the client, so a slow client
+// doesn't delay the others.
+type Client struct {
+	conn *websocket.Conn
+	// id is the ID of the thing, or empty if the client is a user.
+	id       string
+	messages chan messaging.Message
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewClient returns new WebSocket client and starts writing the messages to
+// the connection.
+func NewClient(conn *websocket.Conn) *Client {
+	c := &Client{
+		conn:     conn,
+		messages: make(chan messaging.Message, clientBuffer),
+		done:     make(chan struct{}),
+	}
+	go c.write()
+
+	return c
+}
+
+// Handle queues the message payload to be sent to the client. The messages
+// published by the client itself are not sent back. If the queue is full,
+// the connection is closed.
+func (c *Client) Handle(msg messaging.Message) error {
+	if c.id != "" && msg.Publisher == c.id {
+		return nil
+	}
+
+	select {
+	case <-c.done:
+		return ErrClientClosed
+	default:
+	}
+
+	select {
+	case c.messages <- msg:
+		return nil
+	default:
+		c.close()
+		return ErrSlowClient
+	}
+}
+
+// Close sends the close message with the given code and reason, and closes
+// the connection.
+func (c *Client) Close(code int, reason string) error {
+	msg := websocket.FormatCloseMessage(code, reason)
+	c.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+	return c.close()
+}
+
+// close closes the connection without the close message, since the writer
+// may be blocked, and stops the writer.
+func (c *Client) close() error {
+	var err error
+	c.once.Do(func() {
+		close(c.done)
+		err = c.conn.Close()
+	})
+	return err
+}
+
+func (c *Client) write() {
+	for {
+		select {
+		case msg := <-c.messages:
+			// Text frames must be valid UTF-8.
+			typ := websocket.TextMessage
+			if !utf8.Valid(msg.Payload) {
+				typ = websocket.BinaryMessage
+			}
+
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(typ, msg.Payload); err != nil {
+				c.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
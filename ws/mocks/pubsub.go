@@ -0,0 +1,67 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const chansPrefix = "channels"
+
+var errAlreadySubscribed = errors.New("already subscribed to topic")
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+type pubsub struct {
+	mu       sync.Mutex
+	handlers map[string]messaging.MessageHandler
+}
+
+// NewPubSub returns mock message publisher/subscriber, which delivers the
+// messages to the handler subscribed to the exact subject.
+func NewPubSub() messaging.PubSub {
+	return &pubsub{
+		handlers: make(map[string]messaging.MessageHandler),
+	}
+}
+
+func (ps *pubsub) Publish(topic string, msg messaging.Message) error {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	ps.mu.Lock()
+	h, ok := ps.handlers[subject]
+	ps.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	return h(msg)
+}
+
+func (ps *pubsub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if _, ok := ps.handlers[topic]; ok {
+		return errAlreadySubscribed
+	}
+	ps.handlers[topic] = handler
+
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(topic string) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	delete(ps.handlers, topic)
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/things"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.ThingsServiceClient = (*thingsClient)(nil)
+
+type thingsClient struct {
+	things   map[string]string
+	channels map[string]string
+}
+
+// NewThingsClient returns mock implementation of things service client.
+// Things map keys to thing IDs, while channels map channel IDs to their
+// owners.
+func NewThingsClient(things, channels map[string]string) mainflux.ThingsServiceClient {
+	return &thingsClient{
+		things:   things,
+		channels: channels,
+	}
+}
+
+func (tc thingsClient) CanAccessByKey(ctx context.Context, req *mainflux.AccessByKeyReq, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	key := req.GetToken()
+	if key == "" {
+		return nil, things.ErrUnauthorizedAccess
+	}
+
+	id, ok := tc.things[key]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "invalid credentials provided")
+	}
+
+	return &mainflux.ThingID{Value: id}, nil
+}
+
+func (tc thingsClient) CanAccessByID(context.Context, *mainflux.AccessByIDReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) IsChannelOwner(ctx context.Context, req *mainflux.ChannelOwnerReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if owner, ok := tc.channels[req.GetChanID()]; !ok || owner != req.GetOwner() {
+		return nil, status.Error(codes.PermissionDenied, "invalid credentials provided")
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (tc thingsClient) Identify(ctx context.Context, req *mainflux.Token, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) DerivePSK(ctx context.Context, req *mainflux.ThingID, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}